@@ -24,8 +24,26 @@ import (
 
 // BuildArg represents a build argument used when building a container image.
 type BuildArg struct {
-	Name  string `json:"name"`
+	Name string `json:"name"`
+
+	// +optional
 	Value string `json:"value"`
+
+	// ValueFrom reads the value of the build argument from a ConfigMap or a Secret in the Module's namespace.
+	// Value is ignored if ValueFrom is set.
+	// Secret values are only read by the build Pod, and are never copied to other resources.
+	// +optional
+	ValueFrom *ValueSource `json:"valueFrom,omitempty"`
+}
+
+// ValueSource selects a key of a ConfigMap or of a Secret.
+// Exactly one of its fields must be set.
+type ValueSource struct {
+	// +optional
+	ConfigMapKeyRef *v1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// +optional
+	SecretKeyRef *v1.SecretKeySelector `json:"secretKeyRef,omitempty"`
 }
 
 type TLSOptions struct {
@@ -39,6 +57,19 @@ type TLSOptions struct {
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 }
 
+// BuildBackend is the tool that builds and signs kmod images in the cluster.
+// +kubebuilder:validation:Enum=kaniko;buildah;buildkit
+type BuildBackend string
+
+const (
+	// BuildBackendKaniko builds images with kaniko.
+	BuildBackendKaniko BuildBackend = "kaniko"
+	// BuildBackendBuildah builds images with rootless Buildah.
+	BuildBackendBuildah BuildBackend = "buildah"
+	// BuildBackendBuildKit builds images with rootless BuildKit.
+	BuildBackendBuildKit BuildBackend = "buildkit"
+)
+
 type KanikoParams struct {
 	// +optional
 	// Kaniko image tag to use when creating the build Pod
@@ -50,8 +81,14 @@ type Build struct {
 	// BuildArgs is an array of build variables that are provided to the image building backend.
 	BuildArgs []BuildArg `json:"buildArgs"`
 
-	// ConfigMap that holds Dockerfile contents
-	DockerfileConfigMap *v1.LocalObjectReference `json:"dockerfileConfigMap"`
+	// +optional
+	// ConfigMap that holds Dockerfile contents.
+	// Required unless Source is set; if both are set, it replaces the Dockerfile of the source.
+	DockerfileConfigMap *v1.LocalObjectReference `json:"dockerfileConfigMap,omitempty"`
+
+	// +optional
+	// Source is fetched into the build context before the build.
+	Source *BuildSource `json:"source,omitempty"`
 
 	// +optional
 	// BaseImageRegistryTLS contains settings determining how to access registries of the base images in the build-process' Dockerfile.
@@ -67,9 +104,82 @@ type Build struct {
 	// KanikoParams is used to customize the building process of the image.
 	KanikoParams *KanikoParams `json:"kanikoParams,omitempty"`
 
+	// +optional
+	// Backend is the tool used to build the image, and to sign it if needed.
+	// Defaults to the backend set in the operator's configuration, or kaniko.
+	Backend BuildBackend `json:"backend,omitempty"`
+
+	// +optional
+	// Cache configures the caching of the layers of the image between builds.
+	Cache *BuildCache `json:"cache,omitempty"`
+
 	// +optional
 	// Selector describes on which nodes will run the building process.
 	Selector map[string]string `json:"selector,omitempty"`
+
+	// +optional
+	// ActiveDeadline is the maximum duration of each attempt to build the image, after which it is stopped and considered failed.
+	ActiveDeadline *metav1.Duration `json:"activeDeadline,omitempty"`
+
+	// +optional
+	// MaxRetries is the number of times a failed build is retried before it is reported as failed.
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+
+	// +optional
+	// Backoff is the delay before the first retry of a failed build; it doubles after each failed retry, up to one hour.
+	// Defaults to 10s.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// +optional
+	// Priority orders the build in the build queue, if the operator orders it by priority.
+	// Builds with a higher priority start first.
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// BuildSource describes where the build context comes from.
+type BuildSource struct {
+	// Git is a Git repository cloned into the build context.
+	Git *GitSource `json:"git"`
+}
+
+// GitSource is a Git repository from which an image is built.
+type GitSource struct {
+	// URL of the repository, e.g. https://github.com/org/repo.git or git@github.com:org/repo.git.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// +optional
+	// Ref is the branch, tag or commit to build. Defaults to the repository's default branch.
+	Ref string `json:"ref,omitempty"`
+
+	// +optional
+	// ContextDir is the directory of the repository used as build context. It must contain the Dockerfile, unless
+	// DockerfileConfigMap is set. Defaults to the root of the repository.
+	ContextDir string `json:"contextDir,omitempty"`
+
+	// +optional
+	// CredentialsSecret is a secret of type kubernetes.io/basic-auth or kubernetes.io/ssh-auth used to clone the
+	// repository.
+	CredentialsSecret *v1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+}
+
+// BuildCache configures the caching of image layers in a registry, so that builds for several kernels or rebuilds
+// of the same kernel reuse the layers that did not change.
+type BuildCache struct {
+	// +optional
+	// Enabled turns layer caching on.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +optional
+	// Repository is the image repository, without a tag, in which cached layers are pushed and looked up.
+	// Defaults to the repository of the module's image with a "/cache" suffix.
+	Repository string `json:"repository,omitempty"`
+
+	// +optional
+	// TTL is the duration after which cached layers are not reused anymore.
+	// Defaults to the build backend's default. Not supported by the buildkit backend.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
 }
 
 type Sign struct {
@@ -91,6 +201,25 @@ type Sign struct {
 	// Paths inside the image for the kernel modules to sign.
 	// Full path to explicit files are required or any globs supported by the `Ash` shell
 	FilesToSign []string `json:"filesToSign,omitempty"`
+
+	// +optional
+	// ActiveDeadline is the maximum duration of each attempt to sign the image, after which it is stopped and considered failed.
+	ActiveDeadline *metav1.Duration `json:"activeDeadline,omitempty"`
+
+	// +optional
+	// MaxRetries is the number of times a failed signing is retried before it is reported as failed.
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+
+	// +optional
+	// Backoff is the delay before the first retry of a failed signing; it doubles after each failed retry, up to one hour.
+	// Defaults to 10s.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// +optional
+	// Priority orders the signing in the build queue, if the operator orders it by priority.
+	// Signings with a higher priority start first.
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // KernelMapping pairs kernel versions with a DriverContainer image.
@@ -105,6 +234,12 @@ type KernelMapping struct {
 	// Sign enables in-cluster signing for this mapping
 	Sign *Sign `json:"sign,omitempty"`
 
+	// +optional
+	// Modprobe overrides the Module's modprobe settings for this mapping.
+	// Fields that are set replace the Module's; parameters replace the Module's parameter with the same name, or are
+	// appended.
+	Modprobe *ModprobeOverride `json:"modprobe,omitempty"`
+
 	// ContainerImage is the name of the DriverContainer image that should be used to deploy the module.
 	ContainerImage string `json:"containerImage"`
 
@@ -120,6 +255,31 @@ type KernelMapping struct {
 	// Regexp is a regular expression to be match against node kernels.
 	Regexp string `json:"regexp"`
 
+	// +optional
+	// VersionRange is a set of space-separated constraints that node kernels must all satisfy, for example
+	// ">=5.14.0-284 <5.15".
+	// Each constraint is one of the >=, >, <=, < or = operators followed by a kernel version.
+	VersionRange string `json:"versionRange,omitempty"`
+
+	// +optional
+	// Architectures restricts the mapping to nodes whose architecture, as reported in the node's status, is in the
+	// list (for example amd64 or arm64).
+	Architectures []string `json:"architectures,omitempty"`
+
+	// +optional
+	// OSImageRegexp restricts the mapping to nodes whose OS image, as reported in the node's status, matches this
+	// regular expression.
+	OSImageRegexp string `json:"osImageRegexp,omitempty"`
+
+	// +optional
+	// NodeSelector restricts the mapping to nodes that have all these labels.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +optional
+	// Priority decides which mapping is used when several mappings match a node's kernel: the mapping with the
+	// highest priority wins, and the first one in the list wins among mappings with the same priority.
+	Priority int32 `json:"priority,omitempty"`
+
 	// Deprecated: please use InTreeModulesToRemove.
 	// +optional
 	// InTreeModuleToRemove specifies one in-tree kernel module that should be removed (if present)
@@ -142,6 +302,15 @@ type ModprobeArgs struct {
 	Unload []string `json:"unload,omitempty"`
 }
 
+// ModprobeParameterSource is a kernel module parameter whose value is read from a ConfigMap or a Secret.
+type ModprobeParameterSource struct {
+	// Name is the name of the kernel module parameter.
+	Name string `json:"name"`
+
+	// ValueFrom is the source of the value of the parameter.
+	ValueFrom ValueSource `json:"valueFrom"`
+}
+
 type ModprobeSpec struct {
 	// ModuleName is the name of the Module to be loaded.
 	// This field can only be unset if rawArgs is set.
@@ -153,6 +322,14 @@ type ModprobeSpec struct {
 	// The resulting loading command will be: `modprobe module_name ${Parameters}`.
 	Parameters []string `json:"parameters,omitempty"`
 
+	// ParametersFrom is an optional list of kernel module parameters whose values are read from ConfigMaps or
+	// Secrets in the Module's namespace.
+	// ConfigMap values are resolved into key=value parameters appended to Parameters when the Module is reconciled.
+	// Secret values are only read by the worker Pod loading the kernel module, and are never copied to other
+	// resources.
+	// +optional
+	ParametersFrom []ModprobeParameterSource `json:"parametersFrom,omitempty"`
+
 	// DirName is the root directory for modules.
 	// It adds `-d ${DirName}` to the modprobe command-line.
 	// +kubebuilder:default=/opt
@@ -188,6 +365,52 @@ type ModprobeSpec struct {
 	ModulesLoadingOrder []string `json:"modulesLoadingOrder,omitempty"`
 }
 
+// ModprobeOverride holds the modprobe settings of a kernel mapping that replace those of the Module.
+// Unlike ModprobeSpec, none of its fields has a default value, so that unset fields keep the Module's settings.
+type ModprobeOverride struct {
+	// ModuleName replaces the name of the kernel module to be loaded.
+	// +optional
+	ModuleName string `json:"moduleName,omitempty"`
+
+	// Parameters replace the Module's parameters with the same name, or are appended to them.
+	// +optional
+	Parameters []string `json:"parameters,omitempty"`
+
+	// ParametersFrom is a list of kernel module parameters whose values are read from ConfigMaps or Secrets, added
+	// to those of the Module.
+	// +optional
+	ParametersFrom []ModprobeParameterSource `json:"parametersFrom,omitempty"`
+
+	// DirName replaces the root directory for modules.
+	// +optional
+	DirName string `json:"dirName,omitempty"`
+
+	// Args replaces the arguments passed to modprobe before the name of the kernel module.
+	// +optional
+	Args *ModprobeArgs `json:"args,omitempty"`
+
+	// RawArgs replaces the arguments passed straight to the modprobe binary.
+	// +optional
+	RawArgs *ModprobeArgs `json:"rawArgs,omitempty"`
+
+	// FirmwarePath replaces the path of the firmware(s).
+	// +optional
+	FirmwarePath string `json:"firmwarePath,omitempty"`
+
+	// ModulesLoadingOrder replaces the loading order of the kernel modules.
+	// +optional
+	ModulesLoadingOrder []string `json:"modulesLoadingOrder,omitempty"`
+}
+
+// ParameterOverride holds kernel module parameters that only apply to some nodes.
+type ParameterOverride struct {
+	// Selector is the label selector of the nodes on which the parameters apply.
+	Selector map[string]string `json:"selector"`
+
+	// Parameters is a list of kernel module parameters in the form of key=value.
+	Parameters []string `json:"parameters"`
+}
+
 type ModuleLoaderContainerSpec struct {
 	// Build contains build instructions.
 	// +optional
@@ -223,6 +446,12 @@ type ModuleLoaderContainerSpec struct {
 	// Modprobe is a set of properties to customize which module modprobe loads and with which properties.
 	Modprobe ModprobeSpec `json:"modprobe"`
 
+	// ParameterOverrides replace or add kernel module parameters on the nodes that match their selector.
+	// They are applied in order on top of modprobe.parameters: a parameter replaces the one with the same name (the
+	// part before '='), or is appended if there is none.
+	// +optional
+	ParameterOverrides []ParameterOverride `json:"parameterOverrides,omitempty"`
+
 	// +optional
 	// RegistryTLS set the TLS configs for accessing the registry of the module-loader's image.
 	RegistryTLS TLSOptions `json:"registryTLS"`
@@ -247,8 +476,63 @@ type ModuleLoaderSpec struct {
 	// ServiceAccountName is the name of the ServiceAccount to use to run this pod.
 	// More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=Report
+	// DriftPolicy defines what KMM does when periodic drift detection finds that the kernel module is no longer
+	// loaded on a node although it is recorded as loaded.
+	// Report only raises the Drifted condition; Reload also loads the kernel module again.
+	// Drift detection is only performed if it is enabled in the operator configuration.
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// +optional
+	// ReadinessCheck is run on each node after the kernel module was loaded.
+	// The kernel-module-ready node labels are only set once the check succeeds.
+	ReadinessCheck *ReadinessCheck `json:"readinessCheck,omitempty"`
+
+	// +optional
+	// NodeLabels are added to each node on which the kernel module is loaded and ready.
+	// They are removed from the node when the kernel module is unloaded.
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	// +optional
+	// PublishNodeFeature makes KMM publish the name, version and srcversion of the loaded kernel module as a
+	// NodeFeature object that Node Feature Discovery can consume.
+	// Requires the NodeFeature CRD from Node Feature Discovery to be installed.
+	PublishNodeFeature bool `json:"publishNodeFeature,omitempty"`
+}
+
+// ReadinessCheck describes a container that is run on the node after the kernel module was loaded, to verify that it
+// is working as expected (for example that the driver successfully probed the hardware).
+type ReadinessCheck struct {
+	// +optional
+	// Image is the container image used to run the check.
+	// Defaults to the kernel module image.
+	Image string `json:"image,omitempty"`
+
+	// Command is the entrypoint of the check.
+	// It must exit with status 0 if and only if the kernel module is ready.
+	Command []string `json:"command"`
+
+	// +optional
+	// Args are the arguments passed to Command.
+	Args []string `json:"args,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=1
+	// TimeoutSeconds is the maximum duration of the check, after which it is considered failed.
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Report;Reload
+type DriftPolicy string
+
+const (
+	DriftPolicyReport DriftPolicy = "Report"
+	DriftPolicyReload DriftPolicy = "Reload"
+)
+
 type CommonContainerSpec struct {
 	// Entrypoint array. Not executed within a shell.
 	// The container image's ENTRYPOINT is used if this is not provided.
@@ -406,8 +690,111 @@ type ModuleSpec struct {
 	// all module images.
 	// +optional
 	ImageRebuildTriggerGeneration *int `json:"imageRebuildTriggerGeneration,omitempty"`
+
+	// DeletionPolicy defines what happens to the loaded kernel modules when the Module is deleted.
+	// Unload unloads the kernel module from all nodes before the Module is removed.
+	// Orphan leaves the kernel module loaded; KMM stops managing it.
+	// +optional
+	// +kubebuilder:default=Unload
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// MaintenanceWindow restricts when KMM may add, update or remove the kernel module on nodes where it is already
+	// configured. Nodes that do not have the module configured yet are not subject to the window.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Canary rolls out changes to the ModuleLoader on a subset of the nodes first.
+	// Other nodes where the Module is already configured are only updated once the canary nodes have been healthy
+	// for the soak duration.
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+
+	// OrderedUpgrade lets KMM run the ordered upgrade of the kernel module when spec.moduleLoader.container.version
+	// changes, instead of waiting for the version-module label to be changed on each node.
+	// +optional
+	OrderedUpgrade *OrderedUpgradeSpec `json:"orderedUpgrade,omitempty"`
+
+	// Paused stops KMM from making changes for this Module: NodeModulesConfigs, pull, build and signing Pods, and
+	// device plugin and DRA DaemonSets are left as they are, and no worker Pod is created on the nodes.
+	// A paused Module that is deleted is only removed from the nodes once it is unpaused.
+	// The Module's status is still updated.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// PrebuildKernels lists kernels that the targeted nodes do not run yet, for which KMM builds, signs and pushes
+	// the images ahead of time so that they are ready when the nodes boot them.
+	// +optional
+	PrebuildKernels *PrebuildKernelsSpec `json:"prebuildKernels,omitempty"`
+}
+
+// PrebuildKernelsSpec describes where the kernels to build images for ahead of time come from.
+// The kernels of all sources are combined.
+type PrebuildKernelsSpec struct {
+	// Versions is a list of kernel versions.
+	// +optional
+	Versions []string `json:"versions,omitempty"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap in the Module's namespace that holds kernel versions, separated by
+	// whitespace or newlines.
+	// +optional
+	ConfigMapKeyRef *v1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// NodeAnnotation is the key of an annotation of the targeted nodes that holds the kernel versions each node will
+	// run next, separated by commas. Those kernels are only built for the nodes that have the annotation.
+	// +optional
+	NodeAnnotation string `json:"nodeAnnotation,omitempty"`
+}
+
+// OrderedUpgradeSpec describes how KMM upgrades nodes to a new version of the kernel module.
+type OrderedUpgradeSpec struct {
+	// MaxParallel is the maximum number of nodes being upgraded at the same time.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	MaxParallel int32 `json:"maxParallel,omitempty"`
+}
+
+// CanarySpec describes the canary stage of ModuleLoader upgrades.
+type CanarySpec struct {
+	// Selector selects the canary nodes among the nodes targeted by the Module.
+	Selector map[string]string `json:"selector"`
+
+	// SoakDuration is how long the kernel module must stay healthy on all canary nodes before the change is rolled
+	// out to the other nodes.
+	SoakDuration metav1.Duration `json:"soakDuration"`
+
+	// FailureThreshold is the number of canary nodes on which the kernel module may be unhealthy without stopping
+	// the rollout.
+	// The kernel module is unhealthy on a node if its Ready condition is False or its Drifted condition is True in
+	// the NodeModulesConfig.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
 }
 
+// MaintenanceWindow describes a recurring time window.
+type MaintenanceWindow struct {
+	// Schedule is a standard 5-field cron expression describing when each window opens.
+	// Descriptors (e.g. @every) and TZ= / CRON_TZ= prefixes are not supported.
+	Schedule string `json:"schedule"`
+
+	// Duration is how long each window stays open.
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA name of the time zone Schedule is evaluated in.
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Unload;Orphan
+type DeletionPolicy string
+
+const (
+	DeletionPolicyUnload DeletionPolicy = "Unload"
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
 // DaemonSetStatus contains the status for a daemonset deployed during
 // reconciliation loop
 type DaemonSetStatus struct {
@@ -433,8 +820,94 @@ type ModuleStatus struct {
 	// When this differs from spec.imageRebuildTriggerGeneration, all module images will be re-verified and potentially rebuilt.
 	// +optional
 	ImageRebuildTriggerGeneration *int `json:"imageRebuildTriggerGeneration,omitempty"`
+	// Conditions contains the latest observations of the Module's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// MaintenanceWindow contains the state of spec.maintenanceWindow, if set.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowStatus `json:"maintenanceWindow,omitempty"`
+	// Canary contains the state of the canary stage, if spec.canary is set.
+	// +optional
+	Canary *CanaryStatus `json:"canary,omitempty"`
+	// OrderedUpgrade contains the progress of the ordered upgrade, if spec.orderedUpgrade is set.
+	// +optional
+	OrderedUpgrade *OrderedUpgradeStatus `json:"orderedUpgrade,omitempty"`
+}
+
+// OrderedUpgradeStatus contains the progress of the ordered upgrade of a Module.
+type OrderedUpgradeStatus struct {
+	// TargetVersion is the version the nodes are being upgraded to.
+	TargetVersion string `json:"targetVersion"`
+	// UpgradedNodes is the number of nodes running TargetVersion.
+	UpgradedNodes int32 `json:"upgradedNodes"`
+	// InProgressNodes is the number of nodes being upgraded.
+	InProgressNodes int32 `json:"inProgressNodes"`
+	// PendingNodes is the number of nodes waiting to be upgraded.
+	PendingNodes int32 `json:"pendingNodes"`
+}
+
+// +kubebuilder:validation:Enum=Progressing;Soaking;Succeeded;Failed
+type CanaryPhase string
+
+const (
+	// CanaryPhaseProgressing means that the change is being applied to the canary nodes.
+	CanaryPhaseProgressing CanaryPhase = "Progressing"
+	// CanaryPhaseSoaking means that the change was applied to all canary nodes, which are being monitored.
+	CanaryPhaseSoaking CanaryPhase = "Soaking"
+	// CanaryPhaseSucceeded means that the change is being applied to all nodes.
+	CanaryPhaseSucceeded CanaryPhase = "Succeeded"
+	// CanaryPhaseFailed means that the kernel module was unhealthy on too many canary nodes; the change will not
+	// be applied to the other nodes.
+	CanaryPhaseFailed CanaryPhase = "Failed"
+)
+
+// CanaryStatus contains the state of the canary stage of a Module.
+type CanaryStatus struct {
+	// Phase is the phase of the rollout of the ModuleLoader configuration identified by TargetHash.
+	Phase CanaryPhase `json:"phase"`
+	// TargetHash identifies the ModuleLoader configuration being rolled out.
+	TargetHash string `json:"targetHash"`
+	// PromotedHash identifies the last ModuleLoader configuration that passed the canary stage.
+	PromotedHash string `json:"promotedHash"`
+	// SoakStartTime is the time when all canary nodes finished applying the change.
+	// +optional
+	SoakStartTime *metav1.Time `json:"soakStartTime,omitempty"`
+	// UnhealthyNodes is the number of canary nodes on which the kernel module is unhealthy.
+	// +optional
+	UnhealthyNodes int32 `json:"unhealthyNodes,omitempty"`
+}
+
+// MaintenanceWindowStatus contains the state of a Module's maintenance window.
+type MaintenanceWindowStatus struct {
+	// Open is true if the maintenance window is currently open.
+	Open bool `json:"open"`
+	// CurrentEnd is the time the currently open window closes.
+	// +optional
+	CurrentEnd *metav1.Time `json:"currentEnd,omitempty"`
+	// NextStart is the time the next window opens.
+	// +optional
+	NextStart *metav1.Time `json:"nextStart,omitempty"`
 }
 
+const (
+	// ModuleConditionConflict is True when another Module loads or removes the same kernel module on at least one of
+	// the nodes targeted by this Module.
+	ModuleConditionConflict = "Conflict"
+
+	// ModuleConditionDrifted is True when the kernel module was found to be missing on at least one node where KMM
+	// recorded it as loaded.
+	ModuleConditionDrifted = "Drifted"
+
+	// ModuleConditionPaused is True when spec.paused is set and KMM does not make changes for the Module.
+	ModuleConditionPaused = "Paused"
+
+	// ModuleConditionPendingKernelImageMissing is True when the image of the kernel that at least one of the targeted
+	// nodes will boot next, as published in the node annotation configured in the operator, does not exist yet.
+	ModuleConditionPendingKernelImageMissing = "PendingKernelImageMissing"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:resource:scope=Namespaced
 //+kubebuilder:subresource:status
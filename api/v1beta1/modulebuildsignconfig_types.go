@@ -70,11 +70,44 @@ type ModuleBuildSignConfigSpec struct {
 type BuildSignImageState struct {
 	Image string `json:"image"`
 
+	// Status is empty while an image built for several architectures is still being built or signed, and while a
+	// failed build or sign is retried.
 	// +kubebuilder:validation:Enum=Success;Failure
-	Status BuildOrSignStatus `json:"status"`
+	// +optional
+	Status BuildOrSignStatus `json:"status,omitempty"`
 
 	// +kubebuilder:validation:Enum=BuildImage;SignImage
 	Action BuildOrSignAction `json:"action"`
+
+	// Architectures contains the status of the action for each architecture, if the image is built for several
+	// architectures.
+	// +optional
+	Architectures []BuildSignArchitectureState `json:"architectures,omitempty"`
+
+	// SourceCommit is the commit of the Git source from which the image was built.
+	// +optional
+	SourceCommit string `json:"sourceCommit,omitempty"`
+
+	// Attempts is the number of attempts made to build or sign the image.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// FailureReason is the reason of the last failed attempt.
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// QueuePosition is the position of the image in the build queue while it waits for the build and sign concurrency
+	// limits of the operator.
+	// +optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+}
+
+// BuildSignArchitectureState contains the status of the build or sign of an image for one architecture
+type BuildSignArchitectureState struct {
+	Architecture string `json:"architecture"`
+
+	// +kubebuilder:validation:Enum=Success;Failure
+	Status BuildOrSignStatus `json:"status"`
 }
 
 // ModuleBuildSignConfigStatus describes the status of the images that needed to be built/signed
@@ -62,6 +62,12 @@ type ModuleImageSpec struct {
 	// DirName is the root directory for modules, used during signing.
 	// +kubebuilder:default=/opt
 	DirName string `json:"dirName,omitempty"`
+
+	// +optional
+	// Architectures lists the architectures of the nodes that need the image.
+	// When more than one architecture is listed, the image is built and signed on a node of each architecture and
+	// a manifest list is pushed under Image.
+	Architectures []string `json:"architectures,omitempty"`
 }
 
 // ModuleImagesConfigSpec describes the images of the Module whose status needs to be verified
@@ -91,6 +97,11 @@ type ModuleImagesConfigSpec struct {
 	// Tolerations specifies the tolerations for build/sign pods.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// Paused stops KMM from creating pull, build and signing Pods for the images.
+	// Propagated from Module.spec.paused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
 }
 
 type ModuleImageState struct {
@@ -99,6 +110,16 @@ type ModuleImageState struct {
 	// status of the image
 	// one of: Exists, notExists
 	Status ImageState `json:"status"`
+	// status of the image for each architecture, if it is built for several architectures
+	// +optional
+	Architectures []ArchitectureImageState `json:"architectures,omitempty"`
+}
+
+// ArchitectureImageState is the state of the image for one architecture
+type ArchitectureImageState struct {
+	Architecture string `json:"architecture"`
+	// status of the image for that architecture
+	Status ImageState `json:"status"`
 }
 
 // ModuleImagesConfigStatus describes the status of the images that need to be verified (defined in the spec)
@@ -34,6 +34,10 @@ type ModuleConfig struct {
 	//+optional
 	InTreeModuleToRemove string       `json:"inTreeModuleToRemove,omitempty"`
 	Modprobe             ModprobeSpec `json:"modprobe"`
+	//+optional
+	// ValueSourcesDigest is a digest of the values of the modprobe parameters read from Secrets, which are not part of
+	// the configuration; it changes the configuration when those values change.
+	ValueSourcesDigest string `json:"valueSourcesDigest,omitempty"`
 }
 
 type ModuleItem struct {
@@ -53,6 +57,33 @@ type NodeModuleSpec struct {
 	ModuleItem `json:",inline"`
 
 	Config ModuleConfig `json:"config"`
+
+	//+optional
+	// DriftPolicy defines what the worker does when the kernel module is found to be missing from the node.
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	//+optional
+	// ReadinessCheck is run on the node after the kernel module was loaded.
+	ReadinessCheck *ReadinessCheck `json:"readinessCheck,omitempty"`
+
+	//+optional
+	// NodeLabels are added to the node once the kernel module is loaded and ready.
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	//+optional
+	// PublishNodeFeature defines whether the kernel module is published as a NodeFeature object.
+	PublishNodeFeature bool `json:"publishNodeFeature,omitempty"`
+
+	//+optional
+	// Orphaned is set when the Module was deleted with the Orphan deletion policy.
+	// The kernel module is left loaded: no worker Pod is created for the entry, and the entry is removed once the
+	// worker Pods that were running for it are gone.
+	Orphaned bool `json:"orphaned,omitempty"`
+
+	//+optional
+	// Paused is propagated from Module.spec.paused.
+	// No worker Pod is created for a paused entry; worker Pods that are already running are left to complete.
+	Paused bool `json:"paused,omitempty"`
 }
 
 // NodeModulesConfigSpec describes the desired state of modules on the node
@@ -71,8 +102,31 @@ type NodeModuleStatus struct {
 	Config ModuleConfig `json:"config,omitempty"`
 	//+optional
 	BootId string `json:"bootId,omitempty"`
+	//+optional
+	// LastDriftCheckTime is the last time KMM verified that the kernel module was still loaded on the node.
+	LastDriftCheckTime *metav1.Time `json:"lastDriftCheckTime,omitempty"`
+	//+optional
+	// LastReadinessCheckTime is the last time the readiness check of the kernel module completed on the node.
+	LastReadinessCheckTime *metav1.Time `json:"lastReadinessCheckTime,omitempty"`
+	//+optional
+	// SrcVersion is the srcversion of the kernel module, as reported by the kernel once it was loaded.
+	SrcVersion string `json:"srcVersion,omitempty"`
+	// Conditions hold observations about the kernel module on the node.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// NodeModuleConditionDrifted is True when the kernel module was found to be missing from the node after KMM
+	// had loaded it.
+	NodeModuleConditionDrifted = "Drifted"
+
+	// NodeModuleConditionReady is True when the readiness check of the kernel module passed on the node.
+	NodeModuleConditionReady = "Ready"
+)
+
 // NodeModuleConfigStatus is the most recently observed status of the KMM modules on node.
 // It is populated by the system and is read-only.
 // More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
@@ -82,6 +136,10 @@ type NodeModulesConfigStatus struct {
 	// +patchStrategy=merge
 	// +optional
 	Modules []NodeModuleStatus `json:"modules,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// Excluded is true when the node has the kmm.node.kubernetes.io/excluded=true label.
+	// KMM does not load, unload or check kernel modules on excluded nodes, nor change their labels.
+	// +optional
+	Excluded bool `json:"excluded,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -23,22 +23,45 @@ package v1beta1
 import (
 	"k8s.io/api/core/v1"
 	resourcev1 "k8s.io/api/resource/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchitectureImageState) DeepCopyInto(out *ArchitectureImageState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchitectureImageState.
+func (in *ArchitectureImageState) DeepCopy() *ArchitectureImageState {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchitectureImageState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Build) DeepCopyInto(out *Build) {
 	*out = *in
 	if in.BuildArgs != nil {
 		in, out := &in.BuildArgs, &out.BuildArgs
 		*out = make([]BuildArg, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.DockerfileConfigMap != nil {
 		in, out := &in.DockerfileConfigMap, &out.DockerfileConfigMap
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(BuildSource)
+		(*in).DeepCopyInto(*out)
+	}
 	out.BaseImageRegistryTLS = in.BaseImageRegistryTLS
 	if in.Secrets != nil {
 		in, out := &in.Secrets, &out.Secrets
@@ -50,6 +73,11 @@ func (in *Build) DeepCopyInto(out *Build) {
 		*out = new(KanikoParams)
 		**out = **in
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(BuildCache)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Selector != nil {
 		in, out := &in.Selector, &out.Selector
 		*out = make(map[string]string, len(*in))
@@ -57,6 +85,16 @@ func (in *Build) DeepCopyInto(out *Build) {
 			(*out)[key] = val
 		}
 	}
+	if in.ActiveDeadline != nil {
+		in, out := &in.ActiveDeadline, &out.ActiveDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Build.
@@ -72,6 +110,11 @@ func (in *Build) DeepCopy() *Build {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildArg) DeepCopyInto(out *BuildArg) {
 	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildArg.
@@ -84,9 +127,49 @@ func (in *BuildArg) DeepCopy() *BuildArg {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildCache) DeepCopyInto(out *BuildCache) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildCache.
+func (in *BuildCache) DeepCopy() *BuildCache {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildSignArchitectureState) DeepCopyInto(out *BuildSignArchitectureState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildSignArchitectureState.
+func (in *BuildSignArchitectureState) DeepCopy() *BuildSignArchitectureState {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildSignArchitectureState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildSignImageState) DeepCopyInto(out *BuildSignImageState) {
 	*out = *in
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]BuildSignArchitectureState, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildSignImageState.
@@ -99,6 +182,68 @@ func (in *BuildSignImageState) DeepCopy() *BuildSignImageState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildSource) DeepCopyInto(out *BuildSource) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildSource.
+func (in *BuildSource) DeepCopy() *BuildSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.SoakDuration = in.SoakDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
+func (in *CanarySpec) DeepCopy() *CanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	if in.SoakStartTime != nil {
+		in, out := &in.SoakStartTime, &out.SoakStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonContainerSpec) DeepCopyInto(out *CommonContainerSpec) {
 	*out = *in
@@ -256,6 +401,26 @@ func (in *DevicePluginSpec) DeepCopy() *DevicePluginSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSource) DeepCopyInto(out *GitSource) {
+	*out = *in
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSource.
+func (in *GitSource) DeepCopy() *GitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KanikoParams) DeepCopyInto(out *KanikoParams) {
 	*out = *in
@@ -284,11 +449,28 @@ func (in *KernelMapping) DeepCopyInto(out *KernelMapping) {
 		*out = new(Sign)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Modprobe != nil {
+		in, out := &in.Modprobe, &out.Modprobe
+		*out = new(ModprobeOverride)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RegistryTLS != nil {
 		in, out := &in.RegistryTLS, &out.RegistryTLS
 		*out = new(TLSOptions)
 		**out = **in
 	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.InTreeModulesToRemove != nil {
 		in, out := &in.InTreeModulesToRemove, &out.InTreeModulesToRemove
 		*out = make([]string, len(*in))
@@ -306,6 +488,45 @@ func (in *KernelMapping) DeepCopy() *KernelMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowStatus) DeepCopyInto(out *MaintenanceWindowStatus) {
+	*out = *in
+	if in.CurrentEnd != nil {
+		in, out := &in.CurrentEnd, &out.CurrentEnd
+		*out = (*in).DeepCopy()
+	}
+	if in.NextStart != nil {
+		in, out := &in.NextStart, &out.NextStart
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowStatus.
+func (in *MaintenanceWindowStatus) DeepCopy() *MaintenanceWindowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModprobeArgs) DeepCopyInto(out *ModprobeArgs) {
 	*out = *in
@@ -331,6 +552,64 @@ func (in *ModprobeArgs) DeepCopy() *ModprobeArgs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModprobeOverride) DeepCopyInto(out *ModprobeOverride) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ParametersFrom != nil {
+		in, out := &in.ParametersFrom, &out.ParametersFrom
+		*out = make([]ModprobeParameterSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = new(ModprobeArgs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RawArgs != nil {
+		in, out := &in.RawArgs, &out.RawArgs
+		*out = new(ModprobeArgs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModulesLoadingOrder != nil {
+		in, out := &in.ModulesLoadingOrder, &out.ModulesLoadingOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModprobeOverride.
+func (in *ModprobeOverride) DeepCopy() *ModprobeOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ModprobeOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModprobeParameterSource) DeepCopyInto(out *ModprobeParameterSource) {
+	*out = *in
+	in.ValueFrom.DeepCopyInto(&out.ValueFrom)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModprobeParameterSource.
+func (in *ModprobeParameterSource) DeepCopy() *ModprobeParameterSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ModprobeParameterSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModprobeSpec) DeepCopyInto(out *ModprobeSpec) {
 	*out = *in
@@ -339,6 +618,13 @@ func (in *ModprobeSpec) DeepCopyInto(out *ModprobeSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ParametersFrom != nil {
+		in, out := &in.ParametersFrom, &out.ParametersFrom
+		*out = make([]ModprobeParameterSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Args != nil {
 		in, out := &in.Args, &out.Args
 		*out = new(ModprobeArgs)
@@ -492,7 +778,9 @@ func (in *ModuleBuildSignConfigStatus) DeepCopyInto(out *ModuleBuildSignConfigSt
 	if in.Images != nil {
 		in, out := &in.Images, &out.Images
 		*out = make([]BuildSignImageState, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -561,6 +849,11 @@ func (in *ModuleImageSpec) DeepCopyInto(out *ModuleImageSpec) {
 		*out = new(TLSOptions)
 		**out = **in
 	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleImageSpec.
@@ -576,6 +869,11 @@ func (in *ModuleImageSpec) DeepCopy() *ModuleImageSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModuleImageState) DeepCopyInto(out *ModuleImageState) {
 	*out = *in
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]ArchitectureImageState, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleImageState.
@@ -692,7 +990,9 @@ func (in *ModuleImagesConfigStatus) DeepCopyInto(out *ModuleImagesConfigStatus)
 	if in.ImagesStates != nil {
 		in, out := &in.ImagesStates, &out.ImagesStates
 		*out = make([]ModuleImageState, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ImageRebuildTriggerGeneration != nil {
 		in, out := &in.ImageRebuildTriggerGeneration, &out.ImageRebuildTriggerGeneration
@@ -791,6 +1091,13 @@ func (in *ModuleLoaderContainerSpec) DeepCopyInto(out *ModuleLoaderContainerSpec
 		}
 	}
 	in.Modprobe.DeepCopyInto(&out.Modprobe)
+	if in.ParameterOverrides != nil {
+		in, out := &in.ParameterOverrides, &out.ParameterOverrides
+		*out = make([]ParameterOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.RegistryTLS = in.RegistryTLS
 	if in.InTreeModulesToRemove != nil {
 		in, out := &in.InTreeModulesToRemove, &out.InTreeModulesToRemove
@@ -813,6 +1120,18 @@ func (in *ModuleLoaderContainerSpec) DeepCopy() *ModuleLoaderContainerSpec {
 func (in *ModuleLoaderSpec) DeepCopyInto(out *ModuleLoaderSpec) {
 	*out = *in
 	in.Container.DeepCopyInto(&out.Container)
+	if in.ReadinessCheck != nil {
+		in, out := &in.ReadinessCheck, &out.ReadinessCheck
+		*out = new(ReadinessCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleLoaderSpec.
@@ -867,6 +1186,26 @@ func (in *ModuleSpec) DeepCopyInto(out *ModuleSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrderedUpgrade != nil {
+		in, out := &in.OrderedUpgrade, &out.OrderedUpgrade
+		*out = new(OrderedUpgradeSpec)
+		**out = **in
+	}
+	if in.PrebuildKernels != nil {
+		in, out := &in.PrebuildKernels, &out.PrebuildKernels
+		*out = new(PrebuildKernelsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleSpec.
@@ -890,6 +1229,28 @@ func (in *ModuleStatus) DeepCopyInto(out *ModuleStatus) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrderedUpgrade != nil {
+		in, out := &in.OrderedUpgrade, &out.OrderedUpgrade
+		*out = new(OrderedUpgradeStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleStatus.
@@ -907,6 +1268,18 @@ func (in *NodeModuleSpec) DeepCopyInto(out *NodeModuleSpec) {
 	*out = *in
 	in.ModuleItem.DeepCopyInto(&out.ModuleItem)
 	in.Config.DeepCopyInto(&out.Config)
+	if in.ReadinessCheck != nil {
+		in, out := &in.ReadinessCheck, &out.ReadinessCheck
+		*out = new(ReadinessCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeModuleSpec.
@@ -924,6 +1297,21 @@ func (in *NodeModuleStatus) DeepCopyInto(out *NodeModuleStatus) {
 	*out = *in
 	in.ModuleItem.DeepCopyInto(&out.ModuleItem)
 	in.Config.DeepCopyInto(&out.Config)
+	if in.LastDriftCheckTime != nil {
+		in, out := &in.LastDriftCheckTime, &out.LastDriftCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastReadinessCheckTime != nil {
+		in, out := &in.LastReadinessCheckTime, &out.LastReadinessCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeModuleStatus.
@@ -1039,6 +1427,88 @@ func (in *NodeModulesConfigStatus) DeepCopy() *NodeModulesConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrderedUpgradeSpec) DeepCopyInto(out *OrderedUpgradeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrderedUpgradeSpec.
+func (in *OrderedUpgradeSpec) DeepCopy() *OrderedUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrderedUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrderedUpgradeStatus) DeepCopyInto(out *OrderedUpgradeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrderedUpgradeStatus.
+func (in *OrderedUpgradeStatus) DeepCopy() *OrderedUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrderedUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterOverride) DeepCopyInto(out *ParameterOverride) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterOverride.
+func (in *ParameterOverride) DeepCopy() *ParameterOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrebuildKernelsSpec) DeepCopyInto(out *PrebuildKernelsSpec) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrebuildKernelsSpec.
+func (in *PrebuildKernelsSpec) DeepCopy() *PrebuildKernelsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrebuildKernelsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PreflightValidation) DeepCopyInto(out *PreflightValidation) {
 	*out = *in
@@ -1129,6 +1599,31 @@ func (in *PreflightValidationStatus) DeepCopy() *PreflightValidationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessCheck) DeepCopyInto(out *ReadinessCheck) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessCheck.
+func (in *ReadinessCheck) DeepCopy() *ReadinessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sign) DeepCopyInto(out *Sign) {
 	*out = *in
@@ -1148,6 +1643,16 @@ func (in *Sign) DeepCopyInto(out *Sign) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ActiveDeadline != nil {
+		in, out := &in.ActiveDeadline, &out.ActiveDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sign.
@@ -1174,3 +1679,28 @@ func (in *TLSOptions) DeepCopy() *TLSOptions {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValueSource) DeepCopyInto(out *ValueSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValueSource.
+func (in *ValueSource) DeepCopy() *ValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
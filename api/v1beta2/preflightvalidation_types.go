@@ -37,6 +37,22 @@ type PreflightValidationSpec struct {
 	// +kubebuilder:validation:Required
 	KernelVersion string `json:"kernelVersion"`
 
+	// Architecture is the architecture of the nodes the Modules are checked for, as reported in the nodes' status
+	// (for example amd64 or arm64).
+	// It is required to select the kernel mappings that restrict their nodes' architecture.
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+
+	// OSImage is the OS image of the nodes the Modules are checked for, as reported in the nodes' status.
+	// It is required to select the kernel mappings that restrict their nodes' OS image.
+	// +optional
+	OSImage string `json:"osImage,omitempty"`
+
+	// NodeLabels are the labels of the nodes the Modules are checked for.
+	// They are required to select the kernel mappings that have a node selector.
+	// +optional
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
 	// Boolean flag that determines whether images build during preflight must also
 	// be pushed to a defined repository
 	// +optional
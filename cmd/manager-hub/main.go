@@ -27,6 +27,7 @@ import (
 	"github.com/kubernetes-sigs/kernel-module-management/internal/pod"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2/textlogger"
 	clusterv1 "open-cluster-management.io/api/cluster/v1"
@@ -51,6 +52,7 @@ import (
 	"github.com/kubernetes-sigs/kernel-module-management/internal/metrics"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/nmc"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/registry"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/statusupdater"
 	//+kubebuilder:scaffold:imports
 )
@@ -118,12 +120,21 @@ func main() {
 	metricsAPI.Register()
 
 	buildArgOverrider := module.NewBuildArgOverrider()
-	resourceManager := buildsignresource.NewResourceManager(client, buildArgOverrider, scheme)
+	resourceManager := buildsignresource.NewResourceManager(client, buildArgOverrider, scheme, cfg.Job.BuildBackend,
+		registry.New(client), buildsignresource.SharedRepository{
+			Name:       cfg.Job.SharedBuildRepository,
+			Namespace:  operatorNamespace,
+			PushSecret: cfg.Job.SharedBuildRepositorySecret,
+		}, buildsignresource.RootlessProfiles{
+			Seccomp:  cfg.Job.RootlessBuilds.Seccomp(),
+			AppArmor: cfg.Job.RootlessBuilds.AppArmor(),
+		})
 
 	micAPI := mic.New(client, scheme)
 	mbscAPI := mbsc.New(client, scheme)
 	imagePullerAPI := pod.NewImagePuller(client, scheme)
-	builSignAPI := buildsign.NewManager(client, resourceManager, scheme)
+	buildQueue := buildsign.NewQueue(mgr.GetAPIReader(), &cfg.Job.Queue, metricsAPI)
+	builSignAPI := buildsign.NewManager(client, resourceManager, buildQueue, scheme)
 
 	kernelAPI := module.NewKernelMapper(buildArgOverrider)
 
@@ -152,7 +163,12 @@ func main() {
 	}
 
 	eventRecorder := mgr.GetEventRecorderFor("kmm-hub")
-	jobEventReconcilerHelper := controllers.NewJobEventReconcilerHelper(client)
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		cmd.FatalError(setupLogger, err, "could not create the Kubernetes clientset")
+	}
+
+	jobEventReconcilerHelper := controllers.NewJobEventReconcilerHelper(client, clientset)
 
 	if err = controllers.NewBuildSignEventsReconciler(client, jobEventReconcilerHelper, eventRecorder).SetupWithManager(mgr); err != nil {
 		cmd.FatalError(setupLogger, err, "unable to create controller", "name", controllers.BuildSignEventsReconcilerName)
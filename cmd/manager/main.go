@@ -42,9 +42,11 @@ import (
 	"github.com/kubernetes-sigs/kernel-module-management/internal/metrics"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/nmc"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/registry"
 	resourcev1 "k8s.io/api/resource/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2/textlogger"
 	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
@@ -127,7 +129,15 @@ func main() {
 	metricsAPI.Register()
 
 	buildArgOverriderAPI := module.NewBuildArgOverrider()
-	resourceManager := buildsignresource.NewResourceManager(client, buildArgOverriderAPI, scheme)
+	resourceManager := buildsignresource.NewResourceManager(client, buildArgOverriderAPI, scheme, cfg.Job.BuildBackend,
+		registry.New(client), buildsignresource.SharedRepository{
+			Name:       cfg.Job.SharedBuildRepository,
+			Namespace:  operatorNamespace,
+			PushSecret: cfg.Job.SharedBuildRepositorySecret,
+		}, buildsignresource.RootlessProfiles{
+			Seccomp:  cfg.Job.RootlessBuilds.Seccomp(),
+			AppArmor: cfg.Job.RootlessBuilds.AppArmor(),
+		})
 	nodeAPI := node.NewNode(client)
 	kernelAPI := module.NewKernelMapper(buildArgOverriderAPI)
 	micAPI := mic.New(client, scheme)
@@ -151,6 +161,7 @@ func main() {
 		filterAPI,
 		nodeAPI,
 		micAPI,
+		&cfg.Node,
 		scheme,
 	)
 	if err = mnc.SetupWithManager(mgr); err != nil {
@@ -178,6 +189,10 @@ func main() {
 		cmd.FatalError(setupLogger, err, "unable to create controller", "name", controllers.NodeLabelModuleVersionReconcilerName)
 	}
 
+	if err = controllers.NewOrderedUpgradeReconciler(client, filterAPI, nodeAPI).SetupWithManager(mgr); err != nil {
+		cmd.FatalError(setupLogger, err, "unable to create controller", "name", controllers.OrderedUpgradeReconcilerName)
+	}
+
 	if err = controllers.NewMICReconciler(client, micAPI, mbscAPI, imagePullerAPI, scheme).SetupWithManager(mgr); err != nil {
 		cmd.FatalError(setupLogger, err, "unable to create controller", "name", controllers.MICReconcilerName)
 	}
@@ -193,14 +208,20 @@ func main() {
 			cmd.FatalError(setupLogger, err, "unable to create controller", "name", controllers.NodeKernelClusterClaimReconcilerName)
 		}
 	} else {
-		builSignAPI := buildsign.NewManager(client, resourceManager, scheme)
+		buildQueue := buildsign.NewQueue(mgr.GetAPIReader(), &cfg.Job.Queue, metricsAPI)
+		builSignAPI := buildsign.NewManager(client, resourceManager, buildQueue, scheme)
 
 		mbscr := controllers.NewMBSCReconciler(client, builSignAPI, mbscAPI)
 		if err = mbscr.SetupWithManager(mgr); err != nil {
 			cmd.FatalError(setupLogger, err, "unable to create controller", "name", controllers.MBSCReconcilerName)
 		}
 
-		helper := controllers.NewJobEventReconcilerHelper(client)
+		clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			cmd.FatalError(setupLogger, err, "could not create the Kubernetes clientset")
+		}
+
+		helper := controllers.NewJobEventReconcilerHelper(client, clientset)
 
 		if err = controllers.NewBuildSignEventsReconciler(client, helper, eventRecorder).SetupWithManager(mgr); err != nil {
 			cmd.FatalError(setupLogger, err, "unable to create controller", "name", controllers.BuildSignEventsReconcilerName)
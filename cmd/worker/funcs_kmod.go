@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	kmmcmd "github.com/kubernetes-sigs/kernel-module-management/internal/cmd"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
@@ -43,7 +45,22 @@ func kmodLoadFunc(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	return w.LoadKmod(cmd.Context(), cfg, mountPathFlag.Value.String())
+	if err = w.LoadKmod(cmd.Context(), cfg, mountPathFlag.Value.String()); err != nil {
+		return err
+	}
+
+	srcVersion, err := w.SrcVersion(cfg)
+	if err != nil {
+		logger.Info(utils.WarnString("Could not read the srcversion of the kernel module"), "error", err)
+		return nil
+	}
+
+	// The operator reads the srcversion of the kernel module from the container's termination message.
+	if err = os.WriteFile(terminationMessagePath, []byte(srcVersion), 0644); err != nil {
+		logger.Info(utils.WarnString("Could not write the termination message"), "path", terminationMessagePath, "error", err)
+	}
+
+	return nil
 }
 
 func kmodUnloadFunc(cmd *cobra.Command, args []string) error {
@@ -59,6 +76,29 @@ func kmodUnloadFunc(cmd *cobra.Command, args []string) error {
 	return w.UnloadKmod(cmd.Context(), cfg, cmd.Flags().Lookup(worker.FlagFirmwarePath).Value.String())
 }
 
+func kmodCheckFunc(cmd *cobra.Command, args []string) error {
+	cfgPath := args[0]
+
+	logger.Info("Reading config", "path", cfgPath)
+
+	cfg, err := configHelper.ReadConfigFile(cfgPath)
+	if err != nil {
+		return fmt.Errorf("could not read config file %s: %v", cfgPath, err)
+	}
+
+	missing, err := w.CheckKmod(cfg)
+	if err != nil {
+		return fmt.Errorf("could not check kernel modules: %v", err)
+	}
+
+	// The operator reads the missing kernel modules from the container's termination message.
+	if err = os.WriteFile(terminationMessagePath, []byte(strings.Join(missing, ",")), 0644); err != nil {
+		return fmt.Errorf("could not write the termination message to %s: %v", terminationMessagePath, err)
+	}
+
+	return nil
+}
+
 func setCommandsFlags() {
 	kmodLoadCmd.Flags().String(
 		worker.FlagFirmwarePath,
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/worker"
@@ -10,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
 	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 )
 
@@ -27,10 +30,12 @@ var _ = Describe("kmodLoadFunc", func() {
 		configHelper = ch
 		wo = worker.NewMockWorker(ctrl)
 		w = wo
+		terminationMessagePath = filepath.Join(GinkgoT().TempDir(), "termination-log")
 	})
 
 	AfterEach(func() {
 		configHelper = worker.NewConfigHelper()
+		terminationMessagePath = v1.TerminationMessagePathDefault
 		w = nil
 	})
 
@@ -65,11 +70,13 @@ var _ = Describe("kmodLoadFunc", func() {
 					ch.EXPECT().ReadConfigFile(configPath).Return(cfg, nil),
 					wo.EXPECT().SetFirmwareClassPath(*flagFirmwarePath),
 					wo.EXPECT().LoadKmod(ctx, cfg, *flagFirmwarePath),
+					wo.EXPECT().SrcVersion(cfg),
 				)
 			} else {
 				gomock.InOrder(
 					ch.EXPECT().ReadConfigFile(configPath).Return(cfg, nil),
 					wo.EXPECT().LoadKmod(ctx, cfg, ""),
+					wo.EXPECT().SrcVersion(cfg),
 				)
 			}
 
@@ -83,4 +90,122 @@ var _ = Describe("kmodLoadFunc", func() {
 		Entry("fimrwarePath path defined and empty", ptr.To("")),
 		Entry("firmwarePath defined", ptr.To("/some/path")),
 	)
+
+	It("should write the srcversion to the termination message", func() {
+		cfg := &kmmv1beta1.ModuleConfig{}
+		ctx := context.TODO()
+
+		cmd := &cobra.Command{}
+		cmd.SetContext(ctx)
+		cmd.Flags().String(worker.FlagFirmwarePath, "", "")
+
+		gomock.InOrder(
+			ch.EXPECT().ReadConfigFile(configPath).Return(cfg, nil),
+			wo.EXPECT().LoadKmod(ctx, cfg, ""),
+			wo.EXPECT().SrcVersion(cfg).Return("ABCDEF0123456789", nil),
+		)
+
+		Expect(
+			kmodLoadFunc(cmd, []string{configPath}),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(os.ReadFile(terminationMessagePath)).To(BeEquivalentTo("ABCDEF0123456789"))
+	})
+
+	It("should return an error if the kernel module could not be loaded", func() {
+		cfg := &kmmv1beta1.ModuleConfig{}
+		ctx := context.TODO()
+
+		cmd := &cobra.Command{}
+		cmd.SetContext(ctx)
+		cmd.Flags().String(worker.FlagFirmwarePath, "", "")
+
+		gomock.InOrder(
+			ch.EXPECT().ReadConfigFile(configPath).Return(cfg, nil),
+			wo.EXPECT().LoadKmod(ctx, cfg, "").Return(errors.New("some error")),
+		)
+
+		Expect(
+			kmodLoadFunc(cmd, []string{configPath}),
+		).To(
+			HaveOccurred(),
+		)
+	})
+})
+
+var _ = Describe("kmodCheckFunc", func() {
+	const configPath = "/some/path"
+
+	var (
+		ch *worker.MockConfigHelper
+		wo *worker.MockWorker
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		ch = worker.NewMockConfigHelper(ctrl)
+		configHelper = ch
+		wo = worker.NewMockWorker(ctrl)
+		w = wo
+		terminationMessagePath = filepath.Join(GinkgoT().TempDir(), "termination-log")
+	})
+
+	AfterEach(func() {
+		configHelper = worker.NewConfigHelper()
+		terminationMessagePath = v1.TerminationMessagePathDefault
+		w = nil
+	})
+
+	It("should return an error if we cannot read the config", func() {
+		ch.EXPECT().ReadConfigFile(configPath).Return(nil, errors.New("some error"))
+
+		Expect(
+			kmodCheckFunc(&cobra.Command{}, []string{configPath}),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	It("should return an error if the check failed", func() {
+		cfg := &kmmv1beta1.ModuleConfig{}
+
+		gomock.InOrder(
+			ch.EXPECT().ReadConfigFile(configPath).Return(cfg, nil),
+			wo.EXPECT().CheckKmod(cfg).Return(nil, errors.New("some error")),
+		)
+
+		Expect(
+			kmodCheckFunc(&cobra.Command{}, []string{configPath}),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	DescribeTable(
+		"should write the missing modules to the termination message",
+		func(missing []string, expectedMessage string) {
+			cfg := &kmmv1beta1.ModuleConfig{}
+
+			gomock.InOrder(
+				ch.EXPECT().ReadConfigFile(configPath).Return(cfg, nil),
+				wo.EXPECT().CheckKmod(cfg).Return(missing, nil),
+			)
+
+			Expect(
+				kmodCheckFunc(&cobra.Command{}, []string{configPath}),
+			).NotTo(
+				HaveOccurred(),
+			)
+
+			Expect(
+				os.ReadFile(terminationMessagePath),
+			).To(
+				Equal([]byte(expectedMessage)),
+			)
+		},
+		Entry("nothing missing", []string{}, ""),
+		Entry("modules missing", []string{"a", "b"}, "a,b"),
+	)
 })
@@ -11,15 +11,17 @@ import (
 	kmmcmd "github.com/kubernetes-sigs/kernel-module-management/internal/cmd"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/worker"
 	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2/textlogger"
 )
 
 var (
 	Version = "undefined"
 
-	configHelper = worker.NewConfigHelper()
-	logger       logr.Logger
-	w            worker.Worker
+	configHelper           = worker.NewConfigHelper()
+	logger                 logr.Logger
+	terminationMessagePath = v1.TerminationMessagePathDefault
+	w                      worker.Worker
 )
 
 var rootCmd = &cobra.Command{
@@ -43,6 +45,13 @@ var kmodLoadCmd = &cobra.Command{
 	RunE:  kmodLoadFunc,
 }
 
+var kmodCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check that a kernel module is loaded",
+	Args:  cobra.ExactArgs(1),
+	RunE:  kmodCheckFunc,
+}
+
 var kmodUnloadCmd = &cobra.Command{
 	Use:   "unload",
 	Short: "Unload a kernel module",
@@ -56,7 +65,7 @@ func main() {
 
 	rootCmd.AddCommand(kmodCmd)
 
-	kmodCmd.AddCommand(kmodLoadCmd, kmodUnloadCmd)
+	kmodCmd.AddCommand(kmodCheckCmd, kmodLoadCmd, kmodUnloadCmd)
 
 	setCommandsFlags()
 
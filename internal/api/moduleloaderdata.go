@@ -17,6 +17,17 @@ type ModuleLoaderData struct {
 	// kernel version
 	KernelVersion string
 
+	// Architecture is the architecture of the node the data was prepared for, if any.
+	// Build and sign Pods are scheduled on nodes of that architecture.
+	Architecture string
+
+	// Architectures is only set to combine the images built or signed for several architectures into a manifest
+	// list pushed under ContainerImage.
+	Architectures []string
+
+	// OSID is the operating system ID of the node the data was prepared for, if any.
+	OSID string
+
 	// KernelNormalizedVersion is the kernel version with some characters replaced with '_' so that it can be used in
 	// a Kubernetes label or a container image tag.
 	KernelNormalizedVersion string
@@ -54,6 +65,9 @@ type ModuleLoaderData struct {
 	// Modprobe is a set of properties to customize which module modprobe loads and with which properties.
 	Modprobe kmmv1beta1.ModprobeSpec
 
+	// ParameterOverrides replace or add kernel module parameters on the nodes that match their selector.
+	ParameterOverrides []kmmv1beta1.ParameterOverride
+
 	// RegistryTLS set the TLS configs for accessing the registry of the module-loader's image.
 	RegistryTLS *kmmv1beta1.TLSOptions
 
@@ -63,6 +77,18 @@ type ModuleLoaderData struct {
 	// used for setting the owner field of pods/buildconfigs
 	Owner metav1.Object
 
+	// DriftPolicy defines what to do when the kernel module is found to be missing from a node.
+	DriftPolicy kmmv1beta1.DriftPolicy
+
+	// ReadinessCheck is run on the node after the kernel module was loaded.
+	ReadinessCheck *kmmv1beta1.ReadinessCheck
+
+	// NodeLabels are added to the nodes on which the kernel module is loaded.
+	NodeLabels map[string]string
+
+	// PublishNodeFeature defines whether the kernel module is published as a NodeFeature object.
+	PublishNodeFeature bool
+
 	// If specified, the pod's tolerations.
 	// +optional
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,6 +14,7 @@ import (
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/kernel"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -20,29 +23,58 @@ import (
 //go:generate mockgen -source=manager.go -package=buildsign -destination=mock_manager.go
 
 type Manager interface {
-	GetStatus(ctx context.Context, name, namespace, kernelVersion string,
+	GetStatus(ctx context.Context, name, namespace, kernelVersion, arch string,
 		action kmmv1beta1.BuildOrSignAction, owner metav1.Object) (kmmv1beta1.BuildOrSignStatus, error)
+	GetSourceCommit(ctx context.Context, name, namespace, kernelVersion, arch string, owner metav1.Object) (string, error)
+	GetAttempts(ctx context.Context, name, namespace, kernelVersion, arch string,
+		action kmmv1beta1.BuildOrSignAction, owner metav1.Object) (int32, string, error)
 	Sync(ctx context.Context, mld *api.ModuleLoaderData, pushImage bool, action kmmv1beta1.BuildOrSignAction, owner metav1.Object) error
+	PublishSharedImage(ctx context.Context, mld *api.ModuleLoaderData, owner metav1.Object) error
 	GarbageCollect(ctx context.Context, name, namespace string, action kmmv1beta1.BuildOrSignAction, owner metav1.Object) ([]string, error)
 }
 
+const (
+	defaultRetryBackoff = 10 * time.Second
+	maxRetryBackoff     = time.Hour
+)
+
+// RetryAfterError is returned by Sync when a failed resource will be retried once After has elapsed.
+type RetryAfterError struct {
+	After time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("the failed resource will be retried in %s", e.After)
+}
+
+// QueuedError is returned by Sync when the resource waits in the build queue before being created.
+type QueuedError struct {
+	Position int
+}
+
+func (e *QueuedError) Error() string {
+	return fmt.Sprintf("the resource is queued at position %d", e.Position)
+}
+
 type manager struct {
 	client          client.Client
 	resourceManager ResourceManager
+	queue           Queue
 }
 
-func NewManager(client client.Client, resourceManager ResourceManager, scheme *runtime.Scheme) Manager {
+func NewManager(client client.Client, resourceManager ResourceManager, queue Queue, scheme *runtime.Scheme) Manager {
 	return &manager{
 		client:          client,
 		resourceManager: resourceManager,
+		queue:           queue,
 	}
 }
 
-func (m *manager) GetStatus(ctx context.Context, name, namespace, kernelVersion string,
+func (m *manager) GetStatus(ctx context.Context, name, namespace, kernelVersion, arch string,
 	action kmmv1beta1.BuildOrSignAction, owner metav1.Object) (kmmv1beta1.BuildOrSignStatus, error) {
 
 	normalizedKernel := kernel.DNSSafeKernelVersion(kernelVersion)
-	foundResource, err := m.resourceManager.GetResourceByKernel(ctx, name, namespace, normalizedKernel, action, owner)
+	foundResource, err := m.resourceManager.GetResourceByKernel(ctx, name, namespace, normalizedKernel, arch, action, owner)
 	if err != nil {
 		if !errors.Is(err, ErrNoMatchingBuildSignResource) {
 			return kmmv1beta1.BuildOrSignStatus(""), fmt.Errorf("failed to get resource %s/%s, action %s: %v",
@@ -66,6 +98,64 @@ func (m *manager) GetStatus(ctx context.Context, name, namespace, kernelVersion
 	return kmmv1beta1.BuildOrSignStatus(""), nil
 }
 
+// GetSourceCommit returns the commit of the Git source cloned by the build resource for kernelVersion and arch, or
+// an empty string if there is no such resource or it has not cloned its source yet.
+func (m *manager) GetSourceCommit(ctx context.Context, name, namespace, kernelVersion, arch string,
+	owner metav1.Object) (string, error) {
+
+	normalizedKernel := kernel.DNSSafeKernelVersion(kernelVersion)
+	foundResource, err := m.resourceManager.GetResourceByKernel(ctx, name, namespace, normalizedKernel, arch,
+		kmmv1beta1.BuildImage, owner)
+	if err != nil {
+		if !errors.Is(err, ErrNoMatchingBuildSignResource) {
+			return "", fmt.Errorf("failed to get build resource %s/%s: %v", namespace, name, err)
+		}
+		return "", nil
+	}
+
+	commit, err := m.resourceManager.GetResourceSourceCommit(foundResource)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the source commit of the build resource %s/%s: %v",
+			foundResource.GetNamespace(), foundResource.GetName(), err)
+	}
+
+	return commit, nil
+}
+
+// GetAttempts returns the number of attempts made by the resource for kernelVersion and arch and, if its last
+// attempt failed, the reason of the failure. It returns 0 attempts if there is no such resource.
+func (m *manager) GetAttempts(ctx context.Context, name, namespace, kernelVersion, arch string,
+	action kmmv1beta1.BuildOrSignAction, owner metav1.Object) (int32, string, error) {
+
+	normalizedKernel := kernel.DNSSafeKernelVersion(kernelVersion)
+	foundResource, err := m.resourceManager.GetResourceByKernel(ctx, name, namespace, normalizedKernel, arch, action, owner)
+	if err != nil {
+		if !errors.Is(err, ErrNoMatchingBuildSignResource) {
+			return 0, "", fmt.Errorf("failed to get resource %s/%s, action %s: %v", namespace, name, action, err)
+		}
+		return 0, "", nil
+	}
+
+	attempt := resourceAttempt(foundResource)
+
+	status, err := m.resourceManager.GetResourceStatus(foundResource)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get status for the resource %s/%s, action %s: %v",
+			foundResource.GetNamespace(), foundResource.GetName(), action, err)
+	}
+	if status != StatusFailed {
+		return attempt, "", nil
+	}
+
+	reason, _, err := m.resourceManager.GetResourceFailure(foundResource)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get the failure of the resource %s/%s, action %s: %v",
+			foundResource.GetNamespace(), foundResource.GetName(), action, err)
+	}
+
+	return attempt, reason, nil
+}
+
 func (m *manager) Sync(ctx context.Context, mld *api.ModuleLoaderData, pushImage bool, action kmmv1beta1.BuildOrSignAction,
 	owner metav1.Object) error {
 
@@ -83,13 +173,26 @@ func (m *manager) Sync(ctx context.Context, mld *api.ModuleLoaderData, pushImage
 	}
 
 	resource, err := m.resourceManager.GetResourceByKernel(ctx, mld.Name, mld.Namespace, mld.KernelNormalizedVersion,
-		action, owner)
+		mld.Architecture, action, owner)
 
 	if err != nil {
 		if !errors.Is(err, ErrNoMatchingBuildSignResource) {
 			return fmt.Errorf("error getting the %s resource: %v", action, err)
 		}
 
+		copyTemplate, err := m.copySharedImage(ctx, mld, pushImage, action, resourceTemplate)
+		if err != nil {
+			logger.Info(utils.WarnString(fmt.Sprintf("failed to copy the image of an identical build, building the image: %v", err)))
+		}
+
+		if copyTemplate != nil {
+			// Checking the copied image is cheap: it does not go through the build queue.
+			logger.Info("Copied the image of an identical build from the shared build repository")
+			resourceTemplate = copyTemplate
+		} else if err = m.admit(ctx, mld, action); err != nil {
+			return err
+		}
+
 		logger.Info("Creating resource")
 		err = m.resourceManager.CreateResource(ctx, resourceTemplate)
 		if err != nil {
@@ -115,6 +218,123 @@ func (m *manager) Sync(ctx context.Context, mld *api.ModuleLoaderData, pushImage
 		if err != nil {
 			logger.Info(utils.WarnString(fmt.Sprintf("failed to delete %s resource %s: %v", action, resource.GetName(), err)))
 		}
+
+		return nil
+	}
+
+	return m.retryIfFailed(ctx, mld, action, resource, resourceTemplate)
+}
+
+// copySharedImage copies the image of an identical build from the shared build repository and returns a resource
+// checking the copy in place of buildTemplate, or nil if action does not build an image to push or if there is no
+// identical build.
+func (m *manager) copySharedImage(ctx context.Context, mld *api.ModuleLoaderData, pushImage bool,
+	action kmmv1beta1.BuildOrSignAction, buildTemplate metav1.Object) (metav1.Object, error) {
+
+	if action != kmmv1beta1.BuildImage || !pushImage {
+		return nil, nil
+	}
+
+	return m.resourceManager.CopySharedImage(ctx, mld, buildTemplate)
+}
+
+// PublishSharedImage publishes the image pushed by the completed build resource of mld to the shared build
+// repository, if the build is shared.
+func (m *manager) PublishSharedImage(ctx context.Context, mld *api.ModuleLoaderData, owner metav1.Object) error {
+	resource, err := m.resourceManager.GetResourceByKernel(ctx, mld.Name, mld.Namespace, mld.KernelNormalizedVersion,
+		mld.Architecture, kmmv1beta1.BuildImage, owner)
+	if err != nil {
+		if !errors.Is(err, ErrNoMatchingBuildSignResource) {
+			return fmt.Errorf("failed to get build resource %s/%s: %v", mld.Namespace, mld.Name, err)
+		}
+		return nil
+	}
+
+	status, err := m.resourceManager.GetResourceStatus(resource)
+	if err != nil {
+		return fmt.Errorf("could not get the status of resource %s: %v", resource.GetName(), err)
+	}
+	if status != StatusCompleted {
+		return nil
+	}
+
+	return m.resourceManager.PublishSharedImage(ctx, mld, resource)
+}
+
+// retryIfFailed replaces resource by a new attempt made from resourceTemplate if resource failed, the action has
+// retries left and the backoff delay since the failure has elapsed. It returns a RetryAfterError if the delay has not
+// elapsed yet.
+func (m *manager) retryIfFailed(ctx context.Context, mld *api.ModuleLoaderData, action kmmv1beta1.BuildOrSignAction,
+	resource, resourceTemplate metav1.Object) error {
+
+	maxRetries := MaxRetries(mld.Build, mld.Sign, action)
+	if maxRetries == 0 {
+		return nil
+	}
+
+	status, err := m.resourceManager.GetResourceStatus(resource)
+	if err != nil {
+		return fmt.Errorf("could not get the status of resource %s: %v", resource.GetName(), err)
+	}
+	if status != StatusFailed {
+		return nil
+	}
+
+	logger := log.FromContext(ctx).WithValues("name", resource.GetName(), "action", action)
+
+	attempt := resourceAttempt(resource)
+	if attempt > maxRetries {
+		logger.Info("The resource failed and has no retries left", "attempts", attempt)
+		return nil
+	}
+
+	_, failedAt, err := m.resourceManager.GetResourceFailure(resource)
+	if err != nil {
+		return fmt.Errorf("could not get the failure of resource %s: %v", resource.GetName(), err)
+	}
+
+	if wait := time.Until(failedAt.Add(retryBackoff(mld.Build, mld.Sign, action, attempt))); wait > 0 {
+		return &RetryAfterError{After: wait}
+	}
+
+	if err = m.admit(ctx, mld, action); err != nil {
+		return err
+	}
+
+	logger.Info("Retrying the failed resource", "attempt", attempt+1)
+
+	if err = m.resourceManager.DeleteResource(ctx, resource); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("could not delete the failed resource %s: %v", resource.GetName(), err)
+	}
+
+	setResourceAttempt(resourceTemplate, attempt+1)
+
+	if err = m.resourceManager.CreateResource(ctx, resourceTemplate); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("could not create resource: %v", err)
+	}
+
+	return nil
+}
+
+// admit returns a QueuedError if the resource of mld must wait in the build queue before being created.
+func (m *manager) admit(ctx context.Context, mld *api.ModuleLoaderData, action kmmv1beta1.BuildOrSignAction) error {
+	item := QueueItem{
+		Name:                    mld.Name,
+		Namespace:               mld.Namespace,
+		KernelVersion:           mld.KernelVersion,
+		Architecture:            mld.Architecture,
+		Action:                  action,
+		KernelNormalizedVersion: mld.KernelNormalizedVersion,
+	}
+
+	position, err := m.queue.Admit(ctx, item, priority(mld.Build, mld.Sign, action))
+	if err != nil {
+		return fmt.Errorf("could not check the build queue: %v", err)
+	}
+
+	if position > 0 {
+		log.FromContext(ctx).Info("The resource is queued", "action", action, "position", position)
+		return &QueuedError{Position: position}
 	}
 
 	return nil
@@ -150,3 +370,72 @@ func (m *manager) GarbageCollect(ctx context.Context, name, namespace string, ac
 	}
 	return deleteResourceNames, errors.Join(errs...)
 }
+
+// MaxRetries returns the number of times a failed action may be retried.
+func MaxRetries(build *kmmv1beta1.Build, sign *kmmv1beta1.Sign, action kmmv1beta1.BuildOrSignAction) int32 {
+	switch {
+	case action == kmmv1beta1.BuildImage && build != nil:
+		return build.MaxRetries
+	case action == kmmv1beta1.SignImage && sign != nil:
+		return sign.MaxRetries
+	}
+
+	return 0
+}
+
+// priority returns the priority of the action in the build queue.
+func priority(build *kmmv1beta1.Build, sign *kmmv1beta1.Sign, action kmmv1beta1.BuildOrSignAction) int32 {
+	switch {
+	case action == kmmv1beta1.BuildImage && build != nil:
+		return build.Priority
+	case action == kmmv1beta1.SignImage && sign != nil:
+		return sign.Priority
+	}
+
+	return 0
+}
+
+// retryBackoff returns the delay between the failure of attempt and the next attempt.
+func retryBackoff(build *kmmv1beta1.Build, sign *kmmv1beta1.Sign, action kmmv1beta1.BuildOrSignAction, attempt int32) time.Duration {
+	var backoff *metav1.Duration
+
+	switch {
+	case action == kmmv1beta1.BuildImage && build != nil:
+		backoff = build.Backoff
+	case action == kmmv1beta1.SignImage && sign != nil:
+		backoff = sign.Backoff
+	}
+
+	delay := defaultRetryBackoff
+	if backoff != nil {
+		delay = backoff.Duration
+	}
+
+	for i := int32(1); i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryBackoff)
+}
+
+// resourceAttempt returns the attempt number of a resource; the first attempt has no annotation.
+func resourceAttempt(obj metav1.Object) int32 {
+	attempt, err := strconv.ParseInt(obj.GetAnnotations()[constants.AttemptAnnotation], 10, 32)
+	if err != nil || attempt < 1 {
+		return 1
+	}
+
+	return int32(attempt)
+}
+
+// setResourceAttempt makes obj the given attempt of its resource, under a name of its own.
+func setResourceAttempt(obj metav1.Object, attempt int32) {
+	obj.SetName(fmt.Sprintf("%s-attempt-%d", obj.GetName(), attempt))
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[constants.AttemptAnnotation] = strconv.Itoa(int(attempt))
+	obj.SetAnnotations(annotations)
+}
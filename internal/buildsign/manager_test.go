@@ -2,7 +2,9 @@ package buildsign
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -15,9 +17,146 @@ import (
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/kernel"
 )
 
+var _ = Describe("GetSourceCommit", func() {
+	var (
+		ctrl                *gomock.Controller
+		mockResourceManager *MockResourceManager
+		mgr                 Manager
+	)
+	const (
+		mbscName      = "some-name"
+		mbscNamespace = "some-namespace"
+		kernelVersion = "some version"
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockResourceManager = NewMockResourceManager(ctrl)
+		mgr = NewManager(client.NewMockClient(ctrl), mockResourceManager, nil, scheme)
+	})
+
+	ctx := context.Background()
+	testMBSC := kmmv1beta1.ModuleBuildSignConfig{}
+	normalizedKernel := kernel.DNSSafeKernelVersion(kernelVersion)
+
+	It("should return an empty commit if there is no build resource", func() {
+		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel, "arm64",
+			kmmv1beta1.BuildImage, &testMBSC).
+			Return(nil, ErrNoMatchingBuildSignResource)
+
+		commit, err := mgr.GetSourceCommit(ctx, mbscName, mbscNamespace, kernelVersion, "arm64", &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commit).To(BeEmpty())
+	})
+
+	It("should return an error if the build resource cannot be listed", func() {
+		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel, "",
+			kmmv1beta1.BuildImage, &testMBSC).
+			Return(nil, fmt.Errorf("some error"))
+
+		_, err := mgr.GetSourceCommit(ctx, mbscName, mbscNamespace, kernelVersion, "", &testMBSC)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return the commit of the build resource", func() {
+		foundPod := v1.Pod{}
+		gomock.InOrder(
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel, "",
+				kmmv1beta1.BuildImage, &testMBSC).
+				Return(&foundPod, nil),
+			mockResourceManager.EXPECT().GetResourceSourceCommit(&foundPod).Return("abc123", nil),
+		)
+
+		commit, err := mgr.GetSourceCommit(ctx, mbscName, mbscNamespace, kernelVersion, "", &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commit).To(Equal("abc123"))
+	})
+})
+
+var _ = Describe("PublishSharedImage", func() {
+	var (
+		ctrl                *gomock.Controller
+		mockResourceManager *MockResourceManager
+		mgr                 Manager
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockResourceManager = NewMockResourceManager(ctrl)
+		mgr = NewManager(client.NewMockClient(ctrl), mockResourceManager, nil, scheme)
+	})
+
+	ctx := context.Background()
+	testMBSC := kmmv1beta1.ModuleBuildSignConfig{}
+	mld := api.ModuleLoaderData{
+		Name:                    "some-name",
+		Namespace:               "some-namespace",
+		KernelNormalizedVersion: "some-version",
+		Architecture:            "arm64",
+	}
+
+	It("should do nothing if there is no build resource", func() {
+		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mld.Name, mld.Namespace, mld.KernelNormalizedVersion, "arm64",
+			kmmv1beta1.BuildImage, &testMBSC).
+			Return(nil, ErrNoMatchingBuildSignResource)
+
+		Expect(
+			mgr.PublishSharedImage(ctx, &mld, &testMBSC),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	It("should return an error if the build resource cannot be listed", func() {
+		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mld.Name, mld.Namespace, mld.KernelNormalizedVersion, "arm64",
+			kmmv1beta1.BuildImage, &testMBSC).
+			Return(nil, fmt.Errorf("some error"))
+
+		Expect(
+			mgr.PublishSharedImage(ctx, &mld, &testMBSC),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	It("should not publish the image of a build that did not complete", func() {
+		foundPod := v1.Pod{}
+		gomock.InOrder(
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mld.Name, mld.Namespace, mld.KernelNormalizedVersion,
+				"arm64", kmmv1beta1.BuildImage, &testMBSC).
+				Return(&foundPod, nil),
+			mockResourceManager.EXPECT().GetResourceStatus(&foundPod).Return(StatusFailed, nil),
+		)
+
+		Expect(
+			mgr.PublishSharedImage(ctx, &mld, &testMBSC),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	It("should publish the image of a completed build", func() {
+		foundPod := v1.Pod{}
+		gomock.InOrder(
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mld.Name, mld.Namespace, mld.KernelNormalizedVersion,
+				"arm64", kmmv1beta1.BuildImage, &testMBSC).
+				Return(&foundPod, nil),
+			mockResourceManager.EXPECT().GetResourceStatus(&foundPod).Return(StatusCompleted, nil),
+			mockResourceManager.EXPECT().PublishSharedImage(ctx, &mld, &foundPod).Return(errors.New("some error")),
+		)
+
+		Expect(
+			mgr.PublishSharedImage(ctx, &mld, &testMBSC),
+		).To(
+			HaveOccurred(),
+		)
+	})
+})
+
 var _ = Describe("GetStatus", func() {
 	var (
 		ctrl                *gomock.Controller
@@ -36,7 +175,7 @@ var _ = Describe("GetStatus", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
 		mockResourceManager = NewMockResourceManager(ctrl)
-		mgr = NewManager(clnt, mockResourceManager, scheme)
+		mgr = NewManager(clnt, mockResourceManager, nil, scheme)
 	})
 
 	ctx := context.Background()
@@ -44,22 +183,22 @@ var _ = Describe("GetStatus", func() {
 
 	It("failed flow, GetResourceByKernel fails", func() {
 		normalizedKernel := kernel.DNSSafeKernelVersion(kernelVersion)
-		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel,
+		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel, "",
 			kmmv1beta1.BuildImage, &testMBSC).
 			Return(nil, fmt.Errorf("some error"))
 
-		status, err := mgr.GetStatus(ctx, mbscName, mbscNamespace, kernelVersion, kmmv1beta1.BuildImage, &testMBSC)
+		status, err := mgr.GetStatus(ctx, mbscName, mbscNamespace, kernelVersion, "", kmmv1beta1.BuildImage, &testMBSC)
 		Expect(err).To(HaveOccurred())
 		Expect(status).To(Equal(kmmv1beta1.BuildOrSignStatus("")))
 	})
 
 	It("GetResourceByKernel returns pod does not exists", func() {
 		normalizedKernel := kernel.DNSSafeKernelVersion(kernelVersion)
-		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel,
+		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel, "",
 			kmmv1beta1.BuildImage, &testMBSC).
 			Return(nil, ErrNoMatchingBuildSignResource)
 
-		status, err := mgr.GetStatus(ctx, mbscName, mbscNamespace, kernelVersion, kmmv1beta1.BuildImage, &testMBSC)
+		status, err := mgr.GetStatus(ctx, mbscName, mbscNamespace, kernelVersion, "", kmmv1beta1.BuildImage, &testMBSC)
 		Expect(err).To(BeNil())
 		Expect(status).To(Equal(kmmv1beta1.BuildOrSignStatus("")))
 	})
@@ -68,13 +207,13 @@ var _ = Describe("GetStatus", func() {
 		foundPod := v1.Pod{}
 		normalizedKernel := kernel.DNSSafeKernelVersion(kernelVersion)
 		gomock.InOrder(
-			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel,
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel, "",
 				kmmv1beta1.BuildImage, &testMBSC).
 				Return(&foundPod, nil),
 			mockResourceManager.EXPECT().GetResourceStatus(&foundPod).Return(Status(""), fmt.Errorf("some error")),
 		)
 
-		status, err := mgr.GetStatus(ctx, mbscName, mbscNamespace, kernelVersion, kmmv1beta1.BuildImage, &testMBSC)
+		status, err := mgr.GetStatus(ctx, mbscName, mbscNamespace, kernelVersion, "", kmmv1beta1.BuildImage, &testMBSC)
 		Expect(err).To(HaveOccurred())
 		Expect(status).To(Equal(kmmv1beta1.BuildOrSignStatus("")))
 	})
@@ -83,12 +222,12 @@ var _ = Describe("GetStatus", func() {
 		foundPod := v1.Pod{}
 		normalizedKernel := kernel.DNSSafeKernelVersion(kernelVersion)
 		gomock.InOrder(
-			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel,
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel, "",
 				kmmv1beta1.BuildImage, &testMBSC).
 				Return(&foundPod, nil),
 			mockResourceManager.EXPECT().GetResourceStatus(&foundPod).Return(podStatus, nil),
 		)
-		status, err := mgr.GetStatus(ctx, mbscName, mbscNamespace, kernelVersion, kmmv1beta1.BuildImage, &testMBSC)
+		status, err := mgr.GetStatus(ctx, mbscName, mbscNamespace, kernelVersion, "", kmmv1beta1.BuildImage, &testMBSC)
 		Expect(err).To(BeNil())
 		Expect(status).To(Equal(expectedStatus))
 	},
@@ -104,6 +243,7 @@ var _ = Describe("Sync", func() {
 		ctrl                *gomock.Controller
 		clnt                *client.MockClient
 		mockResourceManager *MockResourceManager
+		mockQueue           *MockQueue
 		mgr                 Manager
 	)
 	const (
@@ -117,7 +257,8 @@ var _ = Describe("Sync", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
 		mockResourceManager = NewMockResourceManager(ctrl)
-		mgr = NewManager(clnt, mockResourceManager, scheme)
+		mockQueue = NewMockQueue(ctrl)
+		mgr = NewManager(clnt, mockResourceManager, mockQueue, scheme)
 	})
 
 	ctx := context.Background()
@@ -146,7 +287,7 @@ var _ = Describe("Sync", func() {
 		gomock.InOrder(
 			mockResourceManager.EXPECT().MakeResourceTemplate(ctx, testMLD, &testMBSC, true, kmmv1beta1.BuildImage).
 				Return(nil, nil),
-			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion,
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion, "",
 				kmmv1beta1.BuildImage, &testMBSC).
 				Return(nil, fmt.Errorf("some error")),
 		)
@@ -159,9 +300,11 @@ var _ = Describe("Sync", func() {
 		gomock.InOrder(
 			mockResourceManager.EXPECT().MakeResourceTemplate(ctx, testMLD, &testMBSC, true, kmmv1beta1.BuildImage).
 				Return(&testTemplate, nil),
-			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion,
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion, "",
 				kmmv1beta1.BuildImage, &testMBSC).
 				Return(nil, ErrNoMatchingBuildSignResource),
+			mockResourceManager.EXPECT().CopySharedImage(ctx, testMLD, &testTemplate).Return(nil, nil),
+			mockQueue.EXPECT().Admit(ctx, gomock.Any(), int32(0)),
 			mockResourceManager.EXPECT().CreateResource(ctx, &testTemplate).Return(fmt.Errorf("some error")),
 		)
 		err := mgr.Sync(ctx, testMLD, true, kmmv1beta1.BuildImage, &testMBSC)
@@ -175,22 +318,57 @@ var _ = Describe("Sync", func() {
 		gomock.InOrder(
 			mockResourceManager.EXPECT().MakeResourceTemplate(ctx, testMLD, &testMBSC, true, kmmv1beta1.BuildImage).
 				Return(&testTemplate, nil),
-			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion,
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion, "",
 				kmmv1beta1.BuildImage, &testMBSC).
 				Return(nil, ErrNoMatchingBuildSignResource),
+			mockResourceManager.EXPECT().CopySharedImage(ctx, testMLD, &testTemplate).Return(nil, nil),
+			mockQueue.EXPECT().Admit(ctx, gomock.Any(), int32(0)),
 			mockResourceManager.EXPECT().CreateResource(ctx, &testTemplate).Return(alreadyExistsErr),
 		)
 		err := mgr.Sync(ctx, testMLD, true, kmmv1beta1.BuildImage, &testMBSC)
 		Expect(err).To(BeNil())
 	})
 
+	It("should copy the image of an identical build instead of building it", func() {
+		testTemplate := v1.Pod{}
+		copyTemplate := v1.Pod{}
+		gomock.InOrder(
+			mockResourceManager.EXPECT().MakeResourceTemplate(ctx, testMLD, &testMBSC, true, kmmv1beta1.BuildImage).
+				Return(&testTemplate, nil),
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion, "",
+				kmmv1beta1.BuildImage, &testMBSC).
+				Return(nil, ErrNoMatchingBuildSignResource),
+			mockResourceManager.EXPECT().CopySharedImage(ctx, testMLD, &testTemplate).Return(&copyTemplate, nil),
+			mockResourceManager.EXPECT().CreateResource(ctx, &copyTemplate),
+		)
+		err := mgr.Sync(ctx, testMLD, true, kmmv1beta1.BuildImage, &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should build the image if looking for an identical build failed", func() {
+		testTemplate := v1.Pod{}
+		gomock.InOrder(
+			mockResourceManager.EXPECT().MakeResourceTemplate(ctx, testMLD, &testMBSC, true, kmmv1beta1.BuildImage).
+				Return(&testTemplate, nil),
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion, "",
+				kmmv1beta1.BuildImage, &testMBSC).
+				Return(nil, ErrNoMatchingBuildSignResource),
+			mockResourceManager.EXPECT().CopySharedImage(ctx, testMLD, &testTemplate).
+				Return(nil, fmt.Errorf("some error")),
+			mockQueue.EXPECT().Admit(ctx, gomock.Any(), int32(0)),
+			mockResourceManager.EXPECT().CreateResource(ctx, &testTemplate),
+		)
+		err := mgr.Sync(ctx, testMLD, true, kmmv1beta1.BuildImage, &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
 	It("IsResourceChanged failed", func() {
 		testTemplate := v1.Pod{}
 		testPod := v1.Pod{}
 		gomock.InOrder(
 			mockResourceManager.EXPECT().MakeResourceTemplate(ctx, testMLD, &testMBSC, true, kmmv1beta1.BuildImage).
 				Return(&testTemplate, nil),
-			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion,
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion, "",
 				kmmv1beta1.BuildImage, &testMBSC).
 				Return(&testPod, nil),
 			mockResourceManager.EXPECT().IsResourceChanged(&testPod, &testTemplate).Return(false, fmt.Errorf("some error")),
@@ -205,7 +383,7 @@ var _ = Describe("Sync", func() {
 		gomock.InOrder(
 			mockResourceManager.EXPECT().MakeResourceTemplate(ctx, testMLD, &testMBSC, true, kmmv1beta1.BuildImage).
 				Return(&testTemplate, nil),
-			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion,
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion, "",
 				kmmv1beta1.BuildImage, &testMBSC).
 				Return(&testPod, nil),
 			mockResourceManager.EXPECT().IsResourceChanged(&testPod, &testTemplate).Return(true, nil),
@@ -234,9 +412,13 @@ var _ = Describe("Sync", func() {
 		if !podExists {
 			getPodError = ErrNoMatchingBuildSignResource
 		}
-		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion,
+		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion, "",
 			testAction, &testMBSC).Return(&existingTestPod, getPodError)
 		if !podExists {
+			if buildAction && pushImage {
+				mockResourceManager.EXPECT().CopySharedImage(ctx, testMLD, &testPodTemplate).Return(nil, nil)
+			}
+			mockQueue.EXPECT().Admit(ctx, gomock.Any(), int32(0))
 			mockResourceManager.EXPECT().CreateResource(ctx, &testPodTemplate).Return(nil)
 			goto executeTestFunction
 		}
@@ -256,6 +438,229 @@ var _ = Describe("Sync", func() {
 		Entry("action build, build pod exists, pod has changed", true, true, true, false),
 		Entry("action sign, sign pod exists, pod has changed", false, true, true, false),
 	)
+
+	Context("retries", func() {
+		var (
+			retryMLD     *api.ModuleLoaderData
+			testTemplate *v1.Pod
+			failedPod    *v1.Pod
+		)
+
+		BeforeEach(func() {
+			retryMLD = &api.ModuleLoaderData{
+				Name:                    mbscName,
+				Namespace:               mbscNamespace,
+				KernelNormalizedVersion: kernelVersion,
+				Build: &kmmv1beta1.Build{
+					MaxRetries: 2,
+					Backoff:    &metav1.Duration{Duration: time.Minute},
+				},
+			}
+			testTemplate = &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "some-name-build-some-version"}}
+			failedPod = &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "some-name-build-some-version"}}
+
+			gomock.InOrder(
+				mockResourceManager.EXPECT().MakeResourceTemplate(ctx, retryMLD, &testMBSC, true, kmmv1beta1.BuildImage).
+					Return(testTemplate, nil),
+				mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion, "",
+					kmmv1beta1.BuildImage, &testMBSC).
+					Return(failedPod, nil),
+				mockResourceManager.EXPECT().IsResourceChanged(failedPod, testTemplate).Return(false, nil),
+				mockResourceManager.EXPECT().GetResourceStatus(failedPod).Return(StatusFailed, nil),
+			)
+		})
+
+		It("should replace the failed resource by a new attempt once the backoff elapsed", func() {
+			gomock.InOrder(
+				mockResourceManager.EXPECT().GetResourceFailure(failedPod).
+					Return("DeadlineExceeded", time.Now().Add(-2*time.Minute), nil),
+				mockQueue.EXPECT().Admit(ctx, gomock.Any(), int32(0)),
+				mockResourceManager.EXPECT().DeleteResource(ctx, failedPod),
+				mockResourceManager.EXPECT().CreateResource(ctx, testTemplate),
+			)
+
+			Expect(
+				mgr.Sync(ctx, retryMLD, true, kmmv1beta1.BuildImage, &testMBSC),
+			).NotTo(
+				HaveOccurred(),
+			)
+			Expect(testTemplate.Name).To(Equal("some-name-build-some-version-attempt-2"))
+			Expect(testTemplate.Annotations).To(HaveKeyWithValue(constants.AttemptAnnotation, "2"))
+		})
+
+		It("should double the backoff after each attempt", func() {
+			failedPod.Annotations = map[string]string{constants.AttemptAnnotation: "2"}
+
+			mockResourceManager.EXPECT().GetResourceFailure(failedPod).
+				Return("DeadlineExceeded", time.Now().Add(-90*time.Second), nil)
+
+			err := mgr.Sync(ctx, retryMLD, true, kmmv1beta1.BuildImage, &testMBSC)
+
+			var retryErr *RetryAfterError
+			Expect(errors.As(err, &retryErr)).To(BeTrue())
+			Expect(retryErr.After).To(BeNumerically("~", 30*time.Second, 5*time.Second))
+		})
+
+		It("should not retry once the retries are exhausted", func() {
+			failedPod.Annotations = map[string]string{constants.AttemptAnnotation: "3"}
+
+			Expect(
+				mgr.Sync(ctx, retryMLD, true, kmmv1beta1.BuildImage, &testMBSC),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should keep the failed resource while the retry is queued", func() {
+			gomock.InOrder(
+				mockResourceManager.EXPECT().GetResourceFailure(failedPod).
+					Return("DeadlineExceeded", time.Now().Add(-2*time.Minute), nil),
+				mockQueue.EXPECT().Admit(ctx, gomock.Any(), int32(0)).Return(1, nil),
+			)
+
+			err := mgr.Sync(ctx, retryMLD, true, kmmv1beta1.BuildImage, &testMBSC)
+
+			var queuedErr *QueuedError
+			Expect(errors.As(err, &queuedErr)).To(BeTrue())
+			Expect(queuedErr.Position).To(Equal(1))
+		})
+	})
+
+	Context("queue", func() {
+		var testTemplate *v1.Pod
+
+		BeforeEach(func() {
+			testTemplate = &v1.Pod{}
+
+			gomock.InOrder(
+				mockResourceManager.EXPECT().MakeResourceTemplate(ctx, gomock.Any(), &testMBSC, true, kmmv1beta1.SignImage).
+					Return(testTemplate, nil),
+				mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, kernelVersion, "arm64",
+					kmmv1beta1.SignImage, &testMBSC).
+					Return(nil, ErrNoMatchingBuildSignResource),
+			)
+		})
+
+		queueMLD := &api.ModuleLoaderData{
+			Name:                    mbscName,
+			Namespace:               mbscNamespace,
+			KernelVersion:           "5.14.0",
+			KernelNormalizedVersion: kernelVersion,
+			Architecture:            "arm64",
+			Sign:                    &kmmv1beta1.Sign{Priority: 3},
+		}
+
+		expectedItem := QueueItem{
+			Name:                    mbscName,
+			Namespace:               mbscNamespace,
+			KernelVersion:           "5.14.0",
+			Architecture:            "arm64",
+			Action:                  kmmv1beta1.SignImage,
+			KernelNormalizedVersion: kernelVersion,
+		}
+
+		It("should not create the resource while it is queued", func() {
+			mockQueue.EXPECT().Admit(ctx, expectedItem, int32(3)).Return(2, nil)
+
+			err := mgr.Sync(ctx, queueMLD, true, kmmv1beta1.SignImage, &testMBSC)
+
+			var queuedErr *QueuedError
+			Expect(errors.As(err, &queuedErr)).To(BeTrue())
+			Expect(queuedErr.Position).To(Equal(2))
+		})
+
+		It("should return an error if the queue could not be checked", func() {
+			mockQueue.EXPECT().Admit(ctx, expectedItem, int32(3)).Return(0, errors.New("some error"))
+
+			Expect(
+				mgr.Sync(ctx, queueMLD, true, kmmv1beta1.SignImage, &testMBSC),
+			).To(
+				MatchError(ContainSubstring("some error")),
+			)
+		})
+
+		It("should create the resource once it is admitted", func() {
+			gomock.InOrder(
+				mockQueue.EXPECT().Admit(ctx, expectedItem, int32(3)),
+				mockResourceManager.EXPECT().CreateResource(ctx, testTemplate),
+			)
+
+			Expect(
+				mgr.Sync(ctx, queueMLD, true, kmmv1beta1.SignImage, &testMBSC),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+	})
+})
+
+var _ = Describe("GetAttempts", func() {
+	var (
+		ctrl                *gomock.Controller
+		mockResourceManager *MockResourceManager
+		mgr                 Manager
+	)
+	const (
+		mbscName      = "some-name"
+		mbscNamespace = "some-namespace"
+		kernelVersion = "some version"
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockResourceManager = NewMockResourceManager(ctrl)
+		mgr = NewManager(client.NewMockClient(ctrl), mockResourceManager, nil, scheme)
+	})
+
+	ctx := context.Background()
+	testMBSC := kmmv1beta1.ModuleBuildSignConfig{}
+	normalizedKernel := kernel.DNSSafeKernelVersion(kernelVersion)
+
+	It("should return no attempts if there is no resource", func() {
+		mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel, "",
+			kmmv1beta1.SignImage, &testMBSC).
+			Return(nil, ErrNoMatchingBuildSignResource)
+
+		attempts, reason, err := mgr.GetAttempts(ctx, mbscName, mbscNamespace, kernelVersion, "", kmmv1beta1.SignImage, &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attempts).To(BeZero())
+		Expect(reason).To(BeEmpty())
+	})
+
+	It("should return the attempt of a successful resource", func() {
+		foundPod := v1.Pod{}
+		gomock.InOrder(
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel, "",
+				kmmv1beta1.BuildImage, &testMBSC).
+				Return(&foundPod, nil),
+			mockResourceManager.EXPECT().GetResourceStatus(&foundPod).Return(StatusCompleted, nil),
+		)
+
+		attempts, reason, err := mgr.GetAttempts(ctx, mbscName, mbscNamespace, kernelVersion, "", kmmv1beta1.BuildImage, &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attempts).To(BeEquivalentTo(1))
+		Expect(reason).To(BeEmpty())
+	})
+
+	It("should return the attempt and the failure of a failed resource", func() {
+		foundPod := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{constants.AttemptAnnotation: "3"},
+			},
+		}
+		gomock.InOrder(
+			mockResourceManager.EXPECT().GetResourceByKernel(ctx, mbscName, mbscNamespace, normalizedKernel, "arm64",
+				kmmv1beta1.BuildImage, &testMBSC).
+				Return(&foundPod, nil),
+			mockResourceManager.EXPECT().GetResourceStatus(&foundPod).Return(StatusFailed, nil),
+			mockResourceManager.EXPECT().GetResourceFailure(&foundPod).Return("DeadlineExceeded", time.Now(), nil),
+		)
+
+		attempts, reason, err := mgr.GetAttempts(ctx, mbscName, mbscNamespace, kernelVersion, "arm64", kmmv1beta1.BuildImage, &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attempts).To(BeEquivalentTo(3))
+		Expect(reason).To(Equal("DeadlineExceeded"))
+	})
 })
 
 var _ = Describe("GarbageCollect", func() {
@@ -276,7 +681,7 @@ var _ = Describe("GarbageCollect", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
 		mockResourceManager = NewMockResourceManager(ctrl)
-		mgr = NewManager(clnt, mockResourceManager, scheme)
+		mgr = NewManager(clnt, mockResourceManager, nil, scheme)
 	})
 
 	ctx := context.Background()
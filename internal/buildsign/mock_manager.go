@@ -56,19 +56,64 @@ func (mr *MockManagerMockRecorder) GarbageCollect(ctx, name, namespace, action,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GarbageCollect", reflect.TypeOf((*MockManager)(nil).GarbageCollect), ctx, name, namespace, action, owner)
 }
 
+// GetAttempts mocks base method.
+func (m *MockManager) GetAttempts(ctx context.Context, name, namespace, kernelVersion, arch string, action v1beta1.BuildOrSignAction, owner v1.Object) (int32, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttempts", ctx, name, namespace, kernelVersion, arch, action, owner)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAttempts indicates an expected call of GetAttempts.
+func (mr *MockManagerMockRecorder) GetAttempts(ctx, name, namespace, kernelVersion, arch, action, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttempts", reflect.TypeOf((*MockManager)(nil).GetAttempts), ctx, name, namespace, kernelVersion, arch, action, owner)
+}
+
+// GetSourceCommit mocks base method.
+func (m *MockManager) GetSourceCommit(ctx context.Context, name, namespace, kernelVersion, arch string, owner v1.Object) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSourceCommit", ctx, name, namespace, kernelVersion, arch, owner)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSourceCommit indicates an expected call of GetSourceCommit.
+func (mr *MockManagerMockRecorder) GetSourceCommit(ctx, name, namespace, kernelVersion, arch, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSourceCommit", reflect.TypeOf((*MockManager)(nil).GetSourceCommit), ctx, name, namespace, kernelVersion, arch, owner)
+}
+
 // GetStatus mocks base method.
-func (m *MockManager) GetStatus(ctx context.Context, name, namespace, kernelVersion string, action v1beta1.BuildOrSignAction, owner v1.Object) (v1beta1.BuildOrSignStatus, error) {
+func (m *MockManager) GetStatus(ctx context.Context, name, namespace, kernelVersion, arch string, action v1beta1.BuildOrSignAction, owner v1.Object) (v1beta1.BuildOrSignStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStatus", ctx, name, namespace, kernelVersion, action, owner)
+	ret := m.ctrl.Call(m, "GetStatus", ctx, name, namespace, kernelVersion, arch, action, owner)
 	ret0, _ := ret[0].(v1beta1.BuildOrSignStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetStatus indicates an expected call of GetStatus.
-func (mr *MockManagerMockRecorder) GetStatus(ctx, name, namespace, kernelVersion, action, owner any) *gomock.Call {
+func (mr *MockManagerMockRecorder) GetStatus(ctx, name, namespace, kernelVersion, arch, action, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockManager)(nil).GetStatus), ctx, name, namespace, kernelVersion, arch, action, owner)
+}
+
+// PublishSharedImage mocks base method.
+func (m *MockManager) PublishSharedImage(ctx context.Context, mld *api.ModuleLoaderData, owner v1.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishSharedImage", ctx, mld, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishSharedImage indicates an expected call of PublishSharedImage.
+func (mr *MockManagerMockRecorder) PublishSharedImage(ctx, mld, owner any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockManager)(nil).GetStatus), ctx, name, namespace, kernelVersion, action, owner)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishSharedImage", reflect.TypeOf((*MockManager)(nil).PublishSharedImage), ctx, mld, owner)
 }
 
 // Sync mocks base method.
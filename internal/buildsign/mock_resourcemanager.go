@@ -11,6 +11,7 @@ package buildsign
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	v1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	api "github.com/kubernetes-sigs/kernel-module-management/internal/api"
@@ -41,6 +42,21 @@ func (m *MockResourceManager) EXPECT() *MockResourceManagerMockRecorder {
 	return m.recorder
 }
 
+// CopySharedImage mocks base method.
+func (m *MockResourceManager) CopySharedImage(ctx context.Context, mld *api.ModuleLoaderData, buildTemplate v1.Object) (v1.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopySharedImage", ctx, mld, buildTemplate)
+	ret0, _ := ret[0].(v1.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopySharedImage indicates an expected call of CopySharedImage.
+func (mr *MockResourceManagerMockRecorder) CopySharedImage(ctx, mld, buildTemplate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopySharedImage", reflect.TypeOf((*MockResourceManager)(nil).CopySharedImage), ctx, mld, buildTemplate)
+}
+
 // CreateResource mocks base method.
 func (m *MockResourceManager) CreateResource(ctx context.Context, template v1.Object) error {
 	m.ctrl.T.Helper()
@@ -85,18 +101,49 @@ func (mr *MockResourceManagerMockRecorder) GetModuleResources(ctx, modName, name
 }
 
 // GetResourceByKernel mocks base method.
-func (m *MockResourceManager) GetResourceByKernel(ctx context.Context, name, namespace, targetKernel string, resourceType v1beta1.BuildOrSignAction, owner v1.Object) (v1.Object, error) {
+func (m *MockResourceManager) GetResourceByKernel(ctx context.Context, name, namespace, targetKernel, arch string, resourceType v1beta1.BuildOrSignAction, owner v1.Object) (v1.Object, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetResourceByKernel", ctx, name, namespace, targetKernel, resourceType, owner)
+	ret := m.ctrl.Call(m, "GetResourceByKernel", ctx, name, namespace, targetKernel, arch, resourceType, owner)
 	ret0, _ := ret[0].(v1.Object)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetResourceByKernel indicates an expected call of GetResourceByKernel.
-func (mr *MockResourceManagerMockRecorder) GetResourceByKernel(ctx, name, namespace, targetKernel, resourceType, owner any) *gomock.Call {
+func (mr *MockResourceManagerMockRecorder) GetResourceByKernel(ctx, name, namespace, targetKernel, arch, resourceType, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceByKernel", reflect.TypeOf((*MockResourceManager)(nil).GetResourceByKernel), ctx, name, namespace, targetKernel, arch, resourceType, owner)
+}
+
+// GetResourceFailure mocks base method.
+func (m *MockResourceManager) GetResourceFailure(obj v1.Object) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceFailure", obj)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetResourceFailure indicates an expected call of GetResourceFailure.
+func (mr *MockResourceManagerMockRecorder) GetResourceFailure(obj any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceByKernel", reflect.TypeOf((*MockResourceManager)(nil).GetResourceByKernel), ctx, name, namespace, targetKernel, resourceType, owner)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceFailure", reflect.TypeOf((*MockResourceManager)(nil).GetResourceFailure), obj)
+}
+
+// GetResourceSourceCommit mocks base method.
+func (m *MockResourceManager) GetResourceSourceCommit(obj v1.Object) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceSourceCommit", obj)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceSourceCommit indicates an expected call of GetResourceSourceCommit.
+func (mr *MockResourceManagerMockRecorder) GetResourceSourceCommit(obj any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceSourceCommit", reflect.TypeOf((*MockResourceManager)(nil).GetResourceSourceCommit), obj)
 }
 
 // GetResourceStatus mocks base method.
@@ -158,3 +205,17 @@ func (mr *MockResourceManagerMockRecorder) MakeResourceTemplate(ctx, mld, owner,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeResourceTemplate", reflect.TypeOf((*MockResourceManager)(nil).MakeResourceTemplate), ctx, mld, owner, pushImage, resourceType)
 }
+
+// PublishSharedImage mocks base method.
+func (m *MockResourceManager) PublishSharedImage(ctx context.Context, mld *api.ModuleLoaderData, buildResource v1.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishSharedImage", ctx, mld, buildResource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishSharedImage indicates an expected call of PublishSharedImage.
+func (mr *MockResourceManagerMockRecorder) PublishSharedImage(ctx, mld, buildResource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishSharedImage", reflect.TypeOf((*MockResourceManager)(nil).PublishSharedImage), ctx, mld, buildResource)
+}
@@ -0,0 +1,255 @@
+package buildsign
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/config"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/metrics"
+)
+
+const (
+	// QueuePollInterval is the delay after which a queued resource checks again if it can be created.
+	QueuePollInterval = 30 * time.Second
+
+	// queuedItemTTL is the delay after which an item that stopped polling, for instance because its owner was
+	// deleted, leaves the queue. It is also the delay after which an admitted item whose resource was not seen,
+	// for instance because its creation failed, stops holding a slot.
+	queuedItemTTL = 10 * QueuePollInterval
+)
+
+//go:generate mockgen -source=queue.go -package=buildsign -destination=mock_queue.go
+
+// Queue limits the number of build and sign resources running at the same time.
+type Queue interface {
+	// Admit returns 0 if the resource of item can be created now, and its position in the queue otherwise.
+	Admit(ctx context.Context, item QueueItem, priority int32) (int, error)
+}
+
+// QueueItem identifies a build or sign resource waiting to be created.
+type QueueItem struct {
+	Name          string
+	Namespace     string
+	KernelVersion string
+	Architecture  string
+	Action        kmmv1beta1.BuildOrSignAction
+
+	// KernelNormalizedVersion is the kernel version in the labels of the resource.
+	KernelNormalizedVersion string
+}
+
+type queuedItem struct {
+	QueueItem
+	priority int32
+	queuedAt time.Time
+	lastSeen time.Time
+	seq      uint64
+}
+
+type queue struct {
+	reader     client.Reader
+	cfg        *config.BuildQueue
+	metricsAPI metrics.Metrics
+
+	mu    sync.Mutex
+	items map[QueueItem]*queuedItem
+	seq   uint64
+
+	// admitted holds the time at which items were admitted. They hold a slot until their resource is seen, because
+	// the resource is only created after Admit returns.
+	admitted map[QueueItem]time.Time
+}
+
+// NewQueue returns a Queue enforcing the limits of cfg. reader should not be cached, so that the resources created by
+// the previous admissions are counted.
+func NewQueue(reader client.Reader, cfg *config.BuildQueue, metricsAPI metrics.Metrics) Queue {
+	return &queue{
+		reader:     reader,
+		cfg:        cfg,
+		metricsAPI: metricsAPI,
+		items:      make(map[QueueItem]*queuedItem),
+		admitted:   make(map[QueueItem]time.Time),
+	}
+}
+
+func (q *queue) Admit(ctx context.Context, item QueueItem, priority int32) (int, error) {
+	if q.cfg.MaxConcurrentBuilds == 0 && q.cfg.MaxConcurrentSigns == 0 && len(q.cfg.NamespaceQuotas) == 0 {
+		return 0, nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+
+	running, runningInNamespace, err := q.runningResources(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	// The item was admitted and still holds its slot, but its resource was not created; let it try again.
+	if _, ok := q.admitted[item]; ok {
+		return 0, nil
+	}
+
+	qi, ok := q.items[item]
+	if !ok {
+		qi = &queuedItem{QueueItem: item, queuedAt: now, seq: q.seq}
+		q.seq++
+		q.items[item] = qi
+	}
+	qi.priority = priority
+	qi.lastSeen = now
+
+	for key, other := range q.items {
+		if now.Sub(other.lastSeen) > queuedItemTTL {
+			q.remove(key)
+		}
+	}
+
+	// Walk the queue in order, reserving the free slots for the items ahead of item so that it cannot overtake them.
+	position := 0
+	positions := make(map[kmmv1beta1.BuildOrSignAction]int)
+
+	for _, other := range q.sortedItems() {
+		if q.hasRoom(other, running, runningInNamespace) {
+			running[other.Action]++
+			runningInNamespace[other.Namespace]++
+			q.deletePositionMetric(other.QueueItem)
+
+			continue
+		}
+
+		positions[other.Action]++
+		if other == qi {
+			position = positions[other.Action]
+		}
+
+		q.metricsAPI.SetKMMQueuePosition(other.Name, other.Namespace, other.KernelVersion, other.Architecture,
+			string(other.Action), positions[other.Action])
+	}
+
+	for _, action := range []kmmv1beta1.BuildOrSignAction{kmmv1beta1.BuildImage, kmmv1beta1.SignImage} {
+		q.metricsAPI.SetKMMQueueLength(string(action), positions[action])
+	}
+
+	if position == 0 {
+		q.remove(item)
+		q.admitted[item] = now
+	}
+
+	return position, nil
+}
+
+func (q *queue) remove(item QueueItem) {
+	delete(q.items, item)
+	q.deletePositionMetric(item)
+}
+
+func (q *queue) deletePositionMetric(item QueueItem) {
+	q.metricsAPI.DeleteKMMQueuePosition(item.Name, item.Namespace, item.KernelVersion, item.Architecture, string(item.Action))
+}
+
+func (q *queue) hasRoom(qi *queuedItem, running map[kmmv1beta1.BuildOrSignAction]int, runningInNamespace map[string]int) bool {
+	limit := q.cfg.MaxConcurrentBuilds
+	if qi.Action == kmmv1beta1.SignImage {
+		limit = q.cfg.MaxConcurrentSigns
+	}
+
+	if limit != 0 && running[qi.Action] >= limit {
+		return false
+	}
+
+	quota, ok := q.cfg.NamespaceQuotas[qi.Namespace]
+
+	return !ok || runningInNamespace[qi.Namespace] < quota
+}
+
+// sortedItems returns the queued items in the order in which they should be admitted.
+func (q *queue) sortedItems() []*queuedItem {
+	items := make([]*queuedItem, 0, len(q.items))
+	for _, qi := range q.items {
+		items = append(items, qi)
+	}
+
+	slices.SortFunc(items, func(a, b *queuedItem) int {
+		if q.cfg.Order == config.QueueOrderPriority && a.priority != b.priority {
+			return cmp.Compare(b.priority, a.priority)
+		}
+		if c := a.queuedAt.Compare(b.queuedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	return items
+}
+
+// runningResources counts the build and sign Pods that did not finish and the admitted items whose Pod was not seen
+// yet, in total for each action and in each namespace.
+// Admitted items stop holding a slot once their Pod is seen, or after queuedItemTTL.
+func (q *queue) runningResources(ctx context.Context, now time.Time) (map[kmmv1beta1.BuildOrSignAction]int, map[string]int, error) {
+	pods := v1.PodList{}
+
+	if err := q.reader.List(ctx, &pods, client.HasLabels{constants.ModuleNameLabel, constants.ResourceType}); err != nil {
+		return nil, nil, fmt.Errorf("could not list build and sign pods: %v", err)
+	}
+
+	running := make(map[kmmv1beta1.BuildOrSignAction]int)
+	runningInNamespace := make(map[string]int)
+
+	for _, p := range pods.Items {
+		action := kmmv1beta1.BuildOrSignAction(p.Labels[constants.ResourceType])
+
+		if action != kmmv1beta1.BuildImage && action != kmmv1beta1.SignImage {
+			continue
+		}
+
+		if !isRunning(&p) {
+			continue
+		}
+
+		running[action]++
+		runningInNamespace[p.Namespace]++
+	}
+
+	for item, admittedAt := range q.admitted {
+		seen := slices.ContainsFunc(pods.Items, func(p v1.Pod) bool {
+			return isResourceOf(&p, item) &&
+				(isRunning(&p) || !p.CreationTimestamp.Time.Before(admittedAt.Truncate(time.Second)))
+		})
+
+		if seen || now.Sub(admittedAt) > queuedItemTTL {
+			delete(q.admitted, item)
+			continue
+		}
+
+		running[item.Action]++
+		runningInNamespace[item.Namespace]++
+	}
+
+	return running, runningInNamespace, nil
+}
+
+// isRunning returns true if p is neither finished nor being deleted.
+func isRunning(p *v1.Pod) bool {
+	return p.DeletionTimestamp == nil && p.Status.Phase != v1.PodSucceeded && p.Status.Phase != v1.PodFailed
+}
+
+// isResourceOf returns true if p is the resource of item.
+func isResourceOf(p *v1.Pod, item QueueItem) bool {
+	return p.Namespace == item.Namespace &&
+		p.Labels[constants.ModuleNameLabel] == item.Name &&
+		p.Labels[constants.ResourceType] == string(item.Action) &&
+		p.Labels[constants.TargetKernelTarget] == item.KernelNormalizedVersion &&
+		p.Labels[constants.TargetArchitecture] == item.Architecture
+}
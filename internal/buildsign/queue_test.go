@@ -0,0 +1,273 @@
+package buildsign
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/config"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/metrics"
+)
+
+var _ = Describe("Admit", func() {
+	var (
+		clnt        *client.MockClient
+		mockMetrics *metrics.MockMetrics
+		cfg         *config.BuildQueue
+		q           *queue
+	)
+
+	ctx := context.Background()
+
+	buildItem := func(namespace, name string) QueueItem {
+		return QueueItem{
+			Name:                    name,
+			Namespace:               namespace,
+			KernelVersion:           "5.14.0",
+			Action:                  kmmv1beta1.BuildImage,
+			KernelNormalizedVersion: "5.14.0",
+		}
+	}
+
+	runningPod := func(namespace string, action kmmv1beta1.BuildOrSignAction, phase v1.PodPhase) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Labels: map[string]string{
+					constants.ModuleNameLabel: "some-module",
+					constants.ResourceType:    string(action),
+				},
+			},
+			Status: v1.PodStatus{Phase: phase},
+		}
+	}
+
+	expectRunning := func(pods ...v1.Pod) {
+		clnt.EXPECT().List(ctx, &v1.PodList{}, ctrlclient.HasLabels{constants.ModuleNameLabel, constants.ResourceType}).
+			DoAndReturn(func(_ context.Context, pl *v1.PodList, _ ...ctrlclient.ListOption) error {
+				pl.Items = pods
+				return nil
+			})
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mockMetrics = metrics.NewMockMetrics(ctrl)
+		cfg = &config.BuildQueue{MaxConcurrentBuilds: 1, Order: config.QueueOrderFIFO}
+		q = NewQueue(clnt, cfg, mockMetrics).(*queue)
+
+		mockMetrics.EXPECT().SetKMMQueuePosition(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		mockMetrics.EXPECT().SetKMMQueueLength(gomock.Any(), gomock.Any()).AnyTimes()
+		mockMetrics.EXPECT().DeleteKMMQueuePosition(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	})
+
+	It("should admit everything if there are no limits", func() {
+		cfg.MaxConcurrentBuilds = 0
+
+		Expect(
+			q.Admit(ctx, buildItem("ns", "a"), 0),
+		).To(
+			Equal(0),
+		)
+	})
+
+	It("should return an error if the pods could not be listed", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+
+		_, err := q.Admit(ctx, buildItem("ns", "a"), 0)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should only count the running pods of the same action", func() {
+		expectRunning(
+			runningPod("ns", kmmv1beta1.SignImage, v1.PodRunning),
+			runningPod("ns", kmmv1beta1.BuildImage, v1.PodSucceeded),
+			runningPod("ns", kmmv1beta1.BuildImage, v1.PodFailed),
+		)
+
+		Expect(
+			q.Admit(ctx, buildItem("ns", "a"), 0),
+		).To(
+			Equal(0),
+		)
+		Expect(q.items).To(BeEmpty())
+	})
+
+	It("should admit the items in FIFO order", func() {
+		expectRunning(runningPod("ns", kmmv1beta1.BuildImage, v1.PodPending))
+		Expect(q.Admit(ctx, buildItem("ns", "a"), 0)).To(Equal(1))
+
+		expectRunning(runningPod("ns", kmmv1beta1.BuildImage, v1.PodRunning))
+		Expect(q.Admit(ctx, buildItem("ns", "b"), 10)).To(Equal(2))
+
+		By("keeping the free slot for the first item")
+		expectRunning()
+		Expect(q.Admit(ctx, buildItem("ns", "b"), 10)).To(Equal(1))
+
+		expectRunning()
+		Expect(q.Admit(ctx, buildItem("ns", "a"), 0)).To(Equal(0))
+
+		expectRunning(runningPod("ns", kmmv1beta1.BuildImage, v1.PodPending))
+		Expect(q.Admit(ctx, buildItem("ns", "b"), 10)).To(Equal(1))
+	})
+
+	It("should admit the items with the highest priority first", func() {
+		cfg.Order = config.QueueOrderPriority
+
+		expectRunning(runningPod("ns", kmmv1beta1.BuildImage, v1.PodRunning))
+		Expect(q.Admit(ctx, buildItem("ns", "a"), 0)).To(Equal(1))
+
+		expectRunning(runningPod("ns", kmmv1beta1.BuildImage, v1.PodRunning))
+		Expect(q.Admit(ctx, buildItem("ns", "b"), 10)).To(Equal(1))
+
+		expectRunning()
+		Expect(q.Admit(ctx, buildItem("ns", "a"), 0)).To(Equal(1))
+
+		expectRunning()
+		Expect(q.Admit(ctx, buildItem("ns", "b"), 10)).To(Equal(0))
+	})
+
+	It("should apply the namespace quotas to builds and signs", func() {
+		cfg.MaxConcurrentBuilds = 0
+		cfg.NamespaceQuotas = map[string]int{"ns1": 1}
+
+		expectRunning(runningPod("ns1", kmmv1beta1.SignImage, v1.PodRunning))
+		Expect(q.Admit(ctx, buildItem("ns1", "a"), 0)).To(Equal(1))
+
+		By("not blocking the other namespaces")
+		expectRunning(runningPod("ns1", kmmv1beta1.SignImage, v1.PodRunning))
+		Expect(q.Admit(ctx, buildItem("ns2", "b"), 0)).To(Equal(0))
+	})
+
+	Context("admitted items", func() {
+		podOf := func(item QueueItem, phase v1.PodPhase, created time.Time) v1.Pod {
+			return v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         item.Namespace,
+					CreationTimestamp: metav1.NewTime(created),
+					Labels: map[string]string{
+						constants.ModuleNameLabel:    item.Name,
+						constants.ResourceType:       string(item.Action),
+						constants.TargetKernelTarget: item.KernelNormalizedVersion,
+					},
+				},
+				Status: v1.PodStatus{Phase: phase},
+			}
+		}
+
+		BeforeEach(func() {
+			expectRunning()
+			Expect(q.Admit(ctx, buildItem("ns", "a"), 0)).To(Equal(0))
+		})
+
+		It("should hold a slot until their pod is created", func() {
+			expectRunning()
+			Expect(q.Admit(ctx, buildItem("ns", "b"), 0)).To(Equal(1))
+
+			expectRunning(podOf(buildItem("ns", "a"), v1.PodRunning, time.Now()))
+			Expect(q.Admit(ctx, buildItem("ns", "b"), 0)).To(Equal(1))
+			Expect(q.admitted).To(BeEmpty())
+
+			expectRunning()
+			Expect(q.Admit(ctx, buildItem("ns", "b"), 0)).To(Equal(0))
+		})
+
+		It("should count against the namespace quotas", func() {
+			cfg.MaxConcurrentBuilds = 0
+			cfg.NamespaceQuotas = map[string]int{"ns": 1}
+
+			expectRunning()
+			Expect(q.Admit(ctx, buildItem("ns", "b"), 0)).To(Equal(1))
+		})
+
+		It("should release the slot if their pod already finished", func() {
+			expectRunning(podOf(buildItem("ns", "a"), v1.PodSucceeded, time.Now()))
+			Expect(q.Admit(ctx, buildItem("ns", "b"), 0)).To(Equal(0))
+		})
+
+		It("should not release the slot for a pod of a previous attempt", func() {
+			expectRunning(podOf(buildItem("ns", "a"), v1.PodFailed, time.Now().Add(-time.Hour)))
+			Expect(q.Admit(ctx, buildItem("ns", "b"), 0)).To(Equal(1))
+		})
+
+		It("should not release the slot for a pod of another kernel", func() {
+			other := buildItem("ns", "a")
+			other.KernelNormalizedVersion = "6.0.0"
+
+			expectRunning(podOf(other, v1.PodSucceeded, time.Now()))
+			Expect(q.Admit(ctx, buildItem("ns", "b"), 0)).To(Equal(1))
+		})
+
+		It("should release the slot after the TTL", func() {
+			q.admitted[buildItem("ns", "a")] = time.Now().Add(-queuedItemTTL - time.Second)
+
+			expectRunning()
+			Expect(q.Admit(ctx, buildItem("ns", "b"), 0)).To(Equal(0))
+		})
+
+		It("should admit them again while they hold their slot", func() {
+			expectRunning()
+			Expect(q.Admit(ctx, buildItem("ns", "a"), 0)).To(Equal(0))
+		})
+	})
+
+	It("should forget the items that stopped polling", func() {
+		expectRunning(runningPod("ns", kmmv1beta1.BuildImage, v1.PodRunning))
+		Expect(q.Admit(ctx, buildItem("ns", "a"), 0)).To(Equal(1))
+
+		q.items[buildItem("ns", "a")].lastSeen = time.Now().Add(-queuedItemTTL - time.Second)
+
+		expectRunning()
+		Expect(q.Admit(ctx, buildItem("ns", "b"), 0)).To(Equal(0))
+		Expect(q.items).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Admit metrics", func() {
+	It("should expose the queue positions and lengths", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		clnt := client.NewMockClient(ctrl)
+		mockMetrics := metrics.NewMockMetrics(ctrl)
+		cfg := &config.BuildQueue{MaxConcurrentSigns: 1}
+		q := NewQueue(clnt, cfg, mockMetrics)
+
+		ctx := context.Background()
+		item := QueueItem{Name: "a", Namespace: "ns", KernelVersion: "5.14.0", Architecture: "arm64", Action: kmmv1beta1.SignImage}
+
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, pl *v1.PodList, _ ...ctrlclient.ListOption) error {
+				pl.Items = []v1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								constants.ModuleNameLabel: "b",
+								constants.ResourceType:    string(kmmv1beta1.SignImage),
+							},
+						},
+					},
+				}
+				return nil
+			},
+		)
+		mockMetrics.EXPECT().SetKMMQueuePosition("a", "ns", "5.14.0", "arm64", string(kmmv1beta1.SignImage), 1)
+		mockMetrics.EXPECT().SetKMMQueueLength(string(kmmv1beta1.BuildImage), 0)
+		mockMetrics.EXPECT().SetKMMQueueLength(string(kmmv1beta1.SignImage), 1)
+
+		Expect(
+			q.Admit(ctx, item, 0),
+		).To(
+			Equal(1),
+		)
+	})
+})
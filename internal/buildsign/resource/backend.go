@@ -0,0 +1,316 @@
+package resource
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// runMount is a directory mounted in the build container that RUN instructions may need, such as
+// /host/lib/modules or a secret.
+type runMount struct {
+	name string
+	path string
+}
+
+// buildOptions describes the build of the Dockerfile mounted in /workspace.
+type buildOptions struct {
+	destinationImg string
+	pushImage      bool
+
+	// pushTLS are the TLS options of the registry of destinationImg.
+	pushTLS kmmv1beta1.TLSOptions
+
+	// pullTLS are the TLS options of the registries of the images in the Dockerfile's FROM instructions.
+	pullTLS kmmv1beta1.TLSOptions
+
+	buildArgs []kmmv1beta1.BuildArg
+
+	// registryAuth is true if the config.json of the image repository secret is mounted in dockerConfigDir.
+	registryAuth bool
+
+	runMounts []runMount
+
+	// cacheRepo is the repository of cached layers; caching is disabled if it is empty.
+	cacheRepo string
+
+	// cacheTTL is the duration for which cached layers are reused; the backend's default applies if it is 0.
+	cacheTTL time.Duration
+
+	kanikoParams *kmmv1beta1.KanikoParams
+}
+
+// backend builds an image in a container of a build or sign Pod.
+type backend interface {
+	// container returns the container running the build described by opts, without its volume mounts.
+	container(opts buildOptions) v1.Container
+
+	// dockerConfigDir returns the directory in which the image repository secret's config.json must be mounted.
+	dockerConfigDir() string
+
+	// runMountFlags returns the flags that a RUN instruction of a Dockerfile generated by KMM needs to access
+	// mounts.
+	runMountFlags(mounts []runMount) string
+}
+
+var backends = map[kmmv1beta1.BuildBackend]func(profiles RootlessProfiles) backend{
+	kmmv1beta1.BuildBackendKaniko:   func(RootlessProfiles) backend { return kaniko{} },
+	kmmv1beta1.BuildBackendBuildah:  func(profiles RootlessProfiles) backend { return buildah{profiles: profiles} },
+	kmmv1beta1.BuildBackendBuildKit: func(profiles RootlessProfiles) backend { return buildKit{profiles: profiles} },
+}
+
+// getBackend returns the backend set in mld's Build, or else the operator's default backend.
+func (rm *resourceManager) getBackend(mld *api.ModuleLoaderData) (backend, error) {
+	name := rm.backendName(mld)
+
+	newBackend, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown build backend %q", name)
+	}
+
+	return newBackend(rm.rootlessProfiles), nil
+}
+
+// backendName returns the name of the backend set in mld's Build, or else of the operator's default backend.
+func (rm *resourceManager) backendName(mld *api.ModuleLoaderData) kmmv1beta1.BuildBackend {
+	name := rm.defaultBackend
+
+	if mld.Build != nil && mld.Build.Backend != "" {
+		name = mld.Build.Backend
+	}
+
+	if name == "" {
+		name = kmmv1beta1.BuildBackendKaniko
+	}
+
+	return name
+}
+
+type kaniko struct{}
+
+func (kaniko) container(opts buildOptions) v1.Container {
+	args := []string{}
+
+	if opts.pushImage {
+		args = append(args, "--destination", opts.destinationImg)
+		if opts.pushTLS.Insecure {
+			args = append(args, "--insecure")
+		}
+		if opts.pushTLS.InsecureSkipTLSVerify {
+			args = append(args, "--skip-tls-verify")
+		}
+	} else {
+		args = append(args, "--no-push")
+	}
+
+	if opts.pullTLS.Insecure {
+		args = append(args, "--insecure-pull")
+	}
+
+	if opts.pullTLS.InsecureSkipTLSVerify {
+		args = append(args, "--skip-tls-verify-pull")
+	}
+
+	if opts.cacheRepo != "" {
+		args = append(args, "--cache=true", "--cache-repo="+opts.cacheRepo)
+		if opts.cacheTTL > 0 {
+			args = append(args, "--cache-ttl="+opts.cacheTTL.String())
+		}
+	}
+
+	for _, ba := range opts.buildArgs {
+		args = append(args, "--build-arg", fmt.Sprintf("%s=%s", ba.Name, ba.Value))
+	}
+
+	kanikoImage := os.Getenv("RELATED_IMAGE_BUILD")
+
+	if opts.kanikoParams != nil && opts.kanikoParams.Tag != "" {
+		if idx := strings.IndexAny(kanikoImage, "@:"); idx != -1 {
+			kanikoImage = kanikoImage[0:idx]
+		}
+
+		kanikoImage += ":" + opts.kanikoParams.Tag
+	}
+
+	return v1.Container{
+		Args:  args,
+		Name:  "kaniko",
+		Image: kanikoImage,
+	}
+}
+
+func (kaniko) dockerConfigDir() string {
+	return "/kaniko/.docker"
+}
+
+// runMountFlags returns nothing, since kaniko runs RUN instructions in its own container.
+func (kaniko) runMountFlags([]runMount) string {
+	return ""
+}
+
+// buildah builds with rootless Buildah, using the vfs storage driver and chroot isolation so that it does not need
+// any privilege.
+type buildah struct {
+	profiles RootlessProfiles
+}
+
+func (b buildah) container(opts buildOptions) v1.Container {
+	script := `set -e
+buildah build --storage-driver=vfs --isolation=chroot --file=/workspace/Dockerfile --tag="$DESTINATION_IMAGE" "$@" /workspace
+`
+
+	if opts.pushImage {
+		tlsVerify := !opts.pushTLS.Insecure && !opts.pushTLS.InsecureSkipTLSVerify
+		script += fmt.Sprintf(`buildah push --storage-driver=vfs --tls-verify=%t "$DESTINATION_IMAGE"
+`, tlsVerify)
+	}
+
+	args := []string{}
+
+	if opts.pullTLS.Insecure || opts.pullTLS.InsecureSkipTLSVerify {
+		args = append(args, "--tls-verify=false")
+	}
+
+	// RUN instructions run in a chroot: bind-mount the directories they may need at the same path.
+	for _, m := range opts.runMounts {
+		args = append(args, fmt.Sprintf("--volume=%s:%s:ro", m.path, m.path))
+	}
+
+	if opts.cacheRepo != "" {
+		args = append(args, "--layers", "--cache-from="+opts.cacheRepo, "--cache-to="+opts.cacheRepo)
+		if opts.cacheTTL > 0 {
+			args = append(args, "--cache-ttl="+opts.cacheTTL.String())
+		}
+	}
+
+	for _, ba := range opts.buildArgs {
+		args = append(args, "--build-arg", fmt.Sprintf("%s=%s", ba.Name, ba.Value))
+	}
+
+	env := []v1.EnvVar{
+		{Name: "DESTINATION_IMAGE", Value: opts.destinationImg},
+	}
+
+	if opts.registryAuth {
+		env = append(env, v1.EnvVar{Name: "REGISTRY_AUTH_FILE", Value: buildah{}.dockerConfigDir() + "/config.json"})
+	}
+
+	return v1.Container{
+		// The first argument after the script is $0.
+		Command:         append([]string{"/bin/sh", "-c", script, "buildah"}, args...),
+		Name:            "buildah",
+		Image:           os.Getenv("RELATED_IMAGE_BUILDAH"),
+		Env:             env,
+		SecurityContext: rootlessSecurityContext(b.profiles),
+	}
+}
+
+func (buildah) dockerConfigDir() string {
+	return "/docker"
+}
+
+// runMountFlags returns nothing, since the directories are bind-mounted in all RUN instructions.
+func (buildah) runMountFlags([]runMount) string {
+	return ""
+}
+
+// buildKit builds with rootless BuildKit, running the daemon in the build container.
+// The daemon runs without its own process sandbox, so that it does not need to mount /proc.
+type buildKit struct {
+	profiles RootlessProfiles
+}
+
+func (b buildKit) container(opts buildOptions) v1.Container {
+	args := []string{
+		"build",
+		"--frontend=dockerfile.v0",
+		"--local=context=/workspace",
+		"--local=dockerfile=/workspace",
+	}
+
+	// RUN instructions run in a sandbox: expose the directories they may need as named contexts, which RUN
+	// instructions can bind-mount.
+	for _, m := range opts.runMounts {
+		args = append(args, fmt.Sprintf("--local=%s=%s", m.name, m.path), fmt.Sprintf("--opt=context:%s=local:%s", m.name, m.name))
+	}
+
+	for _, ba := range opts.buildArgs {
+		args = append(args, fmt.Sprintf("--opt=build-arg:%s=%s", ba.Name, ba.Value))
+	}
+
+	insecure := ""
+	if opts.pushTLS.Insecure || opts.pushTLS.InsecureSkipTLSVerify {
+		insecure = ",registry.insecure=true"
+	}
+
+	// BuildKit has no TTL for cached layers.
+	if opts.cacheRepo != "" {
+		args = append(
+			args,
+			fmt.Sprintf("--import-cache=type=registry,ref=%s%s", opts.cacheRepo, insecure),
+			fmt.Sprintf("--export-cache=type=registry,ref=%s,mode=max%s", opts.cacheRepo, insecure),
+		)
+	}
+
+	output := fmt.Sprintf("--output=type=image,name=%s,push=%t", opts.destinationImg, opts.pushImage)
+	if opts.pushImage {
+		output += insecure
+	}
+
+	args = append(args, output)
+
+	env := []v1.EnvVar{
+		{Name: "BUILDKITD_FLAGS", Value: "--oci-worker-no-process-sandbox"},
+	}
+
+	if opts.registryAuth {
+		env = append(env, v1.EnvVar{Name: "DOCKER_CONFIG", Value: buildKit{}.dockerConfigDir()})
+	}
+
+	return v1.Container{
+		Command:         []string{"buildctl-daemonless.sh"},
+		Args:            args,
+		Name:            "buildkit",
+		Image:           os.Getenv("RELATED_IMAGE_BUILDKIT"),
+		Env:             env,
+		SecurityContext: rootlessSecurityContext(b.profiles),
+	}
+}
+
+func (buildKit) dockerConfigDir() string {
+	return "/docker"
+}
+
+func (buildKit) runMountFlags(mounts []runMount) string {
+	flags := make([]string, 0, len(mounts))
+
+	for _, m := range mounts {
+		flags = append(flags, fmt.Sprintf("--mount=type=bind,from=%s,target=%s", m.name, m.path))
+	}
+
+	return strings.Join(flags, " ")
+}
+
+// rootlessSecurityContext runs the container as the unprivileged user of the Buildah and BuildKit images, with the
+// profiles set in the operator configuration.
+// Both need to create user namespaces, which some default seccomp and AppArmor profiles forbid; the profiles are only
+// relaxed if the administrator chose to.
+func rootlessSecurityContext(profiles RootlessProfiles) *v1.SecurityContext {
+	seccompProfile := profiles.Seccomp
+	if seccompProfile == nil {
+		seccompProfile = &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}
+	}
+
+	return &v1.SecurityContext{
+		RunAsUser:       ptr.To[int64](1000),
+		RunAsGroup:      ptr.To[int64](1000),
+		SeccompProfile:  seccompProfile,
+		AppArmorProfile: profiles.AppArmor,
+	}
+}
@@ -0,0 +1,216 @@
+package resource
+
+import (
+	"bytes"
+	"time"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
+)
+
+var _ = Describe("getBackend", func() {
+	DescribeTable("should select the backend",
+		func(defaultBackend, moduleBackend kmmv1beta1.BuildBackend, expected backend) {
+			rm := &resourceManager{defaultBackend: defaultBackend}
+			mld := &api.ModuleLoaderData{Build: &kmmv1beta1.Build{Backend: moduleBackend}}
+
+			b, err := rm.getBackend(mld)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(expected))
+		},
+		Entry("kaniko when nothing is set", kmmv1beta1.BuildBackend(""), kmmv1beta1.BuildBackend(""), kaniko{}),
+		Entry("the operator's default", kmmv1beta1.BuildBackendBuildah, kmmv1beta1.BuildBackend(""), buildah{}),
+		Entry("the Module's backend over the default", kmmv1beta1.BuildBackendBuildah, kmmv1beta1.BuildBackendBuildKit, buildKit{}),
+	)
+
+	It("should use the operator's default when the Module does not build", func() {
+		rm := &resourceManager{defaultBackend: kmmv1beta1.BuildBackendBuildKit}
+
+		b, err := rm.getBackend(&api.ModuleLoaderData{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(buildKit{}))
+	})
+
+	It("should pass the rootless profiles to the backend", func() {
+		profiles := RootlessProfiles{Seccomp: &v1.SeccompProfile{Type: v1.SeccompProfileTypeUnconfined}}
+		rm := &resourceManager{defaultBackend: kmmv1beta1.BuildBackendBuildah, rootlessProfiles: profiles}
+
+		b, err := rm.getBackend(&api.ModuleLoaderData{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(buildah{profiles: profiles}))
+	})
+
+	It("should return an error for an unknown backend", func() {
+		rm := &resourceManager{defaultBackend: "docker"}
+
+		_, err := rm.getBackend(&api.ModuleLoaderData{})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("cacheRepository", func() {
+	DescribeTable("should return the cache repository",
+		func(cache *kmmv1beta1.BuildCache, image, expected string) {
+			mld := &api.ModuleLoaderData{
+				ContainerImage: image,
+				Build:          &kmmv1beta1.Build{Cache: cache},
+			}
+
+			Expect(cacheRepository(mld)).To(Equal(expected))
+		},
+		Entry("no cache", nil, "registry/org/image:tag", ""),
+		Entry("disabled cache", &kmmv1beta1.BuildCache{Repository: "registry/cache"}, "registry/org/image:tag", ""),
+		Entry("explicit repository", &kmmv1beta1.BuildCache{Enabled: true, Repository: "registry/cache"}, "registry/org/image:tag", "registry/cache"),
+		Entry("default repository", &kmmv1beta1.BuildCache{Enabled: true}, "registry:5000/org/image:tag", "registry:5000/org/image/cache"),
+		Entry("default repository of a digest", &kmmv1beta1.BuildCache{Enabled: true}, "registry/image@sha256:1234", "registry/image/cache"),
+	)
+})
+
+var _ = Describe("backends", func() {
+	opts := buildOptions{
+		destinationImg: "registry/image:tag",
+		pushImage:      true,
+		pushTLS:        kmmv1beta1.TLSOptions{Insecure: true},
+		pullTLS:        kmmv1beta1.TLSOptions{InsecureSkipTLSVerify: true},
+		buildArgs:      []kmmv1beta1.BuildArg{{Name: "KERNEL_VERSION", Value: "1.2.3"}},
+		registryAuth:   true,
+		runMounts:      []runMount{{name: "lib-modules", path: "/host/lib/modules"}},
+	}
+
+	It("kaniko should build with the kaniko executor", func() {
+		GinkgoT().Setenv("RELATED_IMAGE_BUILD", "kaniko:latest")
+
+		c := kaniko{}.container(opts)
+		Expect(c.Name).To(Equal("kaniko"))
+		Expect(c.Image).To(Equal("kaniko:latest"))
+		Expect(c.Args).To(Equal([]string{
+			"--destination", "registry/image:tag",
+			"--insecure",
+			"--skip-tls-verify-pull",
+			"--build-arg", "KERNEL_VERSION=1.2.3",
+		}))
+		Expect(kaniko{}.dockerConfigDir()).To(Equal("/kaniko/.docker"))
+		Expect(kaniko{}.runMountFlags(opts.runMounts)).To(BeEmpty())
+	})
+
+	It("buildah should build and push with rootless Buildah", func() {
+		GinkgoT().Setenv("RELATED_IMAGE_BUILDAH", "buildah:latest")
+
+		c := buildah{}.container(opts)
+		Expect(c.Name).To(Equal("buildah"))
+		Expect(c.Image).To(Equal("buildah:latest"))
+		Expect(c.Command[:2]).To(Equal([]string{"/bin/sh", "-c"}))
+		Expect(c.Command[2]).To(ContainSubstring("buildah build --storage-driver=vfs --isolation=chroot"))
+		Expect(c.Command[2]).To(ContainSubstring(`buildah push --storage-driver=vfs --tls-verify=false "$DESTINATION_IMAGE"`))
+		Expect(c.Command[3:]).To(Equal([]string{
+			"buildah",
+			"--tls-verify=false",
+			"--volume=/host/lib/modules:/host/lib/modules:ro",
+			"--build-arg", "KERNEL_VERSION=1.2.3",
+		}))
+		Expect(c.Env).To(ConsistOf(
+			v1.EnvVar{Name: "DESTINATION_IMAGE", Value: "registry/image:tag"},
+			v1.EnvVar{Name: "REGISTRY_AUTH_FILE", Value: "/docker/config.json"},
+		))
+		Expect(*c.SecurityContext.RunAsUser).To(BeEquivalentTo(1000))
+		Expect(buildah{}.runMountFlags(opts.runMounts)).To(BeEmpty())
+	})
+
+	It("should run the rootless backends with the RuntimeDefault seccomp profile by default", func() {
+		for _, b := range []backend{buildah{}, buildKit{}} {
+			sc := b.container(opts).SecurityContext
+			Expect(sc.SeccompProfile).To(Equal(&v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}))
+			Expect(sc.AppArmorProfile).To(BeNil())
+		}
+	})
+
+	It("should run the rootless backends with the configured profiles", func() {
+		profiles := RootlessProfiles{
+			Seccomp:  &v1.SeccompProfile{Type: v1.SeccompProfileTypeLocalhost, LocalhostProfile: ptr.To("profiles/build.json")},
+			AppArmor: &v1.AppArmorProfile{Type: v1.AppArmorProfileTypeUnconfined},
+		}
+
+		for _, b := range []backend{buildah{profiles: profiles}, buildKit{profiles: profiles}} {
+			sc := b.container(opts).SecurityContext
+			Expect(sc.SeccompProfile).To(Equal(profiles.Seccomp))
+			Expect(sc.AppArmorProfile).To(Equal(profiles.AppArmor))
+		}
+	})
+
+	It("buildah should not push when not asked to", func() {
+		o := opts
+		o.pushImage = false
+		o.registryAuth = false
+
+		c := buildah{}.container(o)
+		Expect(c.Command[2]).NotTo(ContainSubstring("buildah push"))
+		Expect(c.Env).To(ConsistOf(v1.EnvVar{Name: "DESTINATION_IMAGE", Value: "registry/image:tag"}))
+	})
+
+	It("buildkit should build with rootless BuildKit", func() {
+		GinkgoT().Setenv("RELATED_IMAGE_BUILDKIT", "buildkit:rootless")
+
+		c := buildKit{}.container(opts)
+		Expect(c.Name).To(Equal("buildkit"))
+		Expect(c.Image).To(Equal("buildkit:rootless"))
+		Expect(c.Command).To(Equal([]string{"buildctl-daemonless.sh"}))
+		Expect(c.Args).To(Equal([]string{
+			"build",
+			"--frontend=dockerfile.v0",
+			"--local=context=/workspace",
+			"--local=dockerfile=/workspace",
+			"--local=lib-modules=/host/lib/modules",
+			"--opt=context:lib-modules=local:lib-modules",
+			"--opt=build-arg:KERNEL_VERSION=1.2.3",
+			"--output=type=image,name=registry/image:tag,push=true,registry.insecure=true",
+		}))
+		Expect(c.Env).To(ConsistOf(
+			v1.EnvVar{Name: "BUILDKITD_FLAGS", Value: "--oci-worker-no-process-sandbox"},
+			v1.EnvVar{Name: "DOCKER_CONFIG", Value: "/docker"},
+		))
+		Expect(buildKit{}.runMountFlags(opts.runMounts)).To(Equal("--mount=type=bind,from=lib-modules,target=/host/lib/modules"))
+	})
+
+	It("should pass the cache flags", func() {
+		o := opts
+		o.cacheRepo = "registry/cache"
+		o.cacheTTL = 6 * time.Hour
+
+		Expect(kaniko{}.container(o).Args).To(ContainElements("--cache=true", "--cache-repo=registry/cache", "--cache-ttl=6h0m0s"))
+		Expect(buildah{}.container(o).Command).To(
+			ContainElements("--layers", "--cache-from=registry/cache", "--cache-to=registry/cache", "--cache-ttl=6h0m0s"),
+		)
+		Expect(buildKit{}.container(o).Args).To(ContainElements(
+			"--import-cache=type=registry,ref=registry/cache,registry.insecure=true",
+			"--export-cache=type=registry,ref=registry/cache,mode=max,registry.insecure=true",
+		))
+	})
+
+	It("should not pass cache flags if caching is disabled", func() {
+		Expect(kaniko{}.container(opts).Args).NotTo(ContainElement(HavePrefix("--cache")))
+		Expect(buildah{}.container(opts).Command).NotTo(ContainElement(HavePrefix("--cache")))
+		Expect(buildKit{}.container(opts).Args).NotTo(ContainElement(HaveSuffix("-cache")))
+	})
+
+	It("buildkit should bind-mount the signing keys in the sign Dockerfile", func() {
+		var buf bytes.Buffer
+
+		td := TemplateData{
+			FilesToSign:   []string{"/modules/kmod.ko"},
+			SignImage:     "sign:latest",
+			UnsignedImage: "registry/image:tag",
+			DirName:       "/modules",
+			RunMounts:     buildKit{}.runMountFlags(signRunMounts),
+		}
+
+		Expect(tmpl.Execute(&buf, td)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(
+			"RUN --mount=type=bind,from=key,target=/run/secrets/key --mount=type=bind,from=cert,target=/run/secrets/cert for file in /opt/modules/kmod.ko; do",
+		))
+	})
+})
@@ -5,20 +5,28 @@ import (
 	"context"
 	"embed"
 	"fmt"
+	"maps"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
 	"text/template"
+	"time"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/registry"
 	"github.com/mitchellh/hashstructure/v2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type TemplateData struct {
@@ -26,6 +34,7 @@ type TemplateData struct {
 	SignImage     string
 	UnsignedImage string
 	DirName       string
+	RunMounts     string
 }
 
 //go:embed templates
@@ -35,129 +44,282 @@ var tmpl = template.Must(
 	template.ParseFS(templateFS, "templates/Dockerfile.gotmpl"),
 )
 
-func (rm *resourceManager) buildSpec(mld *api.ModuleLoaderData, destinationImg string, pushImage bool) v1.PodSpec {
+func (rm *resourceManager) buildSpec(mld *api.ModuleLoaderData, destinationImg string, pushImage bool) (v1.PodSpec, error) {
 
 	buildConfig := mld.Build
 
-	args := containerArgs(mld, destinationImg, mld.Build.BaseImageRegistryTLS, pushImage)
+	b, err := rm.getBackend(mld)
+	if err != nil {
+		return v1.PodSpec{}, err
+	}
+
+	buildArgs, buildArgsEnv := valueFromBuildArgs(rm.buildArgs(mld))
+
+	selector := mld.Selector
+	if len(mld.Build.Selector) != 0 {
+		selector = mld.Build.Selector
+	}
+
+	volumes, volumeMounts := makeBuildResourceVolumesAndVolumeMounts(*buildConfig, mld.ImageRepoSecret, b.dockerConfigDir())
+
+	container := b.container(buildOptions{
+		destinationImg: destinationImg,
+		pushImage:      pushImage,
+		pushTLS:        registryTLS(mld),
+		pullTLS:        buildConfig.BaseImageRegistryTLS,
+		buildArgs:      buildArgs,
+		registryAuth:   mld.ImageRepoSecret != nil,
+		runMounts:      buildRunMounts(*buildConfig),
+		cacheRepo:      cacheRepository(mld),
+		cacheTTL:       cacheTTL(buildConfig),
+		kanikoParams:   buildConfig.KanikoParams,
+	})
+	container.VolumeMounts = volumeMounts
+	container.Env = append(container.Env, buildArgsEnv...)
+
+	var initContainers []v1.Container
+	if git := gitSource(buildConfig); git != nil {
+		initContainers = []v1.Container{gitCloneContainer(git, buildConfig.DockerfileConfigMap != nil)}
+	}
+
+	return v1.PodSpec{
+		InitContainers:        initContainers,
+		Containers:            []v1.Container{container},
+		RestartPolicy:         v1.RestartPolicyNever,
+		Volumes:               volumes,
+		NodeSelector:          architectureSelector(selector, mld.Architecture),
+		Tolerations:           mld.Tolerations,
+		ActiveDeadlineSeconds: activeDeadlineSeconds(buildConfig.ActiveDeadline),
+	}, nil
+}
+
+// buildArgs returns the build arguments of mld's build, including the ones set by KMM.
+func (rm *resourceManager) buildArgs(mld *api.ModuleLoaderData) []kmmv1beta1.BuildArg {
 	overrides := []kmmv1beta1.BuildArg{
 		{Name: "KERNEL_VERSION", Value: mld.KernelVersion},
 		{Name: "KERNEL_FULL_VERSION", Value: mld.KernelVersion},
 		{Name: "MOD_NAME", Value: mld.Name},
 		{Name: "MOD_NAMESPACE", Value: mld.Namespace},
 	}
-	buildArgs := rm.buildArgOverrider.ApplyBuildArgOverrides(
-		buildConfig.BuildArgs,
-		overrides...,
-	)
-	for _, ba := range buildArgs {
-		args = append(args, "--build-arg", fmt.Sprintf("%s=%s", ba.Name, ba.Value))
-	}
 
-	kanikoImage := os.Getenv("RELATED_IMAGE_BUILD")
+	return rm.buildArgOverrider.ApplyBuildArgOverrides(mld.Build.BuildArgs, overrides...)
+}
 
-	if buildConfig.KanikoParams != nil && buildConfig.KanikoParams.Tag != "" {
-		if idx := strings.IndexAny(kanikoImage, "@:"); idx != -1 {
-			kanikoImage = kanikoImage[0:idx]
+// valueFromBuildArgs replaces the value of the build arguments read from Secrets, or from ConfigMaps that were not
+// resolved yet, with a reference to an environment variable of the build container, which the kubelet expands: the
+// values never appear in the Pod's spec.
+// It returns the build arguments and the environment variables to set.
+func valueFromBuildArgs(args []kmmv1beta1.BuildArg) ([]kmmv1beta1.BuildArg, []v1.EnvVar) {
+	res := make([]kmmv1beta1.BuildArg, 0, len(args))
+	env := make([]v1.EnvVar, 0)
+
+	for _, arg := range args {
+		if arg.ValueFrom != nil {
+			name := fmt.Sprintf("KMM_BUILD_ARG_%d", len(env))
+
+			env = append(env, v1.EnvVar{
+				Name: name,
+				ValueFrom: &v1.EnvVarSource{
+					ConfigMapKeyRef: arg.ValueFrom.ConfigMapKeyRef,
+					SecretKeyRef:    arg.ValueFrom.SecretKeyRef,
+				},
+			})
+
+			arg = kmmv1beta1.BuildArg{Name: arg.Name, Value: fmt.Sprintf("$(%s)", name)}
 		}
 
-		kanikoImage += ":" + buildConfig.KanikoParams.Tag
+		res = append(res, arg)
 	}
 
-	selector := mld.Selector
-	if len(mld.Build.Selector) != 0 {
-		selector = mld.Build.Selector
-	}
+	return res, env
+}
+
+func signSpec(b backend, mld *api.ModuleLoaderData, destinationImg string, pushImage bool) v1.PodSpec {
 
-	volumes, volumeMounts := makeBuildResourceVolumesAndVolumeMounts(*buildConfig, mld.ImageRepoSecret)
+	signConfig := mld.Sign
+	volumes, volumeMounts := makeSignResourceVolumesAndVolumeMounts(signConfig, mld.ImageRepoSecret, b.dockerConfigDir())
+
+	container := b.container(buildOptions{
+		destinationImg: destinationImg,
+		pushImage:      pushImage,
+		pushTLS:        registryTLS(mld),
+		pullTLS:        signConfig.UnsignedImageRegistryTLS,
+		registryAuth:   mld.ImageRepoSecret != nil,
+		runMounts:      signRunMounts,
+	})
+	container.VolumeMounts = volumeMounts
 
 	return v1.PodSpec{
-		Containers: []v1.Container{
-			{
-				Args:         args,
-				Name:         "kaniko",
-				Image:        kanikoImage,
-				VolumeMounts: volumeMounts,
-			},
-		},
-		RestartPolicy: v1.RestartPolicyNever,
-		Volumes:       volumes,
-		NodeSelector:  selector,
-		Tolerations:   mld.Tolerations,
+		Containers:            []v1.Container{container},
+		RestartPolicy:         v1.RestartPolicyNever,
+		Volumes:               volumes,
+		NodeSelector:          architectureSelector(mld.Selector, mld.Architecture),
+		Tolerations:           mld.Tolerations,
+		ActiveDeadlineSeconds: activeDeadlineSeconds(signConfig.ActiveDeadline),
 	}
 }
 
-func signSpec(mld *api.ModuleLoaderData, destinationImg string, pushImage bool) v1.PodSpec {
+// manifestSpec returns the spec of a Pod that pushes a manifest list of the images built or signed for each
+// architecture under the module's image.
+func manifestSpec(mld *api.ModuleLoaderData) v1.PodSpec {
 
-	signConfig := mld.Sign
-	args := containerArgs(mld, destinationImg, signConfig.UnsignedImageRegistryTLS, pushImage)
-	volumes, volumeMounts := makeSignResourceVolumesAndVolumeMounts(signConfig, mld.ImageRepoSecret)
+	args := []string{"index", "append", "--tag", mld.ContainerImage}
+	for _, arch := range mld.Architectures {
+		args = append(args, "--manifest", module.AppendToTag(mld.ContainerImage, arch))
+	}
+
+	if mld.RegistryTLS != nil && (mld.RegistryTLS.Insecure || mld.RegistryTLS.InsecureSkipTLSVerify) {
+		args = append(args, "--insecure")
+	}
+
+	return craneSpec(mld, args)
+}
+
+// copyCheckSpec returns the spec of a Pod that checks that the module's image, copied from the shared build
+// repository, can be pulled with the image repository secret.
+func copyCheckSpec(mld *api.ModuleLoaderData) v1.PodSpec {
+
+	args := []string{"digest", mld.ContainerImage}
+
+	if mld.RegistryTLS != nil && (mld.RegistryTLS.Insecure || mld.RegistryTLS.InsecureSkipTLSVerify) {
+		args = append(args, "--insecure")
+	}
+
+	return craneSpec(mld, args)
+}
+
+// craneSpec returns the spec of a Pod running crane with args, authenticated with the image repository secret.
+func craneSpec(mld *api.ModuleLoaderData, args []string) v1.PodSpec {
+
+	volumes, volumeMounts := makeManifestResourceVolumesAndVolumeMounts(mld.ImageRepoSecret)
 
 	return v1.PodSpec{
 		Containers: []v1.Container{
 			{
 				Args:         args,
-				Name:         "kaniko",
-				Image:        os.Getenv("RELATED_IMAGE_BUILD"),
+				Name:         "crane",
+				Image:        os.Getenv("RELATED_IMAGE_MANIFEST"),
+				Env:          []v1.EnvVar{{Name: "DOCKER_CONFIG", Value: "/docker"}},
 				VolumeMounts: volumeMounts,
 			},
 		},
 		RestartPolicy: v1.RestartPolicyNever,
 		Volumes:       volumes,
-		NodeSelector:  mld.Selector,
 		Tolerations:   mld.Tolerations,
 	}
 }
 
-func containerArgs(mld *api.ModuleLoaderData, destinationImg string,
-	tlsOptions kmmv1beta1.TLSOptions, pushImage bool) []string {
+// architectureSelector returns selector restricted to the nodes of the given architecture, if any.
+func architectureSelector(selector map[string]string, arch string) map[string]string {
+	if arch == "" {
+		return selector
+	}
+
+	res := maps.Clone(selector)
+	if res == nil {
+		res = make(map[string]string, 1)
+	}
 
-	args := []string{}
+	res[v1.LabelArchStable] = arch
 
-	if pushImage {
-		args = append(args, "--destination", destinationImg)
-		if mld.RegistryTLS.Insecure {
-			args = append(args, "--insecure")
-		}
-		if mld.RegistryTLS.InsecureSkipTLSVerify {
-			args = append(args, "--skip-tls-verify")
-		}
-	} else {
-		args = append(args, "--no-push")
+	return res
+}
+
+// activeDeadlineSeconds returns the active deadline of a Pod for deadline, or nil if there is none.
+func activeDeadlineSeconds(deadline *metav1.Duration) *int64 {
+	if deadline == nil {
+		return nil
 	}
 
-	if tlsOptions.Insecure {
-		args = append(args, "--insecure-pull")
+	return ptr.To(int64(deadline.Seconds()))
+}
+
+// cacheRepository returns the repository of the cached layers of mld's build, or an empty string if caching is
+// disabled.
+func cacheRepository(mld *api.ModuleLoaderData) string {
+	cache := mld.Build.Cache
+	if cache == nil || !cache.Enabled {
+		return ""
 	}
 
-	if tlsOptions.InsecureSkipTLSVerify {
-		args = append(args, "--skip-tls-verify-pull")
+	if cache.Repository != "" {
+		return cache.Repository
 	}
 
-	return args
+	return imageRepository(mld.ContainerImage) + "/cache"
+}
+
+func cacheTTL(buildConfig *kmmv1beta1.Build) time.Duration {
+	if buildConfig.Cache == nil || buildConfig.Cache.TTL == nil {
+		return 0
+	}
 
+	return buildConfig.Cache.TTL.Duration
 }
 
-func (rm *resourceManager) getBuildHashAnnotationValue(ctx context.Context, configMapName, namespace string,
-	buildSpec *v1.PodSpec) (uint64, error) {
+// imageRepository returns image without its tag or digest.
+func imageRepository(image string) string {
+	image, _, _ = strings.Cut(image, "@")
 
-	dockerfileCM := &v1.ConfigMap{}
-	namespacedName := types.NamespacedName{Name: configMapName, Namespace: namespace}
-	if err := rm.client.Get(ctx, namespacedName, dockerfileCM); err != nil {
-		return 0, fmt.Errorf("failed to get dockerfile ConfigMap %s: %v", namespacedName, err)
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		image = image[:idx]
 	}
-	dockerfile, ok := dockerfileCM.Data[constants.DockerfileCMKey]
-	if !ok {
-		return 0, fmt.Errorf("invalid Dockerfile ConfigMap %s format, %s key is missing", namespacedName, constants.DockerfileCMKey)
+
+	return image
+}
+
+// registryTLS returns the TLS options of the registry of mld's image.
+func registryTLS(mld *api.ModuleLoaderData) kmmv1beta1.TLSOptions {
+	if mld.RegistryTLS == nil {
+		return kmmv1beta1.TLSOptions{}
 	}
 
-	dataToHash := struct {
+	return *mld.RegistryTLS
+}
+
+func (rm *resourceManager) getBuildHashAnnotationValue(ctx context.Context, configMapName, namespace, cacheRepo,
+	valueSourcesDigest string, buildSpec *v1.PodSpec) (uint64, error) {
+
+	// Builds from a Git source without a Dockerfile ConfigMap use the Dockerfile of the source, which is identified by
+	// the source settings in buildSpec.
+	dockerfile, err := rm.getDockerfile(ctx, configMapName, namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	var dataToHash any = struct {
 		BuildSpec  *v1.PodSpec
 		Dockerfile string
 	}{
 		BuildSpec:  buildSpec,
 		Dockerfile: dockerfile,
 	}
+
+	// Only hash the cache repository if caching is enabled, so that the hash of builds without cache does not change.
+	if cacheRepo != "" {
+		dataToHash = struct {
+			BuildSpec       *v1.PodSpec
+			Dockerfile      string
+			CacheRepository string
+		}{
+			BuildSpec:       buildSpec,
+			Dockerfile:      dockerfile,
+			CacheRepository: cacheRepo,
+		}
+	}
+
+	// The build spec only references the values of the build arguments read from Secrets, so their digest is hashed
+	// for builds to be replaced when those values change; builds without such arguments keep the same hash.
+	if valueSourcesDigest != "" {
+		dataToHash = struct {
+			Data               any
+			ValueSourcesDigest string
+		}{
+			Data:               dataToHash,
+			ValueSourcesDigest: valueSourcesDigest,
+		}
+	}
+
 	hashValue, err := hashstructure.Hash(dataToHash, hashstructure.FormatV2, nil)
 	if err != nil {
 		return 0, fmt.Errorf("could not hash build's spec template and dockefile: %v", err)
@@ -166,6 +328,235 @@ func (rm *resourceManager) getBuildHashAnnotationValue(ctx context.Context, conf
 	return hashValue, nil
 }
 
+// getBuildContentHash returns the hash of what determines the content of the image built for mld: the Dockerfile and
+// the digests of its base images, the build arguments and the content they read from ConfigMaps and Secrets, the
+// build Secrets and their content, the source, the backend, the kernel and the architecture.
+// Unlike the hash of the build resource, it does not depend on the Module, namespace or destination of the build, so
+// that identical builds of different Modules have the same hash; the MOD_NAME and MOD_NAMESPACE build arguments are
+// only hashed if the Dockerfile may read them.
+// It returns an empty string if the content of the image is not fully known before the build: if the Dockerfile is
+// read from a Git source, if the Git reference is not a commit or if a base image cannot be resolved to a digest.
+func (rm *resourceManager) getBuildContentHash(ctx context.Context, mld *api.ModuleLoaderData) (string, error) {
+	logger := log.FromContext(ctx)
+
+	var source *gitSourceContent
+
+	if mld.Build.Source != nil && mld.Build.Source.Git != nil {
+		git := mld.Build.Source.Git
+
+		if !gitCommitRegexp.MatchString(git.Ref) {
+			logger.V(1).Info("Not sharing the build: the Git reference is not a commit", "ref", git.Ref)
+			return "", nil
+		}
+
+		source = &gitSourceContent{URL: git.URL, Commit: git.Ref, ContextDir: git.ContextDir}
+	}
+
+	if mld.Build.DockerfileConfigMap == nil {
+		logger.V(1).Info("Not sharing the build: the Dockerfile is read from the Git source")
+		return "", nil
+	}
+
+	dockerfile, err := rm.getDockerfile(ctx, mld.Build.DockerfileConfigMap.Name, mld.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	buildArgs := make([]kmmv1beta1.BuildArg, 0, len(mld.Build.BuildArgs)+4)
+	argValues := make(map[string]string)
+
+	for _, ba := range rm.buildArgs(mld) {
+		if (ba.Name == "MOD_NAME" || ba.Name == "MOD_NAMESPACE") && !strings.Contains(dockerfile, ba.Name) {
+			continue
+		}
+
+		if ba.ValueFrom != nil {
+			// Only hash the content read by the argument, so that it does not depend on where it is stored.
+			digest, err := module.ValueSourceDigest(ctx, rm.client, ba.ValueFrom, mld.Namespace)
+			if err != nil {
+				return "", fmt.Errorf("could not read the value of build argument %s: %v", ba.Name, err)
+			}
+
+			ba = kmmv1beta1.BuildArg{Name: ba.Name, Value: digest}
+		} else {
+			argValues[ba.Name] = ba.Value
+		}
+
+		buildArgs = append(buildArgs, ba)
+	}
+
+	images, ok := baseImages(dockerfile, argValues)
+	if !ok {
+		logger.V(1).Info("Not sharing the build: a base image depends on an unknown build argument")
+		return "", nil
+	}
+
+	baseImageDigests := make([]string, 0, len(images))
+
+	for _, image := range images {
+		if strings.Contains(image, "@") {
+			baseImageDigests = append(baseImageDigests, image)
+			continue
+		}
+
+		access := registry.Access{
+			Namespace:  mld.Namespace,
+			PullSecret: mld.ImageRepoSecret,
+			TLSOptions: mld.Build.BaseImageRegistryTLS,
+		}
+
+		digest, err := rm.registryAPI.GetDigest(ctx, image, access)
+		if err != nil {
+			logger.Info("Not sharing the build: could not get the digest of a base image", "image", image, "error", err)
+			return "", nil
+		}
+
+		baseImageDigests = append(baseImageDigests, image+"@"+digest)
+	}
+
+	secrets := make([]buildSecretContent, 0, len(mld.Build.Secrets))
+
+	for _, ref := range mld.Build.Secrets {
+		digest, err := module.SecretDigest(ctx, rm.client, ref.Name, mld.Namespace)
+		if err != nil {
+			return "", err
+		}
+
+		secrets = append(secrets, buildSecretContent{Name: ref.Name, Digest: digest})
+	}
+
+	dataToHash := struct {
+		Dockerfile       string
+		BaseImageDigests []string
+		BuildArgs        []kmmv1beta1.BuildArg
+		Secrets          []buildSecretContent
+		Source           *gitSourceContent
+		Backend          kmmv1beta1.BuildBackend
+		KanikoParams     *kmmv1beta1.KanikoParams
+		KernelVersion    string
+		Architecture     string
+	}{
+		Dockerfile:       dockerfile,
+		BaseImageDigests: baseImageDigests,
+		BuildArgs:        buildArgs,
+		Secrets:          secrets,
+		Source:           source,
+		Backend:          rm.backendName(mld),
+		KanikoParams:     mld.Build.KanikoParams,
+		KernelVersion:    mld.KernelVersion,
+		Architecture:     mld.Architecture,
+	}
+
+	hashValue, err := hashstructure.Hash(dataToHash, hashstructure.FormatV2, nil)
+	if err != nil {
+		return "", fmt.Errorf("could not hash the content of the build: %v", err)
+	}
+
+	return fmt.Sprintf("%016x", hashValue), nil
+}
+
+// gitCommitRegexp matches the full SHA-1 or SHA-256 name of a Git commit.
+var gitCommitRegexp = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// gitSourceContent is the part of a Git source that determines the content of the build context; the credentials
+// used to clone the repository do not.
+type gitSourceContent struct {
+	URL        string
+	Commit     string
+	ContextDir string
+}
+
+// buildSecretContent is a build Secret, identified by its name in the Dockerfile, and the digest of its data.
+type buildSecretContent struct {
+	Name   string
+	Digest string
+}
+
+// baseImages returns the images that the stages of dockerfile are built from, other than scratch and earlier stages.
+// Variables are expanded with args, or with the default value of the arguments declared before the first stage.
+// It returns false if an image depends on a variable that has no known value.
+func baseImages(dockerfile string, args map[string]string) ([]string, bool) {
+	values := maps.Clone(args)
+	stages := sets.New[string]()
+	images := make([]string, 0)
+	seenFrom := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(dockerfile, "\\\n", " "), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "ARG":
+			if seenFrom {
+				continue
+			}
+
+			for _, decl := range fields[1:] {
+				if name, value, ok := strings.Cut(decl, "="); ok {
+					if _, set := values[name]; !set {
+						values[name] = strings.Trim(value, `"'`)
+					}
+				}
+			}
+		case "FROM":
+			seenFrom = true
+
+			fields = slices.DeleteFunc(fields[1:], func(f string) bool { return strings.HasPrefix(f, "--") })
+			if len(fields) == 0 {
+				continue
+			}
+
+			unknown := false
+			image := os.Expand(fields[0], func(name string) string {
+				value, ok := values[name]
+				unknown = unknown || !ok
+				return value
+			})
+
+			if unknown {
+				return nil, false
+			}
+
+			if image != "scratch" && !stages.Has(strings.ToLower(image)) {
+				images = append(images, image)
+			}
+
+			if len(fields) >= 3 && strings.EqualFold(fields[1], "AS") {
+				stages.Insert(strings.ToLower(fields[2]))
+			}
+		}
+	}
+
+	return images, true
+}
+
+// sharedImage returns the image of the shared build repository holding the image of the builds with buildHash.
+func (rm *resourceManager) sharedImage(buildHash string) string {
+	return rm.sharedRepository.Name + ":" + buildHash
+}
+
+// getDockerfile returns the Dockerfile in the ConfigMap configMapName, or an empty string if configMapName is empty.
+func (rm *resourceManager) getDockerfile(ctx context.Context, configMapName, namespace string) (string, error) {
+	if configMapName == "" {
+		return "", nil
+	}
+
+	dockerfileCM := &v1.ConfigMap{}
+	namespacedName := types.NamespacedName{Name: configMapName, Namespace: namespace}
+	if err := rm.client.Get(ctx, namespacedName, dockerfileCM); err != nil {
+		return "", fmt.Errorf("failed to get dockerfile ConfigMap %s: %v", namespacedName, err)
+	}
+
+	dockerfile, ok := dockerfileCM.Data[constants.DockerfileCMKey]
+	if !ok {
+		return "", fmt.Errorf("invalid Dockerfile ConfigMap %s format, %s key is missing", namespacedName, constants.DockerfileCMKey)
+	}
+
+	return dockerfile, nil
+}
+
 func (rm *resourceManager) getSignHashAnnotationValue(ctx context.Context, privateSecret, publicSecret, namespace string,
 	signConfig []byte, signSpec *v1.PodSpec) (uint64, error) {
 
@@ -211,9 +602,12 @@ func (rm *resourceManager) getSecretData(ctx context.Context, secretName, secret
 	return data, nil
 }
 
-func resourceLabels(modName, targetKernel string, resourceType kmmv1beta1.BuildOrSignAction) map[string]string {
+func resourceLabels(modName, targetKernel, arch string, resourceType kmmv1beta1.BuildOrSignAction) map[string]string {
 
 	labels := moduleKernelLabels(modName, targetKernel, resourceType)
+	if arch != "" {
+		labels[constants.TargetArchitecture] = arch
+	}
 
 	labels["app.kubernetes.io/name"] = "kmm"
 	labels["app.kubernetes.io/component"] = string(resourceType)
@@ -232,6 +626,38 @@ func filterResourcesByOwner(resources []v1.Pod, owner metav1.Object) []v1.Pod {
 	return ownedResources
 }
 
+// filterResourcesByArchitecture returns the resources targeting arch; an empty arch selects the resources that do not
+// target a specific architecture.
+func filterResourcesByArchitecture(resources []v1.Pod, arch string) []v1.Pod {
+	archResources := []v1.Pod{}
+	for _, obj := range resources {
+		if obj.Labels[constants.TargetArchitecture] == arch {
+			archResources = append(archResources, obj)
+		}
+	}
+	return archResources
+}
+
+func filterTerminatingResources(resources []v1.Pod) []v1.Pod {
+	return slices.DeleteFunc(resources, func(p v1.Pod) bool {
+		return p.DeletionTimestamp != nil
+	})
+}
+
+// resourceName returns the name of the build or sign resource of mld.
+func resourceName(mld *api.ModuleLoaderData, infix string) string {
+	name := mld.Name + "-" + infix + "-" + mld.KernelNormalizedVersion
+
+	switch {
+	case mld.Architecture != "":
+		name += "-" + mld.Architecture
+	case len(mld.Architectures) > 0:
+		name += "-manifest"
+	}
+
+	return name
+}
+
 func moduleKernelLabels(moduleName, targetKernel string, resourceType kmmv1beta1.BuildOrSignAction) map[string]string {
 	labels := moduleLabels(moduleName, resourceType)
 	labels[constants.TargetKernelTarget] = targetKernel
@@ -261,11 +687,35 @@ func (rm *resourceManager) getResources(ctx context.Context, namespace string, l
 func (rm *resourceManager) makeBuildTemplate(ctx context.Context, mld *api.ModuleLoaderData, owner metav1.Object,
 	pushImage bool) (metav1.Object, error) {
 
-	buildSpec := rm.buildSpec(mld, mld.ContainerImage, pushImage)
+	buildSpec, err := rm.buildSpec(mld, mld.ContainerImage, pushImage)
+	if err != nil {
+		return nil, fmt.Errorf("could not make the build spec: %v", err)
+	}
+
+	dockerfileConfigMapName := ""
+	if mld.Build.DockerfileConfigMap != nil {
+		dockerfileConfigMapName = mld.Build.DockerfileConfigMap.Name
+	}
+
+	valueSources := make([]kmmv1beta1.ValueSource, 0)
+
+	for _, ba := range mld.Build.BuildArgs {
+		if ba.ValueFrom != nil {
+			valueSources = append(valueSources, *ba.ValueFrom)
+		}
+	}
+
+	valueSourcesDigest, err := module.ValueSourcesDigest(ctx, rm.client, valueSources, mld.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the values of the build arguments: %v", err)
+	}
+
 	buildSpecHash, err := rm.getBuildHashAnnotationValue(
 		ctx,
-		mld.Build.DockerfileConfigMap.Name,
+		dockerfileConfigMapName,
 		mld.Namespace,
+		cacheRepository(mld),
+		valueSourcesDigest,
 		&buildSpec,
 	)
 	if err != nil {
@@ -274,9 +724,9 @@ func (rm *resourceManager) makeBuildTemplate(ctx context.Context, mld *api.Modul
 
 	build := &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        mld.Name + "-build-" + mld.KernelNormalizedVersion,
+			Name:        resourceName(mld, "build"),
 			Namespace:   mld.Namespace,
-			Labels:      resourceLabels(mld.Name, mld.KernelNormalizedVersion, kmmv1beta1.BuildImage),
+			Labels:      resourceLabels(mld.Name, mld.KernelNormalizedVersion, mld.Architecture, kmmv1beta1.BuildImage),
 			Annotations: map[string]string{constants.ResourceHashAnnotation: fmt.Sprintf("%d", buildSpecHash)},
 			Finalizers:  []string{constants.GCDelayFinalizer, constants.JobEventFinalizer},
 		},
@@ -295,12 +745,18 @@ func (rm *resourceManager) makeSignTemplate(ctx context.Context, mld *api.Module
 
 	signConfig := mld.Sign
 
+	b, err := rm.getBackend(mld)
+	if err != nil {
+		return nil, err
+	}
+
 	var buf bytes.Buffer
 
 	td := TemplateData{
 		FilesToSign: mld.Sign.FilesToSign,
 		SignImage:   os.Getenv("RELATED_IMAGE_SIGN"),
 		DirName:     mld.Modprobe.DirName,
+		RunMounts:   b.runMountFlags(signRunMounts),
 	}
 
 	if module.ShouldBeBuilt(mld) {
@@ -315,7 +771,7 @@ func (rm *resourceManager) makeSignTemplate(ctx context.Context, mld *api.Module
 		return nil, fmt.Errorf("could not execute template: %v", err)
 	}
 
-	signSpec := signSpec(mld, mld.ContainerImage, pushImage)
+	signSpec := signSpec(b, mld, mld.ContainerImage, pushImage)
 	signSpecHash, err := rm.getSignHashAnnotationValue(ctx, signConfig.KeySecret.Name,
 		signConfig.CertSecret.Name, mld.Namespace, buf.Bytes(), &signSpec)
 	if err != nil {
@@ -324,9 +780,9 @@ func (rm *resourceManager) makeSignTemplate(ctx context.Context, mld *api.Module
 
 	sign := &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      mld.Name + "-sign-" + mld.KernelNormalizedVersion,
+			Name:      resourceName(mld, "sign"),
 			Namespace: mld.Namespace,
-			Labels:    resourceLabels(mld.Name, mld.KernelNormalizedVersion, kmmv1beta1.SignImage),
+			Labels:    resourceLabels(mld.Name, mld.KernelNormalizedVersion, mld.Architecture, kmmv1beta1.SignImage),
 			Annotations: map[string]string{
 				constants.ResourceHashAnnotation: fmt.Sprintf("%d", signSpecHash),
 				dockerfileAnnotationKey:          buf.String(),
@@ -342,3 +798,37 @@ func (rm *resourceManager) makeSignTemplate(ctx context.Context, mld *api.Module
 
 	return sign, nil
 }
+
+// makeManifestTemplate returns a Pod that combines the images built or signed for each architecture of mld into a
+// manifest list once the resources of resourceType completed for all of them.
+func (rm *resourceManager) makeManifestTemplate(mld *api.ModuleLoaderData, owner metav1.Object,
+	resourceType kmmv1beta1.BuildOrSignAction) (metav1.Object, error) {
+
+	manifestSpec := manifestSpec(mld)
+	manifestSpecHash, err := hashstructure.Hash(manifestSpec, hashstructure.FormatV2, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not hash manifest's spec template: %v", err)
+	}
+
+	infix := "build"
+	if resourceType == kmmv1beta1.SignImage {
+		infix = "sign"
+	}
+
+	manifest := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        resourceName(mld, infix),
+			Namespace:   mld.Namespace,
+			Labels:      resourceLabels(mld.Name, mld.KernelNormalizedVersion, "", resourceType),
+			Annotations: map[string]string{constants.ResourceHashAnnotation: fmt.Sprintf("%d", manifestSpecHash)},
+			Finalizers:  []string{constants.GCDelayFinalizer},
+		},
+		Spec: manifestSpec,
+	}
+
+	if err = controllerutil.SetControllerReference(owner, manifest, rm.scheme); err != nil {
+		return nil, fmt.Errorf("could not set the owner reference: %v", err)
+	}
+
+	return manifest, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
@@ -20,6 +22,7 @@ import (
 	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/registry"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -111,7 +114,7 @@ var _ = Describe("makeBuildTemplate", func() {
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      mld.Name + "-build-" + mld.KernelNormalizedVersion,
 				Namespace: namespace,
-				Labels:    resourceLabels(mld.Name, mld.KernelNormalizedVersion, kmmv1beta1.BuildImage),
+				Labels:    resourceLabels(mld.Name, mld.KernelNormalizedVersion, "", kmmv1beta1.BuildImage),
 				OwnerReferences: []metav1.OwnerReference{
 					{
 						APIVersion:         "kmm.sigs.x-k8s.io/v1beta1",
@@ -447,6 +450,578 @@ var _ = Describe("makeBuildTemplate", func() {
 		Expect(actualPod.Spec.Containers[0].Args).To(ContainElement("--destination"))
 		Expect(actualPod.Spec.Containers[0].Args).To(ContainElement(image))
 	})
+
+	It("should build on the nodes of the target architecture", func() {
+		ctx := context.Background()
+
+		mld := api.ModuleLoaderData{
+			Name:      mod.Name,
+			Namespace: mod.Namespace,
+			Owner:     &mod,
+			Build: &kmmv1beta1.Build{
+				BuildArgs:           buildArgs,
+				DockerfileConfigMap: &dockerfileConfigMap,
+			},
+			ContainerImage:          image,
+			RegistryTLS:             &kmmv1beta1.TLSOptions{},
+			Selector:                map[string]string{"some-label": "some-value"},
+			KernelVersion:           kernelVersion,
+			KernelNormalizedVersion: kernelNormalizedVersion,
+			Architecture:            "arm64",
+		}
+
+		gomock.InOrder(
+			mbao.EXPECT().ApplyBuildArgOverrides(buildArgs, defaultBuildArgs),
+			clnt.EXPECT().Get(ctx, types.NamespacedName{Name: dockerfileConfigMap.Name, Namespace: mld.Namespace}, gomock.Any()).DoAndReturn(
+				func(_ interface{}, _ interface{}, cm *v1.ConfigMap, _ ...ctrlclient.GetOption) error {
+					cm.Data = dockerfileCMData
+					return nil
+				},
+			),
+		)
+
+		actual, err := rm.makeBuildTemplate(ctx, &mld, mld.Owner, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		actualPod, ok := actual.(*v1.Pod)
+		Expect(ok).To(BeTrue())
+		Expect(actualPod.Name).To(Equal(mld.Name + "-build-" + kernelNormalizedVersion + "-arm64"))
+		Expect(actualPod.Labels).To(HaveKeyWithValue(constants.TargetArchitecture, "arm64"))
+		Expect(actualPod.Spec.NodeSelector).To(Equal(map[string]string{
+			"some-label":       "some-value",
+			v1.LabelArchStable: "arm64",
+		}))
+		Expect(mld.Selector).To(Equal(map[string]string{"some-label": "some-value"}))
+	})
+
+	It("should stop the build after its active deadline", func() {
+		ctx := context.Background()
+
+		mld := api.ModuleLoaderData{
+			Name:      mod.Name,
+			Namespace: mod.Namespace,
+			Owner:     &mod,
+			Build: &kmmv1beta1.Build{
+				BuildArgs:           buildArgs,
+				DockerfileConfigMap: &dockerfileConfigMap,
+				ActiveDeadline:      &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			ContainerImage:          image,
+			KernelVersion:           kernelVersion,
+			KernelNormalizedVersion: kernelNormalizedVersion,
+		}
+
+		gomock.InOrder(
+			mbao.EXPECT().ApplyBuildArgOverrides(buildArgs, defaultBuildArgs),
+			clnt.EXPECT().Get(ctx, types.NamespacedName{Name: dockerfileConfigMap.Name, Namespace: mld.Namespace}, gomock.Any()).DoAndReturn(
+				func(_ interface{}, _ interface{}, cm *v1.ConfigMap, _ ...ctrlclient.GetOption) error {
+					cm.Data = dockerfileCMData
+					return nil
+				},
+			),
+		)
+
+		actual, err := rm.makeBuildTemplate(ctx, &mld, mld.Owner, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.(*v1.Pod).Spec.ActiveDeadlineSeconds).To(HaveValue(BeEquivalentTo(1800)))
+	})
+
+	It("should change the hash of the build when the value of a Secret build argument changes", func() {
+		ctx := context.Background()
+
+		secretArgs := []kmmv1beta1.BuildArg{
+			{
+				Name: "LICENSE_KEY",
+				ValueFrom: &kmmv1beta1.ValueSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "license"},
+						Key:                  "key",
+					},
+				},
+			},
+		}
+
+		mld := api.ModuleLoaderData{
+			Name:      mod.Name,
+			Namespace: mod.Namespace,
+			Owner:     &mod,
+			Build: &kmmv1beta1.Build{
+				BuildArgs:           secretArgs,
+				DockerfileConfigMap: &dockerfileConfigMap,
+			},
+			ContainerImage:          image,
+			KernelVersion:           kernelVersion,
+			KernelNormalizedVersion: kernelNormalizedVersion,
+		}
+
+		secretValue := "value-1"
+
+		mbao.EXPECT().ApplyBuildArgOverrides(secretArgs, defaultBuildArgs).Return(secretArgs).Times(2)
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Name: dockerfileConfigMap.Name, Namespace: mld.Namespace}, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ interface{}, cm *v1.ConfigMap, _ ...ctrlclient.GetOption) error {
+				cm.Data = dockerfileCMData
+				return nil
+			},
+		).Times(2)
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Name: "license", Namespace: mld.Namespace}, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ interface{}, secret *v1.Secret, _ ...ctrlclient.GetOption) error {
+				secret.Data = map[string][]byte{"key": []byte(secretValue)}
+				return nil
+			},
+		).Times(2)
+
+		first, err := rm.makeBuildTemplate(ctx, &mld, mld.Owner, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		secretValue = "value-2"
+
+		second, err := rm.makeBuildTemplate(ctx, &mld, mld.Owner, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(second.(*v1.Pod).Spec).To(Equal(first.(*v1.Pod).Spec))
+		Expect(second.GetAnnotations()[constants.ResourceHashAnnotation]).
+			NotTo(Equal(first.GetAnnotations()[constants.ResourceHashAnnotation]))
+	})
+})
+
+var _ = Describe("getBuildHashAnnotationValue", func() {
+	var (
+		ctrl *gomock.Controller
+		clnt *client.MockClient
+		rm   *resourceManager
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		rm = &resourceManager{client: clnt}
+	})
+
+	It("should depend on the cache repository", func() {
+		ctx := context.Background()
+		spec := &v1.PodSpec{}
+
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Name: "cm", Namespace: "ns"}, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ interface{}, cm *v1.ConfigMap, _ ...ctrlclient.GetOption) error {
+				cm.Data = map[string]string{constants.DockerfileCMKey: "FROM test"}
+				return nil
+			},
+		).Times(3)
+
+		noCache, err := rm.getBuildHashAnnotationValue(ctx, "cm", "ns", "", "", spec)
+		Expect(err).NotTo(HaveOccurred())
+
+		cache1, err := rm.getBuildHashAnnotationValue(ctx, "cm", "ns", "registry/cache1", "", spec)
+		Expect(err).NotTo(HaveOccurred())
+
+		cache2, err := rm.getBuildHashAnnotationValue(ctx, "cm", "ns", "registry/cache2", "", spec)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(noCache).NotTo(Equal(cache1))
+		Expect(cache1).NotTo(Equal(cache2))
+	})
+})
+
+var _ = Describe("getBuildContentHash", func() {
+	var (
+		ctrl         *gomock.Controller
+		clnt         *client.MockClient
+		mockRegistry *registry.MockRegistry
+		rm           *resourceManager
+		digests      map[string]string
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mockRegistry = registry.NewMockRegistry(ctrl)
+		rm = &resourceManager{client: clnt, buildArgOverrider: module.NewBuildArgOverrider(), registryAPI: mockRegistry}
+		digests = map[string]string{"test": "sha256:1", "other": "sha256:2"}
+
+		mockRegistry.EXPECT().GetDigest(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, image string, _ registry.Access) (string, error) {
+				digest, ok := digests[image]
+				if !ok {
+					return "", registry.ErrImageNotFound
+				}
+
+				return digest, nil
+			},
+		).AnyTimes()
+	})
+
+	mldInNamespace := func(name, namespace string) *api.ModuleLoaderData {
+		return &api.ModuleLoaderData{
+			Name:      name,
+			Namespace: namespace,
+			Build: &kmmv1beta1.Build{
+				BuildArgs:           []kmmv1beta1.BuildArg{{Name: "ARG", Value: "value"}},
+				DockerfileConfigMap: &v1.LocalObjectReference{Name: "cm"},
+			},
+			ContainerImage: "registry/" + namespace + "/" + name + ":tag",
+			KernelVersion:  "1.2.3",
+			Architecture:   "amd64",
+		}
+	}
+
+	expectDockerfile := func(ctx context.Context, namespace, dockerfile string) {
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Name: "cm", Namespace: namespace}, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ interface{}, cm *v1.ConfigMap, _ ...ctrlclient.GetOption) error {
+				cm.Data = map[string]string{constants.DockerfileCMKey: dockerfile}
+				return nil
+			},
+		)
+	}
+
+	expectSecret := func(ctx context.Context, name, namespace string, data map[string][]byte) {
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &v1.Secret{}).DoAndReturn(
+			func(_ interface{}, _ interface{}, s *v1.Secret, _ ...ctrlclient.GetOption) error {
+				s.Data = data
+				return nil
+			},
+		)
+	}
+
+	It("should not depend on the Module, namespace or image", func() {
+		ctx := context.Background()
+
+		expectDockerfile(ctx, "ns1", "FROM test")
+		expectDockerfile(ctx, "ns2", "FROM test")
+
+		hash1, err := rm.getBuildContentHash(ctx, mldInNamespace("mod1", "ns1"))
+		Expect(err).NotTo(HaveOccurred())
+
+		hash2, err := rm.getBuildContentHash(ctx, mldInNamespace("mod2", "ns2"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(hash1).To(Equal(hash2))
+		Expect(hash1).To(HaveLen(16))
+	})
+
+	It("should depend on the Module if the Dockerfile uses MOD_NAME", func() {
+		ctx := context.Background()
+
+		expectDockerfile(ctx, "ns", "FROM test\nARG MOD_NAME")
+		expectDockerfile(ctx, "ns", "FROM test\nARG MOD_NAME")
+
+		hash1, err := rm.getBuildContentHash(ctx, mldInNamespace("mod1", "ns"))
+		Expect(err).NotTo(HaveOccurred())
+
+		hash2, err := rm.getBuildContentHash(ctx, mldInNamespace("mod2", "ns"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(hash1).NotTo(Equal(hash2))
+	})
+
+	It("should depend on the Dockerfile, the base images, the build arguments and the kernel", func() {
+		ctx := context.Background()
+
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectDockerfile(ctx, "ns", "FROM other")
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectDockerfile(ctx, "ns", "FROM test")
+
+		base, err := rm.getBuildContentHash(ctx, mldInNamespace("mod", "ns"))
+		Expect(err).NotTo(HaveOccurred())
+
+		otherDockerfile, err := rm.getBuildContentHash(ctx, mldInNamespace("mod", "ns"))
+		Expect(err).NotTo(HaveOccurred())
+
+		mld := mldInNamespace("mod", "ns")
+		mld.Build.BuildArgs[0].Value = "other"
+		otherArgs, err := rm.getBuildContentHash(ctx, mld)
+		Expect(err).NotTo(HaveOccurred())
+
+		mld = mldInNamespace("mod", "ns")
+		mld.KernelVersion = "4.5.6"
+		otherKernel, err := rm.getBuildContentHash(ctx, mld)
+		Expect(err).NotTo(HaveOccurred())
+
+		digests["test"] = "sha256:3"
+		otherBaseImage, err := rm.getBuildContentHash(ctx, mldInNamespace("mod", "ns"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect([]string{otherDockerfile, otherArgs, otherKernel, otherBaseImage}).NotTo(ContainElement(base))
+	})
+
+	It("should depend on the content of the build Secrets", func() {
+		ctx := context.Background()
+
+		withSecret := func(name string) *api.ModuleLoaderData {
+			mld := mldInNamespace("mod", "ns")
+			mld.Build.Secrets = []v1.LocalObjectReference{{Name: name}}
+			return mld
+		}
+
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectSecret(ctx, "secret", "ns", map[string][]byte{"key": []byte("value")})
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectSecret(ctx, "secret", "ns", map[string][]byte{"key": []byte("other")})
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectSecret(ctx, "other-secret", "ns", map[string][]byte{"key": []byte("value")})
+
+		base, err := rm.getBuildContentHash(ctx, withSecret("secret"))
+		Expect(err).NotTo(HaveOccurred())
+
+		otherContent, err := rm.getBuildContentHash(ctx, withSecret("secret"))
+		Expect(err).NotTo(HaveOccurred())
+
+		otherName, err := rm.getBuildContentHash(ctx, withSecret("other-secret"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect([]string{otherContent, otherName}).NotTo(ContainElement(base))
+	})
+
+	It("should depend on the content of the Secret build arguments, but not on the Secret's name", func() {
+		ctx := context.Background()
+
+		withSecretArg := func(name string) *api.ModuleLoaderData {
+			mld := mldInNamespace("mod", "ns")
+			mld.Build.BuildArgs = []kmmv1beta1.BuildArg{
+				{
+					Name: "TOKEN",
+					ValueFrom: &kmmv1beta1.ValueSource{
+						SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: name}, Key: "token"},
+					},
+				},
+			}
+			return mld
+		}
+
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectSecret(ctx, "secret", "ns", map[string][]byte{"token": []byte("value")})
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectSecret(ctx, "other-secret", "ns", map[string][]byte{"token": []byte("value")})
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectSecret(ctx, "secret", "ns", map[string][]byte{"token": []byte("other")})
+
+		base, err := rm.getBuildContentHash(ctx, withSecretArg("secret"))
+		Expect(err).NotTo(HaveOccurred())
+
+		otherName, err := rm.getBuildContentHash(ctx, withSecretArg("other-secret"))
+		Expect(err).NotTo(HaveOccurred())
+
+		otherContent, err := rm.getBuildContentHash(ctx, withSecretArg("secret"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(otherName).To(Equal(base))
+		Expect(otherContent).NotTo(Equal(base))
+	})
+
+	It("should return an error if a required Secret key of a build argument does not exist", func() {
+		ctx := context.Background()
+
+		mld := mldInNamespace("mod", "ns")
+		mld.Build.BuildArgs = []kmmv1beta1.BuildArg{
+			{
+				Name: "TOKEN",
+				ValueFrom: &kmmv1beta1.ValueSource{
+					SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "secret"}, Key: "token"},
+				},
+			},
+		}
+
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectSecret(ctx, "secret", "ns", map[string][]byte{})
+
+		_, err := rm.getBuildContentHash(ctx, mld)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should depend on the Git commit of the source", func() {
+		ctx := context.Background()
+
+		withCommit := func(commit string) *api.ModuleLoaderData {
+			mld := mldInNamespace("mod", "ns")
+			mld.Build.Source = &kmmv1beta1.BuildSource{
+				Git: &kmmv1beta1.GitSource{URL: "https://example.com/repo.git", Ref: commit},
+			}
+			return mld
+		}
+
+		expectDockerfile(ctx, "ns", "FROM test")
+		expectDockerfile(ctx, "ns", "FROM test")
+
+		hash1, err := rm.getBuildContentHash(ctx, withCommit(strings.Repeat("a", 40)))
+		Expect(err).NotTo(HaveOccurred())
+
+		hash2, err := rm.getBuildContentHash(ctx, withCommit(strings.Repeat("b", 40)))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(hash1).NotTo(BeEmpty())
+		Expect(hash2).NotTo(BeEmpty())
+		Expect(hash1).NotTo(Equal(hash2))
+	})
+
+	DescribeTable("should return an empty hash if the content of the build is not known",
+		func(ref string, dockerfileConfigMap bool, dockerfile string) {
+			ctx := context.Background()
+
+			mld := mldInNamespace("mod", "ns")
+
+			if ref != "" {
+				mld.Build.Source = &kmmv1beta1.BuildSource{
+					Git: &kmmv1beta1.GitSource{URL: "https://example.com/repo.git", Ref: ref},
+				}
+			}
+
+			if !dockerfileConfigMap {
+				mld.Build.DockerfileConfigMap = nil
+			} else if dockerfile != "" {
+				expectDockerfile(ctx, "ns", dockerfile)
+			}
+
+			Expect(rm.getBuildContentHash(ctx, mld)).To(BeEmpty())
+		},
+		Entry("Git branch", "main", true, ""),
+		Entry("short Git commit", "abcdef0", true, ""),
+		Entry("Dockerfile in the Git source", strings.Repeat("a", 40), false, ""),
+		Entry("base image from an unknown argument", "", true, "ARG IMAGE\nFROM ${IMAGE}"),
+		Entry("base image without a digest", "", true, "FROM missing"),
+	)
+})
+
+var _ = DescribeTable("baseImages",
+	func(dockerfile string, args map[string]string, expected []string, expectedOK bool) {
+		images, ok := baseImages(dockerfile, args)
+		Expect(ok).To(Equal(expectedOK))
+		Expect(images).To(Equal(expected))
+	},
+	Entry("single image", "FROM image:tag\nRUN make", nil, []string{"image:tag"}, true),
+	Entry(
+		"stages, scratch and flags",
+		"FROM --platform=linux/amd64 builder-image AS Builder\nFROM scratch\nFROM builder\nFROM image@sha256:1234",
+		nil,
+		[]string{"builder-image", "image@sha256:1234"},
+		true,
+	),
+	Entry(
+		"arguments and their default values",
+		"ARG REGISTRY=default.io\nARG TAG=latest\nFROM ${REGISTRY}/image:$TAG",
+		map[string]string{"TAG": "1.0"},
+		[]string{"default.io/image:1.0"},
+		true,
+	),
+	Entry("line continuations", "FROM \\\n  image:tag", nil, []string{"image:tag"}, true),
+	Entry("unknown argument", "ARG IMAGE\nFROM $IMAGE", nil, nil, false),
+	Entry("argument declared after the first stage", "FROM image\nARG TAG=1.0\nFROM other:${TAG}", nil, nil, false),
+)
+
+var _ = Describe("buildSpec", func() {
+	It("should read the build arguments from Secrets through environment variables", func() {
+		rm := &resourceManager{buildArgOverrider: module.NewBuildArgOverrider()}
+
+		secretRef := &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "secret"},
+			Key:                  "key",
+		}
+
+		mld := &api.ModuleLoaderData{
+			Name:      "mod",
+			Namespace: "ns",
+			Build: &kmmv1beta1.Build{
+				BuildArgs: []kmmv1beta1.BuildArg{
+					{Name: "PLAIN", Value: "value"},
+					{Name: "FROM_SECRET", ValueFrom: &kmmv1beta1.ValueSource{SecretKeyRef: secretRef}},
+				},
+			},
+			ContainerImage: "registry/ns/mod:tag",
+			KernelVersion:  "1.2.3",
+		}
+
+		spec, err := rm.buildSpec(mld, mld.ContainerImage, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec.Containers[0].Args).To(ContainElements(
+			"--build-arg", "PLAIN=value",
+			"--build-arg", "FROM_SECRET=$(KMM_BUILD_ARG_0)",
+		))
+		Expect(spec.Containers[0].Env).To(Equal([]v1.EnvVar{
+			{Name: "KMM_BUILD_ARG_0", ValueFrom: &v1.EnvVarSource{SecretKeyRef: secretRef}},
+		}))
+	})
+})
+
+var _ = Describe("copyCheckSpec", func() {
+	It("should pull the copied image with the image repository secret", func() {
+		GinkgoT().Setenv("RELATED_IMAGE_MANIFEST", "crane:latest")
+
+		mld := &api.ModuleLoaderData{
+			ContainerImage:  "registry/ns/mod:tag",
+			ImageRepoSecret: &v1.LocalObjectReference{Name: "pull-push-secret"},
+			RegistryTLS:     &kmmv1beta1.TLSOptions{InsecureSkipTLSVerify: true},
+		}
+
+		spec := copyCheckSpec(mld)
+		Expect(spec.Containers).To(HaveLen(1))
+		Expect(spec.Containers[0].Image).To(Equal("crane:latest"))
+		Expect(spec.Containers[0].Args).To(Equal([]string{
+			"digest", "registry/ns/mod:tag", "--insecure",
+		}))
+		Expect(spec.Volumes).To(HaveLen(1))
+		Expect(spec.Volumes[0].Secret.SecretName).To(Equal("pull-push-secret"))
+	})
+})
+
+var _ = Describe("makeManifestTemplate", func() {
+	const (
+		image                   = "my.registry/my/image:tag"
+		craneImage              = "some-crane-image:some-tag"
+		kernelNormalizedVersion = "1.2.3_4"
+		moduleName              = "module-name"
+		namespace               = "some-namespace"
+	)
+
+	rm := &resourceManager{scheme: scheme}
+
+	mod := kmmv1beta1.Module{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      moduleName,
+			Namespace: namespace,
+		},
+	}
+
+	It("should push a manifest list of the images of all architectures", func() {
+		GinkgoT().Setenv("RELATED_IMAGE_MANIFEST", craneImage)
+
+		mld := api.ModuleLoaderData{
+			Name:                    mod.Name,
+			Namespace:               mod.Namespace,
+			ContainerImage:          image,
+			ImageRepoSecret:         &v1.LocalObjectReference{Name: "pull-push-secret"},
+			RegistryTLS:             &kmmv1beta1.TLSOptions{Insecure: true},
+			KernelNormalizedVersion: kernelNormalizedVersion,
+			Architectures:           []string{"amd64", "arm64"},
+		}
+
+		actual, err := rm.makeManifestTemplate(&mld, &mod, kmmv1beta1.SignImage)
+		Expect(err).NotTo(HaveOccurred())
+
+		actualPod, ok := actual.(*v1.Pod)
+		Expect(ok).To(BeTrue())
+		Expect(actualPod.Name).To(Equal(moduleName + "-sign-" + kernelNormalizedVersion + "-manifest"))
+		Expect(actualPod.Labels).To(Equal(resourceLabels(moduleName, kernelNormalizedVersion, "", kmmv1beta1.SignImage)))
+		Expect(actualPod.Annotations).To(HaveKey(constants.ResourceHashAnnotation))
+		Expect(actualPod.OwnerReferences).To(HaveLen(1))
+		Expect(actualPod.Spec.NodeSelector).To(BeEmpty())
+		Expect(actualPod.Spec.Containers).To(HaveLen(1))
+
+		container := actualPod.Spec.Containers[0]
+		Expect(container.Image).To(Equal(craneImage))
+		Expect(container.Args).To(Equal([]string{
+			"index", "append",
+			"--tag", image,
+			"--manifest", image + "_amd64",
+			"--manifest", image + "_arm64",
+			"--insecure",
+		}))
+		Expect(container.Env).To(ConsistOf(v1.EnvVar{Name: "DOCKER_CONFIG", Value: "/docker"}))
+		Expect(container.VolumeMounts).To(ConsistOf(
+			v1.VolumeMount{Name: "secret-pull-push-secret", ReadOnly: true, MountPath: "/docker"},
+		))
+		Expect(actualPod.Spec.Volumes).To(HaveLen(1))
+		Expect(actualPod.Spec.Volumes[0].Secret.SecretName).To(Equal("pull-push-secret"))
+	})
 })
 
 var _ = Describe("makeSignTemplate", func() {
@@ -577,7 +1152,7 @@ COPY --from=signimage /opt/modules /modules
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      mld.Name + "-sign-" + mld.KernelNormalizedVersion,
 				Namespace: namespace,
-				Labels:    resourceLabels(mld.Name, mld.KernelNormalizedVersion, kmmv1beta1.SignImage),
+				Labels:    resourceLabels(mld.Name, mld.KernelNormalizedVersion, "", kmmv1beta1.SignImage),
 				OwnerReferences: []metav1.OwnerReference{
 					{
 						APIVersion:         "kmm.sigs.x-k8s.io/v1beta1",
@@ -934,6 +1509,21 @@ COPY --from=signimage /opt/modules /modules
 		Expect(actualPod.Annotations["dockerfile"]).To(ContainSubstring(unsignedImage))
 	})
 })
+var _ = Describe("signSpec", func() {
+	It("should stop the signing after its active deadline", func() {
+		mld := api.ModuleLoaderData{
+			Sign: &kmmv1beta1.Sign{
+				KeySecret:      &v1.LocalObjectReference{Name: "key"},
+				CertSecret:     &v1.LocalObjectReference{Name: "cert"},
+				ActiveDeadline: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		}
+
+		spec := signSpec(kaniko{}, &mld, "registry/image:tag", true)
+		Expect(spec.ActiveDeadlineSeconds).To(HaveValue(BeEquivalentTo(300)))
+	})
+})
+
 var _ = Describe("resourceLabels", func() {
 
 	It("get pod labels", func() {
@@ -949,7 +1539,12 @@ var _ = Describe("resourceLabels", func() {
 			constants.ResourceType:        "podType",
 		}
 
-		labels := resourceLabels(mod.Name, "targetKernel", "podType")
+		labels := resourceLabels(mod.Name, "targetKernel", "", "podType")
 		Expect(labels).To(Equal(expected))
 	})
+
+	It("should add the target architecture", func() {
+		labels := resourceLabels("moduleName", "targetKernel", "arm64", "podType")
+		Expect(labels).To(HaveKeyWithValue(constants.TargetArchitecture, "arm64"))
+	})
 })
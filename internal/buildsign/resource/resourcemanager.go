@@ -4,17 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/buildsign"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/meta"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/registry"
 )
 
 const (
@@ -22,31 +28,181 @@ const (
 	dockerfileVolumeName    = "dockerfile"
 )
 
+// SharedRepository is the repository to which KMM publishes the images it built, tagged with the hash of their
+// content, so that identical builds of other Modules copy them instead of building them again.
+type SharedRepository struct {
+	// Name is the repository; builds are not shared if it is empty.
+	Name string
+
+	// Namespace is the operator's namespace.
+	Namespace string
+
+	// PushSecret is the name of the pull secret in Namespace with which KMM pushes images to the repository and reads
+	// them, if any. Only those credentials should be allowed to push to it.
+	PushSecret string
+}
+
+// RootlessProfiles are the security profiles of the containers of the rootless build backends.
+type RootlessProfiles struct {
+	// Seccomp defaults to RuntimeDefault if it is nil.
+	Seccomp *v1.SeccompProfile
+
+	// AppArmor defaults to the profile of the container runtime if it is nil.
+	AppArmor *v1.AppArmorProfile
+}
+
 type resourceManager struct {
 	client            client.Client
 	buildArgOverrider module.BuildArgOverrider
 	scheme            *runtime.Scheme
+	defaultBackend    kmmv1beta1.BuildBackend
+	registryAPI       registry.Registry
+	sharedRepository  SharedRepository
+	rootlessProfiles  RootlessProfiles
 }
 
 func NewResourceManager(client client.Client, buildArgOverrider module.BuildArgOverrider,
-	scheme *runtime.Scheme) buildsign.ResourceManager {
+	scheme *runtime.Scheme, defaultBackend kmmv1beta1.BuildBackend, registryAPI registry.Registry,
+	sharedRepository SharedRepository, rootlessProfiles RootlessProfiles) buildsign.ResourceManager {
 
 	return &resourceManager{
 		client:            client,
 		buildArgOverrider: buildArgOverrider,
 		scheme:            scheme,
+		defaultBackend:    defaultBackend,
+		registryAPI:       registryAPI,
+		sharedRepository:  sharedRepository,
+		rootlessProfiles:  rootlessProfiles,
 	}
 }
 
 func (rm *resourceManager) MakeResourceTemplate(ctx context.Context, mld *api.ModuleLoaderData, owner metav1.Object,
 	pushImage bool, resourceType kmmv1beta1.BuildOrSignAction) (metav1.Object, error) {
 
+	if mld.Architecture == "" && len(mld.Architectures) > 0 {
+		return rm.makeManifestTemplate(mld, owner, resourceType)
+	}
+
 	if resourceType == kmmv1beta1.BuildImage {
 		return rm.makeBuildTemplate(ctx, mld, owner, pushImage)
 	}
 	return rm.makeSignTemplate(ctx, mld, owner, pushImage)
 }
 
+// CopySharedImage copies the image of an identical build from the shared build repository to mld's image, and
+// returns a resource checking the copied image in place of buildTemplate.
+// The shared build repository is read with the operator's credentials, which Pods in the Module's namespace do not
+// have, so the image is copied by the operator itself; the returned resource only pulls the copied image with the
+// Module's pull secret, so that the copy goes through the same status, retries and garbage collection as builds.
+// It returns nil if the build is not shared, or if the repository does not hold the image of an identical build; in
+// the latter case, it records the hash of the build in buildTemplate, so that its image is published to the
+// repository once it succeeded.
+func (rm *resourceManager) CopySharedImage(ctx context.Context, mld *api.ModuleLoaderData,
+	buildTemplate metav1.Object) (metav1.Object, error) {
+
+	buildPod, ok := buildTemplate.(*v1.Pod)
+	if !ok {
+		return nil, errors.New("the build template cannot be converted to the correct resource")
+	}
+
+	buildHash, err := rm.sharedBuildHash(ctx, mld)
+	if err != nil || buildHash == "" {
+		return nil, err
+	}
+
+	sharedImage := rm.sharedImage(buildHash)
+
+	labels, err := rm.registryAPI.GetLabels(ctx, sharedImage, rm.sharedRepositoryAccess())
+	if err != nil && !errors.Is(err, registry.ErrImageNotFound) {
+		return nil, fmt.Errorf("could not get the labels of image %s: %v", sharedImage, err)
+	}
+
+	// The label is only set by KMM when it publishes an image.
+	if labels[constants.BuildHashImageLabel] != buildHash {
+		meta.SetAnnotation(buildPod, constants.SharedBuildHashAnnotation, buildHash)
+		return nil, nil
+	}
+
+	dst := registry.Access{Namespace: mld.Namespace, PullSecret: mld.ImageRepoSecret, TLSOptions: registryTLS(mld)}
+
+	if err = rm.registryAPI.CopyWithLabels(ctx, sharedImage, rm.sharedRepositoryAccess(), mld.ContainerImage, dst, nil); err != nil {
+		return nil, fmt.Errorf("could not copy image %s to %s: %v", sharedImage, mld.ContainerImage, err)
+	}
+
+	copyPod := buildPod.DeepCopy()
+	copyPod.Spec = copyCheckSpec(mld)
+
+	return copyPod, nil
+}
+
+// PublishSharedImage copies the image built for mld by buildResource to the shared build repository, labeled with
+// the hash of the build, if buildResource recorded that hash and the content of the build did not change since.
+func (rm *resourceManager) PublishSharedImage(ctx context.Context, mld *api.ModuleLoaderData,
+	buildResource metav1.Object) error {
+
+	recordedHash := buildResource.GetAnnotations()[constants.SharedBuildHashAnnotation]
+	if recordedHash == "" {
+		return nil
+	}
+
+	buildHash, err := rm.sharedBuildHash(ctx, mld)
+	if err != nil {
+		return err
+	}
+
+	if buildHash != recordedHash {
+		log.FromContext(ctx).Info("Not publishing the image: the content of the build changed since it started")
+		return nil
+	}
+
+	src := registry.Access{Namespace: mld.Namespace, PullSecret: mld.ImageRepoSecret, TLSOptions: registryTLS(mld)}
+	sharedImage := rm.sharedImage(buildHash)
+	labels := map[string]string{constants.BuildHashImageLabel: buildHash}
+
+	if err = rm.registryAPI.CopyWithLabels(ctx, mld.ContainerImage, src, sharedImage, rm.sharedRepositoryAccess(), labels); err != nil {
+		return fmt.Errorf("could not publish image %s to %s: %v", mld.ContainerImage, sharedImage, err)
+	}
+
+	return nil
+}
+
+// sharedBuildHash returns the hash of the content of mld's build if it is shared, or an empty string otherwise.
+// A build is shared if a shared build repository is configured, if it builds the image of a single architecture,
+// if its namespace opted in with the SharedBuildsNamespaceLabel label and if its content is known before it runs.
+func (rm *resourceManager) sharedBuildHash(ctx context.Context, mld *api.ModuleLoaderData) (string, error) {
+	if rm.sharedRepository.Name == "" || mld.Build == nil || (mld.Architecture == "" && len(mld.Architectures) > 0) {
+		return "", nil
+	}
+
+	ns := v1.Namespace{}
+
+	if err := rm.client.Get(ctx, types.NamespacedName{Name: mld.Namespace}, &ns); err != nil {
+		return "", fmt.Errorf("could not get namespace %s: %v", mld.Namespace, err)
+	}
+
+	if ns.Labels[constants.SharedBuildsNamespaceLabel] != "true" {
+		return "", nil
+	}
+
+	buildHash, err := rm.getBuildContentHash(ctx, mld)
+	if err != nil {
+		return "", fmt.Errorf("could not hash the content of the build: %v", err)
+	}
+
+	return buildHash, nil
+}
+
+// sharedRepositoryAccess returns how KMM accesses the shared build repository.
+func (rm *resourceManager) sharedRepositoryAccess() registry.Access {
+	access := registry.Access{Namespace: rm.sharedRepository.Namespace}
+
+	if rm.sharedRepository.PushSecret != "" {
+		access.PullSecret = &v1.LocalObjectReference{Name: rm.sharedRepository.PushSecret}
+	}
+
+	return access
+}
+
 func (rm *resourceManager) CreateResource(ctx context.Context, obj metav1.Object) error {
 
 	resource, ok := obj.(*v1.Pod)
@@ -71,7 +227,7 @@ func (rm *resourceManager) DeleteResource(ctx context.Context, obj metav1.Object
 	return rm.client.Delete(ctx, resource, opts...)
 }
 
-func (rm *resourceManager) GetResourceByKernel(ctx context.Context, name, namespace, targetKernel string,
+func (rm *resourceManager) GetResourceByKernel(ctx context.Context, name, namespace, targetKernel, arch string,
 	resourceType kmmv1beta1.BuildOrSignAction, owner metav1.Object) (metav1.Object, error) {
 
 	matchLabels := moduleKernelLabels(name, targetKernel, resourceType)
@@ -82,7 +238,10 @@ func (rm *resourceManager) GetResourceByKernel(ctx context.Context, name, namesp
 
 	// filter resources by owner, since they could have been created by the preflight
 	// when checking that specific module
-	moduleOwnedResources := filterResourcesByOwner(resources, owner)
+	// ignore the resources being deleted, such as failed resources that have been retried
+	moduleOwnedResources := filterTerminatingResources(
+		filterResourcesByArchitecture(filterResourcesByOwner(resources, owner), arch),
+	)
 	numFoundResources := len(moduleOwnedResources)
 	if numFoundResources == 0 {
 		return nil, buildsign.ErrNoMatchingBuildSignResource
@@ -113,6 +272,54 @@ func (rm *resourceManager) GetResourceStatus(obj metav1.Object) (buildsign.Statu
 	}
 }
 
+// GetResourceFailure returns why a failed resource failed and when.
+func (rm *resourceManager) GetResourceFailure(obj metav1.Object) (string, time.Time, error) {
+
+	resource, ok := obj.(*v1.Pod)
+	if !ok {
+		return "", time.Time{}, errors.New("the existing resource cannot be converted to the correct resource")
+	}
+
+	var (
+		reason   string
+		failedAt time.Time
+	)
+
+	if resource.Status.Reason != "" {
+		// the Pod itself failed, e.g. with DeadlineExceeded
+		reason = resource.Status.Reason
+		if resource.Status.Message != "" {
+			reason += ": " + resource.Status.Message
+		}
+	}
+
+	for _, c := range resource.Status.Conditions {
+		if c.LastTransitionTime.After(failedAt) {
+			failedAt = c.LastTransitionTime.Time
+		}
+	}
+
+	for _, cs := range slices.Concat(resource.Status.InitContainerStatuses, resource.Status.ContainerStatuses) {
+		terminated := cs.State.Terminated
+		if terminated == nil || terminated.ExitCode == 0 {
+			continue
+		}
+
+		if terminated.FinishedAt.After(failedAt) {
+			failedAt = terminated.FinishedAt.Time
+		}
+
+		if reason == "" {
+			reason = fmt.Sprintf("container %s exited with code %d", cs.Name, terminated.ExitCode)
+			if terminated.Reason != "" {
+				reason += ": " + terminated.Reason
+			}
+		}
+	}
+
+	return reason, failedAt, nil
+}
+
 func (rm *resourceManager) IsResourceChanged(existingObj metav1.Object, newObj metav1.Object) (bool, error) {
 
 	existingResource, ok := existingObj.(*v1.Pod)
@@ -4,18 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/buildsign"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/registry"
 	"go.uber.org/mock/gomock"
 	sigclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -32,7 +36,7 @@ var _ = Describe("GetResourceByKernel", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
 		mockBuildArgOverrider = module.NewMockBuildArgOverrider(ctrl)
-		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme)
+		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme, "", nil, SharedRepository{}, RootlessProfiles{})
 	})
 
 	It("should return only one pod", func() {
@@ -66,7 +70,7 @@ var _ = Describe("GetResourceByKernel", func() {
 			},
 		)
 
-		pod, err := rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "resourceType", &mod)
+		pod, err := rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "", "resourceType", &mod)
 
 		Expect(pod).To(Equal(&j))
 		Expect(err).NotTo(HaveOccurred())
@@ -92,7 +96,7 @@ var _ = Describe("GetResourceByKernel", func() {
 
 		clnt.EXPECT().List(ctx, &podList, opts).Return(errors.New("random error"))
 
-		_, err := rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "resourceType", &mod)
+		_, err := rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "", "resourceType", &mod)
 
 		Expect(err).To(HaveOccurred())
 	})
@@ -134,7 +138,7 @@ var _ = Describe("GetResourceByKernel", func() {
 			},
 		)
 
-		pod, err := rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "resourceType", &mod)
+		pod, err := rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "", "resourceType", &mod)
 
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("expected 0 or 1"))
@@ -183,11 +187,99 @@ var _ = Describe("GetResourceByKernel", func() {
 			},
 		)
 
-		pod, err := rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "resourceType", &mod)
+		pod, err := rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "", "resourceType", &mod)
 
 		Expect(err).NotTo(HaveOccurred())
 		Expect(pod).To(Equal(&j1))
 	})
+
+	It("should only return the pod targeting the architecture", func() {
+		ctx := context.Background()
+
+		mod := kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "moduleName", Namespace: "moduleNamespace"},
+		}
+
+		manifest := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "manifestPod", Namespace: "moduleNamespace"},
+		}
+		amd64 := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "amd64Pod",
+				Namespace: "moduleNamespace",
+				Labels:    map[string]string{constants.TargetArchitecture: "amd64"},
+			},
+		}
+		arm64 := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "arm64Pod",
+				Namespace: "moduleNamespace",
+				Labels:    map[string]string{constants.TargetArchitecture: "arm64"},
+			},
+		}
+
+		for _, p := range []*v1.Pod{&manifest, &amd64, &arm64} {
+			Expect(
+				controllerutil.SetControllerReference(&mod, p, scheme),
+			).NotTo(
+				HaveOccurred(),
+			)
+		}
+
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *v1.PodList, _ ...interface{}) error {
+				list.Items = []v1.Pod{manifest, amd64, arm64}
+				return nil
+			},
+		).Times(2)
+
+		pod, err := rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "arm64", "resourceType", &mod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pod).To(Equal(&arm64))
+
+		pod, err = rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "", "resourceType", &mod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pod).To(Equal(&manifest))
+	})
+
+	It("should ignore the pods being deleted", func() {
+		ctx := context.Background()
+
+		mod := kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "moduleName", Namespace: "moduleNamespace"},
+		}
+
+		failed := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "failedPod",
+				Namespace:         "moduleNamespace",
+				DeletionTimestamp: &metav1.Time{Time: time.Now()},
+				Finalizers:        []string{constants.GCDelayFinalizer},
+			},
+		}
+		retry := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "failedPod-attempt-2", Namespace: "moduleNamespace"},
+		}
+
+		for _, p := range []*v1.Pod{&failed, &retry} {
+			Expect(
+				controllerutil.SetControllerReference(&mod, p, scheme),
+			).NotTo(
+				HaveOccurred(),
+			)
+		}
+
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *v1.PodList, _ ...interface{}) error {
+				list.Items = []v1.Pod{failed, retry}
+				return nil
+			},
+		)
+
+		pod, err := rm.GetResourceByKernel(ctx, mod.Name, mod.Namespace, "targetKernel", "", "resourceType", &mod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pod).To(Equal(&retry))
+	})
 })
 
 var _ = Describe("GetModuleResources", func() {
@@ -201,7 +293,7 @@ var _ = Describe("GetModuleResources", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme)
+		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme, "", nil, SharedRepository{}, RootlessProfiles{})
 	})
 
 	It("return all found pods", func() {
@@ -311,7 +403,7 @@ var _ = Describe("DeleteResource", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme)
+		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme, "", nil, SharedRepository{}, RootlessProfiles{})
 	})
 
 	It("good flow", func() {
@@ -356,7 +448,7 @@ var _ = Describe("CreateResource", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme)
+		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme, "", nil, SharedRepository{}, RootlessProfiles{})
 	})
 
 	It("good flow", func() {
@@ -395,7 +487,7 @@ var _ = Describe("PodStatus", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme)
+		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme, "", nil, SharedRepository{}, RootlessProfiles{})
 	})
 
 	DescribeTable("should return the correct status depending on the pod status",
@@ -417,6 +509,65 @@ var _ = Describe("PodStatus", func() {
 	)
 })
 
+var _ = Describe("GetResourceFailure", func() {
+	rm := NewResourceManager(nil, nil, scheme, "", nil, SharedRepository{}, RootlessProfiles{})
+
+	finishedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	scheduledAt := finishedAt.Add(-time.Hour)
+
+	DescribeTable("should return why and when the pod failed",
+		func(status v1.PodStatus, expectedReason string, expectedTime time.Time) {
+			reason, failedAt, err := rm.GetResourceFailure(&v1.Pod{Status: status})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reason).To(Equal(expectedReason))
+			Expect(failedAt).To(BeTemporally("==", expectedTime))
+		},
+		Entry(
+			"deadline exceeded",
+			v1.PodStatus{
+				Phase:   v1.PodFailed,
+				Reason:  "DeadlineExceeded",
+				Message: "Pod was active on the node longer than the specified deadline",
+				Conditions: []v1.PodCondition{
+					{Type: v1.PodScheduled, LastTransitionTime: metav1.NewTime(scheduledAt)},
+					{Type: v1.PodReady, LastTransitionTime: metav1.NewTime(finishedAt)},
+				},
+			},
+			"DeadlineExceeded: Pod was active on the node longer than the specified deadline",
+			finishedAt,
+		),
+		Entry(
+			"container failed",
+			v1.PodStatus{
+				Phase:      v1.PodFailed,
+				Conditions: []v1.PodCondition{{Type: v1.PodScheduled, LastTransitionTime: metav1.NewTime(scheduledAt)}},
+				InitContainerStatuses: []v1.ContainerStatus{
+					{
+						Name: "git-clone",
+						State: v1.ContainerState{
+							Terminated: &v1.ContainerStateTerminated{FinishedAt: metav1.NewTime(scheduledAt)},
+						},
+					},
+				},
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						Name: "kaniko",
+						State: v1.ContainerState{
+							Terminated: &v1.ContainerStateTerminated{
+								ExitCode:   137,
+								Reason:     "OOMKilled",
+								FinishedAt: metav1.NewTime(finishedAt),
+							},
+						},
+					},
+				},
+			},
+			"container kaniko exited with code 137: OOMKilled",
+			finishedAt,
+		),
+	)
+})
+
 var _ = Describe("IsPodChnaged", func() {
 	var (
 		ctrl                  *gomock.Controller
@@ -428,7 +579,7 @@ var _ = Describe("IsPodChnaged", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme)
+		rm = NewResourceManager(clnt, mockBuildArgOverrider, scheme, "", nil, SharedRepository{}, RootlessProfiles{})
 	})
 
 	DescribeTable("should detect if a pod has changed",
@@ -459,3 +610,232 @@ var _ = Describe("IsPodChnaged", func() {
 		Entry("should return false is pod has not changed ", map[string]string{constants.ResourceHashAnnotation: "some hash"}, false, false),
 	)
 })
+
+var _ = Describe("shared builds", func() {
+	const sharedImage = "registry/shared:"
+
+	var (
+		ctrl           *gomock.Controller
+		clnt           *client.MockClient
+		mockRegistry   *registry.MockRegistry
+		rm             buildsign.ResourceManager
+		mld            *api.ModuleLoaderData
+		buildPod       *v1.Pod
+		buildHash      string
+		namespaceLabel string
+	)
+
+	operatorAccess := registry.Access{
+		Namespace:  "operator-ns",
+		PullSecret: &v1.LocalObjectReference{Name: "push-secret"},
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mockRegistry = registry.NewMockRegistry(ctrl)
+		rm = NewResourceManager(clnt, module.NewBuildArgOverrider(), scheme, "", mockRegistry, SharedRepository{
+			Name:       "registry/shared",
+			Namespace:  "operator-ns",
+			PushSecret: "push-secret",
+		}, RootlessProfiles{})
+		namespaceLabel = "true"
+
+		mld = &api.ModuleLoaderData{
+			Name:      "mod",
+			Namespace: "ns",
+			Build: &kmmv1beta1.Build{
+				DockerfileConfigMap: &v1.LocalObjectReference{Name: "cm"},
+			},
+			ContainerImage:  "registry/ns/mod:tag",
+			ImageRepoSecret: &v1.LocalObjectReference{Name: "pull-push-secret"},
+			KernelVersion:   "1.2.3",
+		}
+		buildPod = &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "mod-build-1.2.3",
+				Namespace:   "ns",
+				Annotations: map[string]string{constants.ResourceHashAnnotation: "1234"},
+			},
+		}
+
+		clnt.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "ns"}, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ interface{}, ns *v1.Namespace, _ ...sigclient.GetOption) error {
+				ns.Labels = map[string]string{constants.SharedBuildsNamespaceLabel: namespaceLabel}
+				return nil
+			},
+		).AnyTimes()
+		clnt.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "cm", Namespace: "ns"}, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ interface{}, cm *v1.ConfigMap, _ ...sigclient.GetOption) error {
+				cm.Data = map[string]string{constants.DockerfileCMKey: "FROM test"}
+				return nil
+			},
+		).AnyTimes()
+		mockRegistry.EXPECT().GetDigest(gomock.Any(), "test", registry.Access{Namespace: "ns", PullSecret: mld.ImageRepoSecret}).
+			Return("sha256:1234", nil).
+			AnyTimes()
+
+		var err error
+		buildHash, err = (&resourceManager{client: clnt, buildArgOverrider: module.NewBuildArgOverrider(), registryAPI: mockRegistry}).
+			getBuildContentHash(context.Background(), mld)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buildHash).NotTo(BeEmpty())
+	})
+
+	Context("CopySharedImage", func() {
+		It("should copy the image of an identical build", func() {
+			ctx := context.Background()
+
+			gomock.InOrder(
+				mockRegistry.EXPECT().GetLabels(ctx, sharedImage+buildHash, operatorAccess).
+					Return(map[string]string{constants.BuildHashImageLabel: buildHash}, nil),
+				mockRegistry.EXPECT().CopyWithLabels(
+					ctx,
+					sharedImage+buildHash,
+					operatorAccess,
+					"registry/ns/mod:tag",
+					registry.Access{Namespace: "ns", PullSecret: mld.ImageRepoSecret},
+					nil,
+				),
+			)
+
+			res, err := rm.CopySharedImage(ctx, mld, buildPod)
+			Expect(err).NotTo(HaveOccurred())
+
+			copyPod := res.(*v1.Pod)
+			Expect(copyPod.ObjectMeta).To(Equal(buildPod.ObjectMeta))
+			Expect(copyPod.Spec.Containers).To(HaveLen(1))
+			Expect(copyPod.Spec.Containers[0].Args).To(Equal([]string{"digest", "registry/ns/mod:tag"}))
+		})
+
+		It("should return an error if the image of the identical build could not be copied", func() {
+			ctx := context.Background()
+
+			gomock.InOrder(
+				mockRegistry.EXPECT().GetLabels(ctx, sharedImage+buildHash, operatorAccess).
+					Return(map[string]string{constants.BuildHashImageLabel: buildHash}, nil),
+				mockRegistry.EXPECT().CopyWithLabels(ctx, sharedImage+buildHash, operatorAccess, "registry/ns/mod:tag", gomock.Any(), nil).
+					Return(errors.New("some error")),
+			)
+
+			_, err := rm.CopySharedImage(ctx, mld, buildPod)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should record the hash of the build if there is no identical build", func() {
+			ctx := context.Background()
+
+			mockRegistry.EXPECT().GetLabels(ctx, sharedImage+buildHash, operatorAccess).
+				Return(nil, registry.ErrImageNotFound)
+
+			Expect(rm.CopySharedImage(ctx, mld, buildPod)).To(BeNil())
+			Expect(buildPod.Annotations).To(HaveKeyWithValue(constants.SharedBuildHashAnnotation, buildHash))
+		})
+
+		It("should return nil if the shared image was not published by KMM", func() {
+			ctx := context.Background()
+
+			mockRegistry.EXPECT().GetLabels(ctx, sharedImage+buildHash, operatorAccess).
+				Return(map[string]string{}, nil)
+
+			Expect(rm.CopySharedImage(ctx, mld, buildPod)).To(BeNil())
+		})
+
+		It("should return an error if the registry cannot be read", func() {
+			ctx := context.Background()
+
+			mockRegistry.EXPECT().GetLabels(ctx, sharedImage+buildHash, operatorAccess).
+				Return(nil, errors.New("some error"))
+
+			_, err := rm.CopySharedImage(ctx, mld, buildPod)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return nil if the namespace did not opt in", func() {
+			namespaceLabel = ""
+
+			Expect(rm.CopySharedImage(context.Background(), mld, buildPod)).To(BeNil())
+			Expect(buildPod.Annotations).NotTo(HaveKey(constants.SharedBuildHashAnnotation))
+		})
+
+		It("should return nil if the content of the build is not known before it runs", func() {
+			mld.Build.Source = &kmmv1beta1.BuildSource{
+				Git: &kmmv1beta1.GitSource{URL: "https://example.com/repo.git", Ref: "main"},
+			}
+
+			Expect(rm.CopySharedImage(context.Background(), mld, buildPod)).To(BeNil())
+			Expect(buildPod.Annotations).NotTo(HaveKey(constants.SharedBuildHashAnnotation))
+		})
+
+		It("should return nil if there is no shared build repository", func() {
+			rm = NewResourceManager(clnt, module.NewBuildArgOverrider(), scheme, "", mockRegistry, SharedRepository{}, RootlessProfiles{})
+
+			Expect(rm.CopySharedImage(context.Background(), mld, buildPod)).To(BeNil())
+		})
+	})
+
+	Context("PublishSharedImage", func() {
+		It("should copy the built image to the shared build repository", func() {
+			ctx := context.Background()
+			buildPod.Annotations[constants.SharedBuildHashAnnotation] = buildHash
+
+			mockRegistry.EXPECT().CopyWithLabels(
+				ctx,
+				"registry/ns/mod:tag",
+				registry.Access{Namespace: "ns", PullSecret: mld.ImageRepoSecret},
+				sharedImage+buildHash,
+				operatorAccess,
+				map[string]string{constants.BuildHashImageLabel: buildHash},
+			)
+
+			Expect(
+				rm.PublishSharedImage(ctx, mld, buildPod),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should not publish images of builds that did not record their hash", func() {
+			Expect(
+				rm.PublishSharedImage(context.Background(), mld, buildPod),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should not publish images of builds whose content changed", func() {
+			buildPod.Annotations[constants.SharedBuildHashAnnotation] = "some other hash"
+
+			Expect(
+				rm.PublishSharedImage(context.Background(), mld, buildPod),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should not publish images once the namespace opted out", func() {
+			buildPod.Annotations[constants.SharedBuildHashAnnotation] = buildHash
+			namespaceLabel = "false"
+
+			Expect(
+				rm.PublishSharedImage(context.Background(), mld, buildPod),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should return an error if the image could not be copied", func() {
+			ctx := context.Background()
+			buildPod.Annotations[constants.SharedBuildHashAnnotation] = buildHash
+
+			mockRegistry.EXPECT().CopyWithLabels(ctx, "registry/ns/mod:tag", gomock.Any(), sharedImage+buildHash, operatorAccess, gomock.Any()).
+				Return(errors.New("some error"))
+
+			Expect(
+				rm.PublishSharedImage(ctx, mld, buildPod),
+			).To(
+				HaveOccurred(),
+			)
+		})
+	})
+})
@@ -0,0 +1,139 @@
+package resource
+
+import (
+	"errors"
+	"os"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	gitCloneContainerName      = "git-clone"
+	gitCredentialsVolumeName   = "git-credentials"
+	sourceVolumeName           = "source"
+	sourceRevisionLabel        = "org.opencontainers.image.revision"
+	gitCredentialsMountPath    = "/git-credentials"
+	sourceMountPath            = "/source"
+	dockerfileSourceMountPath  = "/dockerfile"
+	gitCloneTerminationMessage = "/dev/termination-log"
+)
+
+// gitCloneScript fetches GIT_REF from GIT_URL into /source, replaces the Dockerfile of the build context with the one
+// of the Dockerfile ConfigMap if it is mounted, labels the image with the resolved commit and reports the commit as
+// the container's termination message.
+const gitCloneScript = `set -e
+if [ -f ` + gitCredentialsMountPath + `/ssh-privatekey ]; then
+  export GIT_SSH_COMMAND="ssh -i ` + gitCredentialsMountPath + `/ssh-privatekey -o StrictHostKeyChecking=accept-new"
+fi
+if [ -f ` + gitCredentialsMountPath + `/username ]; then
+  git config --global credential.helper '!f() { echo "username=$(cat ` + gitCredentialsMountPath + `/username)"; echo "password=$(cat ` + gitCredentialsMountPath + `/password)"; }; f'
+fi
+git init -q ` + sourceMountPath + `
+cd ` + sourceMountPath + `
+git remote add origin "$GIT_URL"
+git fetch -q --depth 1 origin "${GIT_REF:-HEAD}"
+git checkout -q FETCH_HEAD
+commit=$(git rev-parse HEAD)
+cd "` + sourceMountPath + `/$CONTEXT_DIR"
+if [ -f ` + dockerfileSourceMountPath + `/Dockerfile ]; then
+  cp ` + dockerfileSourceMountPath + `/Dockerfile Dockerfile
+fi
+printf '\nLABEL ` + sourceRevisionLabel + `=%s\n' "$commit" >> Dockerfile
+printf '%s' "$commit" > ` + gitCloneTerminationMessage + `
+`
+
+// gitCloneContainer returns the init container that clones git into the source volume.
+func gitCloneContainer(git *kmmv1beta1.GitSource, hasDockerfileConfigMap bool) v1.Container {
+	volumeMounts := []v1.VolumeMount{
+		{
+			Name:      sourceVolumeName,
+			MountPath: sourceMountPath,
+		},
+	}
+
+	if hasDockerfileConfigMap {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      dockerfileVolumeName,
+			ReadOnly:  true,
+			MountPath: dockerfileSourceMountPath,
+		})
+	}
+
+	if git.CredentialsSecret != nil {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      gitCredentialsVolumeName,
+			ReadOnly:  true,
+			MountPath: gitCredentialsMountPath,
+		})
+	}
+
+	return v1.Container{
+		Name:    gitCloneContainerName,
+		Image:   os.Getenv("RELATED_IMAGE_GIT"),
+		Command: []string{"/bin/sh", "-c", gitCloneScript},
+		Env: []v1.EnvVar{
+			{Name: "GIT_URL", Value: git.URL},
+			{Name: "GIT_REF", Value: git.Ref},
+			{Name: "CONTEXT_DIR", Value: git.ContextDir},
+			// git writes its global configuration in $HOME.
+			{Name: "HOME", Value: "/tmp"},
+		},
+		TerminationMessagePath: gitCloneTerminationMessage,
+		VolumeMounts:           volumeMounts,
+	}
+}
+
+// makeSourceVolumes returns the volumes of the build Pod needed to clone git.
+func makeSourceVolumes(git *kmmv1beta1.GitSource) []v1.Volume {
+	volumes := []v1.Volume{
+		{
+			Name:         sourceVolumeName,
+			VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+		},
+	}
+
+	if git.CredentialsSecret != nil {
+		volumes = append(volumes, v1.Volume{
+			Name: gitCredentialsVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: git.CredentialsSecret.Name,
+					// ssh refuses private keys readable by other users.
+					DefaultMode: ptr.To[int32](0400),
+				},
+			},
+		})
+	}
+
+	return volumes
+}
+
+// gitSource returns the Git source of buildConfig, if any.
+func gitSource(buildConfig *kmmv1beta1.Build) *kmmv1beta1.GitSource {
+	if buildConfig == nil || buildConfig.Source == nil {
+		return nil
+	}
+
+	return buildConfig.Source.Git
+}
+
+// GetResourceSourceCommit returns the commit of the Git source cloned by a build resource, or an empty string if the
+// resource does not build from Git or has not cloned it yet.
+func (rm *resourceManager) GetResourceSourceCommit(obj metav1.Object) (string, error) {
+
+	resource, ok := obj.(*v1.Pod)
+	if !ok {
+		return "", errors.New("the existing resource cannot be converted to the correct resource")
+	}
+
+	for _, cs := range resource.Status.InitContainerStatuses {
+		if cs.Name == gitCloneContainerName && cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0 {
+			return cs.State.Terminated.Message, nil
+		}
+	}
+
+	return "", nil
+}
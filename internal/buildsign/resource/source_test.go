@@ -0,0 +1,148 @@
+package resource
+
+import (
+	"context"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
+)
+
+var _ = Describe("makeBuildTemplate with a Git source", func() {
+	var (
+		ctrl *gomock.Controller
+		clnt *client.MockClient
+		mbao *module.MockBuildArgOverrider
+		rm   *resourceManager
+		mld  api.ModuleLoaderData
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mbao = module.NewMockBuildArgOverrider(ctrl)
+		rm = &resourceManager{
+			client:            clnt,
+			buildArgOverrider: mbao,
+			scheme:            scheme,
+		}
+
+		mod := &kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "module-name", Namespace: "some-namespace"},
+		}
+
+		mld = api.ModuleLoaderData{
+			Owner:     mod,
+			Name:      mod.Name,
+			Namespace: mod.Namespace,
+			Build: &kmmv1beta1.Build{
+				Source: &kmmv1beta1.BuildSource{
+					Git: &kmmv1beta1.GitSource{
+						URL:               "https://github.com/org/repo.git",
+						Ref:               "v1.0",
+						ContextDir:        "driver",
+						CredentialsSecret: &v1.LocalObjectReference{Name: "git-secret"},
+					},
+				},
+			},
+			ContainerImage:          "registry/image:tag",
+			RegistryTLS:             &kmmv1beta1.TLSOptions{},
+			KernelVersion:           "1.2.3",
+			KernelNormalizedVersion: "1.2.3",
+		}
+
+		GinkgoT().Setenv("RELATED_IMAGE_GIT", "git:latest")
+		mbao.EXPECT().ApplyBuildArgOverrides(nil, gomock.Any())
+	})
+
+	It("should clone the source into the build context", func() {
+		actual, err := rm.makeBuildTemplate(context.Background(), &mld, mld.Owner, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		spec := actual.(*v1.Pod).Spec
+
+		Expect(spec.InitContainers).To(HaveLen(1))
+		initContainer := spec.InitContainers[0]
+		Expect(initContainer.Name).To(Equal(gitCloneContainerName))
+		Expect(initContainer.Image).To(Equal("git:latest"))
+		Expect(initContainer.Env).To(ContainElements(
+			v1.EnvVar{Name: "GIT_URL", Value: "https://github.com/org/repo.git"},
+			v1.EnvVar{Name: "GIT_REF", Value: "v1.0"},
+			v1.EnvVar{Name: "CONTEXT_DIR", Value: "driver"},
+		))
+		Expect(initContainer.VolumeMounts).To(ConsistOf(
+			v1.VolumeMount{Name: sourceVolumeName, MountPath: sourceMountPath},
+			v1.VolumeMount{Name: gitCredentialsVolumeName, ReadOnly: true, MountPath: gitCredentialsMountPath},
+		))
+
+		Expect(spec.Containers[0].VolumeMounts).To(ContainElement(
+			v1.VolumeMount{Name: sourceVolumeName, ReadOnly: true, MountPath: "/workspace", SubPath: "driver"},
+		))
+		Expect(spec.Volumes).NotTo(ContainElement(HaveField("Name", dockerfileVolumeName)))
+		Expect(spec.Volumes).To(ContainElement(HaveField("Name", sourceVolumeName)))
+		Expect(spec.Volumes).To(ContainElement(HaveField("Secret.SecretName", "git-secret")))
+	})
+
+	It("should replace the Dockerfile of the source with the ConfigMap", func() {
+		mld.Build.DockerfileConfigMap = &v1.LocalObjectReference{Name: "dockerfile-cm"}
+
+		clnt.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "dockerfile-cm", Namespace: mld.Namespace}, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ interface{}, cm *v1.ConfigMap, _ ...ctrlclient.GetOption) error {
+				cm.Data = map[string]string{constants.DockerfileCMKey: "FROM test"}
+				return nil
+			},
+		)
+
+		actual, err := rm.makeBuildTemplate(context.Background(), &mld, mld.Owner, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		spec := actual.(*v1.Pod).Spec
+		Expect(spec.InitContainers[0].VolumeMounts).To(ContainElement(
+			v1.VolumeMount{Name: dockerfileVolumeName, ReadOnly: true, MountPath: dockerfileSourceMountPath},
+		))
+		Expect(spec.Containers[0].VolumeMounts).NotTo(ContainElement(HaveField("Name", dockerfileVolumeName)))
+	})
+})
+
+var _ = Describe("GetResourceSourceCommit", func() {
+	rm := &resourceManager{}
+
+	DescribeTable("should return the commit reported by the clone container",
+		func(statuses []v1.ContainerStatus, expected string) {
+			pod := &v1.Pod{Status: v1.PodStatus{InitContainerStatuses: statuses}}
+
+			commit, err := rm.GetResourceSourceCommit(pod)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commit).To(Equal(expected))
+		},
+		Entry("no init container", nil, ""),
+		Entry("still cloning",
+			[]v1.ContainerStatus{
+				{Name: gitCloneContainerName, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			},
+			"",
+		),
+		Entry("clone failed",
+			[]v1.ContainerStatus{
+				{Name: gitCloneContainerName, State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 128, Message: "fatal"}}},
+			},
+			"",
+		),
+		Entry("cloned",
+			[]v1.ContainerStatus{
+				{Name: gitCloneContainerName, State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Message: "abc123"}}},
+			},
+			"abc123",
+		),
+	)
+})
@@ -9,11 +9,30 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// signRunMounts are the directories of the sign Pod that the sign Dockerfile's RUN instructions use.
+var signRunMounts = []runMount{
+	{name: "key", path: "/run/secrets/key"},
+	{name: "cert", path: "/run/secrets/cert"},
+}
+
+// buildRunMounts returns the directories of the build Pod that the Dockerfile's RUN instructions may use.
+func buildRunMounts(buildConfig kmmv1beta1.Build) []runMount {
+	mounts := []runMount{{name: "lib-modules", path: "/host/lib/modules"}}
+
+	for _, secretRef := range buildConfig.Secrets {
+		mounts = append(mounts, runMount{name: "secret-" + secretRef.Name, path: "/run/secrets/" + secretRef.Name})
+	}
+
+	return mounts
+}
+
 func makeBuildResourceVolumesAndVolumeMounts(buildConfig kmmv1beta1.Build,
-	imageRepoSecret *v1.LocalObjectReference) ([]v1.Volume, []v1.VolumeMount) {
+	imageRepoSecret *v1.LocalObjectReference, dockerConfigDir string) ([]v1.Volume, []v1.VolumeMount) {
 
-	volumes := []v1.Volume{
-		{
+	volumes := []v1.Volume{}
+
+	if buildConfig.DockerfileConfigMap != nil {
+		volumes = append(volumes, v1.Volume{
 			Name: dockerfileVolumeName,
 			VolumeSource: v1.VolumeSource{
 				ConfigMap: &v1.ConfigMapVolumeSource{
@@ -26,16 +45,30 @@ func makeBuildResourceVolumesAndVolumeMounts(buildConfig kmmv1beta1.Build,
 					},
 				},
 			},
-		},
-		{
-			Name: "lib-modules",
-			VolumeSource: v1.VolumeSource{
-				HostPath: &v1.HostPathVolumeSource{
-					Path: "/lib/modules",
-					Type: ptr.To(v1.HostPathDirectory),
-				},
+		})
+	}
+
+	volumes = append(volumes, v1.Volume{
+		Name: "lib-modules",
+		VolumeSource: v1.VolumeSource{
+			HostPath: &v1.HostPathVolumeSource{
+				Path: "/lib/modules",
+				Type: ptr.To(v1.HostPathDirectory),
 			},
 		},
+	})
+
+	// The build context is the Dockerfile ConfigMap, or the context directory of the cloned Git source.
+	workspaceMount := v1.VolumeMount{
+		Name:      dockerfileVolumeName,
+		ReadOnly:  true,
+		MountPath: "/workspace",
+	}
+
+	if git := gitSource(&buildConfig); git != nil {
+		volumes = append(volumes, makeSourceVolumes(git)...)
+		workspaceMount.Name = sourceVolumeName
+		workspaceMount.SubPath = git.ContextDir
 	}
 
 	for _, secretRef := range buildConfig.Secrets {
@@ -51,11 +84,7 @@ func makeBuildResourceVolumesAndVolumeMounts(buildConfig kmmv1beta1.Build,
 	}
 
 	volumeMounts := []v1.VolumeMount{
-		{
-			Name:      dockerfileVolumeName,
-			ReadOnly:  true,
-			MountPath: "/workspace",
-		},
+		workspaceMount,
 		{
 			Name:      "lib-modules",
 			ReadOnly:  true,
@@ -96,7 +125,7 @@ func makeBuildResourceVolumesAndVolumeMounts(buildConfig kmmv1beta1.Build,
 			v1.VolumeMount{
 				Name:      "secret-" + imageRepoSecret.Name,
 				ReadOnly:  true,
-				MountPath: "/kaniko/.docker",
+				MountPath: dockerConfigDir,
 			},
 		)
 	}
@@ -105,7 +134,7 @@ func makeBuildResourceVolumesAndVolumeMounts(buildConfig kmmv1beta1.Build,
 }
 
 func makeSignResourceVolumesAndVolumeMounts(signConfig *kmmv1beta1.Sign,
-	imageRepoSecret *v1.LocalObjectReference) ([]v1.Volume, []v1.VolumeMount) {
+	imageRepoSecret *v1.LocalObjectReference, dockerConfigDir string) ([]v1.Volume, []v1.VolumeMount) {
 
 	volumes := []v1.Volume{
 		{
@@ -196,10 +225,44 @@ func makeSignResourceVolumesAndVolumeMounts(signConfig *kmmv1beta1.Sign,
 			v1.VolumeMount{
 				Name:      "secret-" + imageRepoSecret.Name,
 				ReadOnly:  true,
-				MountPath: "/kaniko/.docker",
+				MountPath: dockerConfigDir,
 			},
 		)
 	}
 
 	return volumes, volumeMounts
 }
+
+func makeManifestResourceVolumesAndVolumeMounts(imageRepoSecret *v1.LocalObjectReference) ([]v1.Volume, []v1.VolumeMount) {
+
+	if imageRepoSecret == nil {
+		return nil, nil
+	}
+
+	volumes := []v1.Volume{
+		{
+			Name: "secret-" + imageRepoSecret.Name,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: imageRepoSecret.Name,
+					Items: []v1.KeyToPath{
+						{
+							Key:  v1.DockerConfigJsonKey,
+							Path: "config.json",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	volumeMounts := []v1.VolumeMount{
+		{
+			Name:      "secret-" + imageRepoSecret.Name,
+			ReadOnly:  true,
+			MountPath: "/docker",
+		},
+	}
+
+	return volumes, volumeMounts
+}
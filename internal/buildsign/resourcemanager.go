@@ -3,6 +3,7 @@ package buildsign
 import (
 	"context"
 	"errors"
+	"time"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
@@ -25,11 +26,15 @@ var ErrNoMatchingBuildSignResource = errors.New("no matching build or sign resou
 type ResourceManager interface {
 	MakeResourceTemplate(ctx context.Context, mld *api.ModuleLoaderData, owner metav1.Object, pushImage bool,
 		resourceType kmmv1beta1.BuildOrSignAction) (metav1.Object, error)
+	CopySharedImage(ctx context.Context, mld *api.ModuleLoaderData, buildTemplate metav1.Object) (metav1.Object, error)
+	PublishSharedImage(ctx context.Context, mld *api.ModuleLoaderData, buildResource metav1.Object) error
 	CreateResource(ctx context.Context, template metav1.Object) error
 	DeleteResource(ctx context.Context, obj metav1.Object) error
-	GetResourceByKernel(ctx context.Context, name, namespace, targetKernel string, resourceType kmmv1beta1.BuildOrSignAction,
-		owner metav1.Object) (metav1.Object, error)
+	GetResourceByKernel(ctx context.Context, name, namespace, targetKernel, arch string,
+		resourceType kmmv1beta1.BuildOrSignAction, owner metav1.Object) (metav1.Object, error)
 	GetResourceStatus(obj metav1.Object) (Status, error)
+	GetResourceSourceCommit(obj metav1.Object) (string, error)
+	GetResourceFailure(obj metav1.Object) (string, time.Time, error)
 	IsResourceChanged(existingObj metav1.Object, newObj metav1.Object) (bool, error)
 	GetModuleResources(ctx context.Context, modName, namespace string, resourceType kmmv1beta1.BuildOrSignAction,
 		owner metav1.Object) ([]metav1.Object, error)
@@ -8,6 +8,7 @@ import (
 
 	"context"
 	"github.com/go-logr/logr"
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -23,13 +24,97 @@ import (
 )
 
 type Job struct {
-	GCDelay time.Duration `yaml:"gcDelay,omitempty"`
+	GCDelay      time.Duration           `yaml:"gcDelay,omitempty"`
+	BuildBackend kmmv1beta1.BuildBackend `yaml:"buildBackend,omitempty"`
+	Queue        BuildQueue              `yaml:"queue,omitempty"`
+	// SharedBuildRepository is the repository to which KMM publishes the images built in the namespaces that opted in,
+	// tagged with the hash of their content, so that identical builds of other Modules copy them instead of building
+	// them again; empty to disable.
+	SharedBuildRepository string `yaml:"sharedBuildRepository,omitempty"`
+	// SharedBuildRepositorySecret is the name of a pull secret in the operator's namespace with which KMM pushes to
+	// SharedBuildRepository and reads from it.
+	SharedBuildRepositorySecret string `yaml:"sharedBuildRepositorySecret,omitempty"`
+	// RootlessBuilds sets the security profiles of the containers of the buildah and buildkit backends.
+	RootlessBuilds RootlessBuilds `yaml:"rootlessBuilds,omitempty"`
+}
+
+// RootlessBuilds sets the security profiles of the unprivileged build containers.
+// Those containers create user namespaces, which some default profiles forbid; relaxing the profiles is left to the
+// administrator.
+type RootlessBuilds struct {
+	// SeccompProfile defaults to RuntimeDefault.
+	SeccompProfile SecurityProfile `yaml:"seccompProfile,omitempty"`
+	// AppArmorProfile defaults to the profile of the container runtime, if AppArmor is enabled on the node.
+	AppArmorProfile SecurityProfile `yaml:"appArmorProfile,omitempty"`
+}
+
+// SecurityProfile is a seccomp or AppArmor profile.
+type SecurityProfile struct {
+	// Type is RuntimeDefault, Unconfined or Localhost.
+	Type string `yaml:"type,omitempty"`
+	// LocalhostProfile is the profile loaded on the node that is used with the Localhost type.
+	LocalhostProfile string `yaml:"localhostProfile,omitempty"`
+}
+
+// Seccomp returns the seccomp profile of the build containers, or nil if it is not set.
+func (rb RootlessBuilds) Seccomp() *corev1.SeccompProfile {
+	if rb.SeccompProfile.Type == "" {
+		return nil
+	}
+
+	return &corev1.SeccompProfile{
+		Type:             corev1.SeccompProfileType(rb.SeccompProfile.Type),
+		LocalhostProfile: localhostProfile(rb.SeccompProfile),
+	}
+}
+
+// AppArmor returns the AppArmor profile of the build containers, or nil if it is not set.
+func (rb RootlessBuilds) AppArmor() *corev1.AppArmorProfile {
+	if rb.AppArmorProfile.Type == "" {
+		return nil
+	}
+
+	return &corev1.AppArmorProfile{
+		Type:             corev1.AppArmorProfileType(rb.AppArmorProfile.Type),
+		LocalhostProfile: localhostProfile(rb.AppArmorProfile),
+	}
+}
+
+func localhostProfile(p SecurityProfile) *string {
+	if p.LocalhostProfile == "" {
+		return nil
+	}
+
+	return ptr.To(p.LocalhostProfile)
+}
+
+type QueueOrder string
+
+const (
+	QueueOrderFIFO     QueueOrder = "FIFO"
+	QueueOrderPriority QueueOrder = "Priority"
+)
+
+// BuildQueue limits the number of build and sign Pods running at the same time; 0 means no limit.
+type BuildQueue struct {
+	MaxConcurrentBuilds int `yaml:"maxConcurrentBuilds,omitempty"`
+	MaxConcurrentSigns  int `yaml:"maxConcurrentSigns,omitempty"`
+	// NamespaceQuotas limits the number of build and sign Pods running at the same time in each namespace.
+	NamespaceQuotas map[string]int `yaml:"namespaceQuotas,omitempty"`
+	Order           QueueOrder     `yaml:"order,omitempty"`
 }
 
 type Worker struct {
-	RunAsUser        *int64  `yaml:"runAsUser"`
-	SELinuxType      string  `yaml:"seLinuxType"`
-	FirmwareHostPath *string `yaml:"firmwareHostPath,omitempty"`
+	RunAsUser          *int64        `yaml:"runAsUser"`
+	SELinuxType        string        `yaml:"seLinuxType"`
+	FirmwareHostPath   *string       `yaml:"firmwareHostPath,omitempty"`
+	DriftCheckInterval time.Duration `yaml:"driftCheckInterval,omitempty"`
+}
+
+type Node struct {
+	// PendingKernelAnnotation is the key of the node annotation holding the kernel version that the node will boot
+	// next; empty to disable.
+	PendingKernelAnnotation string `yaml:"pendingKernelAnnotation,omitempty"`
 }
 
 type LeaderElection struct {
@@ -48,6 +133,7 @@ type Config struct {
 	Job                    Job            `yaml:"job"`
 	LeaderElection         LeaderElection `yaml:"leaderElection"`
 	Metrics                Metrics        `yaml:"metrics"`
+	Node                   Node           `yaml:"node,omitempty"`
 	WebhookPort            int            `yaml:"webhookPort"`
 	Worker                 Worker         `yaml:"worker"`
 }
@@ -165,6 +251,57 @@ func (ch *configHelper) decodeStrictYAMLIntoConfig(yamlData []byte, config *Conf
 		return fmt.Errorf("error unmarshaling YAML: %v", err)
 	}
 
+	switch config.Job.BuildBackend {
+	case "", kmmv1beta1.BuildBackendKaniko, kmmv1beta1.BuildBackendBuildah, kmmv1beta1.BuildBackendBuildKit:
+	default:
+		return fmt.Errorf("unknown build backend %q", config.Job.BuildBackend)
+	}
+
+	if err := validateSecurityProfile(config.Job.RootlessBuilds.SeccompProfile); err != nil {
+		return fmt.Errorf("invalid job.rootlessBuilds.seccompProfile: %v", err)
+	}
+
+	if err := validateSecurityProfile(config.Job.RootlessBuilds.AppArmorProfile); err != nil {
+		return fmt.Errorf("invalid job.rootlessBuilds.appArmorProfile: %v", err)
+	}
+
+	return validateBuildQueue(&config.Job.Queue)
+}
+
+func validateSecurityProfile(p SecurityProfile) error {
+	switch p.Type {
+	case "", "RuntimeDefault", "Unconfined":
+		if p.LocalhostProfile != "" {
+			return errors.New("localhostProfile can only be set with the Localhost type")
+		}
+	case "Localhost":
+		if p.LocalhostProfile == "" {
+			return errors.New("localhostProfile must be set with the Localhost type")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", p.Type)
+	}
+
+	return nil
+}
+
+func validateBuildQueue(q *BuildQueue) error {
+	switch q.Order {
+	case "", QueueOrderFIFO, QueueOrderPriority:
+	default:
+		return fmt.Errorf("unknown queue order %q", q.Order)
+	}
+
+	if q.MaxConcurrentBuilds < 0 || q.MaxConcurrentSigns < 0 {
+		return errors.New("the maximum numbers of concurrent builds and signs cannot be negative")
+	}
+
+	for ns, quota := range q.NamespaceQuotas {
+		if quota < 0 {
+			return fmt.Errorf("the quota of namespace %s cannot be negative", ns)
+		}
+	}
+
 	return nil
 }
 
@@ -192,7 +329,9 @@ func (ch *configHelper) newDefaultConfig(isHubConfig bool) *Config {
 			FirmwareHostPath: ptr.To("/lib/firmware"),
 		},
 		Job: Job{
-			GCDelay: gcDelay,
+			GCDelay:      gcDelay,
+			BuildBackend: kmmv1beta1.BuildBackendKaniko,
+			Queue:        BuildQueue{Order: QueueOrderFIFO},
 		},
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 	"context"
 	"github.com/go-logr/logr"
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -82,6 +84,7 @@ metrics:
  secureServing: false
 job:
  gcDelay: "2m"
+ buildBackend: buildah
 worker:
  runAsUser: 1000
  seLinuxType: "custom_t"
@@ -99,6 +102,7 @@ worker:
 		Expect(cfg.Worker.SELinuxType).To(Equal("custom_t"))
 		Expect(*cfg.Worker.FirmwareHostPath).To(Equal("/firmware"))
 		Expect(cfg.Job.GCDelay).To(Equal(2 * time.Minute))
+		Expect(cfg.Job.BuildBackend).To(Equal(kmmv1beta1.BuildBackendBuildah))
 		Expect(*cfg.Worker.RunAsUser).To(Equal(int64(1000)))
 	})
 })
@@ -249,6 +253,126 @@ job:
 		Expect(cfg.Job.GCDelay).To(Equal(45 * time.Second))
 	})
 
+	It("should return error on unknown build backend", func() {
+		yamlData := []byte(`
+job:
+ buildBackend: docker
+`)
+		cfg := &Config{}
+		err := ch.decodeStrictYAMLIntoConfig(yamlData, cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unknown build backend "docker"`))
+	})
+
+	It("should decode the build queue", func() {
+		yamlData := []byte(`
+job:
+ queue:
+  maxConcurrentBuilds: 4
+  maxConcurrentSigns: 2
+  namespaceQuotas:
+   ns1: 1
+  order: Priority
+`)
+		cfg := &Config{}
+		err := ch.decodeStrictYAMLIntoConfig(yamlData, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Job.Queue).To(Equal(BuildQueue{
+			MaxConcurrentBuilds: 4,
+			MaxConcurrentSigns:  2,
+			NamespaceQuotas:     map[string]int{"ns1": 1},
+			Order:               QueueOrderPriority,
+		}))
+	})
+
+	It("should decode the shared build repository", func() {
+		yamlData := []byte(`
+job:
+ sharedBuildRepository: registry.example.com/kmm/shared
+ sharedBuildRepositorySecret: shared-push-secret
+`)
+		cfg := &Config{}
+		err := ch.decodeStrictYAMLIntoConfig(yamlData, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Job.SharedBuildRepository).To(Equal("registry.example.com/kmm/shared"))
+		Expect(cfg.Job.SharedBuildRepositorySecret).To(Equal("shared-push-secret"))
+	})
+
+	It("should decode the profiles of the rootless builds", func() {
+		yamlData := []byte(`
+job:
+ rootlessBuilds:
+  seccompProfile:
+   type: Localhost
+   localhostProfile: profiles/buildah.json
+  appArmorProfile:
+   type: Unconfined
+`)
+		cfg := &Config{}
+		err := ch.decodeStrictYAMLIntoConfig(yamlData, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Job.RootlessBuilds.Seccomp()).To(Equal(&corev1.SeccompProfile{
+			Type:             corev1.SeccompProfileTypeLocalhost,
+			LocalhostProfile: ptr.To("profiles/buildah.json"),
+		}))
+		Expect(cfg.Job.RootlessBuilds.AppArmor()).To(Equal(&corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeUnconfined}))
+	})
+
+	It("should not set the profiles of the rootless builds by default", func() {
+		cfg := &Config{}
+		Expect(ch.decodeStrictYAMLIntoConfig([]byte("job: {}"), cfg)).To(Succeed())
+		Expect(cfg.Job.RootlessBuilds.Seccomp()).To(BeNil())
+		Expect(cfg.Job.RootlessBuilds.AppArmor()).To(BeNil())
+	})
+
+	DescribeTable("should return an error on invalid profiles of the rootless builds",
+		func(yamlData string) {
+			cfg := &Config{}
+			Expect(ch.decodeStrictYAMLIntoConfig([]byte(yamlData), cfg)).NotTo(Succeed())
+		},
+		Entry("unknown type", `
+job:
+ rootlessBuilds:
+  seccompProfile:
+   type: Permissive
+`),
+		Entry("Localhost without a profile", `
+job:
+ rootlessBuilds:
+  appArmorProfile:
+   type: Localhost
+`),
+		Entry("profile without the Localhost type", `
+job:
+ rootlessBuilds:
+  seccompProfile:
+   type: RuntimeDefault
+   localhostProfile: profiles/buildah.json
+`),
+	)
+
+	It("should decode the node settings", func() {
+		yamlData := []byte(`
+node:
+ pendingKernelAnnotation: example.com/pending-kernel
+`)
+		cfg := &Config{}
+		err := ch.decodeStrictYAMLIntoConfig(yamlData, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Node.PendingKernelAnnotation).To(Equal("example.com/pending-kernel"))
+	})
+
+	DescribeTable("should return error on an invalid build queue", func(yamlData, expectedErr string) {
+		cfg := &Config{}
+		err := ch.decodeStrictYAMLIntoConfig([]byte(yamlData), cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(expectedErr))
+	},
+		Entry("unknown order", "job: {queue: {order: LIFO}}", `unknown queue order "LIFO"`),
+		Entry("negative builds", "job: {queue: {maxConcurrentBuilds: -1}}", "cannot be negative"),
+		Entry("negative quota", "job: {queue: {namespaceQuotas: {ns1: -1}}}", "namespace ns1 cannot be negative"),
+	)
+
 	It("should return error on unknown field", func() {
 		yamlData := []byte(`
 someUnknownField: true
@@ -5,11 +5,26 @@ const (
 	ModuleNamespaceLabel   = "kmm.node.kubernetes.io/module.namespace"
 	NodeLabelerFinalizer   = "kmm.node.kubernetes.io/node-labeler"
 	TargetKernelTarget     = "kmm.node.kubernetes.io/target-kernel"
+	TargetArchitecture     = "kmm.node.kubernetes.io/target-architecture"
 	ResourceType           = "kmm.node.kubernetes.io/resource-type"
 	ResourceHashAnnotation = "kmm.node.kubernetes.io/last-hash"
+	AttemptAnnotation      = "kmm.node.kubernetes.io/attempt"
+	BuildHashImageLabel    = "kmm.node.kubernetes.io/build-hash"
 	KernelLabel            = "kmm.node.kubernetes.io/kernel-version.full"
 	DaemonSetRole          = "kmm.node.kubernetes.io/role"
 	NamespaceLabelKey      = "kmm.node.k8s.io/contains-modules"
+	NodeExcludedLabel      = "kmm.node.kubernetes.io/excluded"
+
+	KMMNodeLabelDomain = "kmm.node.kubernetes.io"
+
+	// NFDOSReleaseIDLabel is set by Node Feature Discovery to the ID field of the node's /etc/os-release.
+	NFDOSReleaseIDLabel = "feature.node.kubernetes.io/system-os_release.ID"
+
+	// SharedBuildHashAnnotation records on a build Pod the hash of the build, whose image is published to the shared
+	// build repository once it succeeded.
+	SharedBuildHashAnnotation = "kmm.node.kubernetes.io/shared-build-hash"
+	// SharedBuildsNamespaceLabel opts a namespace in to sharing its builds with the other namespaces that opted in.
+	SharedBuildsNamespaceLabel = "kmm.node.kubernetes.io/shared-builds"
 
 	WorkerPodVersionLabelPrefix      = "beta.kmm.node.kubernetes.io/version-worker-pod"
 	SchedulePluginVersionLabelPrefix = "beta.kmm.node.kubernetes.io/version-schedule-plugin"
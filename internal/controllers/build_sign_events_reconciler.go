@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
@@ -16,7 +18,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +34,10 @@ const (
 	BuildSignEventsReconcilerName = "BuildSignEvents"
 
 	createdAnnotationKey = "kmm.node.kubernetes.io/created-event-sent"
+
+	// logTailLines and maxLogBytes bound the logs of each container saved for failed Pods.
+	logTailLines = 200
+	maxLogBytes  = 64 * 1024
 )
 
 type jobEvent struct {
@@ -134,11 +142,22 @@ func (r *JobEventReconciler) Reconcile(ctx context.Context, pod *v1.Pod) (reconc
 
 	var eventType, fmtString, reason string
 
+	args := []interface{}{je.String(), kernelVersion}
+
 	switch pod.Status.Phase {
 	case v1.PodFailed:
 		eventType = v1.EventTypeWarning
 		fmtString = "%s job failed for kernel %s"
 		reason = je.ReasonFailed()
+
+		// The Pod and its logs are garbage-collected after a while: keep the logs for later diagnosis.
+		if cmName, err := r.helper.SaveLogs(ctx, pod); err != nil {
+			logger.Error(err, "Could not save the logs of the failed Pod")
+		} else {
+			eventAnnotations["logs-configmap"] = cmName
+			fmtString += "; logs saved in ConfigMap %s"
+			args = append(args, cmName)
+		}
 	case v1.PodSucceeded:
 		eventType = v1.EventTypeNormal
 		fmtString = "%s job succeeded for kernel %s"
@@ -158,8 +177,7 @@ func (r *JobEventReconciler) Reconcile(ctx context.Context, pod *v1.Pod) (reconc
 		eventType,
 		reason,
 		fmtString,
-		je.String(),
-		kernelVersion,
+		args...,
 	)
 
 	return ctrl.Result{}, nil
@@ -202,14 +220,19 @@ func (r *JobEventReconciler) removeFinalizer(ctx context.Context, pod *v1.Pod) e
 
 type JobEventReconcilerHelper interface {
 	GetOwner(context.Context, metav1.OwnerReference, string) (client.Object, error)
+	SaveLogs(ctx context.Context, pod *v1.Pod) (string, error)
 }
 
 type jobEventReconcilerHelper struct {
-	client client.Client
+	client    client.Client
+	clientset kubernetes.Interface
 }
 
-func NewJobEventReconcilerHelper(client client.Client) JobEventReconcilerHelper {
-	return &jobEventReconcilerHelper{client: client}
+func NewJobEventReconcilerHelper(client client.Client, clientset kubernetes.Interface) JobEventReconcilerHelper {
+	return &jobEventReconcilerHelper{
+		client:    client,
+		clientset: clientset,
+	}
 }
 
 func (h *jobEventReconcilerHelper) GetOwner(ctx context.Context, ref metav1.OwnerReference, namespace string) (client.Object, error) {
@@ -235,3 +258,70 @@ func (h *jobEventReconcilerHelper) GetOwner(ctx context.Context, ref metav1.Owne
 
 	return owner, nil
 }
+
+// SaveLogs saves the tail of the logs of the failed containers of pod in a ConfigMap owned by the owner of pod, and
+// returns the name of the ConfigMap.
+func (h *jobEventReconcilerHelper) SaveLogs(ctx context.Context, pod *v1.Pod) (string, error) {
+	containers := failedContainers(pod)
+	data := make(map[string]string, len(containers))
+
+	for _, c := range containers {
+		opts := &v1.PodLogOptions{
+			Container: c,
+			TailLines: ptr.To[int64](logTailLines),
+		}
+
+		logs, err := h.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(ctx)
+		if err != nil {
+			return "", fmt.Errorf("could not get the logs of container %s of Pod %s/%s: %v", c, pod.Namespace, pod.Name, err)
+		}
+
+		if len(logs) > maxLogBytes {
+			logs = logs[len(logs)-maxLogBytes:]
+		}
+
+		data[c+".log"] = strings.ToValidUTF8(string(logs), "")
+	}
+
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name + "-logs", Namespace: pod.Namespace},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, h.client, cm, func() error {
+		cm.Labels = maps.Clone(pod.Labels)
+		cm.OwnerReferences = pod.OwnerReferences
+		cm.Data = data
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not create or patch ConfigMap %s/%s: %v", cm.Namespace, cm.Name, err)
+	}
+
+	return cm.Name, nil
+}
+
+// failedContainers returns the names of the containers of pod that exited with an error, or of all of its containers
+// that started if none did, e.g. because the Pod exceeded its deadline.
+func failedContainers(pod *v1.Pod) []string {
+	statuses := slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses)
+
+	failed := make([]string, 0, len(statuses))
+	started := make([]string, 0, len(statuses))
+
+	for _, cs := range statuses {
+		if t := cs.State.Terminated; t != nil {
+			started = append(started, cs.Name)
+			if t.ExitCode != 0 {
+				failed = append(failed, cs.Name)
+			}
+		} else if cs.State.Running != nil {
+			started = append(started, cs.Name)
+		}
+	}
+
+	if len(failed) == 0 {
+		return started
+	}
+
+	return failed
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"errors"
 
 	"github.com/kubernetes-sigs/kernel-module-management/api-hub/v1beta1"
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
@@ -18,7 +19,9 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -272,6 +275,9 @@ var _ = Describe("JobEventReconciler_Reconcile", func() {
 			controllerutil.RemoveFinalizer(&podWithoutFinalizer, constants.JobEventFinalizer)
 
 			getOwner := mockHelper.EXPECT().GetOwner(ctx, or, namespace)
+			if phase == v1.PodFailed {
+				getOwner = mockHelper.EXPECT().SaveLogs(ctx, pod).Return("pod-name-logs", nil).After(getOwner)
+			}
 			if sendEventAndRemoveFinalizer {
 				mockClient.EXPECT().Patch(ctx, &podWithoutFinalizer, gomock.Any()).After(getOwner)
 			}
@@ -294,7 +300,7 @@ var _ = Describe("JobEventReconciler_Reconcile", func() {
 		},
 		Entry(nil, "test", v1.PodRunning, false, "", ownerModule),
 		Entry(nil, "test", v1.PodPending, false, "", ownerModule),
-		Entry(nil, "build", v1.PodFailed, true, "Warning BuildFailed Build job failed for kernel "+kernelVersion, ownerModule),
+		Entry(nil, "build", v1.PodFailed, true, "Warning BuildFailed Build job failed for kernel "+kernelVersion+"; logs saved in ConfigMap pod-name-logs", ownerModule),
 		Entry(nil, "sign", v1.PodSucceeded, true, "Normal SignSucceeded Sign job succeeded for kernel "+kernelVersion, ownerModule),
 		Entry(nil, "build", v1.PodFailed, true, "Warning BuildFailed Build job failed for kernel "+kernelVersion, ownerPreflight),
 		Entry(nil, "sign", v1.PodSucceeded, true, "Normal SignSucceeded Sign job succeeded for kernel "+kernelVersion, ownerPreflight),
@@ -303,6 +309,44 @@ var _ = Describe("JobEventReconciler_Reconcile", func() {
 		Entry(nil, "random", v1.PodFailed, true, "Warning RandomFailed Random job failed for kernel "+kernelVersion, ownerModule),
 		Entry(nil, "random", v1.PodSucceeded, true, "Normal RandomSucceeded Random job succeeded for kernel "+kernelVersion, ownerModule),
 	)
+
+	It("should send the failure event even if the logs could not be saved", func() {
+		or := getOwnerReferenceFromObject(ownerModule)
+
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{createdAnnotationKey: ""},
+				Labels: map[string]string{
+					constants.ResourceType:       "build",
+					constants.TargetKernelTarget: kernelVersion,
+				},
+				Finalizers:      []string{constants.JobEventFinalizer},
+				Namespace:       namespace,
+				OwnerReferences: []metav1.OwnerReference{or},
+			},
+			Status: v1.PodStatus{Phase: v1.PodFailed},
+		}
+
+		podWithoutFinalizer := *pod
+		controllerutil.RemoveFinalizer(&podWithoutFinalizer, constants.JobEventFinalizer)
+
+		gomock.InOrder(
+			mockHelper.EXPECT().GetOwner(ctx, or, namespace),
+			mockHelper.EXPECT().SaveLogs(ctx, pod).Return("", errors.New("some error")),
+			mockClient.EXPECT().Patch(ctx, &podWithoutFinalizer, gomock.Any()),
+		)
+
+		Expect(
+			r.Reconcile(ctx, pod),
+		).To(
+			Equal(ctrl.Result{}),
+		)
+
+		events := closeAndGetAllEvents(fakeRecorder.Events)
+		Expect(events).To(HaveLen(1))
+		Expect(events[0]).To(ContainSubstring("Warning BuildFailed Build job failed for kernel " + kernelVersion))
+		Expect(events[0]).NotTo(ContainSubstring("logs"))
+	})
 })
 
 var _ = Describe("jobEventReconcilerHelper_GetOwner", func() {
@@ -432,3 +476,94 @@ var _ = Describe("jobEventPredicate", func() {
 		Entry("no finalizer", string(kmmv1beta1.SignImage), false, false),
 	)
 })
+
+var _ = Describe("jobEventReconcilerHelper_SaveLogs", func() {
+	var (
+		ctx = context.TODO()
+
+		mockClient *testclient.MockClient
+		h          *jobEventReconcilerHelper
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockClient = testclient.NewMockClient(ctrl)
+		h = &jobEventReconcilerHelper{
+			client:    mockClient,
+			clientset: fake.NewClientset(),
+		}
+	})
+
+	or := metav1.OwnerReference{Kind: "ModuleBuildSignConfig", Name: "mbsc", Controller: ptr.To(true)}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "mod-build-1.2.3",
+			Namespace:       namespace,
+			Labels:          map[string]string{constants.ResourceType: string(kmmv1beta1.BuildImage)},
+			OwnerReferences: []metav1.OwnerReference{or},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodFailed,
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "git-clone", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{}}},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "kaniko", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1}}},
+			},
+		},
+	}
+
+	It("should save the logs of the failed containers in a ConfigMap owned by the Pod's owner", func() {
+		cmNSN := types.NamespacedName{Name: "mod-build-1.2.3-logs", Namespace: namespace}
+
+		gomock.InOrder(
+			mockClient.EXPECT().Get(ctx, cmNSN, gomock.Any()).Return(k8serrors.NewNotFound(schema.GroupResource{}, cmNSN.Name)),
+			mockClient.EXPECT().Create(ctx, gomock.Any()).Do(func(_ context.Context, cm *v1.ConfigMap, _ ...ctrlclient.CreateOption) {
+				Expect(cm.OwnerReferences).To(Equal([]metav1.OwnerReference{or}))
+				Expect(cm.Labels).To(Equal(pod.Labels))
+				// the fake clientset returns the same logs for every container
+				Expect(cm.Data).To(Equal(map[string]string{"kaniko.log": "fake logs"}))
+			}),
+		)
+
+		Expect(
+			h.SaveLogs(ctx, pod),
+		).To(
+			Equal("mod-build-1.2.3-logs"),
+		)
+	})
+})
+
+var _ = Describe("failedContainers", func() {
+	It("should return the containers that exited with an error", func() {
+		pod := &v1.Pod{
+			Status: v1.PodStatus{
+				InitContainerStatuses: []v1.ContainerStatus{
+					{Name: "git-clone", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 128}}},
+				},
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "kaniko", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{}}},
+				},
+			},
+		}
+
+		Expect(failedContainers(pod)).To(Equal([]string{"git-clone"}))
+	})
+
+	It("should return all started containers if none exited with an error", func() {
+		pod := &v1.Pod{
+			Status: v1.PodStatus{
+				Reason: "DeadlineExceeded",
+				InitContainerStatuses: []v1.ContainerStatus{
+					{Name: "git-clone", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{}}},
+				},
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "kaniko", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+				},
+			},
+		}
+
+		Expect(failedContainers(pod)).To(Equal([]string{"git-clone", "kaniko"}))
+	})
+})
@@ -106,6 +106,17 @@ func (r *DevicePluginReconciler) Reconcile(ctx context.Context, mod *kmmv1beta1.
 
 	r.reconHelperAPI.setKMMOMetrics(ctx)
 
+	if mod.Spec.Paused {
+		logger.Info("Module is paused; not reconciling the device plugin")
+		if mod.Spec.DevicePlugin == nil {
+			return res, nil
+		}
+		if err = r.reconHelperAPI.moduleUpdateDevicePluginStatus(ctx, mod, existingDevicePluginDS); err != nil {
+			return res, fmt.Errorf("failed to update device-plugin status of the module: %w", err)
+		}
+		return res, nil
+	}
+
 	if mod.Spec.DevicePlugin == nil {
 		if len(existingDevicePluginDS) > 0 {
 			if err = r.reconHelperAPI.deleteDevicePluginDaemonSets(ctx, existingDevicePluginDS); err != nil {
@@ -147,6 +147,37 @@ var _ = Describe("DevicePluginReconciler_Reconcile", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("should only update the status if the Module is paused", func() {
+		mod.Spec.Paused = true
+		devicePluginDS := []appsv1.DaemonSet{{}}
+
+		gomock.InOrder(
+			mockReconHelper.EXPECT().getModuleDevicePluginDaemonSets(ctx, mod.Name, mod.Namespace).Return(devicePluginDS, nil),
+			mockReconHelper.EXPECT().setKMMOMetrics(ctx),
+			mockReconHelper.EXPECT().moduleUpdateDevicePluginStatus(ctx, mod, devicePluginDS).Return(nil),
+		)
+
+		res, err := dpr.Reconcile(ctx, mod)
+
+		Expect(res).To(Equal(reconcile.Result{}))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not delete the DaemonSets if the Module is paused and spec.devicePlugin is nil", func() {
+		mod.Spec.Paused = true
+		mod.Spec.DevicePlugin = nil
+
+		gomock.InOrder(
+			mockReconHelper.EXPECT().getModuleDevicePluginDaemonSets(ctx, mod.Name, mod.Namespace).Return([]appsv1.DaemonSet{{}}, nil),
+			mockReconHelper.EXPECT().setKMMOMetrics(ctx),
+		)
+
+		res, err := dpr.Reconcile(ctx, mod)
+
+		Expect(res).To(Equal(reconcile.Result{}))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
 	It("no-op when spec.devicePlugin is nil and no existing DaemonSets", func() {
 		mod.Spec.DevicePlugin = nil
 		gomock.InOrder(
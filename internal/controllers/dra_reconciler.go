@@ -106,6 +106,17 @@ func (r *DRAReconciler) Reconcile(ctx context.Context, mod *kmmv1beta1.Module) (
 		return ctrl.Result{}, r.reconHelperAPI.deleteDRAResources(ctx, mod.Name, mod.Namespace)
 	}
 
+	if mod.Spec.Paused {
+		logger.Info("Module is paused; not reconciling DRA resources")
+		if mod.Spec.DRA == nil {
+			return res, nil
+		}
+		if err = r.reconHelperAPI.moduleUpdateDRAStatus(ctx, mod, existingDRADS); err != nil {
+			return res, fmt.Errorf("failed to update DRA status of the module: %v", err)
+		}
+		return res, nil
+	}
+
 	if mod.Spec.DRA == nil {
 		if err = r.reconHelperAPI.deleteDRAResources(ctx, mod.Name, mod.Namespace); err != nil {
 			return ctrl.Result{}, err
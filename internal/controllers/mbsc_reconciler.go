@@ -20,17 +20,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"time"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/buildsign"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/kernel"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/mbsc"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -57,6 +65,12 @@ func (r *mbscReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kmmv1beta1.ModuleBuildSignConfig{}).
 		Owns(&v1.Pod{}).
+		// The MBSC has the same name as its MIC: resume builds and signs as soon as the MIC is unpaused.
+		Watches(
+			&kmmv1beta1.ModuleImagesConfig{},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		Named(MBSCReconcilerName).
 		Complete(
 			reconcile.AsReconciler[*kmmv1beta1.ModuleBuildSignConfig](mgr.GetClient(), r),
@@ -78,7 +92,16 @@ func (r *mbscReconciler) Reconcile(ctx context.Context, mbscObj *kmmv1beta1.Modu
 		return res, fmt.Errorf("failed to update MSBC %s status based on the result of the builds: %v", mbscObj.Name, err)
 	}
 
-	err = r.reconHelperAPI.processImagesSpecs(ctx, mbscObj)
+	paused, err := r.reconHelperAPI.isPaused(ctx, mbscObj)
+	if err != nil {
+		return res, fmt.Errorf("failed to determine if MBSC %s is paused: %v", mbscObj.Name, err)
+	}
+	if paused {
+		logger.Info("Owner MIC is paused; not creating build/sign pods")
+		return res, nil
+	}
+
+	requeueAfter, err := r.reconHelperAPI.processImagesSpecs(ctx, mbscObj)
 	if err != nil {
 		return res, fmt.Errorf("failed to process images of MSBC %s: %v", mbscObj.Name, err)
 	}
@@ -89,6 +112,9 @@ func (r *mbscReconciler) Reconcile(ctx context.Context, mbscObj *kmmv1beta1.Modu
 		return res, fmt.Errorf("failed to run garbage collector for MBSC %s: %v", mbscObj.Name, err)
 	}
 
+	// failed builds or signs wait for their backoff delay before being retried, and queued ones for a free slot
+	res.RequeueAfter = requeueAfter
+
 	return res, nil
 }
 
@@ -96,7 +122,8 @@ func (r *mbscReconciler) Reconcile(ctx context.Context, mbscObj *kmmv1beta1.Modu
 
 type mbscReconcilerHelperAPI interface {
 	updateStatus(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig) error
-	processImagesSpecs(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig) error
+	isPaused(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig) (bool, error)
+	processImagesSpecs(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig) (time.Duration, error)
 	garbageCollect(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig) error
 }
 
@@ -118,14 +145,33 @@ func (mrh *mbscReconcilerHelper) updateStatus(ctx context.Context, mbscObj *kmmv
 	errs := make([]error, 0, len(mbscObj.Spec.Images))
 	patchFrom := client.MergeFrom(mbscObj.DeepCopy())
 	for _, imageSpec := range mbscObj.Spec.Images {
-		status, err := mrh.buildSignAPI.GetStatus(ctx, mbscObj.Name, mbscObj.Namespace, imageSpec.ModuleImageSpec.KernelVersion,
-			imageSpec.Action, mbscObj)
+		var (
+			status kmmv1beta1.BuildOrSignStatus
+			err    error
+		)
+		if len(imageSpec.Architectures) > 1 {
+			status, err = mrh.getMultiArchitectureStatus(ctx, mbscObj, &imageSpec)
+		} else {
+			arch := singleArchitecture(&imageSpec.ModuleImageSpec)
+			status, err = mrh.buildSignAPI.GetStatus(ctx, mbscObj.Name, mbscObj.Namespace, imageSpec.ModuleImageSpec.KernelVersion,
+				arch, imageSpec.Action, mbscObj)
+			if err == nil {
+				status, err = mrh.updateAttempts(ctx, mbscObj, &imageSpec, arch, status)
+			}
+			if err == nil {
+				mrh.publishSharedImage(ctx, mbscObj, &imageSpec, arch, status)
+			}
+		}
 		if err != nil || status == kmmv1beta1.BuildOrSignStatus("") {
 			// either we could not get the status or the status is empty
 			errs = append(errs, err)
-			continue
+		} else {
+			mrh.mbscAPI.SetImageStatus(mbscObj, imageSpec.Image, imageSpec.Action, status)
+		}
+
+		if err == nil && imageSpec.Action == kmmv1beta1.BuildImage && buildsFromGit(imageSpec.Build) {
+			errs = append(errs, mrh.updateSourceCommit(ctx, mbscObj, &imageSpec))
 		}
-		mrh.mbscAPI.SetImageStatus(mbscObj, imageSpec.Image, imageSpec.Action, status)
 	}
 
 	err := mrh.client.Status().Patch(ctx, mbscObj, patchFrom)
@@ -133,9 +179,172 @@ func (mrh *mbscReconcilerHelper) updateStatus(ctx context.Context, mbscObj *kmmv
 	return errors.Join(errs...)
 }
 
-func (mrh *mbscReconcilerHelper) processImagesSpecs(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig) error {
+// updateSourceCommit records the commit cloned by the build resources of the image. It must run before those
+// resources are garbage-collected, and only updates images that already have a status.
+func (mrh *mbscReconcilerHelper) updateSourceCommit(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig,
+	imageSpec *kmmv1beta1.ModuleBuildSignSpec) error {
+
+	archs := imageSpec.Architectures
+	if len(archs) <= 1 {
+		archs = []string{singleArchitecture(&imageSpec.ModuleImageSpec)}
+	}
+
+	for _, arch := range archs {
+		commit, err := mrh.buildSignAPI.GetSourceCommit(ctx, mbscObj.Name, mbscObj.Namespace, imageSpec.KernelVersion,
+			arch, mbscObj)
+		if err != nil {
+			return err
+		}
+		if commit != "" {
+			mrh.mbscAPI.SetImageSourceCommit(mbscObj, imageSpec.Image, commit)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// updateAttempts records the attempts made by the resource of the image for arch once it finished, if it failed or may
+// have been retried. It returns an empty status instead of a failure that will be retried.
+func (mrh *mbscReconcilerHelper) updateAttempts(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig,
+	imageSpec *kmmv1beta1.ModuleBuildSignSpec, arch string, status kmmv1beta1.BuildOrSignStatus) (kmmv1beta1.BuildOrSignStatus, error) {
+
+	maxRetries := buildsign.MaxRetries(imageSpec.Build, imageSpec.Sign, imageSpec.Action)
+	if status != kmmv1beta1.ActionFailure && (status != kmmv1beta1.ActionSuccess || maxRetries == 0) {
+		return status, nil
+	}
+
+	attempts, reason, err := mrh.buildSignAPI.GetAttempts(ctx, mbscObj.Name, mbscObj.Namespace, imageSpec.KernelVersion,
+		arch, imageSpec.Action, mbscObj)
+	if err != nil {
+		return "", err
+	}
+
+	if reason != "" && len(imageSpec.Architectures) > 1 {
+		if arch == "" {
+			arch = "manifest list"
+		}
+		reason = arch + ": " + reason
+	}
+
+	mrh.mbscAPI.SetImageAttempts(mbscObj, imageSpec.Image, imageSpec.Action, attempts, reason)
+
+	if status == kmmv1beta1.ActionFailure && attempts <= maxRetries {
+		return "", nil
+	}
+
+	return status, nil
+}
+
+// publishSharedImage publishes the image built for arch to the shared build repository when status reports that its
+// build just succeeded. It must run before the status is recorded.
+// Failing to publish it only prevents identical builds of other Modules from copying it, so errors are only logged.
+func (mrh *mbscReconcilerHelper) publishSharedImage(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig,
+	imageSpec *kmmv1beta1.ModuleBuildSignSpec, arch string, status kmmv1beta1.BuildOrSignStatus) {
+
+	if imageSpec.Action != kmmv1beta1.BuildImage || !mbscObj.Spec.PushBuiltImage || status != kmmv1beta1.ActionSuccess {
+		return
+	}
+
+	mld := createMLD(mbscObj, &imageSpec.ModuleImageSpec)
+
+	var previous kmmv1beta1.BuildOrSignStatus
+
+	if len(imageSpec.Architectures) > 1 {
+		mld.Architecture = arch
+		mld.ContainerImage = module.AppendToTag(imageSpec.Image, arch)
+		previous = mrh.mbscAPI.GetImageArchitectureStatus(mbscObj, imageSpec.Image, imageSpec.Action, arch)
+	} else {
+		previous = mrh.mbscAPI.GetImageStatus(mbscObj, imageSpec.Image, imageSpec.Action)
+	}
+
+	if previous == kmmv1beta1.ActionSuccess {
+		return
+	}
+
+	if err := mrh.buildSignAPI.PublishSharedImage(ctx, mld, mbscObj); err != nil {
+		log.FromContext(ctx).Info(utils.WarnString(
+			fmt.Sprintf("failed to publish image %s to the shared build repository: %v", mld.ContainerImage, err),
+		))
+	}
+}
+
+func buildsFromGit(build *kmmv1beta1.Build) bool {
+	return build != nil && build.Source != nil && build.Source.Git != nil
+}
+
+// getMultiArchitectureStatus records the status of the action for each architecture of the image and returns the
+// status of the whole image: a failure as soon as one architecture failed, and otherwise the status of the
+// manifest list once all architectures succeeded.
+func (mrh *mbscReconcilerHelper) getMultiArchitectureStatus(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig,
+	imageSpec *kmmv1beta1.ModuleBuildSignSpec) (kmmv1beta1.BuildOrSignStatus, error) {
+
+	succeeded := 0
+	failed := false
+
+	for _, arch := range imageSpec.Architectures {
+		status, err := mrh.buildSignAPI.GetStatus(ctx, mbscObj.Name, mbscObj.Namespace, imageSpec.KernelVersion, arch,
+			imageSpec.Action, mbscObj)
+		if err != nil {
+			return "", err
+		}
+		if status, err = mrh.updateAttempts(ctx, mbscObj, imageSpec, arch, status); err != nil {
+			return "", err
+		}
+		if status != kmmv1beta1.BuildOrSignStatus("") {
+			mrh.publishSharedImage(ctx, mbscObj, imageSpec, arch, status)
+			mrh.mbscAPI.SetImageArchitectureStatus(mbscObj, imageSpec.Image, imageSpec.Action, arch, status)
+		}
+
+		switch mrh.mbscAPI.GetImageArchitectureStatus(mbscObj, imageSpec.Image, imageSpec.Action, arch) {
+		case kmmv1beta1.ActionSuccess:
+			succeeded++
+		case kmmv1beta1.ActionFailure:
+			failed = true
+		}
+	}
+
+	switch {
+	case failed:
+		return kmmv1beta1.ActionFailure, nil
+	case succeeded < len(imageSpec.Architectures):
+		return "", nil
+	case !mbscObj.Spec.PushBuiltImage:
+		// nothing was pushed, so there is no manifest list to create
+		return kmmv1beta1.ActionSuccess, nil
+	}
+
+	status, err := mrh.buildSignAPI.GetStatus(ctx, mbscObj.Name, mbscObj.Namespace, imageSpec.KernelVersion, "",
+		imageSpec.Action, mbscObj)
+	if err != nil {
+		return "", err
+	}
+
+	return mrh.updateAttempts(ctx, mbscObj, imageSpec, "", status)
+}
+
+// isPaused returns true if the MIC owning the MBSC is paused. Both objects have the same name.
+func (mrh *mbscReconcilerHelper) isPaused(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig) (bool, error) {
+	micObj := kmmv1beta1.ModuleImagesConfig{}
+
+	if err := mrh.client.Get(ctx, types.NamespacedName{Name: mbscObj.Name, Namespace: mbscObj.Namespace}, &micObj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not get MIC %s/%s: %v", mbscObj.Namespace, mbscObj.Name, err)
+	}
+
+	return micObj.Spec.Paused, nil
+}
+
+// processImagesSpecs syncs the images that did not succeed yet and records their position in the build queue. It
+// returns the delay after which the earliest failed resource waiting for its backoff delay will be retried, or the
+// queued resources will check the queue again, if any.
+func (mrh *mbscReconcilerHelper) processImagesSpecs(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig) (time.Duration, error) {
 	logger := log.FromContext(ctx)
-	errs := make([]error, 0, len(mbscObj.Spec.Images))
+	errs := make([]error, 0, len(mbscObj.Spec.Images)+1)
+	unmodifiedMBSC := mbscObj.DeepCopy()
+	var requeueAfter time.Duration
 	for _, imageSpec := range mbscObj.Spec.Images {
 		imageStatus := mrh.mbscAPI.GetImageStatus(mbscObj, imageSpec.Image, imageSpec.Action)
 		if imageStatus == kmmv1beta1.ActionSuccess {
@@ -144,12 +353,98 @@ func (mrh *mbscReconcilerHelper) processImagesSpecs(ctx context.Context, mbscObj
 			continue
 		}
 		mld := createMLD(mbscObj, &imageSpec.ModuleImageSpec)
-		err := mrh.buildSignAPI.Sync(ctx, mld, mbscObj.Spec.PushBuiltImage, imageSpec.Action, mbscObj)
-		if err != nil {
+		var queuePosition int32
+		if len(imageSpec.Architectures) > 1 {
+			errs = append(errs, mrh.syncMultiArchitecture(ctx, mbscObj, &imageSpec, mld, &requeueAfter, &queuePosition))
+		} else {
+			err := mrh.buildSignAPI.Sync(ctx, mld, mbscObj.Spec.PushBuiltImage, imageSpec.Action, mbscObj)
+			if err != nil && !retryLater(err, &requeueAfter, &queuePosition) {
+				errs = append(errs, err)
+				logger.Info(utils.WarnString(fmt.Sprintf("sync for image %s, action %s failed: %v", imageSpec.Image, imageSpec.Action, err)))
+			}
+		}
+		mrh.mbscAPI.SetImageQueuePosition(mbscObj, imageSpec.Image, imageSpec.Action, queuePosition)
+	}
+
+	if !reflect.DeepEqual(unmodifiedMBSC.Status, mbscObj.Status) {
+		if err := mrh.client.Status().Patch(ctx, mbscObj, client.MergeFrom(unmodifiedMBSC)); err != nil {
+			errs = append(errs, fmt.Errorf("could not update the queue positions of the images: %v", err))
+		}
+	}
+
+	return requeueAfter, errors.Join(errs...)
+}
+
+// retryLater returns true if err is a buildsign.RetryAfterError or a buildsign.QueuedError, lowering requeueAfter to
+// the delay after which the resource should be synced again and queuePosition to its position in the queue if needed.
+func retryLater(err error, requeueAfter *time.Duration, queuePosition *int32) bool {
+	var (
+		retryErr  *buildsign.RetryAfterError
+		queuedErr *buildsign.QueuedError
+		after     time.Duration
+	)
+
+	switch {
+	case errors.As(err, &retryErr):
+		after = retryErr.After
+	case errors.As(err, &queuedErr):
+		after = buildsign.QueuePollInterval
+		if *queuePosition == 0 || int32(queuedErr.Position) < *queuePosition {
+			*queuePosition = int32(queuedErr.Position)
+		}
+	default:
+		return false
+	}
+
+	if *requeueAfter == 0 || after < *requeueAfter {
+		*requeueAfter = after
+	}
+
+	return true
+}
+
+// syncMultiArchitecture runs the action for each architecture of the image that did not succeed yet, each of them
+// pushing to the image's tag suffixed with the architecture. Once all of them succeeded, it combines their images
+// into a manifest list pushed under the image's name.
+func (mrh *mbscReconcilerHelper) syncMultiArchitecture(ctx context.Context, mbscObj *kmmv1beta1.ModuleBuildSignConfig,
+	imageSpec *kmmv1beta1.ModuleBuildSignSpec, mld *api.ModuleLoaderData, requeueAfter *time.Duration, queuePosition *int32) error {
+
+	logger := log.FromContext(ctx)
+	errs := make([]error, 0, len(imageSpec.Architectures))
+	allSucceeded := true
+
+	for _, arch := range imageSpec.Architectures {
+		if mrh.mbscAPI.GetImageArchitectureStatus(mbscObj, imageSpec.Image, imageSpec.Action, arch) == kmmv1beta1.ActionSuccess {
+			continue
+		}
+		allSucceeded = false
+
+		archMLD := *mld
+		archMLD.Architecture = arch
+		archMLD.ContainerImage = module.AppendToTag(imageSpec.Image, arch)
+
+		err := mrh.buildSignAPI.Sync(ctx, &archMLD, mbscObj.Spec.PushBuiltImage, imageSpec.Action, mbscObj)
+		if err != nil && !retryLater(err, requeueAfter, queuePosition) {
 			errs = append(errs, err)
-			logger.Info(utils.WarnString(fmt.Sprintf("sync for image %s, action %s failed: %v", imageSpec.Image, imageSpec.Action, err)))
+			logger.Info(utils.WarnString(
+				fmt.Sprintf("sync for image %s, architecture %s, action %s failed: %v", imageSpec.Image, arch, imageSpec.Action, err),
+			))
 		}
 	}
+
+	if allSucceeded && mbscObj.Spec.PushBuiltImage {
+		manifestMLD := *mld
+		manifestMLD.Architectures = imageSpec.Architectures
+
+		err := mrh.buildSignAPI.Sync(ctx, &manifestMLD, true, imageSpec.Action, mbscObj)
+		if err != nil && !retryLater(err, requeueAfter, queuePosition) {
+			errs = append(errs, err)
+			logger.Info(utils.WarnString(
+				fmt.Sprintf("sync of the manifest list for image %s, action %s failed: %v", imageSpec.Image, imageSpec.Action, err),
+			))
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -189,5 +484,14 @@ func createMLD(mbscObj *kmmv1beta1.ModuleBuildSignConfig, imageSpec *kmmv1beta1.
 		RegistryTLS:             imageSpec.RegistryTLS,
 		Tolerations:             mbscObj.Spec.Tolerations,
 		Modprobe:                kmmv1beta1.ModprobeSpec{DirName: imageSpec.DirName},
+		Architecture:            singleArchitecture(imageSpec),
+	}
+}
+
+// singleArchitecture returns the only architecture the image is needed for, if any.
+func singleArchitecture(imageSpec *kmmv1beta1.ModuleImageSpec) string {
+	if len(imageSpec.Architectures) != 1 {
+		return ""
 	}
+	return imageSpec.Architectures[0]
 }
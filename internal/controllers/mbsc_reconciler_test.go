@@ -4,16 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/buildsign"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/mbsc"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -45,11 +52,12 @@ var _ = Describe("MBSCReconciler_Reconcile", func() {
 			goto executeTestFunction
 		}
 		mockMBSCReconHelper.EXPECT().updateStatus(ctx, &testMBSC).Return(nil)
+		mockMBSCReconHelper.EXPECT().isPaused(ctx, &testMBSC).Return(false, nil)
 		if processImagesSpecsError {
-			mockMBSCReconHelper.EXPECT().processImagesSpecs(ctx, &testMBSC).Return(returnedError)
+			mockMBSCReconHelper.EXPECT().processImagesSpecs(ctx, &testMBSC).Return(time.Duration(0), returnedError)
 			goto executeTestFunction
 		}
-		mockMBSCReconHelper.EXPECT().processImagesSpecs(ctx, &testMBSC).Return(nil)
+		mockMBSCReconHelper.EXPECT().processImagesSpecs(ctx, &testMBSC).Return(time.Duration(0), nil)
 		if garbageCollectError {
 			mockMBSCReconHelper.EXPECT().garbageCollect(ctx, &testMBSC).Return(returnedError)
 			goto executeTestFunction
@@ -72,6 +80,92 @@ var _ = Describe("MBSCReconciler_Reconcile", func() {
 		Entry("garbageCollect failed", false, false, true),
 		Entry("everything worked", false, false, false),
 	)
+
+	It("should requeue the MBSC when a failed resource will be retried", func() {
+		gomock.InOrder(
+			mockMBSCReconHelper.EXPECT().updateStatus(ctx, &testMBSC).Return(nil),
+			mockMBSCReconHelper.EXPECT().isPaused(ctx, &testMBSC).Return(false, nil),
+			mockMBSCReconHelper.EXPECT().processImagesSpecs(ctx, &testMBSC).Return(30*time.Second, nil),
+			mockMBSCReconHelper.EXPECT().garbageCollect(ctx, &testMBSC).Return(nil),
+		)
+
+		res, err := mr.Reconcile(ctx, &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(reconcile.Result{RequeueAfter: 30 * time.Second}))
+	})
+
+	It("should return an error if isPaused failed", func() {
+		gomock.InOrder(
+			mockMBSCReconHelper.EXPECT().updateStatus(ctx, &testMBSC).Return(nil),
+			mockMBSCReconHelper.EXPECT().isPaused(ctx, &testMBSC).Return(false, errors.New("some error")),
+		)
+
+		_, err := mr.Reconcile(ctx, &testMBSC)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not process the images if the MIC is paused", func() {
+		gomock.InOrder(
+			mockMBSCReconHelper.EXPECT().updateStatus(ctx, &testMBSC).Return(nil),
+			mockMBSCReconHelper.EXPECT().isPaused(ctx, &testMBSC).Return(true, nil),
+		)
+
+		res, err := mr.Reconcile(ctx, &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(reconcile.Result{}))
+	})
+})
+
+var _ = Describe("isPaused", func() {
+	var (
+		ctrl     *gomock.Controller
+		clnt     *client.MockClient
+		testMBSC kmmv1beta1.ModuleBuildSignConfig
+		mrh      mbscReconcilerHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mrh = newMBSCReconcilerHelper(clnt, nil, nil)
+		testMBSC = kmmv1beta1.ModuleBuildSignConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some name",
+				Namespace: "some namespace",
+			},
+		}
+	})
+
+	ctx := context.Background()
+	nsn := types.NamespacedName{Name: "some name", Namespace: "some namespace"}
+
+	It("should return false if the MIC does not exist", func() {
+		clnt.EXPECT().Get(ctx, nsn, gomock.Any()).Return(k8serrors.NewNotFound(schema.GroupResource{}, "some name"))
+
+		paused, err := mrh.isPaused(ctx, &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paused).To(BeFalse())
+	})
+
+	It("should return an error if the MIC could not be fetched", func() {
+		clnt.EXPECT().Get(ctx, nsn, gomock.Any()).Return(errors.New("some error"))
+
+		_, err := mrh.isPaused(ctx, &testMBSC)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return the paused flag of the MIC", func() {
+		clnt.EXPECT().Get(ctx, nsn, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ interface{}, mic *kmmv1beta1.ModuleImagesConfig, _ ...ctrlclient.GetOption) error {
+				mic.Spec.Paused = true
+				return nil
+			},
+		)
+
+		paused, err := mrh.isPaused(ctx, &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paused).To(BeTrue())
+	})
 })
 
 var _ = Describe("updateStatus", func() {
@@ -137,12 +231,12 @@ var _ = Describe("updateStatus", func() {
 			},
 		}
 		gomock.InOrder(
-			mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", kmmv1beta1.BuildImage, &testMBSC).
+			mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "", kmmv1beta1.BuildImage, &testMBSC).
 				Return(kmmv1beta1.ActionSuccess, nil),
 			mockMBSC.EXPECT().SetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, kmmv1beta1.ActionSuccess),
-			mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 2", kmmv1beta1.SignImage, &testMBSC).
+			mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 2", "", kmmv1beta1.SignImage, &testMBSC).
 				Return(kmmv1beta1.BuildOrSignStatus(""), nil),
-			mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 3", kmmv1beta1.BuildImage, &testMBSC).
+			mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 3", "", kmmv1beta1.BuildImage, &testMBSC).
 				Return(kmmv1beta1.BuildOrSignStatus(""), fmt.Errorf("some error")),
 			clnt.EXPECT().Status().Return(statusWriter),
 			statusWriter.EXPECT().Patch(ctx, &testMBSC, gomock.Any()).Return(nil),
@@ -151,6 +245,356 @@ var _ = Describe("updateStatus", func() {
 		err := mrh.updateStatus(ctx, &testMBSC)
 		Expect(err).To(HaveOccurred())
 	})
+
+	It("should pass the architecture of a single-architecture image", func() {
+		testMBSC.Spec.Images = []kmmv1beta1.ModuleBuildSignSpec{
+			{
+				ModuleImageSpec: kmmv1beta1.ModuleImageSpec{
+					Image:         "image 1",
+					KernelVersion: "kernel version 1",
+					Architectures: []string{"arm64"},
+				},
+				Action: kmmv1beta1.BuildImage,
+			},
+		}
+		gomock.InOrder(
+			mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "arm64", kmmv1beta1.BuildImage, &testMBSC).
+				Return(kmmv1beta1.ActionSuccess, nil),
+			mockMBSC.EXPECT().SetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, kmmv1beta1.ActionSuccess),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, &testMBSC, gomock.Any()).Return(nil),
+		)
+
+		Expect(
+			mrh.updateStatus(ctx, &testMBSC),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	It("should record the commit of builds from Git", func() {
+		build := &kmmv1beta1.Build{
+			Source: &kmmv1beta1.BuildSource{Git: &kmmv1beta1.GitSource{URL: "https://github.com/org/repo.git"}},
+		}
+		testMBSC.Spec.Images = []kmmv1beta1.ModuleBuildSignSpec{
+			{
+				ModuleImageSpec: kmmv1beta1.ModuleImageSpec{
+					Image:         "image 1",
+					KernelVersion: "kernel version 1",
+					Build:         build,
+				},
+				Action: kmmv1beta1.BuildImage,
+			},
+			{
+				ModuleImageSpec: kmmv1beta1.ModuleImageSpec{
+					Image:         "image 2",
+					KernelVersion: "kernel version 2",
+					Build:         build,
+					Architectures: []string{"amd64", "arm64"},
+				},
+				Action: kmmv1beta1.BuildImage,
+			},
+		}
+		gomock.InOrder(
+			mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "", kmmv1beta1.BuildImage, &testMBSC).
+				Return(kmmv1beta1.ActionSuccess, nil),
+			mockMBSC.EXPECT().SetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, kmmv1beta1.ActionSuccess),
+			mockManager.EXPECT().GetSourceCommit(ctx, "some name", "some namespace", "kernel version 1", "", &testMBSC).
+				Return("abc123", nil),
+			mockMBSC.EXPECT().SetImageSourceCommit(&testMBSC, "image 1", "abc123"),
+			mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 2", "amd64", kmmv1beta1.BuildImage, &testMBSC).
+				Return(kmmv1beta1.BuildOrSignStatus(""), nil),
+			mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 2", kmmv1beta1.BuildImage, "amd64"),
+			mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 2", "arm64", kmmv1beta1.BuildImage, &testMBSC).
+				Return(kmmv1beta1.ActionSuccess, nil),
+			mockMBSC.EXPECT().SetImageArchitectureStatus(&testMBSC, "image 2", kmmv1beta1.BuildImage, "arm64", kmmv1beta1.ActionSuccess),
+			mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 2", kmmv1beta1.BuildImage, "arm64").
+				Return(kmmv1beta1.ActionSuccess),
+			mockManager.EXPECT().GetSourceCommit(ctx, "some name", "some namespace", "kernel version 2", "amd64", &testMBSC).
+				Return("", nil),
+			mockManager.EXPECT().GetSourceCommit(ctx, "some name", "some namespace", "kernel version 2", "arm64", &testMBSC).
+				Return("def456", nil),
+			mockMBSC.EXPECT().SetImageSourceCommit(&testMBSC, "image 2", "def456"),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, &testMBSC, gomock.Any()).Return(nil),
+		)
+
+		Expect(
+			mrh.updateStatus(ctx, &testMBSC),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	Context("retries", func() {
+		BeforeEach(func() {
+			testMBSC.Spec.Images = []kmmv1beta1.ModuleBuildSignSpec{
+				{
+					ModuleImageSpec: kmmv1beta1.ModuleImageSpec{
+						Image:         "image 1",
+						KernelVersion: "kernel version 1",
+						Build:         &kmmv1beta1.Build{MaxRetries: 2},
+					},
+					Action: kmmv1beta1.BuildImage,
+				},
+			}
+		})
+
+		It("should not fail the image while it has retries left", func() {
+			gomock.InOrder(
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.ActionFailure, nil),
+				mockManager.EXPECT().GetAttempts(ctx, "some name", "some namespace", "kernel version 1", "", kmmv1beta1.BuildImage, &testMBSC).
+					Return(int32(2), "container kaniko exited with code 1", nil),
+				mockMBSC.EXPECT().SetImageAttempts(&testMBSC, "image 1", kmmv1beta1.BuildImage, int32(2), "container kaniko exited with code 1"),
+				clnt.EXPECT().Status().Return(statusWriter),
+				statusWriter.EXPECT().Patch(ctx, &testMBSC, gomock.Any()).Return(nil),
+			)
+
+			Expect(
+				mrh.updateStatus(ctx, &testMBSC),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should fail the image once it has no retries left", func() {
+			gomock.InOrder(
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.ActionFailure, nil),
+				mockManager.EXPECT().GetAttempts(ctx, "some name", "some namespace", "kernel version 1", "", kmmv1beta1.BuildImage, &testMBSC).
+					Return(int32(3), "DeadlineExceeded", nil),
+				mockMBSC.EXPECT().SetImageAttempts(&testMBSC, "image 1", kmmv1beta1.BuildImage, int32(3), "DeadlineExceeded"),
+				mockMBSC.EXPECT().SetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, kmmv1beta1.ActionFailure),
+				clnt.EXPECT().Status().Return(statusWriter),
+				statusWriter.EXPECT().Patch(ctx, &testMBSC, gomock.Any()).Return(nil),
+			)
+
+			Expect(
+				mrh.updateStatus(ctx, &testMBSC),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should record the attempts of a successful image", func() {
+			gomock.InOrder(
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.ActionSuccess, nil),
+				mockManager.EXPECT().GetAttempts(ctx, "some name", "some namespace", "kernel version 1", "", kmmv1beta1.BuildImage, &testMBSC).
+					Return(int32(2), "", nil),
+				mockMBSC.EXPECT().SetImageAttempts(&testMBSC, "image 1", kmmv1beta1.BuildImage, int32(2), ""),
+				mockMBSC.EXPECT().SetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, kmmv1beta1.ActionSuccess),
+				clnt.EXPECT().Status().Return(statusWriter),
+				statusWriter.EXPECT().Patch(ctx, &testMBSC, gomock.Any()).Return(nil),
+			)
+
+			Expect(
+				mrh.updateStatus(ctx, &testMBSC),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+	})
+
+	Context("multi-architecture images", func() {
+		BeforeEach(func() {
+			testMBSC.Spec.PushBuiltImage = true
+			testMBSC.Spec.Images = []kmmv1beta1.ModuleBuildSignSpec{
+				{
+					ModuleImageSpec: kmmv1beta1.ModuleImageSpec{
+						Image:         "image 1",
+						KernelVersion: "kernel version 1",
+						Architectures: []string{"amd64", "arm64"},
+					},
+					Action: kmmv1beta1.BuildImage,
+				},
+			}
+		})
+
+		It("should only record the architectures while some are still running", func() {
+			gomock.InOrder(
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "amd64", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.ActionSuccess, nil),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "amd64").Return(kmmv1beta1.BuildOrSignStatus("")),
+				mockManager.EXPECT().PublishSharedImage(ctx, gomock.Any(), &testMBSC).Return(nil),
+				mockMBSC.EXPECT().SetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "amd64", kmmv1beta1.ActionSuccess),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "amd64").Return(kmmv1beta1.ActionSuccess),
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "arm64", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.BuildOrSignStatus(""), nil),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "arm64").Return(kmmv1beta1.BuildOrSignStatus("")),
+				clnt.EXPECT().Status().Return(statusWriter),
+				statusWriter.EXPECT().Patch(ctx, &testMBSC, gomock.Any()).Return(nil),
+			)
+
+			Expect(
+				mrh.updateStatus(ctx, &testMBSC),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should fail the image as soon as one architecture failed", func() {
+			gomock.InOrder(
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "amd64", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.ActionFailure, nil),
+				mockManager.EXPECT().GetAttempts(ctx, "some name", "some namespace", "kernel version 1", "amd64", kmmv1beta1.BuildImage, &testMBSC).
+					Return(int32(1), "DeadlineExceeded", nil),
+				mockMBSC.EXPECT().SetImageAttempts(&testMBSC, "image 1", kmmv1beta1.BuildImage, int32(1), "amd64: DeadlineExceeded"),
+				mockMBSC.EXPECT().SetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "amd64", kmmv1beta1.ActionFailure),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "amd64").Return(kmmv1beta1.ActionFailure),
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "arm64", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.BuildOrSignStatus(""), nil),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "arm64").Return(kmmv1beta1.BuildOrSignStatus("")),
+				mockMBSC.EXPECT().SetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, kmmv1beta1.ActionFailure),
+				clnt.EXPECT().Status().Return(statusWriter),
+				statusWriter.EXPECT().Patch(ctx, &testMBSC, gomock.Any()).Return(nil),
+			)
+
+			Expect(
+				mrh.updateStatus(ctx, &testMBSC),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should use the status of the manifest list once all architectures succeeded", func() {
+			gomock.InOrder(
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "amd64", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.BuildOrSignStatus(""), nil),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "amd64").Return(kmmv1beta1.ActionSuccess),
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "arm64", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.ActionSuccess, nil),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "arm64").Return(kmmv1beta1.BuildOrSignStatus("")),
+				mockManager.EXPECT().PublishSharedImage(ctx, gomock.Any(), &testMBSC).Return(nil),
+				mockMBSC.EXPECT().SetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "arm64", kmmv1beta1.ActionSuccess),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "arm64").Return(kmmv1beta1.ActionSuccess),
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.ActionSuccess, nil),
+				mockMBSC.EXPECT().SetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, kmmv1beta1.ActionSuccess),
+				clnt.EXPECT().Status().Return(statusWriter),
+				statusWriter.EXPECT().Patch(ctx, &testMBSC, gomock.Any()).Return(nil),
+			)
+
+			Expect(
+				mrh.updateStatus(ctx, &testMBSC),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should succeed without a manifest list if images are not pushed", func() {
+			testMBSC.Spec.PushBuiltImage = false
+
+			gomock.InOrder(
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "amd64", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.BuildOrSignStatus(""), nil),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "amd64").Return(kmmv1beta1.ActionSuccess),
+				mockManager.EXPECT().GetStatus(ctx, "some name", "some namespace", "kernel version 1", "arm64", kmmv1beta1.BuildImage, &testMBSC).
+					Return(kmmv1beta1.BuildOrSignStatus(""), nil),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "arm64").Return(kmmv1beta1.ActionSuccess),
+				mockMBSC.EXPECT().SetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, kmmv1beta1.ActionSuccess),
+				clnt.EXPECT().Status().Return(statusWriter),
+				statusWriter.EXPECT().Patch(ctx, &testMBSC, gomock.Any()).Return(nil),
+			)
+
+			Expect(
+				mrh.updateStatus(ctx, &testMBSC),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+	})
+})
+
+var _ = Describe("publishSharedImage", func() {
+	var (
+		ctrl        *gomock.Controller
+		mockManager *buildsign.MockManager
+		mockMBSC    *mbsc.MockMBSC
+		testMBSC    kmmv1beta1.ModuleBuildSignConfig
+		imageSpec   kmmv1beta1.ModuleBuildSignSpec
+		mrh         *mbscReconcilerHelper
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockManager = buildsign.NewMockManager(ctrl)
+		mockMBSC = mbsc.NewMockMBSC(ctrl)
+		mrh = &mbscReconcilerHelper{buildSignAPI: mockManager, mbscAPI: mockMBSC}
+		testMBSC = kmmv1beta1.ModuleBuildSignConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some name",
+				Namespace: "some namespace",
+			},
+			Spec: kmmv1beta1.ModuleBuildSignConfigSpec{PushBuiltImage: true},
+		}
+		imageSpec = kmmv1beta1.ModuleBuildSignSpec{
+			ModuleImageSpec: kmmv1beta1.ModuleImageSpec{Image: "image 1", KernelVersion: "kernel version 1"},
+			Action:          kmmv1beta1.BuildImage,
+		}
+	})
+
+	ctx := context.Background()
+
+	It("should not publish images that were not built and pushed successfully", func() {
+		mrh.publishSharedImage(ctx, &testMBSC, &imageSpec, "", kmmv1beta1.ActionFailure)
+
+		imageSpec.Action = kmmv1beta1.SignImage
+		mrh.publishSharedImage(ctx, &testMBSC, &imageSpec, "", kmmv1beta1.ActionSuccess)
+
+		imageSpec.Action = kmmv1beta1.BuildImage
+		testMBSC.Spec.PushBuiltImage = false
+		mrh.publishSharedImage(ctx, &testMBSC, &imageSpec, "", kmmv1beta1.ActionSuccess)
+	})
+
+	It("should not publish images that had already succeeded", func() {
+		mockMBSC.EXPECT().GetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage).Return(kmmv1beta1.ActionSuccess)
+
+		mrh.publishSharedImage(ctx, &testMBSC, &imageSpec, "", kmmv1beta1.ActionSuccess)
+	})
+
+	It("should publish an image whose build just succeeded", func() {
+		gomock.InOrder(
+			mockMBSC.EXPECT().GetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage).Return(kmmv1beta1.BuildOrSignStatus("")),
+			mockManager.EXPECT().PublishSharedImage(ctx, gomock.Any(), &testMBSC).DoAndReturn(
+				func(_ context.Context, mld *api.ModuleLoaderData, _ metav1.Object) error {
+					Expect(mld.ContainerImage).To(Equal("image 1"))
+					Expect(mld.KernelVersion).To(Equal("kernel version 1"))
+					return nil
+				},
+			),
+		)
+
+		mrh.publishSharedImage(ctx, &testMBSC, &imageSpec, "", kmmv1beta1.ActionSuccess)
+	})
+
+	It("should publish the image of the architecture that just succeeded", func() {
+		imageSpec.Architectures = []string{"amd64", "arm64"}
+
+		gomock.InOrder(
+			mockMBSC.EXPECT().GetImageArchitectureStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage, "arm64").
+				Return(kmmv1beta1.BuildOrSignStatus("")),
+			mockManager.EXPECT().PublishSharedImage(ctx, gomock.Any(), &testMBSC).DoAndReturn(
+				func(_ context.Context, mld *api.ModuleLoaderData, _ metav1.Object) error {
+					Expect(mld.ContainerImage).To(Equal(module.AppendToTag("image 1", "arm64")))
+					Expect(mld.Architecture).To(Equal("arm64"))
+					return nil
+				},
+			),
+		)
+
+		mrh.publishSharedImage(ctx, &testMBSC, &imageSpec, "arm64", kmmv1beta1.ActionSuccess)
+	})
+
+	It("should only log publishing errors", func() {
+		gomock.InOrder(
+			mockMBSC.EXPECT().GetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage).Return(kmmv1beta1.BuildOrSignStatus("")),
+			mockManager.EXPECT().PublishSharedImage(ctx, gomock.Any(), &testMBSC).Return(errors.New("some error")),
+		)
+
+		mrh.publishSharedImage(ctx, &testMBSC, &imageSpec, "", kmmv1beta1.ActionSuccess)
+	})
 })
 
 var _ = Describe("processImagesSpecs", func() {
@@ -206,13 +650,142 @@ var _ = Describe("processImagesSpecs", func() {
 			mockMBSC.EXPECT().GetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage).Return(kmmv1beta1.ActionSuccess),
 			mockMBSC.EXPECT().GetImageStatus(&testMBSC, "image 2", kmmv1beta1.SignImage).Return(kmmv1beta1.ActionFailure),
 			mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.SignImage, &testMBSC).Return(nil),
+			mockMBSC.EXPECT().SetImageQueuePosition(&testMBSC, "image 2", kmmv1beta1.SignImage, int32(0)),
 			mockMBSC.EXPECT().GetImageStatus(&testMBSC, "image 3", kmmv1beta1.BuildImage).Return(kmmv1beta1.BuildOrSignStatus("")),
 			mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.BuildImage, &testMBSC).Return(fmt.Errorf("some error")),
+			mockMBSC.EXPECT().SetImageQueuePosition(&testMBSC, "image 3", kmmv1beta1.BuildImage, int32(0)),
 		)
 
-		err := mrh.processImagesSpecs(ctx, &testMBSC)
+		_, err := mrh.processImagesSpecs(ctx, &testMBSC)
 		Expect(err).To(HaveOccurred())
 	})
+
+	It("should requeue after the earliest retry", func() {
+		gomock.InOrder(
+			mockMBSC.EXPECT().GetImageStatus(&testMBSC, "image 1", kmmv1beta1.BuildImage).Return(kmmv1beta1.BuildOrSignStatus("")),
+			mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.BuildImage, &testMBSC).
+				Return(&buildsign.RetryAfterError{After: time.Minute}),
+			mockMBSC.EXPECT().SetImageQueuePosition(&testMBSC, "image 1", kmmv1beta1.BuildImage, int32(0)),
+			mockMBSC.EXPECT().GetImageStatus(&testMBSC, "image 2", kmmv1beta1.SignImage).Return(kmmv1beta1.BuildOrSignStatus("")),
+			mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.SignImage, &testMBSC).
+				Return(&buildsign.RetryAfterError{After: 20 * time.Second}),
+			mockMBSC.EXPECT().SetImageQueuePosition(&testMBSC, "image 2", kmmv1beta1.SignImage, int32(0)),
+			mockMBSC.EXPECT().GetImageStatus(&testMBSC, "image 3", kmmv1beta1.BuildImage).Return(kmmv1beta1.BuildOrSignStatus("")),
+			mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.BuildImage, &testMBSC).Return(nil),
+			mockMBSC.EXPECT().SetImageQueuePosition(&testMBSC, "image 3", kmmv1beta1.BuildImage, int32(0)),
+		)
+
+		requeueAfter, err := mrh.processImagesSpecs(ctx, &testMBSC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requeueAfter).To(Equal(20 * time.Second))
+	})
+
+	It("should record the queue positions and requeue to check the queue again", func() {
+		mbscObj := testMBSC.DeepCopy()
+		statusWriter := client.NewMockStatusWriter(ctrl)
+		mrh = newMBSCReconcilerHelper(clnt, mockManager, mbsc.New(clnt, scheme))
+
+		gomock.InOrder(
+			mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.BuildImage, mbscObj).
+				Return(&buildsign.QueuedError{Position: 4}),
+			mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.SignImage, mbscObj).Return(nil),
+			mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.BuildImage, mbscObj).
+				Return(&buildsign.QueuedError{Position: 2}),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, mbscObj, gomock.Any()),
+		)
+
+		requeueAfter, err := mrh.processImagesSpecs(ctx, mbscObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requeueAfter).To(Equal(buildsign.QueuePollInterval))
+		Expect(mbscObj.Status.Images).To(Equal([]kmmv1beta1.BuildSignImageState{
+			{Image: "image 1", Action: kmmv1beta1.BuildImage, QueuePosition: 4},
+			{Image: "image 3", Action: kmmv1beta1.BuildImage, QueuePosition: 2},
+		}))
+	})
+
+	Context("multi-architecture images", func() {
+		multiArchMBSC := kmmv1beta1.ModuleBuildSignConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some name",
+				Namespace: "some namespace",
+			},
+			Spec: kmmv1beta1.ModuleBuildSignConfigSpec{
+				Images: []kmmv1beta1.ModuleBuildSignSpec{
+					{
+						ModuleImageSpec: kmmv1beta1.ModuleImageSpec{
+							Image:         "registry/image:tag",
+							KernelVersion: "5.14.0",
+							Architectures: []string{"amd64", "arm64"},
+						},
+						Action: kmmv1beta1.BuildImage,
+					},
+				},
+				PushBuiltImage: true,
+			},
+		}
+
+		It("should build the architectures that did not succeed yet", func() {
+			gomock.InOrder(
+				mockMBSC.EXPECT().GetImageStatus(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage).Return(kmmv1beta1.BuildOrSignStatus("")),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage, "amd64").
+					Return(kmmv1beta1.ActionSuccess),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage, "arm64").
+					Return(kmmv1beta1.ActionFailure),
+				mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.BuildImage, &multiArchMBSC).DoAndReturn(
+					func(_ context.Context, mld *api.ModuleLoaderData, _ bool, _ kmmv1beta1.BuildOrSignAction, _ metav1.Object) error {
+						Expect(mld.Architecture).To(Equal("arm64"))
+						Expect(mld.Architectures).To(BeEmpty())
+						Expect(mld.ContainerImage).To(Equal("registry/image:tag_arm64"))
+						return nil
+					},
+				),
+				mockMBSC.EXPECT().SetImageQueuePosition(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage, int32(0)),
+			)
+
+			_, err := mrh.processImagesSpecs(ctx, &multiArchMBSC)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should record the best queue position of the architectures", func() {
+			gomock.InOrder(
+				mockMBSC.EXPECT().GetImageStatus(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage).Return(kmmv1beta1.BuildOrSignStatus("")),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage, "amd64"),
+				mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.BuildImage, &multiArchMBSC).
+					Return(&buildsign.QueuedError{Position: 3}),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage, "arm64"),
+				mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.BuildImage, &multiArchMBSC).
+					Return(&buildsign.QueuedError{Position: 1}),
+				mockMBSC.EXPECT().SetImageQueuePosition(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage, int32(1)),
+			)
+
+			requeueAfter, err := mrh.processImagesSpecs(ctx, &multiArchMBSC)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(buildsign.QueuePollInterval))
+		})
+
+		It("should push the manifest list once all architectures succeeded", func() {
+			gomock.InOrder(
+				mockMBSC.EXPECT().GetImageStatus(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage).Return(kmmv1beta1.BuildOrSignStatus("")),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage, "amd64").
+					Return(kmmv1beta1.ActionSuccess),
+				mockMBSC.EXPECT().GetImageArchitectureStatus(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage, "arm64").
+					Return(kmmv1beta1.ActionSuccess),
+				mockManager.EXPECT().Sync(ctx, gomock.Any(), true, kmmv1beta1.BuildImage, &multiArchMBSC).DoAndReturn(
+					func(_ context.Context, mld *api.ModuleLoaderData, _ bool, _ kmmv1beta1.BuildOrSignAction, _ metav1.Object) error {
+						Expect(mld.Architecture).To(BeEmpty())
+						Expect(mld.Architectures).To(Equal([]string{"amd64", "arm64"}))
+						Expect(mld.ContainerImage).To(Equal("registry/image:tag"))
+						return errors.New("some error")
+					},
+				),
+				mockMBSC.EXPECT().SetImageQueuePosition(&multiArchMBSC, "registry/image:tag", kmmv1beta1.BuildImage, int32(0)),
+			)
+
+			_, err := mrh.processImagesSpecs(ctx, &multiArchMBSC)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
 
 var _ = Describe("createMLD", func() {
@@ -264,6 +837,20 @@ var _ = Describe("createMLD", func() {
 
 		Expect(mld.Tolerations).To(BeNil())
 	})
+
+	It("should only set the architecture of single-architecture images", func() {
+		mbscObj := &kmmv1beta1.ModuleBuildSignConfig{}
+		imageSpec := &kmmv1beta1.ModuleImageSpec{
+			Image:         "test-image:latest",
+			Architectures: []string{"arm64"},
+		}
+
+		Expect(createMLD(mbscObj, imageSpec).Architecture).To(Equal("arm64"))
+
+		imageSpec.Architectures = []string{"amd64", "arm64"}
+
+		Expect(createMLD(mbscObj, imageSpec).Architecture).To(BeEmpty())
+	})
 })
 
 var _ = Describe("garbageCollect", func() {
@@ -70,12 +70,14 @@ func (r *micReconciler) Reconcile(ctx context.Context, micObj *kmmv1beta1.Module
 		return res, nil
 	}
 
-	triggerChanged, err := r.micReconHelper.handleImageRebuildTriggerGeneration(ctx, micObj)
-	if err != nil {
-		return res, fmt.Errorf("failed to handle ImageRebuildTriggerGeneration for MIC %s: %v", micObj.Name, err)
-	}
-	if triggerChanged {
-		return ctrl.Result{Requeue: true}, nil
+	if !micObj.Spec.Paused {
+		triggerChanged, err := r.micReconHelper.handleImageRebuildTriggerGeneration(ctx, micObj)
+		if err != nil {
+			return res, fmt.Errorf("failed to handle ImageRebuildTriggerGeneration for MIC %s: %v", micObj.Name, err)
+		}
+		if triggerChanged {
+			return ctrl.Result{Requeue: true}, nil
+		}
 	}
 
 	pods, err := r.imagePullerAPI.ListPullPods(ctx, micObj.Name, micObj.Namespace)
@@ -93,6 +95,11 @@ func (r *micReconciler) Reconcile(ctx context.Context, micObj *kmmv1beta1.Module
 		return res, fmt.Errorf("failed tp update the status for MIC %s based on builds: %v", micObj.Name, err)
 	}
 
+	if micObj.Spec.Paused {
+		ctrl.LoggerFrom(ctx).Info("MIC is paused; not creating pull pods or builds")
+		return res, nil
+	}
+
 	err = r.micReconHelper.processImagesSpecs(ctx, micObj, pods)
 	if err != nil {
 		return res, fmt.Errorf("failed to process images spec: %v", err)
@@ -181,19 +188,20 @@ func (mrhi *micReconcilerHelperImpl) updateStatusByPullPods(ctx context.Context,
 			podsToDelete = append(podsToDelete, p)
 			continue
 		}
+		var state kmmv1beta1.ImageState
 		podStatus := mrhi.imagePullerAPI.GetPullPodStatus(&p)
 		switch podStatus {
 		case pod.PullImageFailed:
 			switch {
 			case imageSpec.Build != nil:
 				logger.Info("pull pod failed, build exists, setting status to kmmv1beta1.ImageNeedsBuilding")
-				mrhi.micHelper.SetImageStatus(micObj, image, kmmv1beta1.ImageNeedsBuilding)
+				state = kmmv1beta1.ImageNeedsBuilding
 			case imageSpec.Sign != nil:
 				logger.Info("pull pod failed, build does not exist, sign exists, setting status to kmmv1beta1.ImageNeedsSigning")
-				mrhi.micHelper.SetImageStatus(micObj, image, kmmv1beta1.ImageNeedsSigning)
+				state = kmmv1beta1.ImageNeedsSigning
 			case imageSpec.SkipWaitMissingImage:
 				logger.Info("pull pod failed, SkipWaitMissingImage was set, setting status to kmmv1beta1.ImageDoesNotExist")
-				mrhi.micHelper.SetImageStatus(micObj, image, kmmv1beta1.ImageDoesNotExist)
+				state = kmmv1beta1.ImageDoesNotExist
 			default:
 				logger.Info(utils.WarnString("failed pod without build or sign spec, shoud not have happened"))
 			}
@@ -201,9 +209,25 @@ func (mrhi *micReconcilerHelperImpl) updateStatusByPullPods(ctx context.Context,
 
 		case pod.PullImageSuccess:
 			logger.Info("successful pod, updating image status to ImageExists")
-			mrhi.micHelper.SetImageStatus(micObj, image, kmmv1beta1.ImageExists)
+			state = kmmv1beta1.ImageExists
 			podsToDelete = append(podsToDelete, p)
 		}
+
+		if state == "" {
+			continue
+		}
+
+		arch := mrhi.imagePullerAPI.GetPullPodArchitecture(p)
+		if arch == "" {
+			mrhi.micHelper.SetImageStatus(micObj, image, state)
+			continue
+		}
+
+		mrhi.micHelper.SetImageArchitectureStatus(micObj, image, arch, state)
+
+		if imageState := mrhi.imageStateFromArchitectures(micObj, imageSpec); imageState != "" {
+			mrhi.micHelper.SetImageStatus(micObj, image, imageState)
+		}
 	}
 	// patch the status in the MIC object
 	err := mrhi.client.Status().Patch(ctx, micObj, patchFrom)
@@ -241,6 +265,12 @@ func (mrhi *micReconcilerHelperImpl) updateStatusByMBSC(ctx context.Context, mic
 			continue
 		}
 
+		for _, archState := range mbscImageState.Architectures {
+			if state := imageStateFromBuildSign(archState.Status, mbscImageState.Action, micImageSpec); state != "" {
+				mrhi.micHelper.SetImageArchitectureStatus(micObj, mbscImageState.Image, archState.Architecture, state)
+			}
+		}
+
 		mbscStatus := mbscImageState.Status
 		mbscAction := mbscImageState.Action
 
@@ -267,6 +297,46 @@ func (mrhi *micReconcilerHelperImpl) updateStatusByMBSC(ctx context.Context, mic
 	return mrhi.client.Status().Patch(ctx, micObj, patchFrom)
 }
 
+// imageStateFromArchitectures returns the state of an image from the states of all the architectures it is needed
+// for: it exists if it exists for all of them, and otherwise takes the state of the first one for which it does not.
+// It returns an empty state if the state of one of the architectures is not known yet.
+func (mrhi *micReconcilerHelperImpl) imageStateFromArchitectures(micObj *kmmv1beta1.ModuleImagesConfig,
+	imageSpec *kmmv1beta1.ModuleImageSpec) kmmv1beta1.ImageState {
+
+	imageState := kmmv1beta1.ImageExists
+
+	for _, arch := range imageSpec.Architectures {
+		switch state := mrhi.micHelper.GetImageArchitectureState(micObj, imageSpec.Image, arch); state {
+		case "":
+			return ""
+		case kmmv1beta1.ImageExists:
+		default:
+			if imageState == kmmv1beta1.ImageExists {
+				imageState = state
+			}
+		}
+	}
+
+	return imageState
+}
+
+// imageStateFromBuildSign returns the state of an image, or of one of its architectures, after a build or sign
+// action, or an empty state if the action is not finished.
+func imageStateFromBuildSign(status kmmv1beta1.BuildOrSignStatus, action kmmv1beta1.BuildOrSignAction,
+	imageSpec *kmmv1beta1.ModuleImageSpec) kmmv1beta1.ImageState {
+
+	switch {
+	case status == kmmv1beta1.ActionFailure:
+		return kmmv1beta1.ImageDoesNotExist
+	case status == kmmv1beta1.ActionSuccess && action == kmmv1beta1.BuildImage && imageSpec.Sign != nil:
+		return kmmv1beta1.ImageNeedsSigning
+	case status == kmmv1beta1.ActionSuccess:
+		return kmmv1beta1.ImageExists
+	}
+
+	return ""
+}
+
 func (mrhi *micReconcilerHelperImpl) processImagesSpecs(ctx context.Context, micObj *kmmv1beta1.ModuleImagesConfig, pullPods []v1.Pod) error {
 	errs := make([]error, len(micObj.Spec.Images))
 	for _, imageSpec := range micObj.Spec.Images {
@@ -274,21 +344,10 @@ func (mrhi *micReconcilerHelperImpl) processImagesSpecs(ctx context.Context, mic
 
 		var err error
 		switch imageState {
-		case "":
-			// image State is not set: either new image or pull pod is still running
-			if mrhi.imagePullerAPI.GetPullPodForImage(pullPods, imageSpec.Image) == nil {
-				// no pull pod- create it, otherwise we wait for it to finish
-				oneTimePod := imageSpec.Build != nil || imageSpec.Sign != nil || imageSpec.SkipWaitMissingImage
-				err := mrhi.imagePullerAPI.CreatePullPod(ctx,
-					micObj.Name,
-					micObj.Namespace,
-					imageSpec.Image,
-					oneTimePod,
-					micObj.Spec.ImageRepoSecret,
-					micObj.Spec.ImagePullPolicy,
-					micObj)
-				errs = append(errs, err)
-			}
+		case "", kmmv1beta1.ImageExists:
+			// image State is not set: either new image or pull pod is still running.
+			// An existing image may also be needed for architectures it was not checked for yet.
+			err = mrhi.createPullPods(ctx, micObj, &imageSpec, imageState, pullPods)
 		case kmmv1beta1.ImageDoesNotExist:
 			if imageSpec.Build == nil && imageSpec.Sign == nil {
 				break
@@ -305,3 +364,45 @@ func (mrhi *micReconcilerHelperImpl) processImagesSpecs(ctx context.Context, mic
 	}
 	return errors.Join(errs...)
 }
+
+// createPullPods creates the missing pull pods of imageSpec: one for each architecture the image is needed for and
+// whose state is not known yet, or a single one if the image does not list architectures and imageState is not known.
+func (mrhi *micReconcilerHelperImpl) createPullPods(ctx context.Context, micObj *kmmv1beta1.ModuleImagesConfig,
+	imageSpec *kmmv1beta1.ModuleImageSpec, imageState kmmv1beta1.ImageState, pullPods []v1.Pod) error {
+
+	archs := imageSpec.Architectures
+	if len(archs) == 0 {
+		if imageState != "" {
+			return nil
+		}
+
+		archs = []string{""}
+	}
+
+	errs := make([]error, 0, len(archs))
+
+	for _, arch := range archs {
+		if arch != "" && mrhi.micHelper.GetImageArchitectureState(micObj, imageSpec.Image, arch) != "" {
+			continue
+		}
+
+		if mrhi.imagePullerAPI.GetPullPodForImage(pullPods, imageSpec.Image, arch) != nil {
+			// wait for the pull pod to finish
+			continue
+		}
+
+		oneTimePod := imageSpec.Build != nil || imageSpec.Sign != nil || imageSpec.SkipWaitMissingImage
+		err := mrhi.imagePullerAPI.CreatePullPod(ctx,
+			micObj.Name,
+			micObj.Namespace,
+			imageSpec.Image,
+			arch,
+			oneTimePod,
+			micObj.Spec.ImageRepoSecret,
+			micObj.Spec.ImagePullPolicy,
+			micObj)
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
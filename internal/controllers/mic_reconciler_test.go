@@ -110,6 +110,23 @@ var _ = Describe("MicReconciler_Reconcile", func() {
 		Expect(err).To(BeNil())
 		Expect(res.Requeue).To(BeTrue())
 	})
+
+	It("should only update the status if the MIC is paused", func() {
+		pausedMic := testMic
+		pausedMic.Spec.Paused = true
+		pullPods := []v1.Pod{}
+
+		gomock.InOrder(
+			mockImagePuller.EXPECT().ListPullPods(ctx, "some name", "some namespace").Return(pullPods, nil),
+			mockMicReconHelper.EXPECT().updateStatusByPullPods(ctx, &pausedMic, pullPods).Return(nil),
+			mockMicReconHelper.EXPECT().updateStatusByMBSC(ctx, &pausedMic).Return(nil),
+		)
+
+		res, err := mr.Reconcile(ctx, &pausedMic)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(reconcile.Result{}))
+	})
 })
 
 var _ = Describe("handleImageRebuildTriggerGeneration", func() {
@@ -336,6 +353,7 @@ var _ = Describe("updateStatusByPullPods", func() {
 				mockImagePuller.EXPECT().GetPullPodImage(pullPod).Return("some test image"),
 				micHelper.EXPECT().GetModuleImageSpec(&testMic, "some test image").Return(&micSpec),
 				mockImagePuller.EXPECT().GetPullPodStatus(&pullPod).Return(pod.PullImageFailed),
+				mockImagePuller.EXPECT().GetPullPodArchitecture(pullPod).Return(""),
 				micHelper.EXPECT().SetImageStatus(&testMic, "some test image", stateToSet),
 				clnt.EXPECT().Status().Return(statusWriter),
 				statusWriter.EXPECT().Patch(ctx, &testMic, gomock.Any()),
@@ -377,6 +395,7 @@ var _ = Describe("updateStatusByPullPods", func() {
 			mockImagePuller.EXPECT().GetPullPodImage(pullPod).Return("some test image"),
 			micHelper.EXPECT().GetModuleImageSpec(&testMic, "some test image").Return(&micSpec),
 			mockImagePuller.EXPECT().GetPullPodStatus(&pullPod).Return(pod.PullImageSuccess),
+			mockImagePuller.EXPECT().GetPullPodArchitecture(pullPod).Return(""),
 			micHelper.EXPECT().SetImageStatus(&testMic, "some test image", kmmv1beta1.ImageExists),
 			clnt.EXPECT().Status().Return(statusWriter),
 			statusWriter.EXPECT().Patch(ctx, &testMic, gomock.Any()),
@@ -385,6 +404,48 @@ var _ = Describe("updateStatusByPullPods", func() {
 		err := mrh.updateStatusByPullPods(ctx, &testMic, []v1.Pod{pullPod})
 		Expect(err).To(BeNil())
 	})
+
+	DescribeTable("pod of an architecture finished",
+		func(podStatus pod.PullPodStatus, otherArchState, expectedImageState kmmv1beta1.ImageState) {
+			pullPod := v1.Pod{}
+			micSpec := kmmv1beta1.ModuleImageSpec{
+				Image:         "some test image",
+				Build:         &kmmv1beta1.Build{},
+				Architectures: []string{"amd64", "arm64"},
+			}
+			archState := kmmv1beta1.ImageExists
+			if podStatus == pod.PullImageFailed {
+				archState = kmmv1beta1.ImageNeedsBuilding
+			}
+
+			gomock.InOrder(
+				mockImagePuller.EXPECT().GetPullPodImage(pullPod).Return("some test image"),
+				micHelper.EXPECT().GetModuleImageSpec(&testMic, "some test image").Return(&micSpec),
+				mockImagePuller.EXPECT().GetPullPodStatus(&pullPod).Return(podStatus),
+				mockImagePuller.EXPECT().GetPullPodArchitecture(pullPod).Return("arm64"),
+				micHelper.EXPECT().SetImageArchitectureStatus(&testMic, "some test image", "arm64", archState),
+				micHelper.EXPECT().GetImageArchitectureState(&testMic, "some test image", "amd64").Return(otherArchState),
+			)
+			if otherArchState != "" {
+				micHelper.EXPECT().GetImageArchitectureState(&testMic, "some test image", "arm64").Return(archState)
+			}
+			if expectedImageState != "" {
+				micHelper.EXPECT().SetImageStatus(&testMic, "some test image", expectedImageState)
+			}
+			gomock.InOrder(
+				clnt.EXPECT().Status().Return(statusWriter),
+				statusWriter.EXPECT().Patch(ctx, &testMic, gomock.Any()),
+				mockImagePuller.EXPECT().DeletePod(ctx, &pullPod).Return(nil),
+			)
+
+			err := mrh.updateStatusByPullPods(ctx, &testMic, []v1.Pod{pullPod})
+			Expect(err).To(BeNil())
+		},
+		Entry("other architecture not known yet", pod.PullImageSuccess, kmmv1beta1.ImageState(""), kmmv1beta1.ImageState("")),
+		Entry("image exists for all architectures", pod.PullImageSuccess, kmmv1beta1.ImageExists, kmmv1beta1.ImageExists),
+		Entry("image missing for this architecture", pod.PullImageFailed, kmmv1beta1.ImageExists, kmmv1beta1.ImageNeedsBuilding),
+		Entry("image missing for the other architecture", pod.PullImageSuccess, kmmv1beta1.ImageNeedsBuilding, kmmv1beta1.ImageNeedsBuilding),
+	)
 })
 
 var _ = Describe("updateStatusByMBSC", func() {
@@ -496,6 +557,35 @@ var _ = Describe("updateStatusByMBSC", func() {
 		Entry("sign config exists, action Build, status Succeeded", true, kmmv1beta1.BuildImage, kmmv1beta1.ActionSuccess, kmmv1beta1.ImageNeedsSigning),
 		Entry("sign config exists, action Sign, status Succeeded", true, kmmv1beta1.SignImage, kmmv1beta1.ActionSuccess, kmmv1beta1.ImageExists),
 	)
+
+	It("should set the status of each finished architecture", func() {
+		testMBSC := kmmv1beta1.ModuleBuildSignConfig{
+			Status: kmmv1beta1.ModuleBuildSignConfigStatus{
+				Images: []kmmv1beta1.BuildSignImageState{
+					{
+						Image:  "some image",
+						Action: kmmv1beta1.BuildImage,
+						Architectures: []kmmv1beta1.BuildSignArchitectureState{
+							{Architecture: "amd64", Status: kmmv1beta1.ActionSuccess},
+							{Architecture: "arm64", Status: kmmv1beta1.ActionFailure},
+						},
+					},
+				},
+			},
+		}
+		imageSpec := kmmv1beta1.ModuleImageSpec{Sign: &kmmv1beta1.Sign{}}
+		gomock.InOrder(
+			mbscHelper.EXPECT().Get(ctx, testMic.Name, testMic.Namespace).Return(&testMBSC, nil),
+			micHelper.EXPECT().GetModuleImageSpec(&testMic, "some image").Return(&imageSpec),
+			micHelper.EXPECT().SetImageArchitectureStatus(&testMic, "some image", "amd64", kmmv1beta1.ImageNeedsSigning),
+			micHelper.EXPECT().SetImageArchitectureStatus(&testMic, "some image", "arm64", kmmv1beta1.ImageDoesNotExist),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, &testMic, gomock.Any()),
+		)
+
+		err := mrh.updateStatusByMBSC(ctx, &testMic)
+		Expect(err).To(BeNil())
+	})
 })
 
 var _ = Describe("processImagesSpecs", func() {
@@ -546,8 +636,8 @@ var _ = Describe("processImagesSpecs", func() {
 			testMic.Spec.Images[0].SkipWaitMissingImage = skipWaitMissingImage
 			gomock.InOrder(
 				micHelper.EXPECT().GetImageState(&testMic, "image 1").Return(kmmv1beta1.ImageState("")),
-				mockImagePuller.EXPECT().GetPullPodForImage(pullPods, "image 1").Return(nil),
-				mockImagePuller.EXPECT().CreatePullPod(ctx, "some name", "some namespace", "image 1", expectedOneTimePodFlag,
+				mockImagePuller.EXPECT().GetPullPodForImage(pullPods, "image 1", "").Return(nil),
+				mockImagePuller.EXPECT().CreatePullPod(ctx, "some name", "some namespace", "image 1", "", expectedOneTimePodFlag,
 					nil, v1.PullPolicy(""), &testMic).Return(nil),
 			)
 			err := mrh.processImagesSpecs(ctx, &testMic, pullPods)
@@ -564,12 +654,41 @@ var _ = Describe("processImagesSpecs", func() {
 	It("image status empty, pull pod exists, nothing to do", func() {
 		gomock.InOrder(
 			micHelper.EXPECT().GetImageState(&testMic, "image 1").Return(kmmv1beta1.ImageState("")),
-			mockImagePuller.EXPECT().GetPullPodForImage(pullPods, "image 1").Return(&v1.Pod{}),
+			mockImagePuller.EXPECT().GetPullPodForImage(pullPods, "image 1", "").Return(&v1.Pod{}),
 		)
 		err := mrh.processImagesSpecs(ctx, &testMic, pullPods)
 		Expect(err).To(BeNil())
 	})
 
+	It("image exists without architectures, nothing to do", func() {
+		micHelper.EXPECT().GetImageState(&testMic, "image 1").Return(kmmv1beta1.ImageExists)
+
+		err := mrh.processImagesSpecs(ctx, &testMic, pullPods)
+		Expect(err).To(BeNil())
+	})
+
+	DescribeTable("should create the pull pods of the architectures whose state is not known",
+		func(imageState kmmv1beta1.ImageState) {
+			testMic.Spec.Images[0].Architectures = []string{"amd64", "arm64", "s390x"}
+
+			gomock.InOrder(
+				micHelper.EXPECT().GetImageState(&testMic, "image 1").Return(imageState),
+				micHelper.EXPECT().GetImageArchitectureState(&testMic, "image 1", "amd64").Return(kmmv1beta1.ImageExists),
+				micHelper.EXPECT().GetImageArchitectureState(&testMic, "image 1", "arm64").Return(kmmv1beta1.ImageState("")),
+				mockImagePuller.EXPECT().GetPullPodForImage(pullPods, "image 1", "arm64").Return(&v1.Pod{}),
+				micHelper.EXPECT().GetImageArchitectureState(&testMic, "image 1", "s390x").Return(kmmv1beta1.ImageState("")),
+				mockImagePuller.EXPECT().GetPullPodForImage(pullPods, "image 1", "s390x").Return(nil),
+				mockImagePuller.EXPECT().CreatePullPod(ctx, "some name", "some namespace", "image 1", "s390x", false,
+					nil, v1.PullPolicy(""), &testMic).Return(nil),
+			)
+
+			err := mrh.processImagesSpecs(ctx, &testMic, pullPods)
+			Expect(err).To(BeNil())
+		},
+		Entry("image state not set", kmmv1beta1.ImageState("")),
+		Entry("image exists for other architectures", kmmv1beta1.ImageExists),
+	)
+
 	DescribeTable("images in MBSC status exist in MIC spec",
 		func(imageState kmmv1beta1.ImageState, buildExists, signExists, updateMSBC bool, msbcAction kmmv1beta1.BuildOrSignAction) {
 			testMic := kmmv1beta1.ModuleImagesConfig{
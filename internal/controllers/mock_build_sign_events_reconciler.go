@@ -13,7 +13,8 @@ import (
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/api/core/v1"
+	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -41,7 +42,7 @@ func (m *MockJobEventReconcilerHelper) EXPECT() *MockJobEventReconcilerHelperMoc
 }
 
 // GetOwner mocks base method.
-func (m *MockJobEventReconcilerHelper) GetOwner(arg0 context.Context, arg1 v1.OwnerReference, arg2 string) (client.Object, error) {
+func (m *MockJobEventReconcilerHelper) GetOwner(arg0 context.Context, arg1 v10.OwnerReference, arg2 string) (client.Object, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetOwner", arg0, arg1, arg2)
 	ret0, _ := ret[0].(client.Object)
@@ -54,3 +55,18 @@ func (mr *MockJobEventReconcilerHelperMockRecorder) GetOwner(arg0, arg1, arg2 an
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwner", reflect.TypeOf((*MockJobEventReconcilerHelper)(nil).GetOwner), arg0, arg1, arg2)
 }
+
+// SaveLogs mocks base method.
+func (m *MockJobEventReconcilerHelper) SaveLogs(ctx context.Context, pod *v1.Pod) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLogs", ctx, pod)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveLogs indicates an expected call of SaveLogs.
+func (mr *MockJobEventReconcilerHelperMockRecorder) SaveLogs(ctx, pod any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLogs", reflect.TypeOf((*MockJobEventReconcilerHelper)(nil).SaveLogs), ctx, pod)
+}
@@ -11,6 +11,7 @@ package controllers
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	v1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	gomock "go.uber.org/mock/gomock"
@@ -53,12 +54,28 @@ func (mr *MockmbscReconcilerHelperAPIMockRecorder) garbageCollect(ctx, mbscObj a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "garbageCollect", reflect.TypeOf((*MockmbscReconcilerHelperAPI)(nil).garbageCollect), ctx, mbscObj)
 }
 
+// isPaused mocks base method.
+func (m *MockmbscReconcilerHelperAPI) isPaused(ctx context.Context, mbscObj *v1beta1.ModuleBuildSignConfig) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "isPaused", ctx, mbscObj)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// isPaused indicates an expected call of isPaused.
+func (mr *MockmbscReconcilerHelperAPIMockRecorder) isPaused(ctx, mbscObj any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isPaused", reflect.TypeOf((*MockmbscReconcilerHelperAPI)(nil).isPaused), ctx, mbscObj)
+}
+
 // processImagesSpecs mocks base method.
-func (m *MockmbscReconcilerHelperAPI) processImagesSpecs(ctx context.Context, mbscObj *v1beta1.ModuleBuildSignConfig) error {
+func (m *MockmbscReconcilerHelperAPI) processImagesSpecs(ctx context.Context, mbscObj *v1beta1.ModuleBuildSignConfig) (time.Duration, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "processImagesSpecs", ctx, mbscObj)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // processImagesSpecs indicates an expected call of processImagesSpecs.
@@ -70,6 +70,21 @@ func (mr *MockmoduleReconcilerHelperAPIMockRecorder) enableModuleOnNode(ctx, mld
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "enableModuleOnNode", reflect.TypeOf((*MockmoduleReconcilerHelperAPI)(nil).enableModuleOnNode), ctx, mld, node)
 }
 
+// evaluateCanary mocks base method.
+func (m *MockmoduleReconcilerHelperAPI) evaluateCanary(ctx context.Context, mod *v1beta1.Module, targetedNodes []v1.Node) (*canaryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "evaluateCanary", ctx, mod, targetedNodes)
+	ret0, _ := ret[0].(*canaryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// evaluateCanary indicates an expected call of evaluateCanary.
+func (mr *MockmoduleReconcilerHelperAPIMockRecorder) evaluateCanary(ctx, mod, targetedNodes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "evaluateCanary", reflect.TypeOf((*MockmoduleReconcilerHelperAPI)(nil).evaluateCanary), ctx, mod, targetedNodes)
+}
+
 // finalizeModule mocks base method.
 func (m *MockmoduleReconcilerHelperAPI) finalizeModule(ctx context.Context, mod *v1beta1.Module) error {
 	m.ctrl.T.Helper()
@@ -128,6 +143,20 @@ func (mr *MockmoduleReconcilerHelperAPIMockRecorder) prepareSchedulingData(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "prepareSchedulingData", reflect.TypeOf((*MockmoduleReconcilerHelperAPI)(nil).prepareSchedulingData), ctx, mod, targetedNodes, currentNMCs)
 }
 
+// resolveValueSources mocks base method.
+func (m *MockmoduleReconcilerHelperAPI) resolveValueSources(ctx context.Context, mod *v1beta1.Module) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "resolveValueSources", ctx, mod)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// resolveValueSources indicates an expected call of resolveValueSources.
+func (mr *MockmoduleReconcilerHelperAPIMockRecorder) resolveValueSources(ctx, mod any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "resolveValueSources", reflect.TypeOf((*MockmoduleReconcilerHelperAPI)(nil).resolveValueSources), ctx, mod)
+}
+
 // setFinalizerAndStatus mocks base method.
 func (m *MockmoduleReconcilerHelperAPI) setFinalizerAndStatus(ctx context.Context, mod *v1beta1.Module) error {
 	m.ctrl.T.Helper()
@@ -142,6 +171,20 @@ func (mr *MockmoduleReconcilerHelperAPIMockRecorder) setFinalizerAndStatus(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "setFinalizerAndStatus", reflect.TypeOf((*MockmoduleReconcilerHelperAPI)(nil).setFinalizerAndStatus), ctx, mod)
 }
 
+// syncNMCsPaused mocks base method.
+func (m *MockmoduleReconcilerHelperAPI) syncNMCsPaused(ctx context.Context, mod *v1beta1.Module) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "syncNMCsPaused", ctx, mod)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// syncNMCsPaused indicates an expected call of syncNMCsPaused.
+func (mr *MockmoduleReconcilerHelperAPIMockRecorder) syncNMCsPaused(ctx, mod any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "syncNMCsPaused", reflect.TypeOf((*MockmoduleReconcilerHelperAPI)(nil).syncNMCsPaused), ctx, mod)
+}
+
 // updateModuleStatus mocks base method.
 func (m *MockmoduleReconcilerHelperAPI) updateModuleStatus(ctx context.Context, mod *v1beta1.Module, targetedNodes []v1.Node) error {
 	m.ctrl.T.Helper()
@@ -84,6 +84,20 @@ func (mr *MocknmcReconcilerHelperMockRecorder) ProcessModuleSpec(ctx, nmc, spec,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessModuleSpec", reflect.TypeOf((*MocknmcReconcilerHelper)(nil).ProcessModuleSpec), ctx, nmc, spec, status, node)
 }
 
+// ProcessOrphanedModules mocks base method.
+func (m *MocknmcReconcilerHelper) ProcessOrphanedModules(ctx context.Context, nmc *v1beta1.NodeModulesConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessOrphanedModules", ctx, nmc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessOrphanedModules indicates an expected call of ProcessOrphanedModules.
+func (mr *MocknmcReconcilerHelperMockRecorder) ProcessOrphanedModules(ctx, nmc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessOrphanedModules", reflect.TypeOf((*MocknmcReconcilerHelper)(nil).ProcessOrphanedModules), ctx, nmc)
+}
+
 // ProcessUnconfiguredModuleStatus mocks base method.
 func (m *MocknmcReconcilerHelper) ProcessUnconfiguredModuleStatus(ctx context.Context, nmc *v1beta1.NodeModulesConfig, status *v1beta1.NodeModuleStatus, node *v1.Node) error {
 	m.ctrl.T.Helper()
@@ -124,6 +138,20 @@ func (mr *MocknmcReconcilerHelperMockRecorder) RemovePodFinalizers(ctx, nodeName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePodFinalizers", reflect.TypeOf((*MocknmcReconcilerHelper)(nil).RemovePodFinalizers), ctx, nodeName)
 }
 
+// SyncNodeFeatures mocks base method.
+func (m *MocknmcReconcilerHelper) SyncNodeFeatures(ctx context.Context, nmc *v1beta1.NodeModulesConfig, node *v1.Node) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncNodeFeatures", ctx, nmc, node)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncNodeFeatures indicates an expected call of SyncNodeFeatures.
+func (mr *MocknmcReconcilerHelperMockRecorder) SyncNodeFeatures(ctx, nmc, node any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncNodeFeatures", reflect.TypeOf((*MocknmcReconcilerHelper)(nil).SyncNodeFeatures), ctx, nmc, node)
+}
+
 // SyncStatus mocks base method.
 func (m *MocknmcReconcilerHelper) SyncStatus(ctx context.Context, nmc *v1beta1.NodeModulesConfig, node *v1.Node) error {
 	m.ctrl.T.Helper()
@@ -42,11 +42,11 @@ func (m *MockpreflightReconcilerHelper) EXPECT() *MockpreflightReconcilerHelperM
 }
 
 // getModulesData mocks base method.
-func (m *MockpreflightReconcilerHelper) getModulesData(ctx context.Context, pv *v1beta2.PreflightValidation) ([]*api.ModuleLoaderData, []types.NamespacedName, error) {
+func (m *MockpreflightReconcilerHelper) getModulesData(ctx context.Context, pv *v1beta2.PreflightValidation) ([]*api.ModuleLoaderData, map[types.NamespacedName]string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "getModulesData", ctx, pv)
 	ret0, _ := ret[0].([]*api.ModuleLoaderData)
-	ret1, _ := ret[1].([]types.NamespacedName)
+	ret1, _ := ret[1].(map[types.NamespacedName]string)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
@@ -72,7 +72,7 @@ func (mr *MockpreflightReconcilerHelperMockRecorder) processPreflightValidation(
 }
 
 // updateStatus mocks base method.
-func (m *MockpreflightReconcilerHelper) updateStatus(ctx context.Context, modsWithMapping []*api.ModuleLoaderData, modsWithoutMapping []types.NamespacedName, pv *v1beta2.PreflightValidation) error {
+func (m *MockpreflightReconcilerHelper) updateStatus(ctx context.Context, modsWithMapping []*api.ModuleLoaderData, modsWithoutMapping map[types.NamespacedName]string, pv *v1beta2.PreflightValidation) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "updateStatus", ctx, modsWithMapping, modsWithoutMapping, pv)
 	ret0, _ := ret[0].(error)
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/config"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/filter"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/meta"
@@ -17,18 +21,23 @@ import (
 	"github.com/kubernetes-sigs/kernel-module-management/internal/nmc"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/node"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
+	"github.com/mitchellh/hashstructure/v2"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -39,6 +48,8 @@ import (
 //+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;patch;watch
 //+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;patch
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=create;delete;get;list;patch;watch
+//+kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=create;patch
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch
 //+kubebuilder:rbac:groups=kmm.sigs.x-k8s.io,resources=modulebuildsignconfigs,verbs=get;list;watch;update;patch;create;delete
@@ -51,6 +62,7 @@ import (
 //+kubebuilder:rbac:groups=kmm.sigs.x-k8s.io,resources=nodemodulesconfigs/status,verbs=patch
 //+kubebuilder:rbac:groups=kmm.sigs.x-k8s.io,resources=preflightvalidations,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kmm.sigs.x-k8s.io,resources=preflightvalidations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=nfd.k8s-sigs.io,resources=nodefeatures,verbs=create;delete;get;list;patch;watch
 //+kubebuilder:rbac:groups=resource.k8s.io,resources=deviceclasses,verbs=create;delete;deletecollection;get;list;patch;update;watch
 
 const (
@@ -71,6 +83,7 @@ type ModuleReconciler struct {
 	reconHelper moduleReconcilerHelperAPI
 	nodeAPI     node.Node
 	micAPI      mic.MIC
+	nodeCfg     *config.Node
 }
 
 func NewModuleReconciler(client client.Client,
@@ -79,13 +92,15 @@ func NewModuleReconciler(client client.Client,
 	filter *filter.Filter,
 	nodeAPI node.Node,
 	micAPI mic.MIC,
+	nodeCfg *config.Node,
 	scheme *runtime.Scheme) *ModuleReconciler {
-	reconHelper := newModuleReconcilerHelper(client, kernelAPI, micAPI, nmcHelper, scheme)
+	reconHelper := newModuleReconcilerHelper(client, kernelAPI, micAPI, nmcHelper, nodeAPI, nodeCfg, scheme)
 	return &ModuleReconciler{
 		filter:      filter,
 		nsLabeler:   newNamespaceLabeler(client),
 		reconHelper: reconHelper,
 		nodeAPI:     nodeAPI,
+		nodeCfg:     nodeCfg,
 	}
 }
 
@@ -121,6 +136,10 @@ func (mr *ModuleReconciler) Reconcile(ctx context.Context, mod *kmmv1beta1.Modul
 		return ctrl.Result{}, nil
 	}
 
+	if err = mr.reconHelper.resolveValueSources(ctx, mod); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to resolve ConfigMap and Secret references of Module %s/%s: %v", mod.Namespace, mod.Name, err)
+	}
+
 	// get nodes targeted by selector
 	targetedNodes, err := mr.nodeAPI.GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, append(mod.Spec.Tolerations, module.InternalTolerations...))
 	if err != nil {
@@ -131,16 +150,74 @@ func (mr *ModuleReconciler) Reconcile(ctx context.Context, mod *kmmv1beta1.Modul
 		return ctrl.Result{}, fmt.Errorf("failed to handle MIC: %v", err)
 	}
 
+	if err = mr.reconHelper.syncNMCsPaused(ctx, mod); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to propagate the paused state of Module %s/%s to NMCs: %v", mod.Namespace, mod.Name, err)
+	}
+
+	if mod.Spec.Paused {
+		logger.Info("Module is paused; not changing NodeModulesConfigs")
+
+		if err = mr.reconHelper.updateModuleStatus(ctx, mod, targetedNodes); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile module %s/%s config: %v", mod.Namespace, mod.Name, err)
+		}
+
+		return ctrl.Result{}, nil
+	}
+
 	currentNMCs, err := mr.reconHelper.getNMCsByModuleSet(ctx, mod)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get NMCs for Module %s/%s: %v", mod.Namespace, mod.Name, err)
 	}
 
+	var (
+		windowStatus    *kmmv1beta1.MaintenanceWindowStatus
+		configuredNodes = currentNMCs.Clone()
+		res             ctrl.Result
+	)
+
+	if mw := mod.Spec.MaintenanceWindow; mw != nil {
+		now := time.Now()
+
+		windowStatus, err = module.GetMaintenanceWindowStatus(mw, now)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("invalid maintenance window for Module %s/%s: %v", mod.Namespace, mod.Name, err)
+		}
+
+		res.RequeueAfter = module.NextMaintenanceWindowTransition(windowStatus).Sub(now)
+	}
+
+	var canary *canaryResult
+
+	if mod.Spec.Canary != nil {
+		canary, err = mr.reconHelper.evaluateCanary(ctx, mod, targetedNodes)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to evaluate the canary stage of Module %s/%s: %v", mod.Namespace, mod.Name, err)
+		}
+
+		if canary.requeueAfter > 0 && (res.RequeueAfter == 0 || canary.requeueAfter < res.RequeueAfter) {
+			res.RequeueAfter = canary.requeueAfter
+		}
+	}
+
 	sdMap, prepareErrs := mr.reconHelper.prepareSchedulingData(ctx, mod, targetedNodes, currentNMCs)
 	errs := make([]error, 0, len(sdMap)+1)
 	errs = append(errs, prepareErrs...)
 
+	postponed := 0
+	held := 0
+
 	for nodeName, sd := range sdMap {
+		// Outside of the maintenance window, only nodes that do not have the module yet may be changed.
+		if windowStatus != nil && !windowStatus.Open && (sd.action == actionDelete || configuredNodes.Has(nodeName)) {
+			postponed++
+			continue
+		}
+		// Until the canary stage succeeds, only canary nodes and nodes that do not have the module yet are updated.
+		if canary != nil && !canary.promoted && sd.action == actionAdd && configuredNodes.Has(nodeName) &&
+			!labels.SelectorFromSet(mod.Spec.Canary.Selector).Matches(labels.Set(sd.node.Labels)) {
+			held++
+			continue
+		}
 		if sd.action == actionAdd {
 			err = mr.reconHelper.enableModuleOnNode(ctx, sd.mld, sd.node)
 		}
@@ -150,6 +227,22 @@ func (mr *ModuleReconciler) Reconcile(ctx context.Context, mod *kmmv1beta1.Modul
 		errs = append(errs, err)
 	}
 
+	if postponed > 0 {
+		logger.Info(
+			"Maintenance window is closed; postponing changes on nodes",
+			"count", postponed,
+			"nextStart", windowStatus.NextStart,
+		)
+	}
+
+	if held > 0 {
+		logger.Info(
+			"Canary stage has not succeeded yet; holding changes on non-canary nodes",
+			"count", held,
+			"phase", mod.Status.Canary.Phase,
+		)
+	}
+
 	err = mr.reconHelper.updateModuleStatus(ctx, mod, targetedNodes)
 	errs = append(errs, err)
 
@@ -157,10 +250,22 @@ func (mr *ModuleReconciler) Reconcile(ctx context.Context, mod *kmmv1beta1.Modul
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile module %s/%s config: %v", mod.Namespace, mod.Name, err)
 	}
-	return ctrl.Result{}, nil
+	return res, nil
 }
 
 func (mr *ModuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &kmmv1beta1.Module{}, filter.ModuleValueSourceSecretsIndex, func(o client.Object) []string {
+		return module.ValueSourceSecretNames(o.(*kmmv1beta1.Module))
+	})
+	if err != nil {
+		return fmt.Errorf("could not start the Module Secret references indexer: %v", err)
+	}
+
+	var pendingKernelAnnotation string
+	if mr.nodeCfg != nil {
+		pendingKernelAnnotation = mr.nodeCfg.PendingKernelAnnotation
+	}
+
 	return ctrl.
 		NewControllerManagedBy(mgr).
 		For(&kmmv1beta1.Module{}).
@@ -170,13 +275,27 @@ func (mr *ModuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&v1.Node{},
 			handler.EnqueueRequestsFromMapFunc(mr.filter.FindModulesForNMCNodeChange),
 			builder.WithPredicates(
-				filter.ModuleReconcilerNodePredicate(),
+				mr.filter.ModuleReconcilerNodePredicate(pendingKernelAnnotation),
 			),
 		).
 		Watches(
 			&kmmv1beta1.NodeModulesConfig{},
 			handler.EnqueueRequestsFromMapFunc(filter.ListModulesForNMC),
 		).
+		Watches(
+			&kmmv1beta1.Module{},
+			handler.EnqueueRequestsFromMapFunc(mr.filter.FindModulesWithConflictingKernelModules),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&v1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(mr.filter.FindModulesForConfigMap),
+		).
+		Watches(
+			&v1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(mr.filter.FindModulesForSecret),
+			builder.WithPredicates(filter.SecretDataChangedPredicate()),
+		).
 		Named(ModuleReconcilerName).
 		Complete(
 			reconcile.AsReconciler[*kmmv1beta1.Module](mgr.GetClient(), mr),
@@ -194,6 +313,16 @@ type moduleReconcilerHelperAPI interface {
 	enableModuleOnNode(ctx context.Context, mld *api.ModuleLoaderData, node *v1.Node) error
 	disableModuleOnNode(ctx context.Context, modNamespace, modName, nodeName string) error
 	updateModuleStatus(ctx context.Context, mod *kmmv1beta1.Module, targetedNodes []v1.Node) error
+	evaluateCanary(ctx context.Context, mod *kmmv1beta1.Module, targetedNodes []v1.Node) (*canaryResult, error)
+	resolveValueSources(ctx context.Context, mod *kmmv1beta1.Module) error
+	syncNMCsPaused(ctx context.Context, mod *kmmv1beta1.Module) error
+}
+
+type canaryResult struct {
+	// promoted is true if the current ModuleLoader configuration may be applied to all nodes.
+	promoted bool
+	// requeueAfter is the remaining soak time, if the canary nodes are soaking.
+	requeueAfter time.Duration
 }
 
 type moduleReconcilerHelper struct {
@@ -201,6 +330,8 @@ type moduleReconcilerHelper struct {
 	kernelAPI module.KernelMapper
 	micAPI    mic.MIC
 	nmcHelper nmc.Helper
+	nodeAPI   node.Node
+	nodeCfg   *config.Node
 	scheme    *runtime.Scheme
 }
 
@@ -209,16 +340,180 @@ func newModuleReconcilerHelper(
 	kernelAPI module.KernelMapper,
 	micAPI mic.MIC,
 	nmcHelper nmc.Helper,
+	nodeAPI node.Node,
+	nodeCfg *config.Node,
 	scheme *runtime.Scheme) moduleReconcilerHelperAPI {
 	return &moduleReconcilerHelper{
 		client:    client,
 		kernelAPI: kernelAPI,
 		micAPI:    micAPI,
 		nmcHelper: nmcHelper,
+		nodeAPI:   nodeAPI,
+		nodeCfg:   nodeCfg,
 		scheme:    scheme,
 	}
 }
 
+// resolveValueSources replaces the ConfigMap references in the ModuleLoader of mod with the values they point to, so
+// that the rest of the reconciliation only deals with literal values.
+// Changes to the referenced ConfigMaps thus go through the same flow as changes to the Module itself.
+// Secret references are kept, so that their values are only read by the worker and build Pods; missing optional
+// Secrets or keys are dropped here. A digest of the Secret values is recorded in the NMCs and hashed in the build
+// Pods instead, so that changes to the referenced Secrets also reload the kernel module and replace running builds.
+func (mrh *moduleReconcilerHelper) resolveValueSources(ctx context.Context, mod *kmmv1beta1.Module) error {
+	container := &mod.Spec.ModuleLoader.Container
+
+	type parameterSources struct {
+		parameters     *[]string
+		parametersFrom *[]kmmv1beta1.ModprobeParameterSource
+	}
+
+	modprobes := []parameterSources{
+		{parameters: &container.Modprobe.Parameters, parametersFrom: &container.Modprobe.ParametersFrom},
+	}
+	builds := []*kmmv1beta1.Build{container.Build}
+
+	for i := range container.KernelMappings {
+		if m := container.KernelMappings[i].Modprobe; m != nil {
+			modprobes = append(modprobes, parameterSources{parameters: &m.Parameters, parametersFrom: &m.ParametersFrom})
+		}
+
+		builds = append(builds, container.KernelMappings[i].Build)
+	}
+
+	for _, m := range modprobes {
+		var fromSecrets []kmmv1beta1.ModprobeParameterSource
+
+		for _, p := range *m.parametersFrom {
+			if ref := p.ValueFrom.SecretKeyRef; ref != nil {
+				found, err := mrh.secretKeyExists(ctx, mod.Namespace, ref)
+				if err != nil {
+					return fmt.Errorf("could not resolve the value of parameter %s: %v", p.Name, err)
+				}
+
+				if found {
+					fromSecrets = append(fromSecrets, p)
+				}
+
+				continue
+			}
+
+			value, found, err := mrh.getConfigMapValue(ctx, mod.Namespace, p.ValueFrom.ConfigMapKeyRef)
+			if err != nil {
+				return fmt.Errorf("could not resolve the value of parameter %s: %v", p.Name, err)
+			}
+
+			if found {
+				*m.parameters = append(*m.parameters, p.Name+"="+value)
+			}
+		}
+
+		*m.parametersFrom = fromSecrets
+	}
+
+	for _, b := range builds {
+		if b == nil {
+			continue
+		}
+
+		for i := range b.BuildArgs {
+			arg := &b.BuildArgs[i]
+
+			if arg.ValueFrom == nil {
+				continue
+			}
+
+			if ref := arg.ValueFrom.SecretKeyRef; ref != nil {
+				found, err := mrh.secretKeyExists(ctx, mod.Namespace, ref)
+				if err != nil {
+					return fmt.Errorf("could not resolve the value of build argument %s: %v", arg.Name, err)
+				}
+
+				if !found {
+					arg.Value = ""
+					arg.ValueFrom = nil
+				}
+
+				continue
+			}
+
+			value, _, err := mrh.getConfigMapValue(ctx, mod.Namespace, arg.ValueFrom.ConfigMapKeyRef)
+			if err != nil {
+				return fmt.Errorf("could not resolve the value of build argument %s: %v", arg.Name, err)
+			}
+
+			arg.Value = value
+			arg.ValueFrom = nil
+		}
+	}
+
+	if pk := mod.Spec.PrebuildKernels; pk != nil && pk.ConfigMapKeyRef != nil {
+		value, _, err := mrh.getConfigMapValue(ctx, mod.Namespace, pk.ConfigMapKeyRef)
+		if err != nil {
+			return fmt.Errorf("could not resolve the kernels to prebuild: %v", err)
+		}
+
+		pk.Versions = append(pk.Versions, strings.Fields(value)...)
+		pk.ConfigMapKeyRef = nil
+	}
+
+	return nil
+}
+
+// getConfigMapValue returns the value that ref points to in namespace.
+// It returns false if the ConfigMap or the key is missing and the reference is optional.
+func (mrh *moduleReconcilerHelper) getConfigMapValue(ctx context.Context, namespace string, ref *v1.ConfigMapKeySelector) (string, bool, error) {
+	if ref == nil {
+		return "", false, errors.New("neither configMapKeyRef nor secretKeyRef is set")
+	}
+
+	cm := v1.ConfigMap{}
+
+	if err := mrh.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &cm); err != nil {
+		if apierrors.IsNotFound(err) && ptr.Deref(ref.Optional, false) {
+			return "", false, nil
+		}
+
+		return "", false, fmt.Errorf("could not get ConfigMap %s/%s: %v", namespace, ref.Name, err)
+	}
+
+	value, ok := cm.Data[ref.Key]
+	if !ok {
+		if ptr.Deref(ref.Optional, false) {
+			return "", false, nil
+		}
+
+		return "", false, fmt.Errorf("key %s not found in ConfigMap %s/%s", ref.Key, namespace, ref.Name)
+	}
+
+	return value, true, nil
+}
+
+// secretKeyExists returns true if the Secret that ref points to in namespace has the key.
+// It returns false if the Secret or the key is missing and the reference is optional.
+// The value is not read.
+func (mrh *moduleReconcilerHelper) secretKeyExists(ctx context.Context, namespace string, ref *v1.SecretKeySelector) (bool, error) {
+	secret := v1.Secret{}
+
+	if err := mrh.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &secret); err != nil {
+		if apierrors.IsNotFound(err) && ptr.Deref(ref.Optional, false) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("could not get Secret %s/%s: %v", namespace, ref.Name, err)
+	}
+
+	if _, ok := secret.Data[ref.Key]; !ok {
+		if ptr.Deref(ref.Optional, false) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("key %s not found in Secret %s/%s", ref.Key, namespace, ref.Name)
+	}
+
+	return true, nil
+}
+
 func (mrh *moduleReconcilerHelper) setFinalizerAndStatus(ctx context.Context, mod *kmmv1beta1.Module) error {
 	if controllerutil.ContainsFinalizer(mod, constants.ModuleFinalizer) {
 		return nil
@@ -242,6 +537,25 @@ func (mrh *moduleReconcilerHelper) setFinalizerAndStatus(ctx context.Context, mo
 }
 
 func (mrh *moduleReconcilerHelper) finalizeModule(ctx context.Context, mod *kmmv1beta1.Module) error {
+	if mod.Spec.Paused {
+		// Removing the Module from the NMCs would unload or orphan its kernel modules: keep the finalizer until the
+		// Module is unpaused.
+		ctrl.LoggerFrom(ctx).Info("Module is paused; not removing it from NodeModulesConfigs until it is unpaused")
+
+		unmodifiedMod := mod.DeepCopy()
+		mrh.updatePausedCondition(mod)
+
+		if err := mrh.client.Status().Patch(ctx, mod, client.MergeFrom(unmodifiedMod)); err != nil {
+			return fmt.Errorf("failed to patch the status of module %s/%s: %v", mod.Namespace, mod.Name, err)
+		}
+
+		return nil
+	}
+
+	if mod.Spec.DeletionPolicy == kmmv1beta1.DeletionPolicyOrphan {
+		return mrh.orphanModule(ctx, mod)
+	}
+
 	nmcList := kmmv1beta1.NodeModulesConfigList{}
 
 	modNSN := types.NamespacedName{Namespace: mod.Namespace, Name: mod.Name}
@@ -281,7 +595,106 @@ func (mrh *moduleReconcilerHelper) finalizeModule(ctx context.Context, mod *kmmv
 		return nil
 	}
 
-	// remove finalizer
+	return mrh.removeFinalizer(ctx, mod)
+}
+
+// orphanModule removes the Module from all NMCs without unloading the kernel module from the nodes.
+func (mrh *moduleReconcilerHelper) orphanModule(ctx context.Context, mod *kmmv1beta1.Module) error {
+	modNSN := types.NamespacedName{Namespace: mod.Namespace, Name: mod.Name}
+
+	nmcs := make(map[string]kmmv1beta1.NodeModulesConfig)
+
+	for _, label := range []string{nmc.ModuleConfiguredLabel(mod.Namespace, mod.Name), nmc.ModuleInUseLabel(mod.Namespace, mod.Name)} {
+		nmcList := kmmv1beta1.NodeModulesConfigList{}
+
+		if err := mrh.client.List(ctx, &nmcList, client.MatchingLabels{label: ""}); err != nil {
+			return fmt.Errorf("failed to list NMCs with label %s in the cluster: %v", label, err)
+		}
+
+		for _, nmcObj := range nmcList.Items {
+			nmcs[nmcObj.Name] = nmcObj
+		}
+	}
+
+	errs := make([]error, 0, len(nmcs))
+	for _, nmcObj := range nmcs {
+		errs = append(errs, mrh.orphanModuleInNMC(ctx, &nmcObj, mod.Namespace, mod.Name))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to orphan %s module in some of NMCs: %v", modNSN, err)
+	}
+
+	return mrh.removeFinalizer(ctx, mod)
+}
+
+// orphanModuleInNMC marks the spec entry of a module in nmcObj as orphaned and removes the module's labels, in a
+// single update.
+// The NMC reconciler then stops creating worker Pods for the entry, discards the results of the worker Pods that
+// were already running and removes the entry, without ever unloading the kernel module.
+func (mrh *moduleReconcilerHelper) orphanModuleInNMC(ctx context.Context, nmcObj *kmmv1beta1.NodeModulesConfig, modNamespace, modName string) error {
+	logger := log.FromContext(ctx)
+
+	specFrom := client.MergeFrom(nmcObj.DeepCopy())
+
+	entry, _ := mrh.nmcHelper.GetModuleSpecEntry(nmcObj, modNamespace, modName)
+	if entry == nil {
+		if status := nmc.FindModuleStatus(nmcObj.Status.Modules, modNamespace, modName); status != nil {
+			// The module is being unloaded: keep it loaded instead.
+			nmcObj.Spec.Modules = append(nmcObj.Spec.Modules, kmmv1beta1.NodeModuleSpec{
+				ModuleItem: status.ModuleItem,
+				Config:     status.Config,
+			})
+			entry = &nmcObj.Spec.Modules[len(nmcObj.Spec.Modules)-1]
+		}
+	}
+
+	if entry != nil {
+		entry.Orphaned = true
+	}
+
+	meta.RemoveLabel(nmcObj, nmc.ModuleConfiguredLabel(modNamespace, modName))
+	meta.RemoveLabel(nmcObj, nmc.ModuleInUseLabel(modNamespace, modName))
+
+	if err := mrh.client.Patch(ctx, nmcObj, specFrom); err != nil {
+		return fmt.Errorf("failed to patch NMC %s: %v", nmcObj.Name, err)
+	}
+
+	logger.Info("Orphaned module in NMC", "name", modName, "namespace", modNamespace, "NMC", nmcObj.Name)
+	return nil
+}
+
+// syncNMCsPaused sets the paused flag of the Module's entries in all NMCs to .spec.paused, so that the NMC reconciler
+// stops or resumes creating worker Pods for them.
+func (mrh *moduleReconcilerHelper) syncNMCsPaused(ctx context.Context, mod *kmmv1beta1.Module) error {
+	nmcList, err := mrh.getNMCsForModule(ctx, mod)
+	if err != nil {
+		return fmt.Errorf("failed to get list of %s/%s module's NMCs: %v", mod.Namespace, mod.Name, err)
+	}
+
+	errs := make([]error, 0, len(nmcList))
+
+	for i := range nmcList {
+		nmcObj := &nmcList[i]
+
+		patchFrom := client.MergeFrom(nmcObj.DeepCopy())
+
+		entry, _ := mrh.nmcHelper.GetModuleSpecEntry(nmcObj, mod.Namespace, mod.Name)
+		if entry == nil || entry.Paused == mod.Spec.Paused {
+			continue
+		}
+
+		entry.Paused = mod.Spec.Paused
+
+		if err = mrh.client.Patch(ctx, nmcObj, patchFrom); err != nil {
+			errs = append(errs, fmt.Errorf("failed to patch NMC %s: %v", nmcObj.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (mrh *moduleReconcilerHelper) removeFinalizer(ctx context.Context, mod *kmmv1beta1.Module) error {
 	modCopy := mod.DeepCopy()
 	controllerutil.RemoveFinalizer(mod, constants.ModuleFinalizer)
 
@@ -324,11 +737,28 @@ func (mrh *moduleReconcilerHelper) prepareSchedulingData(ctx context.Context,
 	currentNMCs sets.Set[string]) (map[string]schedulingData, []error) {
 
 	logger := log.FromContext(ctx)
+
+	nmcList := kmmv1beta1.NodeModulesConfigList{}
+	if err := mrh.client.List(ctx, &nmcList); err != nil {
+		return nil, []error{fmt.Errorf("could not list NMCs: %v", err)}
+	}
+
+	nmcByName := make(map[string]*kmmv1beta1.NodeModulesConfig, len(nmcList.Items))
+	for i := range nmcList.Items {
+		nmcByName[nmcList.Items[i].Name] = &nmcList.Items[i]
+	}
+
 	result := make(map[string]schedulingData)
 	errs := make([]error, 0, len(targetedNodes))
 	for _, node := range targetedNodes {
+		if utils.IsNodeExcluded(node.Labels) {
+			// leave the NMC of excluded nodes untouched
+			currentNMCs.Delete(node.Name)
+			logger.Info("Node is excluded from KMM operations; skipping", "node", node.Name)
+			continue
+		}
 		kernelVersion := strings.TrimSuffix(node.Status.NodeInfo.KernelVersion, "+")
-		mld, err := mrh.kernelAPI.GetModuleLoaderDataForKernel(mod, kernelVersion)
+		mld, err := mrh.kernelAPI.GetModuleLoaderDataForNode(mod, &node)
 		if err != nil && !errors.Is(err, module.ErrNoMatchingKernelMapping) {
 			// deleting earlier, so as not to change NMC in case we failed to determine mld
 			currentNMCs.Delete(node.Name)
@@ -338,26 +768,107 @@ func (mrh *moduleReconcilerHelper) prepareSchedulingData(ctx context.Context,
 			errs = append(errs, err)
 			continue
 		}
-		result[node.Name] = prepareNodeSchedulingData(node, mld, currentNMCs)
+		sd := prepareNodeSchedulingData(node, mld, currentNMCs)
+		if sd.action == actionAdd {
+			// refuse to run the Module next to an older Module that manages the same kernel module; if the Module is
+			// already configured on the node, it stays in currentNMCs and is removed below
+			preceding, err := mrh.findPrecedingConflictingModule(ctx, mod, nmcByName[node.Name], mld)
+			if err != nil {
+				currentNMCs.Delete(node.Name)
+				errs = append(errs, err)
+				continue
+			}
+			if preceding != nil {
+				logger.Info(utils.WarnString(
+					fmt.Sprintf("not running the module on node %s: Module %s/%s takes precedence and manages the same kernel module",
+						node.Name, preceding.Namespace, preceding.Name),
+				))
+				continue
+			}
+		}
+		result[node.Name] = sd
 		currentNMCs.Delete(node.Name)
 	}
 	for _, nmcName := range currentNMCs.UnsortedList() {
+		// rely on the NMC status to know if the node is excluded
+		if nmcObj := nmcByName[nmcName]; nmcObj != nil && nmcObj.Status.Excluded {
+			logger.Info("Node is excluded from KMM operations; skipping", "node", nmcName)
+			continue
+		}
 		result[nmcName] = schedulingData{action: actionDelete}
 	}
 	return result, errs
 }
 
+// findPrecedingConflictingModule returns another Module configured in nmcObj that loads or removes one of the kernel
+// modules handled by mld and that takes precedence over mod, or nil if there is none.
+// Entries of Modules that do not exist anymore are ignored.
+func (mrh *moduleReconcilerHelper) findPrecedingConflictingModule(
+	ctx context.Context,
+	mod *kmmv1beta1.Module,
+	nmcObj *kmmv1beta1.NodeModulesConfig,
+	mld *api.ModuleLoaderData,
+) (*kmmv1beta1.Module, error) {
+	if nmcObj == nil {
+		return nil, nil
+	}
+
+	config := conflictModuleConfig(mld)
+
+	for _, entry := range nmcObj.Spec.Modules {
+		if entry.Namespace == mod.Namespace && entry.Name == mod.Name {
+			continue
+		}
+
+		if len(module.ModuleConfigsConflict(&config, &entry.Config)) == 0 {
+			continue
+		}
+
+		other := kmmv1beta1.Module{}
+
+		if err := mrh.client.Get(ctx, types.NamespacedName{Namespace: entry.Namespace, Name: entry.Name}, &other); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("could not get module %s/%s: %v", entry.Namespace, entry.Name, err)
+		}
+
+		if module.TakesPrecedence(&other, mod) {
+			return &other, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// conflictModuleConfig returns the part of the NMC module configuration of mld that determines the kernel modules it
+// loads and removes.
+func conflictModuleConfig(mld *api.ModuleLoaderData) kmmv1beta1.ModuleConfig {
+	return kmmv1beta1.ModuleConfig{
+		InTreeModulesToRemove: mld.InTreeModulesToRemove,
+		Modprobe:              mld.Modprobe,
+	}
+}
+
 func (mrh *moduleReconcilerHelper) handleMIC(ctx context.Context, mod *kmmv1beta1.Module, targetedNodes []v1.Node) error {
 
+	if mod.Spec.Paused {
+		// Keep the existing images, but stop pulling, building and signing them.
+		if err := mrh.micAPI.SetPaused(ctx, mod.Name, mod.Namespace, true); err != nil {
+			return fmt.Errorf("failed to pause %s/%s MIC: %v", mod.Namespace, mod.Name, err)
+		}
+
+		return nil
+	}
+
 	var (
 		logger = log.FromContext(ctx)
 		images []kmmv1beta1.ModuleImageSpec
 		errs   []error
 	)
 
-	for _, node := range targetedNodes {
-		kernelVersion := strings.TrimSuffix(node.Status.NodeInfo.KernelVersion, "+")
-		mld, err := mrh.kernelAPI.GetModuleLoaderDataForKernel(mod, kernelVersion)
+	addImage := func(kernelVersion string, getMLD func() (*api.ModuleLoaderData, error)) {
+		mld, err := getMLD()
 		if err != nil {
 			if !errors.Is(err, module.ErrNoMatchingKernelMapping) {
 				logger.Info(utils.WarnString(
@@ -366,27 +877,106 @@ func (mrh *moduleReconcilerHelper) handleMIC(ctx context.Context, mod *kmmv1beta
 				errs = append(errs, fmt.Errorf("failed to get moduleLoaderData for kernel %s: %v", kernelVersion, err))
 			}
 			// node is not targeted by module
-			continue
+			return
+		}
+		images = append(images, moduleImageSpec(mld))
+	}
+
+	for _, node := range targetedNodes {
+		kernelVersion := strings.TrimSuffix(node.Status.NodeInfo.KernelVersion, "+")
+		addImage(kernelVersion, func() (*api.ModuleLoaderData, error) {
+			return mrh.kernelAPI.GetModuleLoaderDataForNode(mod, &node)
+		})
+
+		upcomingKernels := prebuildKernelsForNode(mod.Spec.PrebuildKernels, &node)
+		if k := mrh.pendingKernel(&node); k != "" {
+			upcomingKernels = append(upcomingKernels, k)
 		}
-		mis := kmmv1beta1.ModuleImageSpec{
-			Image:         mld.ContainerImage,
-			KernelVersion: mld.KernelVersion,
-			Build:         mld.Build,
-			Sign:          mld.Sign,
-			RegistryTLS:   mld.RegistryTLS,
-			DirName:       mld.Modprobe.DirName,
+
+		// Build the images of the upcoming kernels with the node, so that they match its architecture and labels.
+		for _, k := range upcomingKernels {
+			if k == kernelVersion {
+				continue
+			}
+
+			futureNode := node.DeepCopy()
+			futureNode.Status.NodeInfo.KernelVersion = k
+
+			addImage(k, func() (*api.ModuleLoaderData, error) {
+				return mrh.kernelAPI.GetModuleLoaderDataForNode(mod, futureNode)
+			})
+		}
+	}
+
+	// Without any node to take the architecture from, build the listed kernels for the default one.
+	if pk := mod.Spec.PrebuildKernels; pk != nil && len(targetedNodes) == 0 {
+		for _, k := range pk.Versions {
+			addImage(k, func() (*api.ModuleLoaderData, error) {
+				return mrh.kernelAPI.GetModuleLoaderDataForKernel(mod, k)
+			})
 		}
-		images = append(images, mis)
 	}
 
 	if err := mrh.micAPI.CreateOrPatch(ctx, mod.Name, mod.Namespace, images, mod.Spec.ImageRepoSecret,
 		mod.Spec.ModuleLoader.Container.ImagePullPolicy, true, mod.Spec.ImageRebuildTriggerGeneration, mod.Spec.Tolerations, mod); err != nil {
 		errs = append(errs, fmt.Errorf("failed to apply %s/%s MIC: %v", mod.Namespace, mod.Name, err))
+	} else if err = mrh.micAPI.SetPaused(ctx, mod.Name, mod.Namespace, false); err != nil {
+		errs = append(errs, fmt.Errorf("failed to unpause %s/%s MIC: %v", mod.Namespace, mod.Name, err))
 	}
 
 	return errors.Join(errs...)
 }
 
+func moduleImageSpec(mld *api.ModuleLoaderData) kmmv1beta1.ModuleImageSpec {
+	mis := kmmv1beta1.ModuleImageSpec{
+		Image:         mld.ContainerImage,
+		KernelVersion: mld.KernelVersion,
+		Build:         mld.Build,
+		Sign:          mld.Sign,
+		RegistryTLS:   mld.RegistryTLS,
+		DirName:       mld.Modprobe.DirName,
+	}
+	if mld.Architecture != "" {
+		mis.Architectures = []string{mld.Architecture}
+	}
+
+	return mis
+}
+
+// prebuildKernelsForNode returns the kernels of pk that should be built ahead of time for node: the listed ones and
+// those in the node annotation.
+func prebuildKernelsForNode(pk *kmmv1beta1.PrebuildKernelsSpec, node *v1.Node) []string {
+	if pk == nil {
+		return nil
+	}
+
+	kernels := make([]string, 0, len(pk.Versions))
+
+	for _, k := range pk.Versions {
+		kernels = append(kernels, strings.TrimSuffix(k, "+"))
+	}
+
+	if pk.NodeAnnotation != "" {
+		for _, k := range strings.Split(node.Annotations[pk.NodeAnnotation], ",") {
+			if k = strings.TrimSuffix(strings.TrimSpace(k), "+"); k != "" {
+				kernels = append(kernels, k)
+			}
+		}
+	}
+
+	return kernels
+}
+
+// pendingKernel returns the kernel that node will boot next, as published in the node annotation configured in the
+// operator, or an empty string if there is none.
+func (mrh *moduleReconcilerHelper) pendingKernel(node *v1.Node) string {
+	if mrh.nodeCfg == nil || mrh.nodeCfg.PendingKernelAnnotation == "" {
+		return ""
+	}
+
+	return strings.TrimSuffix(strings.TrimSpace(node.Annotations[mrh.nodeCfg.PendingKernelAnnotation]), "+")
+}
+
 func (mrh *moduleReconcilerHelper) enableModuleOnNode(ctx context.Context, mld *api.ModuleLoaderData, node *v1.Node) error {
 
 	logger := log.FromContext(ctx)
@@ -411,6 +1001,18 @@ func (mrh *moduleReconcilerHelper) enableModuleOnNode(ctx context.Context, mld *
 		Modprobe:              mld.Modprobe,
 	}
 
+	moduleConfig.Modprobe.Parameters = module.ResolveModprobeParameters(mld.Modprobe.Parameters, mld.ParameterOverrides, node.Labels)
+
+	valueSources := make([]kmmv1beta1.ValueSource, 0, len(mld.Modprobe.ParametersFrom))
+	for _, p := range mld.Modprobe.ParametersFrom {
+		valueSources = append(valueSources, p.ValueFrom)
+	}
+
+	moduleConfig.ValueSourcesDigest, err = module.ValueSourcesDigest(ctx, mrh.client, valueSources, mld.Namespace)
+	if err != nil {
+		return fmt.Errorf("could not read the values of the modprobe parameters of module %s/%s: %v", mld.Namespace, mld.Name, err)
+	}
+
 	if tls := mld.RegistryTLS; tls != nil {
 		moduleConfig.InsecurePull = tls.Insecure || tls.InsecureSkipTLSVerify
 	}
@@ -478,6 +1080,24 @@ func (mrh *moduleReconcilerHelper) updateModuleStatus(ctx context.Context, mod *
 		errs = append(errs, fmt.Errorf("failed to update ImageRebuildTriggerGeneration status for module %s/%s: %v", mod.Namespace, mod.Name, err))
 	}
 
+	if err := mrh.updateConflictCondition(ctx, mod, targetedNodes); err != nil {
+		errs = append(errs, fmt.Errorf("failed to update the conflict condition for module %s/%s: %v", mod.Namespace, mod.Name, err))
+	}
+
+	mrh.updatePausedCondition(mod)
+
+	if err := mrh.updatePendingKernelCondition(ctx, mod, targetedNodes); err != nil {
+		errs = append(errs, fmt.Errorf("failed to update the pending kernel condition for module %s/%s: %v", mod.Namespace, mod.Name, err))
+	}
+
+	if err := mrh.updateMaintenanceWindowStatus(mod); err != nil {
+		errs = append(errs, fmt.Errorf("failed to update the maintenance window status for module %s/%s: %v", mod.Namespace, mod.Name, err))
+	}
+
+	if mod.Spec.Canary == nil {
+		mod.Status.Canary = nil
+	}
+
 	if err := mrh.client.Status().Patch(ctx, mod, client.MergeFrom(unmodifiedMod)); err != nil {
 		errs = append(errs, fmt.Errorf("failed to patch module status for module %s/%s: %v", mod.Namespace, mod.Name, err))
 	}
@@ -485,6 +1105,250 @@ func (mrh *moduleReconcilerHelper) updateModuleStatus(ctx context.Context, mod *
 	return errors.Join(errs...)
 }
 
+func (mrh *moduleReconcilerHelper) updatePausedCondition(mod *kmmv1beta1.Module) {
+	cond := metav1.Condition{
+		Type:               kmmv1beta1.ModuleConditionPaused,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NotPaused",
+		ObservedGeneration: mod.Generation,
+	}
+
+	if mod.Spec.Paused {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "PausedBySpec"
+		cond.Message = "spec.paused is set; KMM does not make changes for this Module"
+
+		if mod.GetDeletionTimestamp() != nil {
+			cond.Reason = "DeletionPaused"
+			cond.Message = "spec.paused is set; the Module is only removed from the nodes once it is unpaused"
+		}
+	}
+
+	apimeta.SetStatusCondition(&mod.Status.Conditions, cond)
+}
+
+// updatePendingKernelCondition sets the PendingKernelImageMissing condition on the Module if the image of the pending
+// kernel of at least one of the targeted nodes does not exist yet.
+// The condition is removed if no pending kernel annotation is configured.
+func (mrh *moduleReconcilerHelper) updatePendingKernelCondition(ctx context.Context, mod *kmmv1beta1.Module, targetedNodes []v1.Node) error {
+	if mrh.nodeCfg == nil || mrh.nodeCfg.PendingKernelAnnotation == "" {
+		apimeta.RemoveStatusCondition(&mod.Status.Conditions, kmmv1beta1.ModuleConditionPendingKernelImageMissing)
+		return nil
+	}
+
+	var (
+		micObj *kmmv1beta1.ModuleImagesConfig
+		nodes  = make([]string, 0)
+	)
+
+	for _, n := range targetedNodes {
+		kernelVersion := mrh.pendingKernel(&n)
+		if kernelVersion == "" || kernelVersion == strings.TrimSuffix(n.Status.NodeInfo.KernelVersion, "+") {
+			continue
+		}
+
+		futureNode := n.DeepCopy()
+		futureNode.Status.NodeInfo.KernelVersion = kernelVersion
+
+		mld, err := mrh.kernelAPI.GetModuleLoaderDataForNode(mod, futureNode)
+		if err != nil {
+			if !errors.Is(err, module.ErrNoMatchingKernelMapping) {
+				return fmt.Errorf("failed to get moduleLoaderData for kernel %s: %v", kernelVersion, err)
+			}
+
+			nodes = append(nodes, fmt.Sprintf("%s (%s, no kernel mapping)", n.Name, kernelVersion))
+			continue
+		}
+
+		if micObj == nil {
+			if micObj, err = mrh.micAPI.Get(ctx, mod.Name, mod.Namespace); err != nil {
+				return err
+			}
+		}
+
+		if mrh.micAPI.GetImageState(micObj, mld.ContainerImage) != kmmv1beta1.ImageExists {
+			nodes = append(nodes, fmt.Sprintf("%s (%s)", n.Name, kernelVersion))
+		}
+	}
+
+	cond := metav1.Condition{
+		Type:               kmmv1beta1.ModuleConditionPendingKernelImageMissing,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NoMissingImage",
+		ObservedGeneration: mod.Generation,
+	}
+
+	if len(nodes) > 0 {
+		sort.Strings(nodes)
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "ImageMissing"
+		cond.Message = "No image for the pending kernel of node(s): " + strings.Join(nodes, ", ")
+	}
+
+	apimeta.SetStatusCondition(&mod.Status.Conditions, cond)
+
+	return nil
+}
+
+func (mrh *moduleReconcilerHelper) updateMaintenanceWindowStatus(mod *kmmv1beta1.Module) error {
+	if mod.Spec.MaintenanceWindow == nil {
+		mod.Status.MaintenanceWindow = nil
+		return nil
+	}
+
+	status, err := module.GetMaintenanceWindowStatus(mod.Spec.MaintenanceWindow, time.Now())
+	if err != nil {
+		return err
+	}
+
+	mod.Status.MaintenanceWindow = status
+
+	return nil
+}
+
+// moduleLoaderHash returns a hash identifying the ModuleLoader configuration of the Module.
+func moduleLoaderHash(mod *kmmv1beta1.Module) (string, error) {
+	hashValue, err := hashstructure.Hash(mod.Spec.ModuleLoader, hashstructure.FormatV2, nil)
+	if err != nil {
+		return "", fmt.Errorf("could not hash the ModuleLoader: %v", err)
+	}
+
+	return strconv.FormatUint(hashValue, 16), nil
+}
+
+// evaluateCanary moves the canary stage of the Module forward and persists it in the Module's status.
+// A new rollout starts every time the ModuleLoader configuration changes; it is promoted to all nodes once the kernel
+// module was applied on all canary nodes and stayed healthy there for the soak duration.
+func (mrh *moduleReconcilerHelper) evaluateCanary(ctx context.Context, mod *kmmv1beta1.Module, targetedNodes []v1.Node) (*canaryResult, error) {
+	logger := log.FromContext(ctx)
+
+	hash, err := moduleLoaderHash(mod)
+	if err != nil {
+		return nil, err
+	}
+
+	unmodifiedMod := mod.DeepCopy()
+	res := &canaryResult{}
+
+	switch st := mod.Status.Canary; {
+	case st == nil || st.PromotedHash == hash:
+		// Nothing was rolled out under the canary stage yet, or the current configuration was already promoted.
+		mod.Status.Canary = &kmmv1beta1.CanaryStatus{
+			Phase:        kmmv1beta1.CanaryPhaseSucceeded,
+			TargetHash:   hash,
+			PromotedHash: hash,
+		}
+		res.promoted = true
+	case st.TargetHash != hash:
+		logger.Info("Starting the canary stage for a new ModuleLoader configuration", "hash", hash)
+
+		mod.Status.Canary = &kmmv1beta1.CanaryStatus{
+			Phase:        kmmv1beta1.CanaryPhaseProgressing,
+			TargetHash:   hash,
+			PromotedHash: st.PromotedHash,
+		}
+	case st.Phase == kmmv1beta1.CanaryPhaseFailed:
+		// The rollout only restarts once the ModuleLoader configuration changes again.
+	default:
+		if res.promoted, res.requeueAfter, err = mrh.checkCanaryNodes(ctx, mod, targetedNodes); err != nil {
+			return nil, err
+		}
+	}
+
+	if !reflect.DeepEqual(unmodifiedMod.Status.Canary, mod.Status.Canary) {
+		if err = mrh.client.Status().Patch(ctx, mod, client.MergeFrom(unmodifiedMod)); err != nil {
+			return nil, fmt.Errorf("could not patch the canary status: %v", err)
+		}
+	}
+
+	return res, nil
+}
+
+// checkCanaryNodes updates mod.Status.Canary with the state of the kernel module on the canary nodes.
+func (mrh *moduleReconcilerHelper) checkCanaryNodes(ctx context.Context, mod *kmmv1beta1.Module, targetedNodes []v1.Node) (bool, time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	nmcs, err := mrh.getNMCsForModule(ctx, mod)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to get configured NMCs for module %s/%s: %v", mod.Namespace, mod.Name, err)
+	}
+
+	nmcsByName := make(map[string]*kmmv1beta1.NodeModulesConfig, len(nmcs))
+
+	for i := range nmcs {
+		nmcsByName[nmcs[i].Name] = &nmcs[i]
+	}
+
+	selector := labels.SelectorFromSet(mod.Spec.Canary.Selector)
+	converged := true
+	unhealthy := int32(0)
+
+	for _, node := range targetedNodes {
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+
+		nmcObj := nmcsByName[node.Name]
+		if nmcObj == nil {
+			converged = false
+			continue
+		}
+
+		modSpec, _ := mrh.nmcHelper.GetModuleSpecEntry(nmcObj, mod.Namespace, mod.Name)
+		modStatus := mrh.nmcHelper.GetModuleStatusEntry(nmcObj, mod.Namespace, mod.Name)
+
+		if modSpec == nil || modStatus == nil || !reflect.DeepEqual(modSpec.Config, modStatus.Config) {
+			converged = false
+			continue
+		}
+
+		if apimeta.IsStatusConditionFalse(modStatus.Conditions, kmmv1beta1.NodeModuleConditionReady) ||
+			apimeta.IsStatusConditionTrue(modStatus.Conditions, kmmv1beta1.NodeModuleConditionDrifted) {
+			unhealthy++
+			continue
+		}
+
+		if modSpec.ReadinessCheck != nil && !apimeta.IsStatusConditionTrue(modStatus.Conditions, kmmv1beta1.NodeModuleConditionReady) {
+			converged = false
+		}
+	}
+
+	st := mod.Status.Canary
+	st.UnhealthyNodes = unhealthy
+
+	if unhealthy > mod.Spec.Canary.FailureThreshold {
+		logger.Info("Kernel module is unhealthy on too many canary nodes; stopping the rollout", "unhealthy", unhealthy)
+		st.Phase = kmmv1beta1.CanaryPhaseFailed
+		st.SoakStartTime = nil
+		return false, 0, nil
+	}
+
+	if !converged {
+		st.Phase = kmmv1beta1.CanaryPhaseProgressing
+		st.SoakStartTime = nil
+		return false, 0, nil
+	}
+
+	now := time.Now()
+
+	if st.SoakStartTime == nil {
+		st.Phase = kmmv1beta1.CanaryPhaseSoaking
+		st.SoakStartTime = &metav1.Time{Time: now}
+	}
+
+	if remaining := mod.Spec.Canary.SoakDuration.Duration - now.Sub(st.SoakStartTime.Time); remaining > 0 {
+		return false, remaining, nil
+	}
+
+	logger.Info("Canary stage succeeded; rolling out to all nodes", "hash", st.TargetHash)
+
+	st.Phase = kmmv1beta1.CanaryPhaseSucceeded
+	st.PromotedHash = st.TargetHash
+	st.SoakStartTime = nil
+
+	return true, 0, nil
+}
+
 func (mrh *moduleReconcilerHelper) updateModuleLoaderStatus(ctx context.Context, mod *kmmv1beta1.Module, targetedNodes []v1.Node) error {
 	logger := log.FromContext(ctx)
 	// get nmcs with configured
@@ -494,6 +1358,7 @@ func (mrh *moduleReconcilerHelper) updateModuleLoaderStatus(ctx context.Context,
 	}
 
 	numAvailable := 0
+	driftedNodes := make([]string, 0)
 	for _, nmc := range nmcs {
 		modSpec, _ := mrh.nmcHelper.GetModuleSpecEntry(&nmc, mod.Namespace, mod.Name)
 		if modSpec == nil {
@@ -504,11 +1369,32 @@ func (mrh *moduleReconcilerHelper) updateModuleLoaderStatus(ctx context.Context,
 			continue
 		}
 		modStatus := mrh.nmcHelper.GetModuleStatusEntry(&nmc, mod.Namespace, mod.Name)
-		if modStatus != nil && reflect.DeepEqual(modSpec.Config, modStatus.Config) {
+		if modStatus != nil && reflect.DeepEqual(modSpec.Config, modStatus.Config) &&
+			(modSpec.ReadinessCheck == nil || apimeta.IsStatusConditionTrue(modStatus.Conditions, kmmv1beta1.NodeModuleConditionReady)) {
 			numAvailable += 1
 		}
+		if modStatus != nil && apimeta.IsStatusConditionTrue(modStatus.Conditions, kmmv1beta1.NodeModuleConditionDrifted) {
+			driftedNodes = append(driftedNodes, nmc.Name)
+		}
+	}
+
+	cond := metav1.Condition{
+		Type:               kmmv1beta1.ModuleConditionDrifted,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NotDrifted",
+		ObservedGeneration: mod.Generation,
+	}
+
+	if len(driftedNodes) > 0 {
+		sort.Strings(driftedNodes)
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "KernelModuleDrifted"
+		cond.Message = fmt.Sprintf("Kernel module not loaded anymore on %d node(s): %s",
+			len(driftedNodes), strings.Join(driftedNodes, ", "))
 	}
 
+	apimeta.SetStatusCondition(&mod.Status.Conditions, cond)
+
 	mod.Status.ModuleLoader.NodesMatchingSelectorNumber = int32(len(targetedNodes))
 	mod.Status.ModuleLoader.DesiredNumber = int32(len(nmcs))
 	mod.Status.ModuleLoader.AvailableNumber = int32(numAvailable)
@@ -532,6 +1418,120 @@ func (mrh *moduleReconcilerHelper) updateImageRebuildTriggerGenerationStatus(ctx
 	return nil
 }
 
+// updateConflictCondition sets the Conflict condition on the Module if any other Module loads or removes one of
+// its kernel modules on at least one of the targeted nodes.
+// Nodes are targeted by the other Module with the same rules as in prepareSchedulingData, and the kernel modules of
+// both Modules are resolved for the kernel mapping that applies to each node.
+// The check is symmetric, so that both conflicting Modules report the condition and agree on which of them takes
+// precedence.
+func (mrh *moduleReconcilerHelper) updateConflictCondition(ctx context.Context, mod *kmmv1beta1.Module, targetedNodes []v1.Node) error {
+	modList := kmmv1beta1.ModuleList{}
+
+	if err := mrh.client.List(ctx, &modList); err != nil {
+		return fmt.Errorf("could not list modules: %v", err)
+	}
+
+	messages := make([]string, 0)
+
+	for _, other := range modList.Items {
+		if other.Namespace == mod.Namespace && other.Name == mod.Name {
+			continue
+		}
+
+		// Cheap check across all kernel mappings, before resolving the mappings of each node.
+		if len(module.ModulesConflict(mod, &other)) == 0 {
+			continue
+		}
+
+		kernelModules, numNodes, err := mrh.conflictsOnNodes(mod, &other, targetedNodes)
+		if err != nil {
+			return err
+		}
+
+		if numNodes > 0 {
+			precedence := "this Module takes precedence"
+			if module.TakesPrecedence(&other, mod) {
+				precedence = "it takes precedence"
+			}
+
+			messages = append(
+				messages,
+				fmt.Sprintf("Module %s/%s also manages kernel module(s) %s on %d node(s); %s",
+					other.Namespace, other.Name, strings.Join(kernelModules, ","), numNodes, precedence),
+			)
+		}
+	}
+
+	cond := metav1.Condition{
+		Type:               kmmv1beta1.ModuleConditionConflict,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NoConflict",
+		ObservedGeneration: mod.Generation,
+	}
+
+	if len(messages) > 0 {
+		sort.Strings(messages)
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "KernelModuleConflict"
+		cond.Message = strings.Join(messages, "; ")
+	}
+
+	apimeta.SetStatusCondition(&mod.Status.Conditions, cond)
+
+	return nil
+}
+
+// conflictsOnNodes returns the kernel modules on which mod and other conflict on targetedNodes, and the number of
+// nodes on which they conflict.
+func (mrh *moduleReconcilerHelper) conflictsOnNodes(mod, other *kmmv1beta1.Module, targetedNodes []v1.Node) ([]string, int, error) {
+	kernelModules := sets.New[string]()
+	numNodes := 0
+
+	otherTolerations := append(other.Spec.Tolerations, module.InternalTolerations...)
+
+	for i := range targetedNodes {
+		n := &targetedNodes[i]
+
+		if utils.IsNodeExcluded(n.Labels) {
+			continue
+		}
+
+		selected, err := utils.IsObjectSelectedByLabels(n.GetLabels(), other.Spec.Selector)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not determine if node %s is selected by module %s/%s: %v", n.Name, other.Namespace, other.Name, err)
+		}
+		if !selected || !mrh.nodeAPI.IsNodeSchedulable(n, otherTolerations) {
+			continue
+		}
+
+		modMLD, err := mrh.kernelAPI.GetModuleLoaderDataForNode(mod, n)
+		if err != nil {
+			if errors.Is(err, module.ErrNoMatchingKernelMapping) {
+				continue
+			}
+			return nil, 0, fmt.Errorf("could not get the kernel mapping of module %s/%s for node %s: %v", mod.Namespace, mod.Name, n.Name, err)
+		}
+
+		otherMLD, err := mrh.kernelAPI.GetModuleLoaderDataForNode(other, n)
+		if err != nil {
+			if errors.Is(err, module.ErrNoMatchingKernelMapping) {
+				continue
+			}
+			return nil, 0, fmt.Errorf("could not get the kernel mapping of module %s/%s for node %s: %v", other.Namespace, other.Name, n.Name, err)
+		}
+
+		modConfig := conflictModuleConfig(modMLD)
+		otherConfig := conflictModuleConfig(otherMLD)
+
+		if conflicts := module.ModuleConfigsConflict(&modConfig, &otherConfig); len(conflicts) > 0 {
+			kernelModules.Insert(conflicts...)
+			numNodes++
+		}
+	}
+
+	return sets.List(kernelModules), numNodes, nil
+}
+
 type namespaceLabeler interface {
 	setLabel(ctx context.Context, name string) error
 	tryRemovingLabel(ctx context.Context, name, moduleName string) error
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kubernetes-sigs/kernel-module-management/internal/mic"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/node"
@@ -11,6 +12,7 @@ import (
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/config"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/meta"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
@@ -21,10 +23,12 @@ import (
 	"go.uber.org/mock/gomock"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -91,8 +95,10 @@ var _ = Describe("ModuleReconciler_Reconcile", func() {
 
 	type errorFlowTestCase struct {
 		setFinalizerAndStatusError bool
+		resolveValueSourcesError   bool
 		getNodesError              bool
 		handleMICError             bool
+		syncNMCsPausedError        bool
 		getNMCsMapError            bool
 		prepareSchedulingError     bool
 		shouldBeOnNode             bool
@@ -118,6 +124,11 @@ var _ = Describe("ModuleReconciler_Reconcile", func() {
 			goto executeTestFunction
 		}
 		mockReconHelper.EXPECT().setFinalizerAndStatus(ctx, mod).Return(nil)
+		if c.resolveValueSourcesError {
+			mockReconHelper.EXPECT().resolveValueSources(ctx, mod).Return(returnedError)
+			goto executeTestFunction
+		}
+		mockReconHelper.EXPECT().resolveValueSources(ctx, mod).Return(nil)
 		if c.getNodesError {
 			mn.EXPECT().GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).Return(nil, returnedError)
 			goto executeTestFunction
@@ -129,6 +140,11 @@ var _ = Describe("ModuleReconciler_Reconcile", func() {
 		} else {
 			mockReconHelper.EXPECT().handleMIC(ctx, mod, targetedNodes).Return(nil)
 		}
+		if c.syncNMCsPausedError {
+			mockReconHelper.EXPECT().syncNMCsPaused(ctx, mod).Return(returnedError)
+			goto executeTestFunction
+		}
+		mockReconHelper.EXPECT().syncNMCsPaused(ctx, mod).Return(nil)
 		if c.getNMCsMapError {
 			mockReconHelper.EXPECT().getNMCsByModuleSet(ctx, mod).Return(nil, returnedError)
 			goto executeTestFunction
@@ -167,8 +183,10 @@ var _ = Describe("ModuleReconciler_Reconcile", func() {
 		Expect(err).To(HaveOccurred())
 	},
 		Entry("setFinalizerAndStatus failed", errorFlowTestCase{setFinalizerAndStatusError: true}),
+		Entry("resolveValueSources failed", errorFlowTestCase{resolveValueSourcesError: true}),
 		Entry("getNodesListBySelector failed", errorFlowTestCase{getNodesError: true}),
 		Entry("handleMIC failed", errorFlowTestCase{handleMICError: true}),
+		Entry("syncNMCsPaused failed", errorFlowTestCase{syncNMCsPausedError: true}),
 		Entry("getNMCsByModuleMap failed", errorFlowTestCase{getNMCsMapError: true}),
 		Entry("prepareSchedulingData failed", errorFlowTestCase{prepareSchedulingError: true}),
 		Entry("enableModuleOnNode failed", errorFlowTestCase{shouldBeOnNode: true, disableEnableError: true}),
@@ -182,8 +200,10 @@ var _ = Describe("ModuleReconciler_Reconcile", func() {
 		gomock.InOrder(
 			mockNamespaceHelper.EXPECT().setLabel(ctx, mod.Namespace),
 			mockReconHelper.EXPECT().setFinalizerAndStatus(ctx, mod).Return(nil),
+			mockReconHelper.EXPECT().resolveValueSources(ctx, mod).Return(nil),
 			mn.EXPECT().GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).Return(targetedNodes, nil),
 			mockReconHelper.EXPECT().handleMIC(ctx, mod, targetedNodes).Return(nil),
+			mockReconHelper.EXPECT().syncNMCsPaused(ctx, mod).Return(nil),
 			mockReconHelper.EXPECT().getNMCsByModuleSet(ctx, mod).Return(currentNMCs, nil),
 			mockReconHelper.EXPECT().prepareSchedulingData(ctx, mod, targetedNodes, currentNMCs).Return(nmcMLDConfigs, nil),
 			mockReconHelper.EXPECT().enableModuleOnNode(ctx, &mld, &node).Return(nil),
@@ -201,8 +221,10 @@ var _ = Describe("ModuleReconciler_Reconcile", func() {
 		gomock.InOrder(
 			mockNamespaceHelper.EXPECT().setLabel(ctx, mod.Namespace),
 			mockReconHelper.EXPECT().setFinalizerAndStatus(ctx, mod).Return(nil),
+			mockReconHelper.EXPECT().resolveValueSources(ctx, mod).Return(nil),
 			mn.EXPECT().GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).Return(targetedNodes, nil),
 			mockReconHelper.EXPECT().handleMIC(ctx, mod, targetedNodes).Return(nil),
+			mockReconHelper.EXPECT().syncNMCsPaused(ctx, mod).Return(nil),
 			mockReconHelper.EXPECT().getNMCsByModuleSet(ctx, mod).Return(currentNMCs, nil),
 			mockReconHelper.EXPECT().prepareSchedulingData(ctx, mod, targetedNodes, currentNMCs).Return(nmcMLDConfigs, nil),
 			mockReconHelper.EXPECT().disableModuleOnNode(ctx, mod.Namespace, mod.Name, node.Name).Return(nil),
@@ -228,6 +250,174 @@ var _ = Describe("ModuleReconciler_Reconcile", func() {
 		Expect(res).To(Equal(reconcile.Result{}))
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	It("should only update the status if the Module is paused", func() {
+		mod.Spec.Paused = true
+
+		gomock.InOrder(
+			mockNamespaceHelper.EXPECT().setLabel(ctx, mod.Namespace),
+			mockReconHelper.EXPECT().setFinalizerAndStatus(ctx, mod).Return(nil),
+			mockReconHelper.EXPECT().resolveValueSources(ctx, mod).Return(nil),
+			mn.EXPECT().GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).Return(targetedNodes, nil),
+			mockReconHelper.EXPECT().handleMIC(ctx, mod, targetedNodes).Return(nil),
+			mockReconHelper.EXPECT().syncNMCsPaused(ctx, mod).Return(nil),
+			mockReconHelper.EXPECT().updateModuleStatus(ctx, mod, targetedNodes).Return(nil),
+		)
+
+		res, err := mr.Reconcile(ctx, mod)
+
+		Expect(res).To(Equal(reconcile.Result{}))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("gating changes on configured nodes", func() {
+		const otherNodeName = "otherNodeName"
+
+		otherNode := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: otherNodeName},
+		}
+
+		// Opens on Feb 29 only, for a minute.
+		closedWindow := &kmmv1beta1.MaintenanceWindow{
+			Schedule: "0 0 29 2 *",
+			Duration: metav1.Duration{Duration: time.Minute},
+		}
+
+		// Opens every minute, for two minutes.
+		openWindow := &kmmv1beta1.MaintenanceWindow{
+			Schedule: "* * * * *",
+			Duration: metav1.Duration{Duration: 2 * time.Minute},
+		}
+
+		It("should only enable the module on unconfigured nodes while the window is closed", func() {
+			mod.Spec.MaintenanceWindow = closedWindow
+
+			nodes := []v1.Node{node, otherNode}
+			nmcMLDConfigs := map[string]schedulingData{
+				nodeName:      enableSchedulingData,
+				otherNodeName: {action: actionAdd, mld: &mld, node: &otherNode},
+				"deletedNode": disableSchedulingData,
+			}
+
+			gomock.InOrder(
+				mockNamespaceHelper.EXPECT().setLabel(ctx, mod.Namespace),
+				mockReconHelper.EXPECT().setFinalizerAndStatus(ctx, mod).Return(nil),
+				mockReconHelper.EXPECT().resolveValueSources(ctx, mod).Return(nil),
+				mn.EXPECT().GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).Return(nodes, nil),
+				mockReconHelper.EXPECT().handleMIC(ctx, mod, nodes).Return(nil),
+				mockReconHelper.EXPECT().syncNMCsPaused(ctx, mod).Return(nil),
+				mockReconHelper.EXPECT().getNMCsByModuleSet(ctx, mod).Return(sets.New(nodeName, "deletedNode"), nil),
+				mockReconHelper.EXPECT().prepareSchedulingData(ctx, mod, nodes, gomock.Any()).Return(nmcMLDConfigs, nil),
+				mockReconHelper.EXPECT().enableModuleOnNode(ctx, &mld, &otherNode).Return(nil),
+				mockReconHelper.EXPECT().updateModuleStatus(ctx, mod, nodes).Return(nil),
+			)
+
+			res, err := mr.Reconcile(ctx, mod)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+		})
+
+		It("should apply all changes while the window is open", func() {
+			mod.Spec.MaintenanceWindow = openWindow
+
+			nmcMLDConfigs := map[string]schedulingData{
+				nodeName:      enableSchedulingData,
+				"deletedNode": disableSchedulingData,
+			}
+
+			mockNamespaceHelper.EXPECT().setLabel(ctx, mod.Namespace)
+			mockReconHelper.EXPECT().setFinalizerAndStatus(ctx, mod).Return(nil)
+			mockReconHelper.EXPECT().resolveValueSources(ctx, mod).Return(nil)
+			mn.EXPECT().GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).Return(targetedNodes, nil)
+			mockReconHelper.EXPECT().handleMIC(ctx, mod, targetedNodes).Return(nil)
+			mockReconHelper.EXPECT().syncNMCsPaused(ctx, mod).Return(nil)
+			mockReconHelper.EXPECT().getNMCsByModuleSet(ctx, mod).Return(sets.New(nodeName, "deletedNode"), nil)
+			mockReconHelper.EXPECT().prepareSchedulingData(ctx, mod, targetedNodes, gomock.Any()).Return(nmcMLDConfigs, nil)
+			mockReconHelper.EXPECT().enableModuleOnNode(ctx, &mld, &node).Return(nil)
+			mockReconHelper.EXPECT().disableModuleOnNode(ctx, mod.Namespace, mod.Name, "deletedNode").Return(nil)
+			mockReconHelper.EXPECT().updateModuleStatus(ctx, mod, targetedNodes).Return(nil)
+
+			res, err := mr.Reconcile(ctx, mod)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(res.RequeueAfter).To(BeNumerically("<=", 2*time.Minute))
+		})
+
+		It("should hold changes on non-canary nodes until the canary stage succeeds", func() {
+			canaryNode := v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "canaryNode", Labels: map[string]string{"canary": "true"}},
+			}
+
+			mod.Spec.Canary = &kmmv1beta1.CanarySpec{Selector: map[string]string{"canary": "true"}}
+			mod.Status.Canary = &kmmv1beta1.CanaryStatus{Phase: kmmv1beta1.CanaryPhaseProgressing}
+
+			nodes := []v1.Node{node, otherNode, canaryNode}
+			nmcMLDConfigs := map[string]schedulingData{
+				nodeName:        enableSchedulingData,
+				otherNodeName:   {action: actionAdd, mld: &mld, node: &otherNode},
+				canaryNode.Name: {action: actionAdd, mld: &mld, node: &canaryNode},
+				"deletedNode":   disableSchedulingData,
+			}
+
+			mockNamespaceHelper.EXPECT().setLabel(ctx, mod.Namespace)
+			mockReconHelper.EXPECT().setFinalizerAndStatus(ctx, mod).Return(nil)
+			mockReconHelper.EXPECT().resolveValueSources(ctx, mod).Return(nil)
+			mn.EXPECT().GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).Return(nodes, nil)
+			mockReconHelper.EXPECT().handleMIC(ctx, mod, nodes).Return(nil)
+			mockReconHelper.EXPECT().syncNMCsPaused(ctx, mod).Return(nil)
+			mockReconHelper.EXPECT().getNMCsByModuleSet(ctx, mod).Return(sets.New(nodeName, canaryNode.Name, "deletedNode"), nil)
+			mockReconHelper.EXPECT().evaluateCanary(ctx, mod, nodes).Return(&canaryResult{requeueAfter: time.Minute}, nil)
+			mockReconHelper.EXPECT().prepareSchedulingData(ctx, mod, nodes, gomock.Any()).Return(nmcMLDConfigs, nil)
+			mockReconHelper.EXPECT().enableModuleOnNode(ctx, &mld, &otherNode).Return(nil)
+			mockReconHelper.EXPECT().enableModuleOnNode(ctx, &mld, &canaryNode).Return(nil)
+			mockReconHelper.EXPECT().disableModuleOnNode(ctx, mod.Namespace, mod.Name, "deletedNode").Return(nil)
+			mockReconHelper.EXPECT().updateModuleStatus(ctx, mod, nodes).Return(nil)
+
+			res, err := mr.Reconcile(ctx, mod)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(Equal(time.Minute))
+		})
+
+		It("should return an error if the canary stage could not be evaluated", func() {
+			mod.Spec.Canary = &kmmv1beta1.CanarySpec{Selector: map[string]string{"canary": "true"}}
+
+			gomock.InOrder(
+				mockNamespaceHelper.EXPECT().setLabel(ctx, mod.Namespace),
+				mockReconHelper.EXPECT().setFinalizerAndStatus(ctx, mod).Return(nil),
+				mockReconHelper.EXPECT().resolveValueSources(ctx, mod).Return(nil),
+				mn.EXPECT().GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).Return(targetedNodes, nil),
+				mockReconHelper.EXPECT().handleMIC(ctx, mod, targetedNodes).Return(nil),
+				mockReconHelper.EXPECT().syncNMCsPaused(ctx, mod).Return(nil),
+				mockReconHelper.EXPECT().getNMCsByModuleSet(ctx, mod).Return(currentNMCs, nil),
+				mockReconHelper.EXPECT().evaluateCanary(ctx, mod, targetedNodes).Return(nil, errors.New("some error")),
+			)
+
+			_, err := mr.Reconcile(ctx, mod)
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error if the window is invalid", func() {
+			mod.Spec.MaintenanceWindow = &kmmv1beta1.MaintenanceWindow{Schedule: "invalid"}
+
+			gomock.InOrder(
+				mockNamespaceHelper.EXPECT().setLabel(ctx, mod.Namespace),
+				mockReconHelper.EXPECT().setFinalizerAndStatus(ctx, mod).Return(nil),
+				mockReconHelper.EXPECT().resolveValueSources(ctx, mod).Return(nil),
+				mn.EXPECT().GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).Return(targetedNodes, nil),
+				mockReconHelper.EXPECT().handleMIC(ctx, mod, targetedNodes).Return(nil),
+				mockReconHelper.EXPECT().syncNMCsPaused(ctx, mod).Return(nil),
+				mockReconHelper.EXPECT().getNMCsByModuleSet(ctx, mod).Return(currentNMCs, nil),
+			)
+
+			_, err := mr.Reconcile(ctx, mod)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
 
 var _ = Describe("setFinalizerAndStatus", func() {
@@ -244,7 +434,7 @@ var _ = Describe("setFinalizerAndStatus", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
 		statusWriter = client.NewMockStatusWriter(ctrl)
-		mrh = newModuleReconcilerHelper(clnt, nil, nil, nil, scheme)
+		mrh = newModuleReconcilerHelper(clnt, nil, nil, nil, nil, &config.Node{}, scheme)
 		mod = kmmv1beta1.Module{}
 		expectedMod = mod.DeepCopy()
 	})
@@ -307,12 +497,35 @@ var _ = Describe("finalizeModule", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
 		helper = nmc.NewMockHelper(ctrl)
-		mrh = newModuleReconcilerHelper(clnt, nil, nil, helper, scheme)
+		mrh = newModuleReconcilerHelper(clnt, nil, nil, helper, nil, &config.Node{}, scheme)
 		mod = &kmmv1beta1.Module{
 			ObjectMeta: metav1.ObjectMeta{Name: moduleName, Namespace: moduleNamespace},
 		}
 	})
 
+	It("should not change NMCs while the Module is paused", func() {
+		mod.Spec.Paused = true
+		mod.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+
+		statusWriter := client.NewMockStatusWriter(ctrl)
+
+		gomock.InOrder(
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, mod, gomock.Any()),
+		)
+
+		Expect(
+			mrh.finalizeModule(ctx, mod),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		cond := apimeta.FindStatusCondition(mod.Status.Conditions, kmmv1beta1.ModuleConditionPaused)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("DeletionPaused"))
+	})
+
 	It("failed to get list of NMCs", func() {
 		clnt.
 			EXPECT().
@@ -405,6 +618,143 @@ var _ = Describe("finalizeModule", func() {
 
 		Expect(err).To(HaveOccurred())
 	})
+
+	Context("Orphan deletion policy", func() {
+		BeforeEach(func() {
+			mod.Spec.DeletionPolicy = kmmv1beta1.DeletionPolicyOrphan
+		})
+
+		It("should return an error if the NMCs could not be listed", func() {
+			clnt.
+				EXPECT().
+				List(ctx, &kmmv1beta1.NodeModulesConfigList{}, ctrlclient.MatchingLabels(matchConfiguredModules)).
+				Return(fmt.Errorf("some error"))
+
+			Expect(
+				mrh.finalizeModule(ctx, mod),
+			).To(
+				HaveOccurred(),
+			)
+		})
+
+		getModuleSpecEntry := func(n *kmmv1beta1.NodeModulesConfig, namespace, name string) (*kmmv1beta1.NodeModuleSpec, int) {
+			return nmc.NewHelper(nil).GetModuleSpecEntry(n, namespace, name)
+		}
+
+		It("should mark the module as orphaned in the NMCs in a single update", func() {
+			nmcObj := kmmv1beta1.NodeModulesConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "nmc1",
+					Labels: map[string]string{
+						nmc.ModuleConfiguredLabel(moduleNamespace, moduleName): "",
+						nmc.ModuleInUseLabel(moduleNamespace, moduleName):      "",
+					},
+				},
+				Spec: kmmv1beta1.NodeModulesConfigSpec{
+					Modules: []kmmv1beta1.NodeModuleSpec{
+						{ModuleItem: kmmv1beta1.ModuleItem{Namespace: moduleNamespace, Name: moduleName}},
+					},
+				},
+				Status: kmmv1beta1.NodeModulesConfigStatus{
+					Modules: []kmmv1beta1.NodeModuleStatus{
+						{ModuleItem: kmmv1beta1.ModuleItem{Namespace: moduleNamespace, Name: moduleName}},
+					},
+				},
+			}
+
+			returnNMC := func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+				list.Items = []kmmv1beta1.NodeModulesConfig{*nmcObj.DeepCopy()}
+				return nil
+			}
+
+			gomock.InOrder(
+				clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.MatchingLabels(matchConfiguredModules)).DoAndReturn(returnNMC),
+				clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.MatchingLabels(matchLoadedModules)).DoAndReturn(returnNMC),
+				helper.EXPECT().GetModuleSpecEntry(gomock.Any(), moduleNamespace, moduleName).DoAndReturn(getModuleSpecEntry),
+				clnt.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, obj ctrlclient.Object, _ ctrlclient.Patch, _ ...ctrlclient.PatchOption) {
+						n := obj.(*kmmv1beta1.NodeModulesConfig)
+						Expect(n.GetLabels()).To(BeEmpty())
+						Expect(n.Spec.Modules).To(HaveLen(1))
+						Expect(n.Spec.Modules[0].Orphaned).To(BeTrue())
+						Expect(n.Status.Modules).To(HaveLen(1))
+					},
+				),
+				clnt.EXPECT().Patch(ctx, mod, gomock.Any()),
+			)
+
+			Expect(
+				mrh.finalizeModule(ctx, mod),
+			).NotTo(
+				HaveOccurred(),
+			)
+
+			Expect(mod.Finalizers).NotTo(ContainElement(constants.ModuleFinalizer))
+		})
+
+		It("should keep loaded a module that was being unloaded", func() {
+			cfg := kmmv1beta1.ModuleConfig{KernelVersion: "1.2.3"}
+
+			nmcObj := kmmv1beta1.NodeModulesConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "nmc1"},
+				Status: kmmv1beta1.NodeModulesConfigStatus{
+					Modules: []kmmv1beta1.NodeModuleStatus{
+						{ModuleItem: kmmv1beta1.ModuleItem{Namespace: moduleNamespace, Name: moduleName}, Config: cfg},
+					},
+				},
+			}
+
+			gomock.InOrder(
+				clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.MatchingLabels(matchConfiguredModules)),
+				clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.MatchingLabels(matchLoadedModules)).DoAndReturn(
+					func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+						list.Items = []kmmv1beta1.NodeModulesConfig{nmcObj}
+						return nil
+					},
+				),
+				helper.EXPECT().GetModuleSpecEntry(gomock.Any(), moduleNamespace, moduleName).DoAndReturn(getModuleSpecEntry),
+				clnt.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, obj ctrlclient.Object, _ ctrlclient.Patch, _ ...ctrlclient.PatchOption) {
+						n := obj.(*kmmv1beta1.NodeModulesConfig)
+						Expect(n.Spec.Modules).To(HaveLen(1))
+						Expect(n.Spec.Modules[0].Orphaned).To(BeTrue())
+						Expect(n.Spec.Modules[0].Config).To(Equal(cfg))
+					},
+				),
+				clnt.EXPECT().Patch(ctx, mod, gomock.Any()),
+			)
+
+			Expect(
+				mrh.finalizeModule(ctx, mod),
+			).NotTo(
+				HaveOccurred(),
+			)
+		})
+
+		It("should not remove the finalizer if the NMC could not be patched", func() {
+			nmcObj := kmmv1beta1.NodeModulesConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "nmc1"},
+			}
+
+			gomock.InOrder(
+				clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.MatchingLabels(matchConfiguredModules)).DoAndReturn(
+					func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+						list.Items = []kmmv1beta1.NodeModulesConfig{nmcObj}
+						return nil
+					},
+				),
+				clnt.EXPECT().List(ctx, gomock.Any(), ctrlclient.MatchingLabels(matchLoadedModules)),
+				helper.EXPECT().GetModuleSpecEntry(gomock.Any(), moduleNamespace, moduleName),
+				clnt.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error")),
+			)
+
+			Expect(
+				mrh.finalizeModule(ctx, mod),
+			).To(
+				HaveOccurred(),
+			)
+		})
+	})
 })
 
 var _ = Describe("handleMIC", func() {
@@ -433,7 +783,7 @@ var _ = Describe("handleMIC", func() {
 		mockKernelMapper = module.NewMockKernelMapper(ctrl)
 		mockMICAPI = mic.NewMockMIC(ctrl)
 		helper = nmc.NewMockHelper(ctrl)
-		mrh = newModuleReconcilerHelper(clnt, mockKernelMapper, mockMICAPI, helper, scheme)
+		mrh = newModuleReconcilerHelper(clnt, mockKernelMapper, mockMICAPI, helper, nil, &config.Node{}, scheme)
 		mod = &kmmv1beta1.Module{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      moduleName,
@@ -457,8 +807,9 @@ var _ = Describe("handleMIC", func() {
 
 	It("should return an error if we failed to get moduleLoaderData for kernel", func() {
 
-		mockKernelMapper.EXPECT().GetModuleLoaderDataForKernel(mod, gomock.Any()).Return(nil, errors.New("some error"))
+		mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(mod, &targetedNodes[0]).Return(nil, errors.New("some error"))
 		mockMICAPI.EXPECT().CreateOrPatch(ctx, mod.Name, mod.Namespace, gomock.Any(), mod.Spec.ImageRepoSecret, v1.PullPolicy(""), true, mod.Spec.ImageRebuildTriggerGeneration, mod.Spec.Tolerations, mod).Return(nil)
+		mockMICAPI.EXPECT().SetPaused(ctx, mod.Name, mod.Namespace, false).Return(nil)
 
 		err := mrh.handleMIC(ctx, mod, targetedNodes)
 		Expect(err).To(HaveOccurred())
@@ -469,7 +820,7 @@ var _ = Describe("handleMIC", func() {
 
 		img := "example.registry.com/org/image:tag"
 		mld := &api.ModuleLoaderData{ContainerImage: img}
-		mockKernelMapper.EXPECT().GetModuleLoaderDataForKernel(mod, gomock.Any()).Return(mld, nil)
+		mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(mod, &targetedNodes[0]).Return(mld, nil)
 		mockMICAPI.EXPECT().CreateOrPatch(ctx, mod.Name, mod.Namespace, gomock.Any(), mod.Spec.ImageRepoSecret,
 			v1.PullPolicy(""), true, mod.Spec.ImageRebuildTriggerGeneration, mod.Spec.Tolerations, mod).Return(errors.New("some error"))
 
@@ -478,8 +829,39 @@ var _ = Describe("handleMIC", func() {
 		Expect(err.Error()).To(ContainSubstring("failed to apply"))
 	})
 
+	It("should only pause the MIC if the Module is paused", func() {
+		mod.Spec.Paused = true
+
+		mockMICAPI.EXPECT().SetPaused(ctx, mod.Name, mod.Namespace, true).Return(nil)
+
+		err := mrh.handleMIC(ctx, mod, targetedNodes)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should return an error if the MIC could not be paused", func() {
+		mod.Spec.Paused = true
+
+		mockMICAPI.EXPECT().SetPaused(ctx, mod.Name, mod.Namespace, true).Return(errors.New("some error"))
+
+		err := mrh.handleMIC(ctx, mod, targetedNodes)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should unpause the MIC once it was applied", func() {
+		gomock.InOrder(
+			mockMICAPI.EXPECT().CreateOrPatch(ctx, mod.Name, mod.Namespace, gomock.Any(), mod.Spec.ImageRepoSecret,
+				v1.PullPolicy(""), true, mod.Spec.ImageRebuildTriggerGeneration, mod.Spec.Tolerations, mod).Return(nil),
+			mockMICAPI.EXPECT().SetPaused(ctx, mod.Name, mod.Namespace, false).Return(errors.New("some error")),
+		)
+
+		err := mrh.handleMIC(ctx, mod, []v1.Node{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to unpause"))
+	})
+
 	It("should not do anything if targetedNodes is empty", func() {
 		mockMICAPI.EXPECT().CreateOrPatch(ctx, mod.Name, mod.Namespace, gomock.Any(), mod.Spec.ImageRepoSecret, v1.PullPolicy(""), true, mod.Spec.ImageRebuildTriggerGeneration, mod.Spec.Tolerations, mod).Return(nil)
+		mockMICAPI.EXPECT().SetPaused(ctx, mod.Name, mod.Namespace, false).Return(nil)
 		err := mrh.handleMIC(ctx, mod, []v1.Node{})
 		Expect(err).NotTo(HaveOccurred())
 	})
@@ -501,107 +883,602 @@ var _ = Describe("handleMIC", func() {
 			Sign:          mld.Sign,
 			RegistryTLS:   mld.RegistryTLS,
 		}
-		mockKernelMapper.EXPECT().GetModuleLoaderDataForKernel(mod, gomock.Any()).Return(mld, nil)
+		mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(mod, &targetedNodes[0]).Return(mld, nil)
 		mockMICAPI.EXPECT().CreateOrPatch(ctx, mod.Name, mod.Namespace, []kmmv1beta1.ModuleImageSpec{expectedSpec},
 			mod.Spec.ImageRepoSecret, v1.PullPolicy(""), true, mod.Spec.ImageRebuildTriggerGeneration, mod.Spec.Tolerations, mod).Return(nil)
+		mockMICAPI.EXPECT().SetPaused(ctx, mod.Name, mod.Namespace, false).Return(nil)
 
 		err := mrh.handleMIC(ctx, mod, targetedNodes)
 		Expect(err).NotTo(HaveOccurred())
 	})
-})
-
-var _ = Describe("getNMCsByModuleSet", func() {
-	var (
-		ctrl *gomock.Controller
-		clnt *client.MockClient
-		mrh  moduleReconcilerHelperAPI
-	)
-
-	BeforeEach(func() {
-		ctrl = gomock.NewController(GinkgoT())
-		clnt = client.NewMockClient(ctrl)
-		mrh = newModuleReconcilerHelper(clnt, nil, nil, nil, scheme)
-	})
-
-	ctx := context.Background()
 
-	It("list failed", func() {
-		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+	It("should set the architecture of the node the image is needed for", func() {
 
-		nodes, err := mrh.getNMCsByModuleSet(ctx, &kmmv1beta1.Module{})
+		img := "example.registry.com/org/image:tag"
+		mld := &api.ModuleLoaderData{
+			ContainerImage: img,
+			KernelVersion:  "some version",
+			Architecture:   "arm64",
+		}
+		expectedSpec := kmmv1beta1.ModuleImageSpec{
+			Image:         img,
+			KernelVersion: "some version",
+			Architectures: []string{"arm64"},
+		}
+		mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(mod, &targetedNodes[0]).Return(mld, nil)
+		mockMICAPI.EXPECT().CreateOrPatch(ctx, mod.Name, mod.Namespace, []kmmv1beta1.ModuleImageSpec{expectedSpec},
+			mod.Spec.ImageRepoSecret, v1.PullPolicy(""), true, mod.Spec.ImageRebuildTriggerGeneration, mod.Spec.Tolerations, mod).Return(nil)
+		mockMICAPI.EXPECT().SetPaused(ctx, mod.Name, mod.Namespace, false).Return(nil)
 
-		Expect(err).To(HaveOccurred())
-		Expect(nodes).To(BeNil())
+		err := mrh.handleMIC(ctx, mod, targetedNodes)
+		Expect(err).NotTo(HaveOccurred())
 	})
 
-	It("Return NMCs", func() {
-		nmc1 := kmmv1beta1.NodeModulesConfig{
-			ObjectMeta: metav1.ObjectMeta{Name: "nmc1"},
+	It("should add the images of the kernels to prebuild for each node", func() {
+		mod.Spec.PrebuildKernels = &kmmv1beta1.PrebuildKernelsSpec{
+			Versions:       []string{"5.14.0", "6.0.0"},
+			NodeAnnotation: "example.com/next-kernel",
 		}
-		nmc2 := kmmv1beta1.NodeModulesConfig{
-			ObjectMeta: metav1.ObjectMeta{Name: "nmc2"},
+		targetedNodes[0].Annotations = map[string]string{"example.com/next-kernel": " 6.1.0+, ,"}
+		targetedNodes[0].Status.NodeInfo.KernelVersion = "5.14.0"
+
+		nodeWithKernel := func(kernelVersion string) *v1.Node {
+			n := targetedNodes[0].DeepCopy()
+			n.Status.NodeInfo.KernelVersion = kernelVersion
+			return n
 		}
-		nmc3 := kmmv1beta1.NodeModulesConfig{
-			ObjectMeta: metav1.ObjectMeta{Name: "nmc3"},
+
+		mldForKernel := func(kernelVersion string) *api.ModuleLoaderData {
+			return &api.ModuleLoaderData{ContainerImage: "image:" + kernelVersion, KernelVersion: kernelVersion, Architecture: "arm64"}
 		}
-		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
-			func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
-				list.Items = []kmmv1beta1.NodeModulesConfig{nmc1, nmc2, nmc3}
-				return nil
+
+		gomock.InOrder(
+			mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(mod, &targetedNodes[0]).Return(mldForKernel("5.14.0"), nil),
+			mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(mod, nodeWithKernel("6.0.0")).Return(nil, module.ErrNoMatchingKernelMapping),
+			mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(mod, nodeWithKernel("6.1.0")).Return(mldForKernel("6.1.0"), nil),
+		)
+		mockMICAPI.EXPECT().CreateOrPatch(
+			ctx,
+			mod.Name,
+			mod.Namespace,
+			[]kmmv1beta1.ModuleImageSpec{
+				{Image: "image:5.14.0", KernelVersion: "5.14.0", Architectures: []string{"arm64"}},
+				{Image: "image:6.1.0", KernelVersion: "6.1.0", Architectures: []string{"arm64"}},
 			},
+			mod.Spec.ImageRepoSecret,
+			v1.PullPolicy(""),
+			true,
+			mod.Spec.ImageRebuildTriggerGeneration,
+			mod.Spec.Tolerations,
+			mod,
+		).Return(nil)
+		mockMICAPI.EXPECT().SetPaused(ctx, mod.Name, mod.Namespace, false).Return(nil)
+
+		err := mrh.handleMIC(ctx, mod, targetedNodes)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should add the image of the pending kernel of each node", func() {
+		mrh = newModuleReconcilerHelper(
+			clnt,
+			mockKernelMapper,
+			mockMICAPI,
+			helper,
+			nil,
+			&config.Node{PendingKernelAnnotation: "example.com/pending-kernel"},
+			scheme,
 		)
+		targetedNodes[0].Annotations = map[string]string{"example.com/pending-kernel": "6.1.0"}
+		targetedNodes[0].Status.NodeInfo.KernelVersion = "5.14.0"
 
-		nmcsSet, err := mrh.getNMCsByModuleSet(ctx, &kmmv1beta1.Module{})
+		futureNode := targetedNodes[0].DeepCopy()
+		futureNode.Status.NodeInfo.KernelVersion = "6.1.0"
 
-		expectedSet := sets.New[string]([]string{"nmc1", "nmc2", "nmc3"}...)
+		gomock.InOrder(
+			mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(mod, &targetedNodes[0]).Return(
+				&api.ModuleLoaderData{ContainerImage: "image:5.14.0", KernelVersion: "5.14.0"}, nil,
+			),
+			mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(mod, futureNode).Return(
+				&api.ModuleLoaderData{ContainerImage: "image:6.1.0", KernelVersion: "6.1.0"}, nil,
+			),
+		)
+		mockMICAPI.EXPECT().CreateOrPatch(
+			ctx,
+			mod.Name,
+			mod.Namespace,
+			[]kmmv1beta1.ModuleImageSpec{
+				{Image: "image:5.14.0", KernelVersion: "5.14.0"},
+				{Image: "image:6.1.0", KernelVersion: "6.1.0"},
+			},
+			mod.Spec.ImageRepoSecret,
+			v1.PullPolicy(""),
+			true,
+			mod.Spec.ImageRebuildTriggerGeneration,
+			mod.Spec.Tolerations,
+			mod,
+		).Return(nil)
+		mockMICAPI.EXPECT().SetPaused(ctx, mod.Name, mod.Namespace, false).Return(nil)
 
+		err := mrh.handleMIC(ctx, mod, targetedNodes)
 		Expect(err).NotTo(HaveOccurred())
-		Expect(nmcsSet.Equal(expectedSet)).To(BeTrue())
+	})
+
+	It("should add the images of the listed kernels to prebuild if there are no nodes", func() {
+		mod.Spec.PrebuildKernels = &kmmv1beta1.PrebuildKernelsSpec{Versions: []string{"6.0.0"}}
+
+		mld := &api.ModuleLoaderData{ContainerImage: "image:6.0.0", KernelVersion: "6.0.0"}
+
+		mockKernelMapper.EXPECT().GetModuleLoaderDataForKernel(mod, "6.0.0").Return(mld, nil)
+		mockMICAPI.EXPECT().CreateOrPatch(
+			ctx,
+			mod.Name,
+			mod.Namespace,
+			[]kmmv1beta1.ModuleImageSpec{{Image: "image:6.0.0", KernelVersion: "6.0.0"}},
+			mod.Spec.ImageRepoSecret,
+			v1.PullPolicy(""),
+			true,
+			mod.Spec.ImageRebuildTriggerGeneration,
+			mod.Spec.Tolerations,
+			mod,
+		).Return(nil)
+		mockMICAPI.EXPECT().SetPaused(ctx, mod.Name, mod.Namespace, false).Return(nil)
 
+		err := mrh.handleMIC(ctx, mod, []v1.Node{})
+		Expect(err).NotTo(HaveOccurred())
 	})
 })
 
-var _ = Describe("prepareSchedulingData", func() {
+var _ = Describe("resolveValueSources", func() {
 	const (
-		kernelVersion   = "some kernel version"
-		nodeName        = "nodeName"
-		moduleName      = "moduleName"
-		moduleNamespace = "moduleNamespace"
+		name      = "values"
+		namespace = "namespace"
 	)
+
 	var (
-		ctrl          *gomock.Controller
-		clnt          *client.MockClient
-		mockKernel    *module.MockKernelMapper
-		mockHelper    *nmc.MockHelper
-		mrh           moduleReconcilerHelperAPI
-		node          v1.Node
-		targetedNodes []v1.Node
+		ctrl *gomock.Controller
+		clnt *client.MockClient
+		mrh  moduleReconcilerHelperAPI
+		mod  *kmmv1beta1.Module
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
-		mockKernel = module.NewMockKernelMapper(ctrl)
-		mockHelper = nmc.NewMockHelper(ctrl)
-		mrh = newModuleReconcilerHelper(clnt, mockKernel, nil, mockHelper, scheme)
-		node = v1.Node{
-			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
-			Status: v1.NodeStatus{
-				NodeInfo: v1.NodeSystemInfo{KernelVersion: kernelVersion},
+		mrh = newModuleReconcilerHelper(clnt, nil, nil, nil, nil, &config.Node{}, scheme)
+		mod = &kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "module", Namespace: namespace},
+			Spec: kmmv1beta1.ModuleSpec{
+				ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{
+					Container: kmmv1beta1.ModuleLoaderContainerSpec{
+						Modprobe: kmmv1beta1.ModprobeSpec{Parameters: []string{"a=b"}},
+					},
+				},
 			},
 		}
-		targetedNodes = []v1.Node{node}
 	})
 
 	ctx := context.Background()
-	mod := kmmv1beta1.Module{}
-	mld := api.ModuleLoaderData{KernelVersion: "some version", Name: moduleName, Namespace: moduleNamespace}
+	nsn := types.NamespacedName{Namespace: namespace, Name: name}
+
+	configMapSource := func(optional bool) kmmv1beta1.ValueSource {
+		return kmmv1beta1.ValueSource{
+			ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: name},
+				Key:                  "key",
+				Optional:             ptr.To(optional),
+			},
+		}
+	}
 
-	It("failed to determine mld", func() {
-		currentNMCs := sets.New[string](nodeName)
-		mockKernel.EXPECT().GetModuleLoaderDataForKernel(&mod, kernelVersion).Return(nil, fmt.Errorf("some error"))
+	It("should add the parameters read from a ConfigMap", func() {
+		mod.Spec.ModuleLoader.Container.Modprobe.ParametersFrom = []kmmv1beta1.ModprobeParameterSource{
+			{Name: "param", ValueFrom: configMapSource(false)},
+		}
 
-		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
+		clnt.EXPECT().Get(ctx, nsn, &v1.ConfigMap{}).DoAndReturn(
+			func(_ interface{}, _ interface{}, cm *v1.ConfigMap, _ ...ctrlclient.GetOption) error {
+				cm.Data = map[string]string{"key": "value"}
+				return nil
+			},
+		)
+
+		Expect(
+			mrh.resolveValueSources(ctx, mod),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(mod.Spec.ModuleLoader.Container.Modprobe.Parameters).To(Equal([]string{"a=b", "param=value"}))
+		Expect(mod.Spec.ModuleLoader.Container.Modprobe.ParametersFrom).To(BeNil())
+	})
+
+	It("should add the parameters of kernel mappings read from a ConfigMap", func() {
+		mod.Spec.ModuleLoader.Container.KernelMappings = []kmmv1beta1.KernelMapping{
+			{},
+			{
+				Modprobe: &kmmv1beta1.ModprobeOverride{
+					ParametersFrom: []kmmv1beta1.ModprobeParameterSource{
+						{Name: "param", ValueFrom: configMapSource(false)},
+					},
+				},
+			},
+		}
+
+		clnt.EXPECT().Get(ctx, nsn, &v1.ConfigMap{}).DoAndReturn(
+			func(_ interface{}, _ interface{}, cm *v1.ConfigMap, _ ...ctrlclient.GetOption) error {
+				cm.Data = map[string]string{"key": "value"}
+				return nil
+			},
+		)
+
+		Expect(
+			mrh.resolveValueSources(ctx, mod),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(
+			mod.Spec.ModuleLoader.Container.KernelMappings[1].Modprobe,
+		).To(
+			Equal(&kmmv1beta1.ModprobeOverride{Parameters: []string{"param=value"}}),
+		)
+		Expect(mod.Spec.ModuleLoader.Container.Modprobe.Parameters).To(Equal([]string{"a=b"}))
+	})
+
+	secretSource := func(optional bool) *kmmv1beta1.ValueSource {
+		return &kmmv1beta1.ValueSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: name},
+				Key:                  "key",
+				Optional:             ptr.To(optional),
+			},
+		}
+	}
+
+	It("should keep the references to existing Secret keys without reading their values", func() {
+		mod.Spec.ModuleLoader.Container.Modprobe.ParametersFrom = []kmmv1beta1.ModprobeParameterSource{
+			{Name: "param", ValueFrom: *secretSource(false)},
+		}
+		mod.Spec.ModuleLoader.Container.KernelMappings = []kmmv1beta1.KernelMapping{
+			{
+				Build: &kmmv1beta1.Build{
+					BuildArgs: []kmmv1beta1.BuildArg{{Name: "TOKEN", ValueFrom: secretSource(false)}},
+				},
+			},
+		}
+
+		clnt.EXPECT().Get(ctx, nsn, &v1.Secret{}).DoAndReturn(
+			func(_ interface{}, _ interface{}, s *v1.Secret, _ ...ctrlclient.GetOption) error {
+				s.Data = map[string][]byte{"key": []byte("secret-value")}
+				return nil
+			},
+		).Times(2)
+
+		Expect(
+			mrh.resolveValueSources(ctx, mod),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(mod.Spec.ModuleLoader.Container.Modprobe.Parameters).To(Equal([]string{"a=b"}))
+		Expect(
+			mod.Spec.ModuleLoader.Container.Modprobe.ParametersFrom,
+		).To(
+			Equal([]kmmv1beta1.ModprobeParameterSource{{Name: "param", ValueFrom: *secretSource(false)}}),
+		)
+		Expect(
+			mod.Spec.ModuleLoader.Container.KernelMappings[0].Build.BuildArgs,
+		).To(
+			Equal([]kmmv1beta1.BuildArg{{Name: "TOKEN", ValueFrom: secretSource(false)}}),
+		)
+	})
+
+	It("should drop the references to missing optional Secret keys", func() {
+		mod.Spec.ModuleLoader.Container.Modprobe.ParametersFrom = []kmmv1beta1.ModprobeParameterSource{
+			{Name: "param", ValueFrom: *secretSource(true)},
+		}
+		mod.Spec.ModuleLoader.Container.Build = &kmmv1beta1.Build{
+			BuildArgs: []kmmv1beta1.BuildArg{{Name: "TOKEN", ValueFrom: secretSource(true)}},
+		}
+
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, nsn, &v1.Secret{}).Return(apierrors.NewNotFound(schema.GroupResource{}, name)),
+			clnt.EXPECT().Get(ctx, nsn, &v1.Secret{}),
+		)
+
+		Expect(
+			mrh.resolveValueSources(ctx, mod),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(mod.Spec.ModuleLoader.Container.Modprobe.ParametersFrom).To(BeNil())
+		Expect(mod.Spec.ModuleLoader.Container.Build.BuildArgs).To(Equal([]kmmv1beta1.BuildArg{{Name: "TOKEN"}}))
+	})
+
+	It("should return an error if a required Secret key does not exist", func() {
+		mod.Spec.ModuleLoader.Container.Modprobe.ParametersFrom = []kmmv1beta1.ModprobeParameterSource{
+			{Name: "param", ValueFrom: *secretSource(false)},
+		}
+
+		clnt.EXPECT().Get(ctx, nsn, &v1.Secret{})
+
+		Expect(
+			mrh.resolveValueSources(ctx, mod),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	It("should add the kernels to prebuild read from a ConfigMap", func() {
+		mod.Spec.PrebuildKernels = &kmmv1beta1.PrebuildKernelsSpec{
+			Versions:        []string{"5.14.0"},
+			ConfigMapKeyRef: configMapSource(false).ConfigMapKeyRef,
+		}
+
+		clnt.EXPECT().Get(ctx, nsn, &v1.ConfigMap{}).DoAndReturn(
+			func(_ interface{}, _ interface{}, cm *v1.ConfigMap, _ ...ctrlclient.GetOption) error {
+				cm.Data = map[string]string{"key": "6.0.0\n6.1.0 6.2.0\n"}
+				return nil
+			},
+		)
+
+		Expect(
+			mrh.resolveValueSources(ctx, mod),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(
+			mod.Spec.PrebuildKernels,
+		).To(
+			Equal(&kmmv1beta1.PrebuildKernelsSpec{Versions: []string{"5.14.0", "6.0.0", "6.1.0", "6.2.0"}}),
+		)
+	})
+
+	It("should skip optional references that do not exist", func() {
+		mod.Spec.ModuleLoader.Container.Modprobe.ParametersFrom = []kmmv1beta1.ModprobeParameterSource{
+			{Name: "missing-object", ValueFrom: configMapSource(true)},
+			{Name: "missing-key", ValueFrom: configMapSource(true)},
+		}
+
+		gomock.InOrder(
+			clnt.EXPECT().Get(ctx, nsn, &v1.ConfigMap{}).Return(apierrors.NewNotFound(schema.GroupResource{}, name)),
+			clnt.EXPECT().Get(ctx, nsn, &v1.ConfigMap{}),
+		)
+
+		Expect(
+			mrh.resolveValueSources(ctx, mod),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(mod.Spec.ModuleLoader.Container.Modprobe.Parameters).To(Equal([]string{"a=b"}))
+	})
+
+	It("should return an error if a required ConfigMap does not exist", func() {
+		mod.Spec.ModuleLoader.Container.Modprobe.ParametersFrom = []kmmv1beta1.ModprobeParameterSource{
+			{Name: "param", ValueFrom: configMapSource(false)},
+		}
+
+		clnt.EXPECT().Get(ctx, nsn, &v1.ConfigMap{}).Return(apierrors.NewNotFound(schema.GroupResource{}, name))
+
+		Expect(
+			mrh.resolveValueSources(ctx, mod),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	It("should return an error if a required key does not exist", func() {
+		mod.Spec.ModuleLoader.Container.Modprobe.ParametersFrom = []kmmv1beta1.ModprobeParameterSource{
+			{Name: "param", ValueFrom: configMapSource(false)},
+		}
+
+		clnt.EXPECT().Get(ctx, nsn, &v1.ConfigMap{})
+
+		Expect(
+			mrh.resolveValueSources(ctx, mod),
+		).To(
+			HaveOccurred(),
+		)
+	})
+})
+
+var _ = Describe("getNMCsByModuleSet", func() {
+	var (
+		ctrl *gomock.Controller
+		clnt *client.MockClient
+		mrh  moduleReconcilerHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mrh = newModuleReconcilerHelper(clnt, nil, nil, nil, nil, &config.Node{}, scheme)
+	})
+
+	ctx := context.Background()
+
+	It("list failed", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+
+		nodes, err := mrh.getNMCsByModuleSet(ctx, &kmmv1beta1.Module{})
+
+		Expect(err).To(HaveOccurred())
+		Expect(nodes).To(BeNil())
+	})
+
+	It("Return NMCs", func() {
+		nmc1 := kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "nmc1"},
+		}
+		nmc2 := kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "nmc2"},
+		}
+		nmc3 := kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "nmc3"},
+		}
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+				list.Items = []kmmv1beta1.NodeModulesConfig{nmc1, nmc2, nmc3}
+				return nil
+			},
+		)
+
+		nmcsSet, err := mrh.getNMCsByModuleSet(ctx, &kmmv1beta1.Module{})
+
+		expectedSet := sets.New[string]([]string{"nmc1", "nmc2", "nmc3"}...)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nmcsSet.Equal(expectedSet)).To(BeTrue())
+
+	})
+})
+
+var _ = Describe("syncNMCsPaused", func() {
+	const (
+		modName      = "mod-name"
+		modNamespace = "mod-namespace"
+	)
+
+	var (
+		ctx  = context.Background()
+		clnt *client.MockClient
+		mrh  moduleReconcilerHelperAPI
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mrh = newModuleReconcilerHelper(clnt, nil, nil, nmc.NewHelper(clnt), nil, &config.Node{}, scheme)
+	})
+
+	nmcWithEntry := func(name string, paused bool) kmmv1beta1.NodeModulesConfig {
+		return kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kmmv1beta1.NodeModulesConfigSpec{
+				Modules: []kmmv1beta1.NodeModuleSpec{
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{Name: modName, Namespace: modNamespace},
+						Paused:     paused,
+					},
+				},
+			},
+		}
+	}
+
+	DescribeTable("should only patch the NMCs whose entry differs",
+		func(paused bool) {
+			mod := &kmmv1beta1.Module{
+				ObjectMeta: metav1.ObjectMeta{Name: modName, Namespace: modNamespace},
+				Spec:       kmmv1beta1.ModuleSpec{Paused: paused},
+			}
+
+			gomock.InOrder(
+				clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+						list.Items = []kmmv1beta1.NodeModulesConfig{nmcWithEntry("up-to-date", paused), nmcWithEntry("outdated", !paused)}
+						return nil
+					},
+				),
+				clnt.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, obj *kmmv1beta1.NodeModulesConfig, _ ctrlclient.Patch, _ ...ctrlclient.PatchOption) error {
+						Expect(obj.Name).To(Equal("outdated"))
+						Expect(obj.Spec.Modules[0].Paused).To(Equal(paused))
+						return nil
+					},
+				),
+			)
+
+			Expect(
+				mrh.syncNMCsPaused(ctx, mod),
+			).NotTo(
+				HaveOccurred(),
+			)
+		},
+		Entry("pausing", true),
+		Entry("resuming", false),
+	)
+
+	It("should return an error if an NMC could not be patched", func() {
+		mod := &kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: modName, Namespace: modNamespace},
+			Spec:       kmmv1beta1.ModuleSpec{Paused: true},
+		}
+
+		gomock.InOrder(
+			clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+					list.Items = []kmmv1beta1.NodeModulesConfig{nmcWithEntry("nmc", false)}
+					return nil
+				},
+			),
+			clnt.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Return(errors.New("some error")),
+		)
+
+		Expect(
+			mrh.syncNMCsPaused(ctx, mod),
+		).To(
+			HaveOccurred(),
+		)
+	})
+})
+
+var _ = Describe("prepareSchedulingData", func() {
+	const (
+		kernelVersion   = "some kernel version"
+		nodeName        = "nodeName"
+		moduleName      = "moduleName"
+		moduleNamespace = "moduleNamespace"
+	)
+	var (
+		ctrl          *gomock.Controller
+		clnt          *client.MockClient
+		mockKernel    *module.MockKernelMapper
+		mockHelper    *nmc.MockHelper
+		mrh           moduleReconcilerHelperAPI
+		node          v1.Node
+		targetedNodes []v1.Node
+		nmcs          []kmmv1beta1.NodeModulesConfig
+		listErr       error
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mockKernel = module.NewMockKernelMapper(ctrl)
+		mockHelper = nmc.NewMockHelper(ctrl)
+		mrh = newModuleReconcilerHelper(clnt, mockKernel, nil, mockHelper, nil, &config.Node{}, scheme)
+		node = v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Status: v1.NodeStatus{
+				NodeInfo: v1.NodeSystemInfo{KernelVersion: kernelVersion},
+			},
+		}
+		targetedNodes = []v1.Node{node}
+		nmcs = nil
+		listErr = nil
+	})
+
+	ctx := context.Background()
+	mod := kmmv1beta1.Module{}
+
+	JustBeforeEach(func() {
+		clnt.EXPECT().List(ctx, &kmmv1beta1.NodeModulesConfigList{}).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+				list.Items = nmcs
+				return listErr
+			},
+		)
+	})
+	mld := api.ModuleLoaderData{KernelVersion: "some version", Name: moduleName, Namespace: moduleNamespace}
+
+	It("failed to determine mld", func() {
+		currentNMCs := sets.New[string](nodeName)
+		mockKernel.EXPECT().GetModuleLoaderDataForNode(&mod, &node).Return(nil, fmt.Errorf("some error"))
+
+		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
 
 		Expect(len(errs)).To(Equal(1))
 		Expect(scheduleData).To(Equal(map[string]schedulingData{}))
@@ -617,7 +1494,7 @@ var _ = Describe("prepareSchedulingData", func() {
 				currentNMCs.Insert(nodeName)
 			}
 
-			mockKernel.EXPECT().GetModuleLoaderDataForKernel(&mod, kernelVersion).Return(nil, module.ErrNoMatchingKernelMapping)
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&mod, &node).Return(nil, module.ErrNoMatchingKernelMapping)
 
 			scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
 
@@ -631,7 +1508,7 @@ var _ = Describe("prepareSchedulingData", func() {
 
 	It("mld exists", func() {
 		currentNMCs := sets.New[string](nodeName)
-		mockKernel.EXPECT().GetModuleLoaderDataForKernel(&mod, kernelVersion).Return(&mld, nil)
+		mockKernel.EXPECT().GetModuleLoaderDataForNode(&mod, &node).Return(&mld, nil)
 
 		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
 
@@ -642,7 +1519,7 @@ var _ = Describe("prepareSchedulingData", func() {
 
 	It("mld exists, nmc exists for other node", func() {
 		currentNMCs := sets.New[string]("some other node")
-		mockKernel.EXPECT().GetModuleLoaderDataForKernel(&mod, kernelVersion).Return(&mld, nil)
+		mockKernel.EXPECT().GetModuleLoaderDataForNode(&mod, &node).Return(&mld, nil)
 
 		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
 
@@ -653,7 +1530,7 @@ var _ = Describe("prepareSchedulingData", func() {
 
 	It("failed to determine mld for one of the nodes/nmcs", func() {
 		currentNMCs := sets.New[string]("some other node")
-		mockKernel.EXPECT().GetModuleLoaderDataForKernel(&mod, kernelVersion).Return(nil, fmt.Errorf("some error"))
+		mockKernel.EXPECT().GetModuleLoaderDataForNode(&mod, &node).Return(nil, fmt.Errorf("some error"))
 
 		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
 
@@ -662,6 +1539,41 @@ var _ = Describe("prepareSchedulingData", func() {
 		Expect(scheduleData).To(Equal(expectedScheduleData))
 	})
 
+	It("should not change the NMC of an excluded node", func() {
+		currentNMCs := sets.New[string](nodeName)
+		targetedNodes[0].Labels = map[string]string{constants.NodeExcludedLabel: "true"}
+
+		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
+
+		Expect(errs).To(BeEmpty())
+		Expect(scheduleData).To(BeEmpty())
+	})
+
+	It("should not remove the module from the NMC of an untargeted excluded node", func() {
+		currentNMCs := sets.New[string]("some other node")
+		targetedNodes = nil
+		nmcs = []kmmv1beta1.NodeModulesConfig{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "some other node"},
+				Status:     kmmv1beta1.NodeModulesConfigStatus{Excluded: true},
+			},
+		}
+
+		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
+
+		Expect(errs).To(BeEmpty())
+		Expect(scheduleData).To(BeEmpty())
+	})
+
+	It("should return an error if the NMCs could not be listed", func() {
+		listErr = errors.New("some error")
+
+		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, sets.New[string](nodeName))
+
+		Expect(errs).To(HaveLen(1))
+		Expect(scheduleData).To(BeEmpty())
+	})
+
 	It("should produce correct scheduling data when there are two nodes", func() {
 		const (
 			otherNodeName          = "other-node-name"
@@ -678,8 +1590,8 @@ var _ = Describe("prepareSchedulingData", func() {
 		otherNodeMLD.KernelVersion = otherNodeKernelVersion
 
 		gomock.InOrder(
-			mockKernel.EXPECT().GetModuleLoaderDataForKernel(&mod, kernelVersion).Return(&mld, nil),
-			mockKernel.EXPECT().GetModuleLoaderDataForKernel(&mod, otherNodeKernelVersion).Return(&otherNodeMLD, nil),
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&mod, &node).Return(&mld, nil),
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&mod, &otherNode).Return(&otherNodeMLD, nil),
 		)
 
 		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, sets.New[string]())
@@ -693,12 +1605,128 @@ var _ = Describe("prepareSchedulingData", func() {
 		Expect(scheduleData).To(Equal(expected))
 	})
 
+	Context("with a Module that manages the same kernel module on the node", func() {
+		var (
+			conflictingMLD api.ModuleLoaderData
+			ownMod         kmmv1beta1.Module
+			other          kmmv1beta1.Module
+		)
+
+		BeforeEach(func() {
+			conflictingMLD = mld
+			conflictingMLD.Modprobe = kmmv1beta1.ModprobeSpec{ModuleName: "kmod-a"}
+
+			ownMod = kmmv1beta1.Module{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              moduleName,
+					Namespace:         moduleNamespace,
+					CreationTimestamp: metav1.NewTime(time.Unix(2000, 0)),
+				},
+			}
+
+			other = kmmv1beta1.Module{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "other-module",
+					Namespace:         "other-namespace",
+					CreationTimestamp: metav1.NewTime(time.Unix(1000, 0)),
+				},
+			}
+
+			nmcs = []kmmv1beta1.NodeModulesConfig{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+					Spec: kmmv1beta1.NodeModulesConfigSpec{
+						Modules: []kmmv1beta1.NodeModuleSpec{
+							{
+								ModuleItem: kmmv1beta1.ModuleItem{Name: "other-module", Namespace: "other-namespace"},
+								Config:     kmmv1beta1.ModuleConfig{Modprobe: kmmv1beta1.ModprobeSpec{ModuleName: "kmod_a"}},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		expectOtherModule := func() {
+			clnt.EXPECT().Get(ctx, types.NamespacedName{Name: "other-module", Namespace: "other-namespace"}, &kmmv1beta1.Module{}).DoAndReturn(
+				func(_ interface{}, _ types.NamespacedName, m *kmmv1beta1.Module, _ ...ctrlclient.GetOption) error {
+					other.DeepCopyInto(m)
+					return nil
+				},
+			)
+		}
+
+		It("should not add the module if the other Module is older", func() {
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&ownMod, &node).Return(&conflictingMLD, nil)
+			expectOtherModule()
+
+			scheduleData, errs := mrh.prepareSchedulingData(ctx, &ownMod, targetedNodes, sets.New[string]())
+
+			Expect(errs).To(BeEmpty())
+			Expect(scheduleData).To(BeEmpty())
+		})
+
+		It("should remove the module from the node if the other Module is older", func() {
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&ownMod, &node).Return(&conflictingMLD, nil)
+			expectOtherModule()
+
+			scheduleData, errs := mrh.prepareSchedulingData(ctx, &ownMod, targetedNodes, sets.New[string](nodeName))
+
+			Expect(errs).To(BeEmpty())
+			Expect(scheduleData).To(Equal(map[string]schedulingData{nodeName: {action: actionDelete}}))
+		})
+
+		It("should add the module if it is older than the other Module", func() {
+			other.CreationTimestamp = metav1.NewTime(time.Unix(3000, 0))
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&ownMod, &node).Return(&conflictingMLD, nil)
+			expectOtherModule()
+
+			scheduleData, errs := mrh.prepareSchedulingData(ctx, &ownMod, targetedNodes, sets.New[string]())
+
+			Expect(errs).To(BeEmpty())
+			Expect(scheduleData).To(HaveKeyWithValue(nodeName, schedulingData{action: actionAdd, mld: &conflictingMLD, node: &node}))
+		})
+
+		It("should order Modules created at the same time by namespace and name", func() {
+			other.CreationTimestamp = ownMod.CreationTimestamp
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&ownMod, &node).Return(&conflictingMLD, nil)
+			expectOtherModule()
+
+			scheduleData, errs := mrh.prepareSchedulingData(ctx, &ownMod, targetedNodes, sets.New[string]())
+
+			Expect(errs).To(BeEmpty())
+			Expect(scheduleData).To(HaveKeyWithValue(nodeName, schedulingData{action: actionAdd, mld: &conflictingMLD, node: &node}))
+		})
+
+		It("should ignore the entries of Modules that do not exist anymore", func() {
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&ownMod, &node).Return(&conflictingMLD, nil)
+			clnt.EXPECT().Get(ctx, types.NamespacedName{Name: "other-module", Namespace: "other-namespace"}, &kmmv1beta1.Module{}).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, "other-module"))
+
+			scheduleData, errs := mrh.prepareSchedulingData(ctx, &ownMod, targetedNodes, sets.New[string]())
+
+			Expect(errs).To(BeEmpty())
+			Expect(scheduleData).To(HaveKeyWithValue(nodeName, schedulingData{action: actionAdd, mld: &conflictingMLD, node: &node}))
+		})
+
+		It("should not change the node if the other Module could not be fetched", func() {
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&ownMod, &node).Return(&conflictingMLD, nil)
+			clnt.EXPECT().Get(ctx, types.NamespacedName{Name: "other-module", Namespace: "other-namespace"}, &kmmv1beta1.Module{}).
+				Return(errors.New("some error"))
+
+			scheduleData, errs := mrh.prepareSchedulingData(ctx, &ownMod, targetedNodes, sets.New[string](nodeName))
+
+			Expect(errs).To(HaveLen(1))
+			Expect(scheduleData).To(BeEmpty())
+		})
+	})
+
 	It("module version exists, workerPod version label exists, versions are equal", func() {
 		node.SetLabels(map[string]string{utils.GetWorkerPodVersionLabelName(moduleNamespace, moduleName): "moduleVersion1"})
 		targetedNodes[0] = node
 		currentNMCs := sets.New[string](nodeName)
 		mld.ModuleVersion = "moduleVersion1"
-		mockKernel.EXPECT().GetModuleLoaderDataForKernel(&mod, kernelVersion).Return(&mld, nil)
+		mockKernel.EXPECT().GetModuleLoaderDataForNode(&mod, &node).Return(&mld, nil)
 
 		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
 
@@ -711,7 +1739,7 @@ var _ = Describe("prepareSchedulingData", func() {
 		targetedNodes[0] = node
 		currentNMCs := sets.New[string](nodeName)
 		mld.ModuleVersion = "moduleVersion2"
-		mockKernel.EXPECT().GetModuleLoaderDataForKernel(&mod, kernelVersion).Return(&mld, nil)
+		mockKernel.EXPECT().GetModuleLoaderDataForNode(&mod, &node).Return(&mld, nil)
 
 		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
 
@@ -722,7 +1750,7 @@ var _ = Describe("prepareSchedulingData", func() {
 	It("module version exists, moduleLoader version label does not exist", func() {
 		currentNMCs := sets.New[string](nodeName)
 		mld.ModuleVersion = "moduleVersion2"
-		mockKernel.EXPECT().GetModuleLoaderDataForKernel(&mod, kernelVersion).Return(&mld, nil)
+		mockKernel.EXPECT().GetModuleLoaderDataForNode(&mod, &node).Return(&mld, nil)
 
 		scheduleData, errs := mrh.prepareSchedulingData(ctx, &mod, targetedNodes, currentNMCs)
 
@@ -756,7 +1784,7 @@ var _ = Describe("enableModuleOnNode", func() {
 		clnt = client.NewMockClient(ctrl)
 		helper = nmc.NewMockHelper(ctrl)
 		mockMIC = mic.NewMockMIC(ctrl)
-		mrh = newModuleReconcilerHelper(clnt, nil, mockMIC, helper, scheme)
+		mrh = newModuleReconcilerHelper(clnt, nil, mockMIC, helper, nil, &config.Node{}, scheme)
 		node = v1.Node{
 			ObjectMeta: metav1.ObjectMeta{Name: "nodeName"},
 		}
@@ -848,6 +1876,82 @@ var _ = Describe("enableModuleOnNode", func() {
 		err := mrh.enableModuleOnNode(ctx, mld, &node)
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	It("should apply the parameter overrides matching the node", func() {
+		node.SetLabels(map[string]string{"example.com/nic": "fast"})
+		mld.Modprobe.Parameters = []string{"queues=4", "debug=0"}
+		mld.ParameterOverrides = []kmmv1beta1.ParameterOverride{
+			{Selector: map[string]string{"example.com/nic": "fast"}, Parameters: []string{"queues=16"}},
+			{Selector: map[string]string{"example.com/nic": "slow"}, Parameters: []string{"queues=2"}},
+		}
+		expectedModuleConfig.Modprobe.Parameters = []string{"queues=16", "debug=0"}
+
+		gomock.InOrder(
+			mockMIC.EXPECT().Get(ctx, moduleName, moduleNamespace).Return(&kmmv1beta1.ModuleImagesConfig{}, nil),
+			mockMIC.EXPECT().GetImageState(gomock.Any(), containerImage).Return(kmmv1beta1.ImageExists),
+			clnt.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			helper.EXPECT().SetModuleConfig(gomock.Any(), mld, expectedModuleConfig).Return(nil),
+			clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil),
+		)
+
+		err := mrh.enableModuleOnNode(ctx, mld, &node)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should change the module config when the value of a Secret parameter changes", func() {
+		mld.Modprobe.ParametersFrom = []kmmv1beta1.ModprobeParameterSource{
+			{
+				Name: "license",
+				ValueFrom: kmmv1beta1.ValueSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "secret"},
+						Key:                  "key",
+					},
+				},
+			},
+		}
+
+		secretValue := "value-1"
+		configs := make([]kmmv1beta1.ModuleConfig, 0, 2)
+
+		mockMIC.EXPECT().Get(ctx, moduleName, moduleNamespace).Return(&kmmv1beta1.ModuleImagesConfig{}, nil).Times(2)
+		mockMIC.EXPECT().GetImageState(gomock.Any(), containerImage).Return(kmmv1beta1.ImageExists).Times(2)
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Name: "secret", Namespace: moduleNamespace}, &v1.Secret{}).DoAndReturn(
+			func(_ interface{}, _ interface{}, secret *v1.Secret, _ ...ctrlclient.GetOption) error {
+				secret.Data = map[string][]byte{"key": []byte(secretValue)}
+				return nil
+			},
+		).Times(2)
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Name: node.Name}, gomock.Any()).
+			Return(apierrors.NewNotFound(schema.GroupResource{}, "whatever")).
+			Times(2)
+		helper.EXPECT().SetModuleConfig(gomock.Any(), mld, gomock.Any()).DoAndReturn(
+			func(_ *kmmv1beta1.NodeModulesConfig, _ *api.ModuleLoaderData, cfg *kmmv1beta1.ModuleConfig) error {
+				configs = append(configs, *cfg)
+				return nil
+			},
+		).Times(2)
+		clnt.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(2)
+
+		Expect(
+			mrh.enableModuleOnNode(ctx, mld, &node),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		secretValue = "value-2"
+
+		Expect(
+			mrh.enableModuleOnNode(ctx, mld, &node),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(configs).To(HaveLen(2))
+		Expect(configs[0].ValueSourcesDigest).NotTo(BeEmpty())
+		Expect(configs[1].ValueSourcesDigest).NotTo(Equal(configs[0].ValueSourcesDigest))
+		Expect(configs[1].Modprobe).To(Equal(configs[0].Modprobe))
+	})
 })
 
 var _ = Describe("disableModuleOnNode", func() {
@@ -867,7 +1971,7 @@ var _ = Describe("disableModuleOnNode", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		clnt = client.NewMockClient(ctrl)
 		helper = nmc.NewMockHelper(ctrl)
-		mrh = newModuleReconcilerHelper(clnt, nil, nil, helper, scheme)
+		mrh = newModuleReconcilerHelper(clnt, nil, nil, helper, nil, &config.Node{}, scheme)
 		nodeName = "node name"
 		moduleName = "moduleName"
 		moduleNamespace = "moduleNamespace"
@@ -1059,51 +2163,272 @@ var _ = Describe("updateModuleLoaderStatus", func() {
 				return nil
 			},
 		)
-		helper.EXPECT().GetModuleSpecEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleSpec, 0)
-		if modulePresentInStatus {
-			helper.EXPECT().GetModuleStatusEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleStatus)
-		} else {
-			helper.EXPECT().GetModuleStatusEntry(&nmc1, mod.Namespace, mod.Name).Return(nil)
-		}
+		helper.EXPECT().GetModuleSpecEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleSpec, 0)
+		if modulePresentInStatus {
+			helper.EXPECT().GetModuleStatusEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleStatus)
+		} else {
+			helper.EXPECT().GetModuleStatusEntry(&nmc1, mod.Namespace, mod.Name).Return(nil)
+		}
+
+		err := mrh.updateModuleLoaderStatus(ctx, &mod, targetedNodes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mod.Status.ModuleLoader.NodesMatchingSelectorNumber).To(Equal(int32(expectedNodesMatchingSelectorNumber)))
+		Expect(mod.Status.ModuleLoader.DesiredNumber).To(Equal(int32(expectedDesiredNumber)))
+		Expect(mod.Status.ModuleLoader.AvailableNumber).To(Equal(int32(expectedAvailableNumber)))
+	},
+		Entry("2 targeted nodes, module not in status", 2, false, false, 2, 1, 0),
+		Entry("3 targeted nodes, module in status, configs not equal", 2, true, false, 2, 1, 0),
+		Entry("3 targeted nodes, module in status, configs equal", 2, true, true, 2, 1, 1),
+	)
+
+	It("multiple module in spec and status", func() {
+		moduleConfig1 := kmmv1beta1.ModuleConfig{ContainerImage: "some image1"}
+		//moduleConfig2 := kmmv1beta1.ModuleConfig{ContainerImage: "some image2",}
+		nmcModuleSpec := kmmv1beta1.NodeModuleSpec{
+			Config: moduleConfig1,
+		}
+		nmcModuleStatus := kmmv1beta1.NodeModuleStatus{
+			Config: moduleConfig1,
+		}
+		nmc1 := kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "nmc1"},
+		}
+		targetedNodes := []v1.Node{v1.Node{}, v1.Node{}}
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+				list.Items = []kmmv1beta1.NodeModulesConfig{nmc1}
+				return nil
+			},
+		)
+		helper.EXPECT().GetModuleSpecEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleSpec, 0)
+		helper.EXPECT().GetModuleStatusEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleStatus)
+
+		err := mrh.updateModuleLoaderStatus(ctx, &mod, targetedNodes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mod.Status.ModuleLoader.NodesMatchingSelectorNumber).To(Equal(int32(2)))
+		Expect(mod.Status.ModuleLoader.DesiredNumber).To(Equal(int32(1)))
+		Expect(mod.Status.ModuleLoader.AvailableNumber).To(Equal(int32(1)))
+	})
+
+	DescribeTable("should only count modules that passed their readiness check as available", func(conditions []metav1.Condition, expectedAvailableNumber int) {
+		moduleConfig := kmmv1beta1.ModuleConfig{ContainerImage: "some image"}
+		nmc1 := kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "nmc1"},
+		}
+		nmcModuleSpec := kmmv1beta1.NodeModuleSpec{
+			Config:         moduleConfig,
+			ReadinessCheck: &kmmv1beta1.ReadinessCheck{Command: []string{"/bin/check"}},
+		}
+		nmcModuleStatus := kmmv1beta1.NodeModuleStatus{
+			Config:     moduleConfig,
+			Conditions: conditions,
+		}
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+				list.Items = []kmmv1beta1.NodeModulesConfig{nmc1}
+				return nil
+			},
+		)
+		helper.EXPECT().GetModuleSpecEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleSpec, 0)
+		helper.EXPECT().GetModuleStatusEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleStatus)
+
+		err := mrh.updateModuleLoaderStatus(ctx, &mod, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mod.Status.ModuleLoader.AvailableNumber).To(Equal(int32(expectedAvailableNumber)))
+	},
+		Entry("readiness check not run yet", nil, 0),
+		Entry("readiness check failed", []metav1.Condition{{Type: kmmv1beta1.NodeModuleConditionReady, Status: metav1.ConditionFalse}}, 0),
+		Entry("readiness check passed", []metav1.Condition{{Type: kmmv1beta1.NodeModuleConditionReady, Status: metav1.ConditionTrue}}, 1),
+	)
+
+	DescribeTable("should set the Drifted condition", func(drifted bool, expectedStatus metav1.ConditionStatus) {
+		nmc1 := kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "nmc1"},
+		}
+		nmcModuleStatus := kmmv1beta1.NodeModuleStatus{}
+		if drifted {
+			nmcModuleStatus.Conditions = []metav1.Condition{
+				{Type: kmmv1beta1.NodeModuleConditionDrifted, Status: metav1.ConditionTrue},
+			}
+		}
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+				list.Items = []kmmv1beta1.NodeModulesConfig{nmc1}
+				return nil
+			},
+		)
+		helper.EXPECT().GetModuleSpecEntry(&nmc1, mod.Namespace, mod.Name).Return(&kmmv1beta1.NodeModuleSpec{}, 0)
+		helper.EXPECT().GetModuleStatusEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleStatus)
+
+		err := mrh.updateModuleLoaderStatus(ctx, &mod, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		cond := apimeta.FindStatusCondition(mod.Status.Conditions, kmmv1beta1.ModuleConditionDrifted)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(expectedStatus))
+		if drifted {
+			Expect(cond.Message).To(ContainSubstring("nmc1"))
+		}
+	},
+		Entry("module loaded", false, metav1.ConditionFalse),
+		Entry("module drifted", true, metav1.ConditionTrue),
+	)
+})
+
+var _ = Describe("updateConflictCondition", func() {
+	var (
+		ctx  context.Context
+		ctrl *gomock.Controller
+		clnt *client.MockClient
+		mod  kmmv1beta1.Module
+		mrh  *moduleReconcilerHelper
+	)
+
+	newModule := func(namespace, name, kmod string, selector map[string]string) kmmv1beta1.Module {
+		return kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(time.Unix(2000, 0)),
+			},
+			Spec: kmmv1beta1.ModuleSpec{
+				ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{
+					Container: kmmv1beta1.ModuleLoaderContainerSpec{
+						ContainerImage: "registry/" + name + ":${KERNEL_FULL_VERSION}",
+						KernelMappings: []kmmv1beta1.KernelMapping{{Regexp: ".*"}},
+						Modprobe:       kmmv1beta1.ModprobeSpec{ModuleName: kmod},
+					},
+				},
+				Selector: selector,
+			},
+		}
+	}
+
+	newNode := func(name, pool string) v1.Node {
+		return v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"pool": pool}},
+			Status:     v1.NodeStatus{NodeInfo: v1.NodeSystemInfo{KernelVersion: "6.1.0"}},
+		}
+	}
+
+	var targetedNodes []v1.Node
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		mod = newModule("modNamespace", "modName", "kmod", nil)
+		mrh = &moduleReconcilerHelper{
+			client:    clnt,
+			kernelAPI: module.NewKernelMapper(module.NewBuildArgOverrider()),
+			nodeAPI:   node.NewNode(clnt),
+		}
+		targetedNodes = []v1.Node{newNode("node1", "a"), newNode("node2", "b")}
+	})
+
+	It("should return an error if the modules could not be listed", func() {
+		clnt.EXPECT().List(ctx, gomock.Any()).Return(errors.New("some error"))
+
+		Expect(
+			mrh.updateConflictCondition(ctx, &mod, targetedNodes),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	DescribeTable("should set the condition", func(others []kmmv1beta1.Module, expectedStatus metav1.ConditionStatus, expectedMessage string) {
+		clnt.EXPECT().List(ctx, gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.ModuleList, _ ...interface{}) error {
+				list.Items = append([]kmmv1beta1.Module{mod}, others...)
+				return nil
+			},
+		)
 
-		err := mrh.updateModuleLoaderStatus(ctx, &mod, targetedNodes)
-		Expect(err).NotTo(HaveOccurred())
-		Expect(mod.Status.ModuleLoader.NodesMatchingSelectorNumber).To(Equal(int32(expectedNodesMatchingSelectorNumber)))
-		Expect(mod.Status.ModuleLoader.DesiredNumber).To(Equal(int32(expectedDesiredNumber)))
-		Expect(mod.Status.ModuleLoader.AvailableNumber).To(Equal(int32(expectedAvailableNumber)))
+		Expect(
+			mrh.updateConflictCondition(ctx, &mod, targetedNodes),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		cond := apimeta.FindStatusCondition(mod.Status.Conditions, kmmv1beta1.ModuleConditionConflict)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(expectedStatus))
+		Expect(cond.Message).To(Equal(expectedMessage))
 	},
-		Entry("2 targeted nodes, module not in status", 2, false, false, 2, 1, 0),
-		Entry("3 targeted nodes, module in status, configs not equal", 2, true, false, 2, 1, 0),
-		Entry("3 targeted nodes, module in status, configs equal", 2, true, true, 2, 1, 1),
+		Entry("no other module", nil, metav1.ConditionFalse, ""),
+		Entry(
+			"other module with a different kernel module",
+			[]kmmv1beta1.Module{newModule("ns", "other", "other_kmod", nil)},
+			metav1.ConditionFalse,
+			"",
+		),
+		Entry(
+			"other module with the same kernel module on different nodes",
+			[]kmmv1beta1.Module{newModule("ns", "other", "kmod", map[string]string{"pool": "c"})},
+			metav1.ConditionFalse,
+			"",
+		),
+		Entry(
+			"other module with the same kernel module on a common node",
+			[]kmmv1beta1.Module{newModule("ns", "other", "kmod", map[string]string{"pool": "b"})},
+			metav1.ConditionTrue,
+			"Module ns/other also manages kernel module(s) kmod on 1 node(s); this Module takes precedence",
+		),
+		Entry(
+			"older other module with the same kernel module on a common node",
+			[]kmmv1beta1.Module{func() kmmv1beta1.Module {
+				other := newModule("ns", "other", "kmod", map[string]string{"pool": "b"})
+				other.CreationTimestamp = metav1.NewTime(time.Unix(1000, 0))
+				return other
+			}()},
+			metav1.ConditionTrue,
+			"Module ns/other also manages kernel module(s) kmod on 1 node(s); it takes precedence",
+		),
+		Entry(
+			"other module loading the same kernel module through a kernel mapping",
+			[]kmmv1beta1.Module{func() kmmv1beta1.Module {
+				other := newModule("ns", "other", "other_kmod", nil)
+				other.Spec.ModuleLoader.Container.KernelMappings[0].Modprobe = &kmmv1beta1.ModprobeOverride{ModuleName: "kmod"}
+				return other
+			}()},
+			metav1.ConditionTrue,
+			"Module ns/other also manages kernel module(s) kmod on 2 node(s); this Module takes precedence",
+		),
+		Entry(
+			"other module whose kernel mapping loads the kernel module for another kernel",
+			[]kmmv1beta1.Module{func() kmmv1beta1.Module {
+				other := newModule("ns", "other", "other_kmod", nil)
+				other.Spec.ModuleLoader.Container.KernelMappings = append(
+					[]kmmv1beta1.KernelMapping{{Literal: "5.14.0", Modprobe: &kmmv1beta1.ModprobeOverride{ModuleName: "kmod"}}},
+					other.Spec.ModuleLoader.Container.KernelMappings...,
+				)
+				return other
+			}()},
+			metav1.ConditionFalse,
+			"",
+		),
 	)
 
-	It("multiple module in spec and status", func() {
-		moduleConfig1 := kmmv1beta1.ModuleConfig{ContainerImage: "some image1"}
-		//moduleConfig2 := kmmv1beta1.ModuleConfig{ContainerImage: "some image2",}
-		nmcModuleSpec := kmmv1beta1.NodeModuleSpec{
-			Config: moduleConfig1,
-		}
-		nmcModuleStatus := kmmv1beta1.NodeModuleStatus{
-			Config: moduleConfig1,
-		}
-		nmc1 := kmmv1beta1.NodeModulesConfig{
-			ObjectMeta: metav1.ObjectMeta{Name: "nmc1"},
-		}
-		targetedNodes := []v1.Node{v1.Node{}, v1.Node{}}
-		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
-			func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
-				list.Items = []kmmv1beta1.NodeModulesConfig{nmc1}
+	It("should ignore the excluded nodes and the nodes whose taints the other module does not tolerate", func() {
+		targetedNodes[0].Labels[constants.NodeExcludedLabel] = "true"
+		targetedNodes[1].Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}}
+
+		clnt.EXPECT().List(ctx, gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.ModuleList, _ ...interface{}) error {
+				list.Items = []kmmv1beta1.Module{mod, newModule("ns", "other", "kmod", nil)}
 				return nil
 			},
 		)
-		helper.EXPECT().GetModuleSpecEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleSpec, 0)
-		helper.EXPECT().GetModuleStatusEntry(&nmc1, mod.Namespace, mod.Name).Return(&nmcModuleStatus)
 
-		err := mrh.updateModuleLoaderStatus(ctx, &mod, targetedNodes)
-		Expect(err).NotTo(HaveOccurred())
-		Expect(mod.Status.ModuleLoader.NodesMatchingSelectorNumber).To(Equal(int32(2)))
-		Expect(mod.Status.ModuleLoader.DesiredNumber).To(Equal(int32(1)))
-		Expect(mod.Status.ModuleLoader.AvailableNumber).To(Equal(int32(1)))
+		Expect(
+			mrh.updateConflictCondition(ctx, &mod, targetedNodes),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		cond := apimeta.FindStatusCondition(mod.Status.Conditions, kmmv1beta1.ModuleConditionConflict)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
 	})
 })
 
@@ -1276,3 +2601,432 @@ var _ = Describe("namespaceHelper_tryRemovingLabel", func() {
 	})
 
 })
+
+var _ = Describe("updatePausedCondition", func() {
+	mrh := &moduleReconcilerHelper{}
+
+	DescribeTable("should set the Paused condition",
+		func(paused bool, expectedStatus metav1.ConditionStatus, expectedReason string) {
+			mod := kmmv1beta1.Module{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       kmmv1beta1.ModuleSpec{Paused: paused},
+			}
+
+			mrh.updatePausedCondition(&mod)
+
+			cond := apimeta.FindStatusCondition(mod.Status.Conditions, kmmv1beta1.ModuleConditionPaused)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(expectedStatus))
+			Expect(cond.Reason).To(Equal(expectedReason))
+			Expect(cond.ObservedGeneration).To(BeEquivalentTo(3))
+		},
+		Entry("paused", true, metav1.ConditionTrue, "PausedBySpec"),
+		Entry("not paused", false, metav1.ConditionFalse, "NotPaused"),
+	)
+})
+
+var _ = Describe("updatePendingKernelCondition", func() {
+	const annotation = "example.com/pending-kernel"
+
+	var (
+		ctx              context.Context
+		mockKernelMapper *module.MockKernelMapper
+		mockMICAPI       *mic.MockMIC
+		mod              kmmv1beta1.Module
+		mrh              *moduleReconcilerHelper
+	)
+
+	newNode := func(name, kernelVersion, pendingKernel string) v1.Node {
+		return v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{annotation: pendingKernel},
+			},
+			Status: v1.NodeStatus{
+				NodeInfo: v1.NodeSystemInfo{KernelVersion: kernelVersion},
+			},
+		}
+	}
+
+	withKernel := func(n v1.Node, kernelVersion string) *v1.Node {
+		nn := n.DeepCopy()
+		nn.Status.NodeInfo.KernelVersion = kernelVersion
+		return nn
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl := gomock.NewController(GinkgoT())
+		mockKernelMapper = module.NewMockKernelMapper(ctrl)
+		mockMICAPI = mic.NewMockMIC(ctrl)
+		mod = kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "modName", Namespace: "modNamespace", Generation: 2},
+		}
+		mrh = &moduleReconcilerHelper{
+			kernelAPI: mockKernelMapper,
+			micAPI:    mockMICAPI,
+			nodeCfg:   &config.Node{PendingKernelAnnotation: annotation},
+		}
+	})
+
+	It("should remove the condition if no annotation is configured", func() {
+		mrh.nodeCfg = &config.Node{}
+		mod.Status.Conditions = []metav1.Condition{{Type: kmmv1beta1.ModuleConditionPendingKernelImageMissing}}
+
+		Expect(
+			mrh.updatePendingKernelCondition(ctx, &mod, []v1.Node{newNode("node1", "5.14.0", "6.1.0")}),
+		).NotTo(
+			HaveOccurred(),
+		)
+		Expect(mod.Status.Conditions).To(BeEmpty())
+	})
+
+	It("should return an error if the MIC could not be fetched", func() {
+		n := newNode("node1", "5.14.0", "6.1.0")
+
+		mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(&mod, withKernel(n, "6.1.0")).Return(&api.ModuleLoaderData{}, nil)
+		mockMICAPI.EXPECT().Get(ctx, mod.Name, mod.Namespace).Return(nil, errors.New("some error"))
+
+		Expect(
+			mrh.updatePendingKernelCondition(ctx, &mod, []v1.Node{n}),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	It("should report the nodes whose pending kernel has no image", func() {
+		nodes := []v1.Node{
+			newNode("node1", "5.14.0", "6.1.0"),
+			newNode("node2", "5.14.0", "6.2.0"),
+			newNode("node3", "5.14.0", "6.3.0"),
+			newNode("node4", "6.1.0", "6.1.0"),
+			newNode("node5", "5.14.0", ""),
+		}
+		micObj := &kmmv1beta1.ModuleImagesConfig{}
+
+		mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(&mod, withKernel(nodes[0], "6.1.0")).Return(
+			&api.ModuleLoaderData{ContainerImage: "image:6.1.0"}, nil,
+		)
+		mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(&mod, withKernel(nodes[1], "6.2.0")).Return(
+			&api.ModuleLoaderData{ContainerImage: "image:6.2.0"}, nil,
+		)
+		mockKernelMapper.EXPECT().GetModuleLoaderDataForNode(&mod, withKernel(nodes[2], "6.3.0")).Return(
+			nil, module.ErrNoMatchingKernelMapping,
+		)
+		mockMICAPI.EXPECT().Get(ctx, mod.Name, mod.Namespace).Return(micObj, nil)
+		mockMICAPI.EXPECT().GetImageState(micObj, "image:6.1.0").Return(kmmv1beta1.ImageExists)
+		mockMICAPI.EXPECT().GetImageState(micObj, "image:6.2.0").Return(kmmv1beta1.ImageDoesNotExist)
+
+		Expect(
+			mrh.updatePendingKernelCondition(ctx, &mod, nodes),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		cond := apimeta.FindStatusCondition(mod.Status.Conditions, kmmv1beta1.ModuleConditionPendingKernelImageMissing)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("ImageMissing"))
+		Expect(cond.Message).To(Equal("No image for the pending kernel of node(s): node2 (6.2.0), node3 (6.3.0, no kernel mapping)"))
+		Expect(cond.ObservedGeneration).To(BeEquivalentTo(2))
+	})
+
+	It("should set the condition to false if all images exist", func() {
+		Expect(
+			mrh.updatePendingKernelCondition(ctx, &mod, []v1.Node{newNode("node1", "6.1.0+", "6.1.0")}),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		cond := apimeta.FindStatusCondition(mod.Status.Conditions, kmmv1beta1.ModuleConditionPendingKernelImageMissing)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("NoMissingImage"))
+	})
+})
+
+var _ = Describe("updateMaintenanceWindowStatus", func() {
+	mrh := &moduleReconcilerHelper{}
+
+	It("should clear the status if no window is set", func() {
+		mod := kmmv1beta1.Module{
+			Status: kmmv1beta1.ModuleStatus{
+				MaintenanceWindow: &kmmv1beta1.MaintenanceWindowStatus{Open: true},
+			},
+		}
+
+		Expect(
+			mrh.updateMaintenanceWindowStatus(&mod),
+		).NotTo(
+			HaveOccurred(),
+		)
+		Expect(mod.Status.MaintenanceWindow).To(BeNil())
+	})
+
+	It("should return an error if the window is invalid", func() {
+		mod := kmmv1beta1.Module{
+			Spec: kmmv1beta1.ModuleSpec{
+				MaintenanceWindow: &kmmv1beta1.MaintenanceWindow{Schedule: "invalid"},
+			},
+		}
+
+		Expect(
+			mrh.updateMaintenanceWindowStatus(&mod),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	It("should set the next window start", func() {
+		mod := kmmv1beta1.Module{
+			Spec: kmmv1beta1.ModuleSpec{
+				MaintenanceWindow: &kmmv1beta1.MaintenanceWindow{
+					Schedule: "0 0 29 2 *",
+					Duration: metav1.Duration{Duration: time.Minute},
+				},
+			},
+		}
+
+		Expect(
+			mrh.updateMaintenanceWindowStatus(&mod),
+		).NotTo(
+			HaveOccurred(),
+		)
+		Expect(mod.Status.MaintenanceWindow).NotTo(BeNil())
+		Expect(mod.Status.MaintenanceWindow.NextStart).NotTo(BeNil())
+		Expect(mod.Status.MaintenanceWindow.NextStart.Month()).To(Equal(time.February))
+		Expect(mod.Status.MaintenanceWindow.NextStart.Day()).To(Equal(29))
+	})
+})
+
+var _ = Describe("evaluateCanary", func() {
+	var (
+		ctx          context.Context
+		ctrl         *gomock.Controller
+		clnt         *client.MockClient
+		statusWriter *client.MockStatusWriter
+		mod          *kmmv1beta1.Module
+		mrh          *moduleReconcilerHelper
+		hash         string
+	)
+
+	const soakDuration = 10 * time.Minute
+
+	canaryNode := v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "canary", Labels: map[string]string{"canary": "true"}},
+	}
+	otherNode := v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+	}
+	targetedNodes := []v1.Node{canaryNode, otherNode}
+
+	newNMC := func(name string, statusConfig kmmv1beta1.ModuleConfig, conditions ...metav1.Condition) kmmv1beta1.NodeModulesConfig {
+		item := kmmv1beta1.ModuleItem{Name: mod.Name, Namespace: mod.Namespace}
+
+		return kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kmmv1beta1.NodeModulesConfigSpec{
+				Modules: []kmmv1beta1.NodeModuleSpec{
+					{ModuleItem: item, Config: kmmv1beta1.ModuleConfig{ContainerImage: "new-image"}},
+				},
+			},
+			Status: kmmv1beta1.NodeModulesConfigStatus{
+				Modules: []kmmv1beta1.NodeModuleStatus{
+					{ModuleItem: item, Config: statusConfig, Conditions: conditions},
+				},
+			},
+		}
+	}
+
+	expectNMCs := func(nmcs ...kmmv1beta1.NodeModulesConfig) {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.NodeModulesConfigList, _ ...interface{}) error {
+				list.Items = nmcs
+				return nil
+			},
+		)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		statusWriter = client.NewMockStatusWriter(ctrl)
+		mrh = &moduleReconcilerHelper{client: clnt, nmcHelper: nmc.NewHelper(clnt)}
+		mod = &kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "modName", Namespace: "modNamespace"},
+			Spec: kmmv1beta1.ModuleSpec{
+				ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{
+					Container: kmmv1beta1.ModuleLoaderContainerSpec{ContainerImage: "new-image"},
+				},
+				Canary: &kmmv1beta1.CanarySpec{
+					Selector:     map[string]string{"canary": "true"},
+					SoakDuration: metav1.Duration{Duration: soakDuration},
+				},
+			},
+		}
+
+		var err error
+
+		hash, err = moduleLoaderHash(mod)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should promote the current configuration if the canary stage was never run", func() {
+		clnt.EXPECT().Status().Return(statusWriter)
+		statusWriter.EXPECT().Patch(ctx, mod, gomock.Any())
+
+		res, err := mrh.evaluateCanary(ctx, mod, targetedNodes)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.promoted).To(BeTrue())
+		Expect(mod.Status.Canary).To(Equal(&kmmv1beta1.CanaryStatus{
+			Phase:        kmmv1beta1.CanaryPhaseSucceeded,
+			TargetHash:   hash,
+			PromotedHash: hash,
+		}))
+	})
+
+	It("should not patch the status if the current configuration was already promoted", func() {
+		mod.Status.Canary = &kmmv1beta1.CanaryStatus{
+			Phase:        kmmv1beta1.CanaryPhaseSucceeded,
+			TargetHash:   hash,
+			PromotedHash: hash,
+		}
+
+		res, err := mrh.evaluateCanary(ctx, mod, targetedNodes)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.promoted).To(BeTrue())
+	})
+
+	It("should start a new rollout when the configuration changes", func() {
+		mod.Status.Canary = &kmmv1beta1.CanaryStatus{
+			Phase:        kmmv1beta1.CanaryPhaseSucceeded,
+			TargetHash:   "old",
+			PromotedHash: "old",
+		}
+
+		clnt.EXPECT().Status().Return(statusWriter)
+		statusWriter.EXPECT().Patch(ctx, mod, gomock.Any())
+
+		res, err := mrh.evaluateCanary(ctx, mod, targetedNodes)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.promoted).To(BeFalse())
+		Expect(mod.Status.Canary).To(Equal(&kmmv1beta1.CanaryStatus{
+			Phase:        kmmv1beta1.CanaryPhaseProgressing,
+			TargetHash:   hash,
+			PromotedHash: "old",
+		}))
+	})
+
+	It("should not do anything once the rollout failed", func() {
+		mod.Status.Canary = &kmmv1beta1.CanaryStatus{
+			Phase:        kmmv1beta1.CanaryPhaseFailed,
+			TargetHash:   hash,
+			PromotedHash: "old",
+		}
+
+		res, err := mrh.evaluateCanary(ctx, mod, targetedNodes)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.promoted).To(BeFalse())
+	})
+
+	Context("rollout in progress", func() {
+		BeforeEach(func() {
+			mod.Status.Canary = &kmmv1beta1.CanaryStatus{
+				Phase:        kmmv1beta1.CanaryPhaseProgressing,
+				TargetHash:   hash,
+				PromotedHash: "old",
+			}
+		})
+
+		It("should return an error if the NMCs could not be listed", func() {
+			clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+
+			_, err := mrh.evaluateCanary(ctx, mod, targetedNodes)
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should keep progressing while the canary nodes have not applied the change", func() {
+			expectNMCs(newNMC(canaryNode.Name, kmmv1beta1.ModuleConfig{ContainerImage: "old-image"}))
+
+			res, err := mrh.evaluateCanary(ctx, mod, targetedNodes)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.promoted).To(BeFalse())
+			Expect(mod.Status.Canary.Phase).To(Equal(kmmv1beta1.CanaryPhaseProgressing))
+		})
+
+		It("should start soaking once all canary nodes have applied the change", func() {
+			expectNMCs(newNMC(canaryNode.Name, kmmv1beta1.ModuleConfig{ContainerImage: "new-image"}))
+			clnt.EXPECT().Status().Return(statusWriter)
+			statusWriter.EXPECT().Patch(ctx, mod, gomock.Any())
+
+			res, err := mrh.evaluateCanary(ctx, mod, targetedNodes)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.promoted).To(BeFalse())
+			Expect(res.requeueAfter).To(BeNumerically("~", soakDuration, time.Second))
+			Expect(mod.Status.Canary.Phase).To(Equal(kmmv1beta1.CanaryPhaseSoaking))
+			Expect(mod.Status.Canary.SoakStartTime).NotTo(BeNil())
+		})
+
+		It("should promote the configuration after the soak duration", func() {
+			mod.Status.Canary.Phase = kmmv1beta1.CanaryPhaseSoaking
+			mod.Status.Canary.SoakStartTime = &metav1.Time{Time: time.Now().Add(-2 * soakDuration)}
+
+			expectNMCs(newNMC(canaryNode.Name, kmmv1beta1.ModuleConfig{ContainerImage: "new-image"}))
+			clnt.EXPECT().Status().Return(statusWriter)
+			statusWriter.EXPECT().Patch(ctx, mod, gomock.Any())
+
+			res, err := mrh.evaluateCanary(ctx, mod, targetedNodes)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.promoted).To(BeTrue())
+			Expect(mod.Status.Canary).To(Equal(&kmmv1beta1.CanaryStatus{
+				Phase:        kmmv1beta1.CanaryPhaseSucceeded,
+				TargetHash:   hash,
+				PromotedHash: hash,
+			}))
+		})
+
+		DescribeTable("should fail the rollout if too many canary nodes are unhealthy",
+			func(cond metav1.Condition, threshold int32, expectedPhase kmmv1beta1.CanaryPhase) {
+				mod.Spec.Canary.FailureThreshold = threshold
+
+				expectNMCs(newNMC(canaryNode.Name, kmmv1beta1.ModuleConfig{ContainerImage: "new-image"}, cond))
+				clnt.EXPECT().Status().Return(statusWriter)
+				statusWriter.EXPECT().Patch(ctx, mod, gomock.Any())
+
+				res, err := mrh.evaluateCanary(ctx, mod, targetedNodes)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.promoted).To(BeFalse())
+				Expect(mod.Status.Canary.Phase).To(Equal(expectedPhase))
+				Expect(mod.Status.Canary.UnhealthyNodes).To(Equal(int32(1)))
+			},
+			Entry(
+				"not ready",
+				metav1.Condition{Type: kmmv1beta1.NodeModuleConditionReady, Status: metav1.ConditionFalse},
+				int32(0),
+				kmmv1beta1.CanaryPhaseFailed,
+			),
+			Entry(
+				"drifted",
+				metav1.Condition{Type: kmmv1beta1.NodeModuleConditionDrifted, Status: metav1.ConditionTrue},
+				int32(0),
+				kmmv1beta1.CanaryPhaseFailed,
+			),
+			Entry(
+				"within the threshold",
+				metav1.Condition{Type: kmmv1beta1.NodeModuleConditionDrifted, Status: metav1.ConditionTrue},
+				int32(1),
+				kmmv1beta1.CanaryPhaseSoaking,
+			),
+		)
+	})
+})
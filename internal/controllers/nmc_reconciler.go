@@ -1,10 +1,15 @@
 package controllers
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/kubernetes-sigs/kernel-module-management/internal/node"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/pod"
@@ -17,10 +22,15 @@ import (
 	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,13 +45,22 @@ type WorkerAction string
 
 const (
 	NodeModulesConfigReconcilerName = "NodeModulesConfig"
+
+	// readinessCheckRetryDelay is the minimum delay between two runs of a failing readiness check.
+	readinessCheckRetryDelay = time.Minute
+
+	nodeFeatureComponent = "node-feature"
+	nfdNodeNameLabel     = "nfd.node.kubernetes.io/node-name"
 )
 
+var nodeFeatureGVK = schema.GroupVersionKind{Group: "nfd.k8s-sigs.io", Version: "v1alpha1", Kind: "NodeFeature"}
+
 type NMCReconciler struct {
-	client     client.Client
-	helper     nmcReconcilerHelper
-	nodeAPI    node.Node
-	podManager pod.WorkerPodManager
+	client             client.Client
+	helper             nmcReconcilerHelper
+	nodeAPI            node.Node
+	podManager         pod.WorkerPodManager
+	driftCheckInterval time.Duration
 }
 
 func NewNMCReconciler(
@@ -53,12 +72,13 @@ func NewNMCReconciler(
 	nodeAPI node.Node,
 	podManager pod.WorkerPodManager,
 ) *NMCReconciler {
-	helper := newNMCReconcilerHelper(client, podManager, recorder, nodeAPI)
+	helper := newNMCReconcilerHelper(client, podManager, recorder, nodeAPI, workerCfg.DriftCheckInterval)
 	return &NMCReconciler{
-		client:     client,
-		helper:     helper,
-		nodeAPI:    nodeAPI,
-		podManager: podManager,
+		client:             client,
+		helper:             helper,
+		nodeAPI:            nodeAPI,
+		podManager:         podManager,
+		driftCheckInterval: workerCfg.DriftCheckInterval,
 	}
 }
 
@@ -88,10 +108,29 @@ func (r *NMCReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r
 		return ctrl.Result{}, fmt.Errorf("could not get node %s: %v", nmcObj.Name, err)
 	}
 
+	if excluded := utils.IsNodeExcluded(node.Labels); excluded != nmcObj.Status.Excluded {
+		patchFrom := client.MergeFrom(nmcObj.DeepCopy())
+
+		nmcObj.Status.Excluded = excluded
+
+		if err := r.client.Status().Patch(ctx, &nmcObj, patchFrom); err != nil {
+			return ctrl.Result{}, fmt.Errorf("could not patch the excluded status of NMC %s: %v", nmcObj.Name, err)
+		}
+	}
+
+	if nmcObj.Status.Excluded {
+		logger.Info("Node is excluded from KMM operations; not processing the NMC")
+		return ctrl.Result{}, nil
+	}
+
 	if err := r.helper.SyncStatus(ctx, &nmcObj, &node); err != nil {
 		return reconcile.Result{}, fmt.Errorf("could not reconcile status for NodeModulesConfig %s: %v", nmcObj.Name, err)
 	}
 
+	if err := r.helper.ProcessOrphanedModules(ctx, &nmcObj); err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not process the orphaned modules of NodeModulesConfig %s: %v", nmcObj.Name, err)
+	}
+
 	// Statuses are now up-to-date.
 
 	statusMap := make(map[string]*kmmv1beta1.NodeModuleStatus, len(nmcObj.Status.Modules))
@@ -108,6 +147,12 @@ func (r *NMCReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r
 
 		logger := logger.WithValues("module", moduleNameKey)
 
+		if mod.Orphaned {
+			// handled by ProcessOrphanedModules; the kernel module must not be unloaded
+			delete(statusMap, moduleNameKey)
+			continue
+		}
+
 		// skipping handling NMC spec module until node is ready
 		if !r.nodeAPI.IsNodeSchedulable(&node, mod.Tolerations) {
 			readyLabelsToRemove[utils.GetKernelModuleReadyNodeLabel(mod.Namespace, mod.Name)] = ""
@@ -163,7 +208,168 @@ func (r *NMCReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r
 		r.helper.RecordEvents(&node, loaded, unloaded)
 	}
 
-	return ctrl.Result{}, errors.Join(errs...)
+	if err := r.helper.SyncNodeFeatures(ctx, &nmcObj, &node); err != nil {
+		errs = append(errs, fmt.Errorf("could not sync NodeFeatures for NMC %s: %v", req.NamespacedName, err))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var requeueAfter time.Duration
+
+	// Come back later to check that the loaded kernel modules have not drifted.
+	if r.driftCheckInterval > 0 && len(nmcObj.Status.Modules) > 0 {
+		requeueAfter = r.driftCheckInterval
+	}
+
+	// Come back later to retry failed readiness checks.
+	if hasFailedReadinessCheck(&nmcObj) && (requeueAfter == 0 || readinessCheckRetryDelay < requeueAfter) {
+		requeueAfter = readinessCheckRetryDelay
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func hasFailedReadinessCheck(nmcObj *kmmv1beta1.NodeModulesConfig) bool {
+	for _, s := range nmcObj.Spec.Modules {
+		if s.ReadinessCheck == nil {
+			continue
+		}
+
+		status := nmc.FindModuleStatus(nmcObj.Status.Modules, s.Namespace, s.Name)
+		if status != nil && apimeta.IsStatusConditionFalse(status.Conditions, kmmv1beta1.NodeModuleConditionReady) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notReadyModules returns the modules that are recorded as loaded in the status, but that drifted or did not pass
+// their readiness check yet.
+func notReadyModules(nmcObj *kmmv1beta1.NodeModulesConfig) sets.Set[types.NamespacedName] {
+	notReady := sets.New[types.NamespacedName]()
+
+	for _, s := range nmcObj.Status.Modules {
+		if apimeta.IsStatusConditionTrue(s.Conditions, kmmv1beta1.NodeModuleConditionDrifted) {
+			notReady.Insert(types.NamespacedName{Namespace: s.Namespace, Name: s.Name})
+		}
+	}
+
+	for _, s := range nmcObj.Spec.Modules {
+		if s.ReadinessCheck == nil {
+			continue
+		}
+
+		status := nmc.FindModuleStatus(nmcObj.Status.Modules, s.Namespace, s.Name)
+		if status != nil && !apimeta.IsStatusConditionTrue(status.Conditions, kmmv1beta1.NodeModuleConditionReady) {
+			notReady.Insert(types.NamespacedName{Namespace: s.Namespace, Name: s.Name})
+		}
+	}
+
+	return notReady
+}
+
+// readyModules returns the modules that are loaded on the node with the configuration from the spec, and that did not
+// drift or fail their readiness check.
+func readyModules(nmcObj *kmmv1beta1.NodeModulesConfig) sets.Set[types.NamespacedName] {
+	ready := sets.New[types.NamespacedName]()
+	notReady := notReadyModules(nmcObj)
+
+	for _, s := range nmcObj.Spec.Modules {
+		if s.Orphaned {
+			continue
+		}
+
+		nsn := types.NamespacedName{Namespace: s.Namespace, Name: s.Name}
+
+		status := nmc.FindModuleStatus(nmcObj.Status.Modules, s.Namespace, s.Name)
+		if status != nil && reflect.DeepEqual(s.Config, status.Config) && !notReady.Has(nsn) {
+			ready.Insert(nsn)
+		}
+	}
+
+	return ready
+}
+
+// nodeLabelChanges returns the user-defined labels to add to and to remove from node.
+// The keys of the labels that KMM added for each module are recorded in a node annotation, so that they can be removed
+// once the module is not ready or not configured anymore.
+// If several modules set the same label to different values, the first module by namespace and name wins; a message
+// is returned for each label that another module wanted to set to a different value.
+func nodeLabelChanges(nmcObj *kmmv1beta1.NodeModulesConfig, node *v1.Node) (labelsToAdd, labelsToRemove, annotationsToAdd, annotationsToRemove map[string]string, conflicts []string) {
+	labelsToAdd = make(map[string]string)
+	labelsToRemove = make(map[string]string)
+	annotationsToAdd = make(map[string]string)
+	annotationsToRemove = make(map[string]string)
+
+	ready := readyModules(nmcObj)
+	desiredAnnotations := make(map[string]string)
+	labelOwners := make(map[string]types.NamespacedName)
+
+	specs := slices.Clone(nmcObj.Spec.Modules)
+	slices.SortFunc(specs, func(a, b kmmv1beta1.NodeModuleSpec) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+
+	for _, s := range specs {
+		nsn := types.NamespacedName{Namespace: s.Namespace, Name: s.Name}
+
+		if len(s.NodeLabels) == 0 || !ready.Has(nsn) {
+			continue
+		}
+
+		for label, value := range s.NodeLabels {
+			owner, ok := labelOwners[label]
+			if !ok {
+				labelsToAdd[label] = value
+				labelOwners[label] = nsn
+				continue
+			}
+
+			if labelsToAdd[label] != value {
+				conflicts = append(
+					conflicts,
+					fmt.Sprintf("Module %s sets node label %s to %q, but Module %s sets it to %q which takes precedence",
+						nsn, label, value, owner, labelsToAdd[label]),
+				)
+			}
+		}
+
+		key := utils.GetNodeLabelsAnnotation(s.Namespace, s.Name)
+		desiredAnnotations[key] = strings.Join(slices.Sorted(maps.Keys(s.NodeLabels)), ",")
+
+		if value, ok := node.Annotations[key]; !ok || value != desiredAnnotations[key] {
+			annotationsToAdd[key] = desiredAnnotations[key]
+		}
+	}
+
+	slices.Sort(conflicts)
+
+	for key, value := range node.GetAnnotations() {
+		if ok, _, _ := utils.IsNodeLabelsAnnotation(key); !ok {
+			continue
+		}
+
+		for _, label := range strings.Split(value, ",") {
+			if _, ok := labelsToAdd[label]; !ok && label != "" {
+				labelsToRemove[label] = ""
+			}
+		}
+
+		if _, ok := desiredAnnotations[key]; !ok {
+			annotationsToRemove[key] = ""
+		}
+	}
+
+	for label, value := range labelsToAdd {
+		if current, ok := node.Labels[label]; ok && current == value {
+			delete(labelsToAdd, label)
+		}
+	}
+
+	return labelsToAdd, labelsToRemove, annotationsToAdd, annotationsToRemove, conflicts
 }
 
 func (r *NMCReconciler) SetupWithManager(ctx context.Context, mgr manager.Manager) error {
@@ -212,28 +418,38 @@ type nmcReconcilerHelper interface {
 	GarbageCollectInUseLabels(ctx context.Context, nmc *kmmv1beta1.NodeModulesConfig) error
 	GarbageCollectWorkerPods(ctx context.Context, nmc *kmmv1beta1.NodeModulesConfig) error
 	ProcessModuleSpec(ctx context.Context, nmc *kmmv1beta1.NodeModulesConfig, spec *kmmv1beta1.NodeModuleSpec, status *kmmv1beta1.NodeModuleStatus, node *v1.Node) error
+	ProcessOrphanedModules(ctx context.Context, nmc *kmmv1beta1.NodeModulesConfig) error
 	ProcessUnconfiguredModuleStatus(ctx context.Context, nmc *kmmv1beta1.NodeModulesConfig, status *kmmv1beta1.NodeModuleStatus, node *v1.Node) error
 	RemovePodFinalizers(ctx context.Context, nodeName string) error
 	SyncStatus(ctx context.Context, nmc *kmmv1beta1.NodeModulesConfig, node *v1.Node) error
 	UpdateNodeLabels(ctx context.Context, nmc *kmmv1beta1.NodeModulesConfig, node *v1.Node) ([]types.NamespacedName, []types.NamespacedName, error)
 	RecordEvents(node *v1.Node, loadedModules, unloadedModules []types.NamespacedName)
+	SyncNodeFeatures(ctx context.Context, nmc *kmmv1beta1.NodeModulesConfig, node *v1.Node) error
 }
 
 type nmcReconcilerHelperImpl struct {
-	client     client.Client
-	podManager pod.WorkerPodManager
-	recorder   record.EventRecorder
-	nodeAPI    node.Node
-	lph        labelPreparationHelper
+	client             client.Client
+	podManager         pod.WorkerPodManager
+	recorder           record.EventRecorder
+	nodeAPI            node.Node
+	lph                labelPreparationHelper
+	driftCheckInterval time.Duration
 }
 
-func newNMCReconcilerHelper(client client.Client, podManager pod.WorkerPodManager, recorder record.EventRecorder, nodeAPI node.Node) nmcReconcilerHelper {
+func newNMCReconcilerHelper(
+	client client.Client,
+	podManager pod.WorkerPodManager,
+	recorder record.EventRecorder,
+	nodeAPI node.Node,
+	driftCheckInterval time.Duration,
+) nmcReconcilerHelper {
 	return &nmcReconcilerHelperImpl{
-		client:     client,
-		podManager: podManager,
-		recorder:   recorder,
-		nodeAPI:    nodeAPI,
-		lph:        newLabelPreparationHelper(),
+		client:             client,
+		podManager:         podManager,
+		recorder:           recorder,
+		nodeAPI:            nodeAPI,
+		lph:                newLabelPreparationHelper(),
+		driftCheckInterval: driftCheckInterval,
 	}
 }
 
@@ -332,6 +548,11 @@ func (h *nmcReconcilerHelperImpl) GarbageCollectInUseLabels(ctx context.Context,
 //
 // An unloading worker Pod is created when the entry in .spec.modules has a different config compared to the entry in
 // .status.modules.
+// If the module defines a readiness check, a readiness check Pod is created once the module is loaded and until the
+// check passes.
+// If drift detection is enabled, a checker Pod is created when the last drift check is older than the configured
+// interval, and a loading worker Pod is created if the module has drifted and its drift policy is Reload.
+// No Pod is created for a paused module.
 func (h *nmcReconcilerHelperImpl) ProcessModuleSpec(
 	ctx context.Context,
 	nmcObj *kmmv1beta1.NodeModulesConfig,
@@ -343,6 +564,11 @@ func (h *nmcReconcilerHelperImpl) ProcessModuleSpec(
 
 	logger := ctrl.LoggerFrom(ctx)
 
+	if spec.Paused {
+		logger.Info("Module is paused; not creating worker Pods")
+		return nil
+	}
+
 	p, err := h.podManager.GetWorkerPod(ctx, podName, spec.Namespace)
 	if err != nil {
 		return fmt.Errorf("could not get the worker Pod %s: %v", podName, err)
@@ -373,6 +599,33 @@ func (h *nmcReconcilerHelperImpl) ProcessModuleSpec(
 			return h.podManager.CreateLoaderPod(ctx, nmcObj, spec)
 		}
 
+		if spec.ReadinessCheck != nil && !apimeta.IsStatusConditionTrue(status.Conditions, kmmv1beta1.NodeModuleConditionReady) {
+			if status.LastReadinessCheckTime != nil && time.Since(status.LastReadinessCheckTime.Time) < readinessCheckRetryDelay {
+				logger.Info("Readiness check failed recently; waiting before retrying")
+				return nil
+			}
+
+			logger.Info("Kernel module loaded but not ready yet; creating readiness check Pod")
+			// The Pod of a previous reconciliation may still be running.
+			return client.IgnoreAlreadyExists(h.podManager.CreateReadinessCheckPod(ctx, nmcObj, spec))
+		}
+
+		if h.driftCheckInterval == 0 {
+			return nil
+		}
+
+		if spec.DriftPolicy == kmmv1beta1.DriftPolicyReload &&
+			apimeta.IsStatusConditionTrue(status.Conditions, kmmv1beta1.NodeModuleConditionDrifted) {
+			logger.Info("Kernel module drifted and the drift policy is Reload; creating loader Pod")
+			return h.podManager.CreateLoaderPod(ctx, nmcObj, spec)
+		}
+
+		if status.LastDriftCheckTime == nil || time.Since(status.LastDriftCheckTime.Time) >= h.driftCheckInterval {
+			logger.Info("Drift check is due; creating checker Pod")
+			// The Pod of a previous reconciliation may still be running.
+			return client.IgnoreAlreadyExists(h.podManager.CreateCheckerPod(ctx, nmcObj, status))
+		}
+
 		return nil
 	}
 
@@ -399,6 +652,86 @@ func (h *nmcReconcilerHelperImpl) ProcessModuleSpec(
 	return nil
 }
 
+// ProcessOrphanedModules removes the orphaned spec entries and their status entries from nmcObj, leaving the kernel
+// modules loaded on the node.
+// The worker Pods of an orphaned module are deleted first, and its entries are only removed once they are gone, so
+// that no worker Pod can record a status after the spec entry was removed, which would unload the kernel module.
+func (h *nmcReconcilerHelperImpl) ProcessOrphanedModules(ctx context.Context, nmcObj *kmmv1beta1.NodeModulesConfig) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	orphaned := sets.New[types.NamespacedName]()
+
+	for _, s := range nmcObj.Spec.Modules {
+		if s.Orphaned {
+			orphaned.Insert(types.NamespacedName{Namespace: s.Namespace, Name: s.Name})
+		}
+	}
+
+	if orphaned.Len() == 0 {
+		return nil
+	}
+
+	pods, err := h.podManager.ListWorkerPodsOnNode(ctx, nmcObj.Name)
+	if err != nil {
+		return fmt.Errorf("could not list worker Pods: %v", err)
+	}
+
+	errs := make([]error, 0, len(pods))
+	withPods := sets.New[types.NamespacedName]()
+
+	for i := range pods {
+		p := &pods[i]
+		nsn := types.NamespacedName{Namespace: p.Namespace, Name: p.Labels[constants.ModuleNameLabel]}
+
+		if !orphaned.Has(nsn) {
+			continue
+		}
+
+		withPods.Insert(nsn)
+
+		if err = h.podManager.DeletePod(ctx, p); err != nil {
+			errs = append(errs, fmt.Errorf("could not delete worker Pod %s/%s: %v", p.Namespace, p.Name, err))
+		}
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if withPods.Len() > 0 {
+		logger.Info("Waiting for the worker Pods of orphaned modules to be deleted", "count", withPods.Len())
+	}
+
+	toRemove := orphaned.Difference(withPods)
+	if toRemove.Len() == 0 {
+		return nil
+	}
+
+	statusFrom := client.MergeFrom(nmcObj.DeepCopy())
+
+	for nsn := range toRemove {
+		nmc.RemoveModuleStatus(&nmcObj.Status.Modules, nsn.Namespace, nsn.Name)
+	}
+
+	if err = h.client.Status().Patch(ctx, nmcObj, statusFrom); err != nil {
+		return fmt.Errorf("could not remove the status of orphaned modules: %v", err)
+	}
+
+	specFrom := client.MergeFrom(nmcObj.DeepCopy())
+
+	nmcObj.Spec.Modules = slices.DeleteFunc(nmcObj.Spec.Modules, func(s kmmv1beta1.NodeModuleSpec) bool {
+		return toRemove.Has(types.NamespacedName{Namespace: s.Namespace, Name: s.Name})
+	})
+
+	if err = h.client.Patch(ctx, nmcObj, specFrom); err != nil {
+		return fmt.Errorf("could not remove orphaned modules from the spec: %v", err)
+	}
+
+	logger.Info("Removed orphaned modules; their kernel modules are left loaded", "count", toRemove.Len())
+
+	return nil
+}
+
 // ProcessUnconfiguredModuleStatus cleans up a NodeModuleStatus.
 // It should be called for each status entry for which the NodeModulesConfigs does not have a spec entry; this means
 // that KMM wants the module unloaded from the node.
@@ -504,9 +837,14 @@ func (h *nmcReconcilerHelperImpl) SyncStatus(ctx context.Context, nmcObj *kmmv1b
 	}
 
 	specEntries := sets.New[types.NamespacedName]()
+	orphaned := sets.New[types.NamespacedName]()
 
 	for _, e := range nmcObj.Spec.Modules {
 		specEntries.Insert(types.NamespacedName{Namespace: e.Namespace, Name: e.Name})
+
+		if e.Orphaned {
+			orphaned.Insert(types.NamespacedName{Namespace: e.Namespace, Name: e.Name})
+		}
 	}
 
 	patchFrom := client.MergeFrom(nmcObj.DeepCopy())
@@ -524,6 +862,12 @@ func (h *nmcReconcilerHelperImpl) SyncStatus(ctx context.Context, nmcObj *kmmv1b
 
 		logger.Info("Processing worker Pod")
 
+		if orphaned.Has(types.NamespacedName{Namespace: modNamespace, Name: modName}) {
+			// ProcessOrphanedModules deletes the Pod; its result must not change the status of the module.
+			logger.Info("Pod of an orphaned module; ignoring it")
+			continue
+		}
+
 		status := nmc.FindModuleStatus(nmcObj.Status.Modules, modNamespace, modName)
 
 		switch phase {
@@ -534,6 +878,15 @@ func (h *nmcReconcilerHelperImpl) SyncStatus(ctx context.Context, nmcObj *kmmv1b
 				podsToDelete = append(podsToDelete, p)
 			}
 		case v1.PodFailed:
+			if status != nil {
+				if h.podManager.IsCheckerPod(&p) {
+					// Do not retry the check before the next interval.
+					logger.Info(utils.WarnString("Drift check failed"))
+					status.LastDriftCheckTime = ptr.To(metav1.Now())
+				} else if h.podManager.IsReadinessCheckPod(&p) {
+					h.setReadyCondition(node, status, &p)
+				}
+			}
 			podsToDelete = append(podsToDelete, p)
 		case v1.PodSucceeded:
 			if h.podManager.IsUnloaderPod(&p) {
@@ -542,6 +895,22 @@ func (h *nmcReconcilerHelperImpl) SyncStatus(ctx context.Context, nmcObj *kmmv1b
 				break
 			}
 
+			if h.podManager.IsCheckerPod(&p) {
+				if status != nil {
+					h.setDriftedCondition(node, status, &p)
+				}
+				podsToDelete = append(podsToDelete, p)
+				break
+			}
+
+			if h.podManager.IsReadinessCheckPod(&p) {
+				if status != nil {
+					h.setReadyCondition(node, status, &p)
+				}
+				podsToDelete = append(podsToDelete, p)
+				break
+			}
+
 			if status == nil {
 				status = &kmmv1beta1.NodeModuleStatus{
 					ModuleItem: kmmv1beta1.ModuleItem{
@@ -577,6 +946,17 @@ func (h *nmcReconcilerHelperImpl) SyncStatus(ctx context.Context, nmcObj *kmmv1b
 
 			status.Version = h.podManager.GetModuleVersionAnnotation(&p)
 
+			// The loader Pod writes the srcversion of the kernel module in its termination message.
+			status.SrcVersion = ""
+			if terminated := GetContainerStatus(p.Status.ContainerStatuses, pod.WorkerContainerName).State.Terminated; terminated != nil {
+				status.SrcVersion = terminated.Message
+			}
+
+			// The kernel module was just loaded, so it has not drifted and its readiness must be checked again.
+			apimeta.RemoveStatusCondition(&status.Conditions, kmmv1beta1.NodeModuleConditionDrifted)
+			apimeta.RemoveStatusCondition(&status.Conditions, kmmv1beta1.NodeModuleConditionReady)
+			status.LastReadinessCheckTime = nil
+
 			nmc.SetModuleStatus(&nmcObj.Status.Modules, *status)
 
 			podsToDelete = append(podsToDelete, p)
@@ -602,6 +982,83 @@ func (h *nmcReconcilerHelperImpl) SyncStatus(ctx context.Context, nmcObj *kmmv1b
 	return errors.Join(errs...)
 }
 
+// setDriftedCondition records the result of a successful checker Pod in status.
+// The checker Pod writes the comma-separated list of missing kernel modules in its termination message.
+func (h *nmcReconcilerHelperImpl) setDriftedCondition(node *v1.Node, status *kmmv1beta1.NodeModuleStatus, p *v1.Pod) {
+	missing := ""
+
+	if terminated := GetContainerStatus(p.Status.ContainerStatuses, pod.WorkerContainerName).State.Terminated; terminated != nil {
+		missing = terminated.Message
+	}
+
+	cond := metav1.Condition{
+		Type:    kmmv1beta1.NodeModuleConditionDrifted,
+		Status:  metav1.ConditionFalse,
+		Reason:  "Loaded",
+		Message: "All kernel modules are loaded",
+	}
+
+	if missing != "" {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "NotLoaded"
+		cond.Message = fmt.Sprintf("Kernel module(s) %s not found in /proc/modules", missing)
+	}
+
+	status.LastDriftCheckTime = ptr.To(metav1.Now())
+
+	if apimeta.SetStatusCondition(&status.Conditions, cond) && cond.Status == metav1.ConditionTrue {
+		nsn := types.NamespacedName{Namespace: status.Namespace, Name: status.Name}
+
+		h.recorder.AnnotatedEventf(
+			node,
+			map[string]string{"module": nsn.String()},
+			v1.EventTypeWarning,
+			"ModuleDrifted",
+			"Module %s: %s",
+			nsn.String(),
+			cond.Message,
+		)
+	}
+}
+
+// setReadyCondition records the result of a completed readiness check Pod in status.
+func (h *nmcReconcilerHelperImpl) setReadyCondition(node *v1.Node, status *kmmv1beta1.NodeModuleStatus, p *v1.Pod) {
+	cond := metav1.Condition{
+		Type:    kmmv1beta1.NodeModuleConditionReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ReadinessCheckPassed",
+		Message: "The readiness check passed",
+	}
+
+	if p.Status.Phase == v1.PodFailed {
+		message := p.Status.Message
+
+		if terminated := GetContainerStatus(p.Status.ContainerStatuses, pod.WorkerContainerName).State.Terminated; terminated != nil {
+			message = fmt.Sprintf("exit code %d: %s", terminated.ExitCode, terminated.Message)
+		}
+
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "ReadinessCheckFailed"
+		cond.Message = fmt.Sprintf("The readiness check failed: %s", message)
+
+		nsn := types.NamespacedName{Namespace: status.Namespace, Name: status.Name}
+
+		h.recorder.AnnotatedEventf(
+			node,
+			map[string]string{"module": nsn.String()},
+			v1.EventTypeWarning,
+			"ModuleReadinessCheckFailed",
+			"Module %s: %s",
+			nsn.String(),
+			cond.Message,
+		)
+	}
+
+	status.LastReadinessCheckTime = ptr.To(metav1.Now())
+
+	apimeta.SetStatusCondition(&status.Conditions, cond)
+}
+
 func (h *nmcReconcilerHelperImpl) UpdateNodeLabels(ctx context.Context, nmc *kmmv1beta1.NodeModulesConfig, node *v1.Node) ([]types.NamespacedName, []types.NamespacedName, error) {
 
 	// get all the kernel module ready labels of the node
@@ -620,6 +1077,15 @@ func (h *nmcReconcilerHelperImpl) UpdateNodeLabels(ctx context.Context, nmc *kmm
 	// label in node but not in spec or status - should be removed
 	nsnLabelsToBeRemoved := h.lph.removeOrphanedLabels(nodeModuleReadyLabels, specLabels, statusLabels)
 
+	// a module that drifted or did not pass its readiness check is not ready - its label should be removed and not added back
+	for nsn := range notReadyModules(nmc) {
+		delete(statusLabels, nsn)
+
+		if nodeModuleReadyLabels.Has(nsn) {
+			nsnLabelsToBeRemoved = append(nsnLabelsToBeRemoved, nsn)
+		}
+	}
+
 	// label in spec and status and config equal - should be added
 	nsnLabelsToBeLoaded := h.lph.addEqualLabels(nodeModuleReadyLabels, specLabels, statusLabels)
 
@@ -640,10 +1106,34 @@ func (h *nmcReconcilerHelperImpl) UpdateNodeLabels(ctx context.Context, nmc *kmm
 		}
 	}
 
+	userLabelsToAdd, userLabelsToRemove, annotationsToAdd, annotationsToRemove, conflicts := nodeLabelChanges(nmc, node)
+
+	for _, c := range conflicts {
+		ctrl.LoggerFrom(ctx).Info(utils.WarnString(c))
+		h.recorder.Event(node, v1.EventTypeWarning, "NodeLabelConflict", c)
+	}
+
+	maps.Copy(loadedLabels, userLabelsToAdd)
+
+	if len(userLabelsToRemove) > 0 {
+		if unloadedLabels == nil {
+			unloadedLabels = make(map[string]string, len(userLabelsToRemove))
+		}
+
+		maps.Copy(unloadedLabels, userLabelsToRemove)
+	}
+
 	if err := h.nodeAPI.UpdateLabels(ctx, node, loadedLabels, unloadedLabels); err != nil {
 		return nil, nil, fmt.Errorf("could not update labels on the node: %v", err)
 	}
 
+	// Record which user-defined labels were applied, so that they can be removed once the module is unloaded.
+	if len(annotationsToAdd) > 0 || len(annotationsToRemove) > 0 {
+		if err := h.nodeAPI.UpdateAnnotations(ctx, node, annotationsToAdd, annotationsToRemove); err != nil {
+			return nil, nil, fmt.Errorf("could not update annotations on the node: %v", err)
+		}
+	}
+
 	return nsnLabelsToBeLoaded, nsnLabelsToBeRemoved, nil
 }
 
@@ -670,6 +1160,110 @@ func (h *nmcReconcilerHelperImpl) RecordEvents(node *v1.Node, loadedModules, unl
 	}
 }
 
+// SyncNodeFeatures creates a NodeFeature object for each ready module that should be published to Node Feature
+// Discovery, and deletes the NodeFeature objects of the other modules.
+func (h *nmcReconcilerHelperImpl) SyncNodeFeatures(ctx context.Context, nmcObj *kmmv1beta1.NodeModulesConfig, node *v1.Node) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	ready := readyModules(nmcObj)
+	toPublish := make(map[types.NamespacedName]*kmmv1beta1.NodeModuleStatus)
+
+	for _, s := range nmcObj.Spec.Modules {
+		nsn := types.NamespacedName{Namespace: s.Namespace, Name: s.Name}
+
+		if s.PublishNodeFeature && ready.Has(nsn) {
+			toPublish[nsn] = nmc.FindModuleStatus(nmcObj.Status.Modules, s.Namespace, s.Name)
+		}
+	}
+
+	existing := unstructured.UnstructuredList{}
+	existing.SetGroupVersionKind(nodeFeatureGVK.GroupVersion().WithKind(nodeFeatureGVK.Kind + "List"))
+
+	opts := client.MatchingLabels{
+		nfdNodeNameLabel:              node.Name,
+		"app.kubernetes.io/component": nodeFeatureComponent,
+		"app.kubernetes.io/part-of":   "kmm",
+	}
+
+	if err := h.client.List(ctx, &existing, opts); err != nil {
+		if apimeta.IsNoMatchError(err) && len(toPublish) == 0 {
+			// Node Feature Discovery is not installed and no module needs it.
+			return nil
+		}
+
+		return fmt.Errorf("could not list NodeFeatures for node %s: %v", node.Name, err)
+	}
+
+	errs := make([]error, 0, len(existing.Items)+len(toPublish))
+
+	for _, nf := range existing.Items {
+		nsn := types.NamespacedName{Namespace: nf.GetNamespace(), Name: nf.GetLabels()[constants.ModuleNameLabel]}
+
+		if _, ok := toPublish[nsn]; ok {
+			continue
+		}
+
+		logger.Info("Deleting NodeFeature", "name", nf.GetName(), "namespace", nf.GetNamespace())
+
+		if err := h.client.Delete(ctx, &nf); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("could not delete NodeFeature %s/%s: %v", nf.GetNamespace(), nf.GetName(), err))
+		}
+	}
+
+	for nsn, status := range toPublish {
+		nf := &unstructured.Unstructured{}
+		nf.SetGroupVersionKind(nodeFeatureGVK)
+		nf.SetName(nodeFeatureName(node.Name, nsn.Name))
+		nf.SetNamespace(nsn.Namespace)
+
+		res, err := controllerutil.CreateOrPatch(ctx, h.client, nf, func() error {
+			nf.SetLabels(map[string]string{
+				nfdNodeNameLabel:              node.Name,
+				"app.kubernetes.io/component": nodeFeatureComponent,
+				"app.kubernetes.io/name":      "kmm",
+				"app.kubernetes.io/part-of":   "kmm",
+				constants.ModuleNameLabel:     nsn.Name,
+			})
+
+			elements := map[string]interface{}{
+				"name":       status.Config.Modprobe.ModuleName,
+				"version":    status.Version,
+				"srcversion": status.SrcVersion,
+			}
+
+			features := map[string]interface{}{
+				"attributes": map[string]interface{}{
+					nodeFeatureAttributeName(nsn): map[string]interface{}{"elements": elements},
+				},
+			}
+
+			if err := unstructured.SetNestedMap(nf.Object, features, "spec", "features"); err != nil {
+				return fmt.Errorf("could not set the features: %v", err)
+			}
+
+			return controllerutil.SetOwnerReference(nmcObj, nf, h.client.Scheme())
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not create or patch NodeFeature for module %s: %v", nsn, err))
+			continue
+		}
+
+		logger.V(1).Info("Reconciled NodeFeature", "module", nsn, "result", res)
+	}
+
+	return errors.Join(errs...)
+}
+
+func nodeFeatureName(nodeName, moduleName string) string {
+	return fmt.Sprintf("kmm-%s-%s", nodeName, moduleName)
+}
+
+// nodeFeatureAttributeName returns the name of the Node Feature Discovery attribute feature that describes the kernel
+// module loaded by a Module.
+func nodeFeatureAttributeName(nsn types.NamespacedName) string {
+	return fmt.Sprintf("kmm.%s.%s", nsn.Namespace, nsn.Name)
+}
+
 type labelPreparationHelper interface {
 	getDeprecatedKernelModuleReadyLabels(node v1.Node) map[string]string
 	getNodeKernelModuleReadyLabels(node v1.Node) sets.Set[types.NamespacedName]
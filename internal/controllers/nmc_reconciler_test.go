@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/kubernetes-sigs/kernel-module-management/internal/node"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/pod"
@@ -20,10 +21,13 @@ import (
 	"go.uber.org/mock/gomock"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -123,6 +127,89 @@ var _ = Describe("NodeModulesConfigReconciler_Reconcile", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("should mark the NMC as excluded and not process it if the node is excluded", func() {
+		nmc := &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+		}
+		sw := testclient.NewMockStatusWriter(gomock.NewController(GinkgoT()))
+		gomock.InOrder(
+			kubeClient.
+				EXPECT().
+				Get(ctx, nmcNsn, &kmmv1beta1.NodeModulesConfig{}).
+				Do(func(_ context.Context, _ types.NamespacedName, kubeNmc ctrlclient.Object, _ ...ctrlclient.Options) {
+					*kubeNmc.(*kmmv1beta1.NodeModulesConfig) = *nmc
+				}),
+			kubeClient.
+				EXPECT().
+				Get(ctx, types.NamespacedName{Name: nmc.Name}, &v1.Node{}).
+				Do(func(_ context.Context, _ types.NamespacedName, kubeNode ctrlclient.Object, _ ...ctrlclient.Options) {
+					kubeNode.SetLabels(map[string]string{constants.NodeExcludedLabel: "true"})
+				}),
+			kubeClient.EXPECT().Status().Return(sw),
+			sw.
+				EXPECT().
+				Patch(ctx, gomock.Any(), gomock.Any()).
+				Do(func(_ context.Context, obj ctrlclient.Object, _ ctrlclient.Patch, _ ...ctrlclient.SubResourcePatchOption) {
+					Expect(obj.(*kmmv1beta1.NodeModulesConfig).Status.Excluded).To(BeTrue())
+				}),
+		)
+
+		Expect(
+			r.Reconcile(ctx, req),
+		).To(
+			Equal(ctrl.Result{}),
+		)
+	})
+
+	It("should not patch the NMC status if the node is still excluded", func() {
+		nmc := &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+			Status:     kmmv1beta1.NodeModulesConfigStatus{Excluded: true},
+		}
+		gomock.InOrder(
+			kubeClient.
+				EXPECT().
+				Get(ctx, nmcNsn, &kmmv1beta1.NodeModulesConfig{}).
+				Do(func(_ context.Context, _ types.NamespacedName, kubeNmc ctrlclient.Object, _ ...ctrlclient.Options) {
+					*kubeNmc.(*kmmv1beta1.NodeModulesConfig) = *nmc
+				}),
+			kubeClient.
+				EXPECT().
+				Get(ctx, types.NamespacedName{Name: nmc.Name}, &v1.Node{}).
+				Do(func(_ context.Context, _ types.NamespacedName, kubeNode ctrlclient.Object, _ ...ctrlclient.Options) {
+					kubeNode.SetLabels(map[string]string{constants.NodeExcludedLabel: "true"})
+				}),
+		)
+
+		Expect(
+			r.Reconcile(ctx, req),
+		).To(
+			Equal(ctrl.Result{}),
+		)
+	})
+
+	It("should return an error if the excluded status could not be patched", func() {
+		nmc := &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+			Status:     kmmv1beta1.NodeModulesConfigStatus{Excluded: true},
+		}
+		sw := testclient.NewMockStatusWriter(gomock.NewController(GinkgoT()))
+		gomock.InOrder(
+			kubeClient.
+				EXPECT().
+				Get(ctx, nmcNsn, &kmmv1beta1.NodeModulesConfig{}).
+				Do(func(_ context.Context, _ types.NamespacedName, kubeNmc ctrlclient.Object, _ ...ctrlclient.Options) {
+					*kubeNmc.(*kmmv1beta1.NodeModulesConfig) = *nmc
+				}),
+			kubeClient.EXPECT().Get(ctx, types.NamespacedName{Name: nmc.Name}, &v1.Node{}),
+			kubeClient.EXPECT().Status().Return(sw),
+			sw.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Return(errors.New("some error")),
+		)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("should remove kmod labels and not continue if node is not schedulable", func() {
 		spec0 := kmmv1beta1.NodeModuleSpec{
 			ModuleItem: kmmv1beta1.ModuleItem{
@@ -165,6 +252,7 @@ var _ = Describe("NodeModulesConfigReconciler_Reconcile", func() {
 				},
 			),
 			wh.EXPECT().SyncStatus(ctx, nmc, &node),
+			wh.EXPECT().ProcessOrphanedModules(ctx, nmc),
 			nm.EXPECT().IsNodeSchedulable(&node, nil).Return(false),
 			nm.EXPECT().UpdateLabels(ctx, &node, nil, map[string]string{kmodReadyLabel: "", kmodVersionReadyLabel: ""}).DoAndReturn(
 				func(_ context.Context, obj ctrlclient.Object, _, _ map[string]string) error {
@@ -223,6 +311,7 @@ var _ = Describe("NodeModulesConfigReconciler_Reconcile", func() {
 				},
 			),
 			wh.EXPECT().SyncStatus(ctx, nmc, &node),
+			wh.EXPECT().ProcessOrphanedModules(ctx, nmc),
 			nm.EXPECT().IsNodeSchedulable(&node, nil).Return(false),
 			nm.EXPECT().UpdateLabels(ctx, &node, nil, map[string]string{kmodReadyLabel: "", kmodVersionReadyLabel: ""}).DoAndReturn(
 				func(_ context.Context, obj ctrlclient.Object, _, _ map[string]string) error {
@@ -299,6 +388,7 @@ var _ = Describe("NodeModulesConfigReconciler_Reconcile", func() {
 				}),
 			kubeClient.EXPECT().Get(ctx, types.NamespacedName{Name: nmc.Name}, &node).Return(nil),
 			wh.EXPECT().SyncStatus(ctx, nmc, &node),
+			wh.EXPECT().ProcessOrphanedModules(ctx, nmc),
 			nm.EXPECT().IsNodeSchedulable(&node, nil).Return(true),
 			wh.EXPECT().ProcessModuleSpec(contextWithValueMatch, nmc, &spec0, &status0, &node),
 			nm.EXPECT().IsNodeSchedulable(&node, nil).Return(true),
@@ -308,6 +398,7 @@ var _ = Describe("NodeModulesConfigReconciler_Reconcile", func() {
 			wh.EXPECT().GarbageCollectWorkerPods(ctx, nmc),
 			wh.EXPECT().UpdateNodeLabels(ctx, nmc, &node).Return(loaded, unloaded, err),
 			wh.EXPECT().RecordEvents(&node, loaded, unloaded),
+			wh.EXPECT().SyncNodeFeatures(ctx, nmc, &node),
 		)
 
 		Expect(
@@ -335,6 +426,7 @@ var _ = Describe("NodeModulesConfigReconciler_Reconcile", func() {
 			fmt.Errorf("failed to GC in-use labels for NMC %s: %v", types.NamespacedName{Name: nmcName}, errorMeassge),
 			fmt.Errorf("failed to GC orphan worker pods for NMC %s: %v", types.NamespacedName{Name: nmcName}, errorMeassge),
 			fmt.Errorf("could not update node's labels for NMC %s: %v", types.NamespacedName{Name: nmcName}, errorMeassge),
+			fmt.Errorf("could not sync NodeFeatures for NMC %s: %v", types.NamespacedName{Name: nmcName}, errorMeassge),
 		}
 
 		spec0 := kmmv1beta1.NodeModuleSpec{
@@ -381,12 +473,14 @@ var _ = Describe("NodeModulesConfigReconciler_Reconcile", func() {
 				}),
 			kubeClient.EXPECT().Get(ctx, types.NamespacedName{Name: nmc.Name}, &node).Return(nil),
 			wh.EXPECT().SyncStatus(ctx, nmc, &node).Return(nil),
+			wh.EXPECT().ProcessOrphanedModules(ctx, nmc),
 			nm.EXPECT().IsNodeSchedulable(&node, nil).Return(true),
 			wh.EXPECT().ProcessModuleSpec(contextWithValueMatch, nmc, &spec0, &status0, &node).Return(errors.New(errorMeassge)),
 			wh.EXPECT().ProcessUnconfiguredModuleStatus(contextWithValueMatch, nmc, &status2, &node).Return(errors.New(errorMeassge)),
 			wh.EXPECT().GarbageCollectInUseLabels(ctx, nmc).Return(errors.New(errorMeassge)),
 			wh.EXPECT().GarbageCollectWorkerPods(ctx, nmc).Return(errors.New(errorMeassge)),
 			wh.EXPECT().UpdateNodeLabels(ctx, nmc, &node).Return(nil, nil, errors.New(errorMeassge)),
+			wh.EXPECT().SyncNodeFeatures(ctx, nmc, &node).Return(errors.New(errorMeassge)),
 		)
 
 		_, err = r.Reconcile(ctx, req)
@@ -406,7 +500,7 @@ var _ = Describe("nmcReconcilerHelperImpl_GarbageCollectWorkerPods", func() {
 		ctrl := gomock.NewController(GinkgoT())
 		client = testclient.NewMockClient(ctrl)
 		pm = pod.NewMockWorkerPodManager(ctrl)
-		nrh = newNMCReconcilerHelper(client, pm, nil, nil)
+		nrh = newNMCReconcilerHelper(client, pm, nil, nil, 0)
 	})
 
 	It("should delete orphaned worker pod", func() {
@@ -492,7 +586,7 @@ var _ = Describe("nmcReconcilerHelperImpl_GarbageCollectInUseLabels", func() {
 		ctrl := gomock.NewController(GinkgoT())
 		client = testclient.NewMockClient(ctrl)
 		mockWorkerPodManager = pod.NewMockWorkerPodManager(ctrl)
-		wh = newNMCReconcilerHelper(client, mockWorkerPodManager, nil, nil)
+		wh = newNMCReconcilerHelper(client, mockWorkerPodManager, nil, nil, 0)
 	})
 
 	It("should do nothing if no labels should be collected", func() {
@@ -626,7 +720,27 @@ var _ = Describe("nmcReconcilerHelperImpl_ProcessModuleSpec", func() {
 		client = testclient.NewMockClient(ctrl)
 		mockWorkerPodManager = pod.NewMockWorkerPodManager(ctrl)
 		nm = node.NewMockNode(ctrl)
-		wh = newNMCReconcilerHelper(client, mockWorkerPodManager, nil, nm)
+		wh = newNMCReconcilerHelper(client, mockWorkerPodManager, nil, nm, 0)
+	})
+
+	It("should not create any Pod if the module is paused", func() {
+		nmc := &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+		}
+		spec := &kmmv1beta1.NodeModuleSpec{
+			ModuleItem: kmmv1beta1.ModuleItem{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Config: moduleConfig,
+			Paused: true,
+		}
+
+		Expect(
+			wh.ProcessModuleSpec(ctx, nmc, spec, nil, nil),
+		).NotTo(
+			HaveOccurred(),
+		)
 	})
 
 	It("should create a loader Pod if there is no existing Pod and the status is missing", func() {
@@ -767,6 +881,103 @@ var _ = Describe("nmcReconcilerHelperImpl_ProcessModuleSpec", func() {
 		Entry("pod status is older then node's Ready condition, worker pod should be created", true),
 	)
 
+	DescribeTable(
+		"drift detection",
+		func(driftPolicy kmmv1beta1.DriftPolicy, lastCheck *metav1.Time, drifted, expectChecker, expectLoader bool) {
+			wh = newNMCReconcilerHelper(client, mockWorkerPodManager, nil, nm, time.Hour)
+
+			spec := spec.DeepCopy()
+			spec.DriftPolicy = driftPolicy
+
+			status := status.DeepCopy()
+			status.LastDriftCheckTime = lastCheck
+
+			if drifted {
+				status.Conditions = []metav1.Condition{
+					{Type: kmmv1beta1.NodeModuleConditionDrifted, Status: metav1.ConditionTrue},
+				}
+			}
+
+			gomock.InOrder(
+				mockWorkerPodManager.EXPECT().GetWorkerPod(ctx, podName, namespace),
+				nm.EXPECT().IsNodeRebooted(node, status.BootId).Return(false),
+			)
+
+			if expectChecker {
+				mockWorkerPodManager.EXPECT().CreateCheckerPod(ctx, nmc, status)
+			}
+
+			if expectLoader {
+				mockWorkerPodManager.EXPECT().CreateLoaderPod(ctx, nmc, spec)
+			}
+
+			Expect(
+				wh.ProcessModuleSpec(ctx, nmc, spec, status, node),
+			).NotTo(
+				HaveOccurred(),
+			)
+		},
+		Entry("never checked", kmmv1beta1.DriftPolicyReport, nil, false, true, false),
+		Entry("checked recently", kmmv1beta1.DriftPolicyReport, ptr.To(metav1.Now()), false, false, false),
+		Entry("last check too old", kmmv1beta1.DriftPolicyReport, ptr.To(metav1.NewTime(time.Now().Add(-2*time.Hour))), false, true, false),
+		Entry("drifted, Report policy", kmmv1beta1.DriftPolicyReport, ptr.To(metav1.Now()), true, false, false),
+		Entry("drifted, Reload policy", kmmv1beta1.DriftPolicyReload, ptr.To(metav1.Now()), true, false, true),
+	)
+
+	DescribeTable(
+		"readiness check",
+		func(readyStatus metav1.ConditionStatus, lastCheck *metav1.Time, expectPod bool) {
+			spec := spec.DeepCopy()
+			spec.ReadinessCheck = &kmmv1beta1.ReadinessCheck{Command: []string{"/bin/check"}}
+
+			status := status.DeepCopy()
+			status.LastReadinessCheckTime = lastCheck
+
+			if readyStatus != "" {
+				status.Conditions = []metav1.Condition{
+					{Type: kmmv1beta1.NodeModuleConditionReady, Status: readyStatus},
+				}
+			}
+
+			gomock.InOrder(
+				mockWorkerPodManager.EXPECT().GetWorkerPod(ctx, podName, namespace),
+				nm.EXPECT().IsNodeRebooted(node, status.BootId).Return(false),
+			)
+
+			if expectPod {
+				mockWorkerPodManager.EXPECT().CreateReadinessCheckPod(ctx, nmc, spec)
+			}
+
+			Expect(
+				wh.ProcessModuleSpec(ctx, nmc, spec, status, node),
+			).NotTo(
+				HaveOccurred(),
+			)
+		},
+		Entry("never checked", metav1.ConditionStatus(""), nil, true),
+		Entry("failed recently", metav1.ConditionFalse, ptr.To(metav1.Now()), false),
+		Entry("failed a while ago", metav1.ConditionFalse, ptr.To(metav1.NewTime(time.Now().Add(-2*time.Minute))), true),
+		Entry("ready", metav1.ConditionTrue, ptr.To(metav1.Now()), false),
+	)
+
+	It("should not fail if the readiness check Pod is still running", func() {
+		spec := spec.DeepCopy()
+		spec.ReadinessCheck = &kmmv1beta1.ReadinessCheck{Command: []string{"/bin/check"}}
+
+		gomock.InOrder(
+			mockWorkerPodManager.EXPECT().GetWorkerPod(ctx, podName, namespace),
+			nm.EXPECT().IsNodeRebooted(node, status.BootId).Return(false),
+			mockWorkerPodManager.EXPECT().CreateReadinessCheckPod(ctx, nmc, spec).
+				Return(k8serrors.NewAlreadyExists(schema.GroupResource{Resource: "pods"}, "readiness")),
+		)
+
+		Expect(
+			wh.ProcessModuleSpec(ctx, nmc, spec, status, node),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
 	It("should do nothing if the pod is not loading a kmod", func() {
 
 		gomock.InOrder(
@@ -871,7 +1082,7 @@ var _ = Describe("nmcReconcilerHelperImpl_ProcessUnconfiguredModuleStatus", func
 		sw = testclient.NewMockStatusWriter(ctrl)
 		mockWorkerPodManager = pod.NewMockWorkerPodManager(ctrl)
 		nm = node.NewMockNode(ctrl)
-		helper = newNMCReconcilerHelper(client, mockWorkerPodManager, nil, nm)
+		helper = newNMCReconcilerHelper(client, mockWorkerPodManager, nil, nm, 0)
 	})
 
 	nmc := &kmmv1beta1.NodeModulesConfig{
@@ -1024,11 +1235,15 @@ var _ = Describe("nmcReconcilerHelperImpl_ProcessUnconfiguredModuleStatus", func
 
 })
 
-var _ = Describe("nmcReconcilerHelperImpl_SyncStatus", func() {
+var _ = Describe("nmcReconcilerHelperImpl_ProcessOrphanedModules", func() {
+	const (
+		modName      = "mod-name"
+		modNamespace = "mod-namespace"
+	)
+
 	var (
 		ctx = context.TODO()
 
-		ctrl                 *gomock.Controller
 		kubeClient           *testclient.MockClient
 		mockWorkerPodManager *pod.MockWorkerPodManager
 		sw                   *testclient.MockStatusWriter
@@ -1036,148 +1251,496 @@ var _ = Describe("nmcReconcilerHelperImpl_SyncStatus", func() {
 	)
 
 	BeforeEach(func() {
-		ctrl = gomock.NewController(GinkgoT())
+		ctrl := gomock.NewController(GinkgoT())
 		kubeClient = testclient.NewMockClient(ctrl)
 		mockWorkerPodManager = pod.NewMockWorkerPodManager(ctrl)
-		wh = newNMCReconcilerHelper(kubeClient, mockWorkerPodManager, nil, nil)
 		sw = testclient.NewMockStatusWriter(ctrl)
+		wh = newNMCReconcilerHelper(kubeClient, mockWorkerPodManager, nil, nil, 0)
 	})
 
-	const (
-		podName      = "pod-name"
-		podNamespace = "pod-namespace"
-	)
-
-	It("should do nothing if there are no running pods for this NMC", func() {
-		mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName)
-
-		nmc := &kmmv1beta1.NodeModulesConfig{
+	orphanedNMC := func() *kmmv1beta1.NodeModulesConfig {
+		return &kmmv1beta1.NodeModulesConfig{
 			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
-		}
-		node := v1.Node{}
-		Expect(
-			wh.SyncStatus(ctx, nmc, &node),
-		).NotTo(
-			HaveOccurred(),
-		)
-	})
-
-	It("failed pods", func() {
-		podWithStatus := v1.Pod{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: podNamespace,
-				Name:      podName,
-				Labels: map[string]string{
-					constants.ModuleNameLabel: "mod name 1",
+			Spec: kmmv1beta1.NodeModulesConfigSpec{
+				Modules: []kmmv1beta1.NodeModuleSpec{
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{Name: modName, Namespace: modNamespace},
+						Orphaned:   true,
+					},
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{Name: "other", Namespace: modNamespace},
+					},
 				},
 			},
-			Status: v1.PodStatus{Phase: v1.PodFailed},
-		}
-
-		podWithoutStatus := v1.Pod{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: podNamespace,
-				Name:      podName,
-				Labels: map[string]string{
-					constants.ModuleNameLabel: "mod name 2",
+			Status: kmmv1beta1.NodeModulesConfigStatus{
+				Modules: []kmmv1beta1.NodeModuleStatus{
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{Name: modName, Namespace: modNamespace},
+					},
 				},
 			},
-			Status: v1.PodStatus{Phase: v1.PodFailed},
 		}
+	}
 
-		pods := []v1.Pod{podWithStatus, podWithoutStatus}
-
-		nmc := &kmmv1beta1.NodeModulesConfig{
+	It("should do nothing if no module is orphaned", func() {
+		nmcObj := &kmmv1beta1.NodeModulesConfig{
 			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
-			Status: kmmv1beta1.NodeModulesConfigStatus{
-				Modules: []kmmv1beta1.NodeModuleStatus{
+			Spec: kmmv1beta1.NodeModulesConfigSpec{
+				Modules: []kmmv1beta1.NodeModuleSpec{
 					{
-						ModuleItem: kmmv1beta1.ModuleItem{
-							Name:      "mod name 1",
-							Namespace: podNamespace,
-						},
-						Config: kmmv1beta1.ModuleConfig{ContainerImage: "some image"},
+						ModuleItem: kmmv1beta1.ModuleItem{Name: modName, Namespace: modNamespace},
 					},
 				},
 			},
 		}
 
-		gomock.InOrder(
-			mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName).Return(pods, nil),
-			kubeClient.EXPECT().Status().Return(sw),
-			sw.EXPECT().Patch(ctx, nmc, gomock.Any()),
-			mockWorkerPodManager.EXPECT().DeletePod(ctx, &podWithStatus),
-			mockWorkerPodManager.EXPECT().DeletePod(ctx, &podWithoutStatus),
-		)
-		node := v1.Node{}
 		Expect(
-			wh.SyncStatus(ctx, nmc, &node),
+			wh.ProcessOrphanedModules(ctx, nmcObj),
 		).NotTo(
 			HaveOccurred(),
 		)
-
-		Expect(nmc.Status.Modules).To(HaveLen(1))
+		Expect(nmcObj.Spec.Modules).To(HaveLen(1))
 	})
 
-	It("should remove the status and label if an unloader pod was successful", func() {
-		const (
-			modName      = "module"
-			modNamespace = "namespace"
-		)
+	It("should delete the worker Pods of an orphaned module and keep its entries", func() {
+		nmcObj := orphanedNMC()
 
-		nmc := &kmmv1beta1.NodeModulesConfig{
-			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
-			Status: kmmv1beta1.NodeModulesConfigStatus{
-				Modules: []kmmv1beta1.NodeModuleStatus{
-					{
-						ModuleItem: kmmv1beta1.ModuleItem{
-							Name:      modName,
-							Namespace: modNamespace,
-						},
-					},
-				},
+		workerPod := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "worker",
+				Namespace: modNamespace,
+				Labels:    map[string]string{constants.ModuleNameLabel: modName},
 			},
 		}
 
-		pod := v1.Pod{
+		otherPod := v1.Pod{
 			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-worker",
 				Namespace: modNamespace,
-				Labels: map[string]string{
-					constants.ModuleNameLabel: modName,
-				},
+				Labels:    map[string]string{constants.ModuleNameLabel: "other"},
 			},
-			Status: v1.PodStatus{Phase: v1.PodSucceeded},
 		}
 
 		gomock.InOrder(
-			mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName).Return([]v1.Pod{pod}, nil),
-			mockWorkerPodManager.EXPECT().IsUnloaderPod(&pod).Return(true),
-			kubeClient.EXPECT().Status().Return(sw),
-			sw.EXPECT().Patch(ctx, nmc, gomock.Any()),
-			mockWorkerPodManager.EXPECT().DeletePod(ctx, &pod),
+			mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName).Return([]v1.Pod{workerPod, otherPod}, nil),
+			mockWorkerPodManager.EXPECT().DeletePod(ctx, &workerPod),
 		)
-		node := v1.Node{}
+
 		Expect(
-			wh.SyncStatus(ctx, nmc, &node),
+			wh.ProcessOrphanedModules(ctx, nmcObj),
 		).NotTo(
 			HaveOccurred(),
 		)
-
-		Expect(nmc.Status.Modules).To(BeEmpty())
+		Expect(nmcObj.Spec.Modules).To(HaveLen(2))
+		Expect(nmcObj.Status.Modules).To(HaveLen(1))
 	})
 
-	It("should add the status if a loader pod was successful", func() {
-		const (
-			irsName            = "some-secret"
-			modName            = "module"
-			modNamespace       = "namespace"
-			serviceAccountName = "some-sa"
+	It("should remove the status and spec entries of an orphaned module without worker Pods", func() {
+		nmcObj := orphanedNMC()
+
+		gomock.InOrder(
+			mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName),
+			kubeClient.EXPECT().Status().Return(sw),
+			sw.EXPECT().Patch(ctx, nmcObj, gomock.Any()),
+			kubeClient.EXPECT().Patch(ctx, nmcObj, gomock.Any()),
 		)
 
-		testToleration := v1.Toleration{
-			Key:    "test-key",
-			Value:  "test-value",
-			Effect: v1.TaintEffectNoExecute,
+		Expect(
+			wh.ProcessOrphanedModules(ctx, nmcObj),
+		).NotTo(
+			HaveOccurred(),
+		)
+		Expect(nmcObj.Status.Modules).To(BeEmpty())
+		Expect(nmcObj.Spec.Modules).To(
+			Equal([]kmmv1beta1.NodeModuleSpec{
+				{ModuleItem: kmmv1beta1.ModuleItem{Name: "other", Namespace: modNamespace}},
+			}),
+		)
+	})
+
+	It("should not remove the spec entry if the status could not be patched", func() {
+		nmcObj := orphanedNMC()
+
+		gomock.InOrder(
+			mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName),
+			kubeClient.EXPECT().Status().Return(sw),
+			sw.EXPECT().Patch(ctx, nmcObj, gomock.Any()).Return(errors.New("random error")),
+		)
+
+		Expect(
+			wh.ProcessOrphanedModules(ctx, nmcObj),
+		).To(
+			HaveOccurred(),
+		)
+		Expect(nmcObj.Spec.Modules).To(HaveLen(2))
+	})
+})
+
+var _ = Describe("nmcReconcilerHelperImpl_SyncStatus", func() {
+	var (
+		ctx = context.TODO()
+
+		ctrl                 *gomock.Controller
+		kubeClient           *testclient.MockClient
+		mockWorkerPodManager *pod.MockWorkerPodManager
+		sw                   *testclient.MockStatusWriter
+		wh                   nmcReconcilerHelper
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubeClient = testclient.NewMockClient(ctrl)
+		mockWorkerPodManager = pod.NewMockWorkerPodManager(ctrl)
+		wh = newNMCReconcilerHelper(kubeClient, mockWorkerPodManager, nil, nil, 0)
+		sw = testclient.NewMockStatusWriter(ctrl)
+	})
+
+	const (
+		podName      = "pod-name"
+		podNamespace = "pod-namespace"
+	)
+
+	It("should do nothing if there are no running pods for this NMC", func() {
+		mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName)
+
+		nmc := &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+		}
+		node := v1.Node{}
+		Expect(
+			wh.SyncStatus(ctx, nmc, &node),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	It("should ignore the Pods of orphaned modules", func() {
+		const modName = "mod-name"
+
+		loaderPod := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: podNamespace,
+				Name:      podName,
+				Labels:    map[string]string{constants.ModuleNameLabel: modName},
+			},
+			Status: v1.PodStatus{Phase: v1.PodSucceeded},
+		}
+
+		nmc := &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+			Spec: kmmv1beta1.NodeModulesConfigSpec{
+				Modules: []kmmv1beta1.NodeModuleSpec{
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{Name: modName, Namespace: podNamespace},
+						Orphaned:   true,
+					},
+				},
+			},
+		}
+
+		gomock.InOrder(
+			mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName).Return([]v1.Pod{loaderPod}, nil),
+			kubeClient.EXPECT().Status().Return(sw),
+			sw.EXPECT().Patch(ctx, nmc, gomock.Any()),
+		)
+		node := v1.Node{}
+		Expect(
+			wh.SyncStatus(ctx, nmc, &node),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(nmc.Status.Modules).To(BeEmpty())
+	})
+
+	It("failed pods", func() {
+		podWithStatus := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: podNamespace,
+				Name:      podName,
+				Labels: map[string]string{
+					constants.ModuleNameLabel: "mod name 1",
+				},
+			},
+			Status: v1.PodStatus{Phase: v1.PodFailed},
+		}
+
+		podWithoutStatus := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: podNamespace,
+				Name:      podName,
+				Labels: map[string]string{
+					constants.ModuleNameLabel: "mod name 2",
+				},
+			},
+			Status: v1.PodStatus{Phase: v1.PodFailed},
+		}
+
+		pods := []v1.Pod{podWithStatus, podWithoutStatus}
+
+		nmc := &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+			Status: kmmv1beta1.NodeModulesConfigStatus{
+				Modules: []kmmv1beta1.NodeModuleStatus{
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{
+							Name:      "mod name 1",
+							Namespace: podNamespace,
+						},
+						Config: kmmv1beta1.ModuleConfig{ContainerImage: "some image"},
+					},
+				},
+			},
+		}
+
+		gomock.InOrder(
+			mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName).Return(pods, nil),
+			mockWorkerPodManager.EXPECT().IsCheckerPod(&podWithStatus).Return(false),
+			mockWorkerPodManager.EXPECT().IsReadinessCheckPod(&podWithStatus).Return(false),
+			kubeClient.EXPECT().Status().Return(sw),
+			sw.EXPECT().Patch(ctx, nmc, gomock.Any()),
+			mockWorkerPodManager.EXPECT().DeletePod(ctx, &podWithStatus),
+			mockWorkerPodManager.EXPECT().DeletePod(ctx, &podWithoutStatus),
+		)
+		node := v1.Node{}
+		Expect(
+			wh.SyncStatus(ctx, nmc, &node),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(nmc.Status.Modules).To(HaveLen(1))
+	})
+
+	DescribeTable(
+		"should record the result of a successful checker pod",
+		func(terminationMessage string, expectedStatus metav1.ConditionStatus, expectEvent bool) {
+			const (
+				modName      = "module"
+				modNamespace = "namespace"
+			)
+
+			fakeRecorder := record.NewFakeRecorder(10)
+			wh = newNMCReconcilerHelper(kubeClient, mockWorkerPodManager, fakeRecorder, nil, time.Hour)
+
+			nmc := &kmmv1beta1.NodeModulesConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+				Status: kmmv1beta1.NodeModulesConfigStatus{
+					Modules: []kmmv1beta1.NodeModuleStatus{
+						{
+							ModuleItem: kmmv1beta1.ModuleItem{
+								Name:      modName,
+								Namespace: modNamespace,
+							},
+						},
+					},
+				},
+			}
+
+			p := v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: modNamespace,
+					Labels: map[string]string{
+						constants.ModuleNameLabel: modName,
+					},
+				},
+				Status: v1.PodStatus{
+					Phase: v1.PodSucceeded,
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: pod.WorkerContainerName,
+							State: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{Message: terminationMessage},
+							},
+						},
+					},
+				},
+			}
+
+			gomock.InOrder(
+				mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName).Return([]v1.Pod{p}, nil),
+				mockWorkerPodManager.EXPECT().IsUnloaderPod(&p).Return(false),
+				mockWorkerPodManager.EXPECT().IsCheckerPod(&p).Return(true),
+				kubeClient.EXPECT().Status().Return(sw),
+				sw.EXPECT().Patch(ctx, nmc, gomock.Any()),
+				mockWorkerPodManager.EXPECT().DeletePod(ctx, &p),
+			)
+
+			Expect(
+				wh.SyncStatus(ctx, nmc, &v1.Node{}),
+			).NotTo(
+				HaveOccurred(),
+			)
+
+			Expect(nmc.Status.Modules).To(HaveLen(1))
+
+			status := nmc.Status.Modules[0]
+			Expect(status.LastDriftCheckTime).NotTo(BeNil())
+
+			cond := apimeta.FindStatusCondition(status.Conditions, kmmv1beta1.NodeModuleConditionDrifted)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(expectedStatus))
+
+			if expectEvent {
+				Expect(fakeRecorder.Events).To(HaveLen(1))
+				Expect(<-fakeRecorder.Events).To(ContainSubstring("ModuleDrifted"))
+			} else {
+				Expect(fakeRecorder.Events).To(BeEmpty())
+			}
+		},
+		Entry("all modules loaded", "", metav1.ConditionFalse, false),
+		Entry("modules missing", "a,b", metav1.ConditionTrue, true),
+	)
+
+	DescribeTable(
+		"should record the result of a readiness check pod",
+		func(phase v1.PodPhase, expectedStatus metav1.ConditionStatus, expectEvent bool) {
+			const (
+				modName      = "module"
+				modNamespace = "namespace"
+			)
+
+			fakeRecorder := record.NewFakeRecorder(10)
+			wh = newNMCReconcilerHelper(kubeClient, mockWorkerPodManager, fakeRecorder, nil, 0)
+
+			nmc := &kmmv1beta1.NodeModulesConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+				Status: kmmv1beta1.NodeModulesConfigStatus{
+					Modules: []kmmv1beta1.NodeModuleStatus{
+						{
+							ModuleItem: kmmv1beta1.ModuleItem{
+								Name:      modName,
+								Namespace: modNamespace,
+							},
+						},
+					},
+				},
+			}
+
+			p := v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: modNamespace,
+					Labels: map[string]string{
+						constants.ModuleNameLabel: modName,
+					},
+				},
+				Status: v1.PodStatus{
+					Phase: phase,
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: pod.WorkerContainerName,
+							State: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Message: "no device found"},
+							},
+						},
+					},
+				},
+			}
+
+			if phase == v1.PodSucceeded {
+				gomock.InOrder(
+					mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName).Return([]v1.Pod{p}, nil),
+					mockWorkerPodManager.EXPECT().IsUnloaderPod(&p).Return(false),
+					mockWorkerPodManager.EXPECT().IsCheckerPod(&p).Return(false),
+					mockWorkerPodManager.EXPECT().IsReadinessCheckPod(&p).Return(true),
+				)
+			} else {
+				gomock.InOrder(
+					mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName).Return([]v1.Pod{p}, nil),
+					mockWorkerPodManager.EXPECT().IsCheckerPod(&p).Return(false),
+					mockWorkerPodManager.EXPECT().IsReadinessCheckPod(&p).Return(true),
+				)
+			}
+
+			gomock.InOrder(
+				kubeClient.EXPECT().Status().Return(sw),
+				sw.EXPECT().Patch(ctx, nmc, gomock.Any()),
+				mockWorkerPodManager.EXPECT().DeletePod(ctx, &p),
+			)
+
+			Expect(
+				wh.SyncStatus(ctx, nmc, &v1.Node{}),
+			).NotTo(
+				HaveOccurred(),
+			)
+
+			status := nmc.Status.Modules[0]
+			Expect(status.LastReadinessCheckTime).NotTo(BeNil())
+
+			cond := apimeta.FindStatusCondition(status.Conditions, kmmv1beta1.NodeModuleConditionReady)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(expectedStatus))
+
+			if expectEvent {
+				Expect(fakeRecorder.Events).To(HaveLen(1))
+				Expect(<-fakeRecorder.Events).To(ContainSubstring("no device found"))
+			} else {
+				Expect(fakeRecorder.Events).To(BeEmpty())
+			}
+		},
+		Entry("check passed", v1.PodSucceeded, metav1.ConditionTrue, false),
+		Entry("check failed", v1.PodFailed, metav1.ConditionFalse, true),
+	)
+
+	It("should remove the status and label if an unloader pod was successful", func() {
+		const (
+			modName      = "module"
+			modNamespace = "namespace"
+		)
+
+		nmc := &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+			Status: kmmv1beta1.NodeModulesConfigStatus{
+				Modules: []kmmv1beta1.NodeModuleStatus{
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{
+							Name:      modName,
+							Namespace: modNamespace,
+						},
+					},
+				},
+			},
+		}
+
+		pod := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: modNamespace,
+				Labels: map[string]string{
+					constants.ModuleNameLabel: modName,
+				},
+			},
+			Status: v1.PodStatus{Phase: v1.PodSucceeded},
+		}
+
+		gomock.InOrder(
+			mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName).Return([]v1.Pod{pod}, nil),
+			mockWorkerPodManager.EXPECT().IsUnloaderPod(&pod).Return(true),
+			kubeClient.EXPECT().Status().Return(sw),
+			sw.EXPECT().Patch(ctx, nmc, gomock.Any()),
+			mockWorkerPodManager.EXPECT().DeletePod(ctx, &pod),
+		)
+		node := v1.Node{}
+		Expect(
+			wh.SyncStatus(ctx, nmc, &node),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(nmc.Status.Modules).To(BeEmpty())
+	})
+
+	It("should add the status if a loader pod was successful", func() {
+		const (
+			irsName            = "some-secret"
+			modName            = "module"
+			modNamespace       = "namespace"
+			serviceAccountName = "some-sa"
+		)
+
+		testToleration := v1.Toleration{
+			Key:    "test-key",
+			Value:  "test-value",
+			Effect: v1.TaintEffectNoExecute,
 		}
 
 		nmc := &kmmv1beta1.NodeModulesConfig{
@@ -1222,7 +1785,7 @@ var _ = Describe("nmcReconcilerHelperImpl_SyncStatus", func() {
 					{
 						Name: "worker",
 						State: v1.ContainerState{
-							Terminated: &v1.ContainerStateTerminated{FinishedAt: now},
+							Terminated: &v1.ContainerStateTerminated{FinishedAt: now, Message: "ABCDEF"},
 						},
 					},
 				},
@@ -1238,6 +1801,8 @@ var _ = Describe("nmcReconcilerHelperImpl_SyncStatus", func() {
 		gomock.InOrder(
 			mockWorkerPodManager.EXPECT().ListWorkerPodsOnNode(ctx, nmcName).Return([]v1.Pod{p}, nil),
 			mockWorkerPodManager.EXPECT().IsUnloaderPod(&p).Return(false),
+			mockWorkerPodManager.EXPECT().IsCheckerPod(&p).Return(false),
+			mockWorkerPodManager.EXPECT().IsReadinessCheckPod(&p).Return(false),
 			mockWorkerPodManager.EXPECT().GetConfigAnnotation(&p).Return(string(b)),
 			mockWorkerPodManager.EXPECT().GetTolerationsAnnotation(&p).Return(string(tolerations)),
 			mockWorkerPodManager.EXPECT().GetModuleVersionAnnotation(&p).Return("some version"),
@@ -1263,7 +1828,8 @@ var _ = Describe("nmcReconcilerHelperImpl_SyncStatus", func() {
 				Tolerations:        []v1.Toleration{testToleration},
 				Version:            "some version",
 			},
-			Config: cfg,
+			Config:     cfg,
+			SrcVersion: "ABCDEF",
 		}
 
 		Expect(nmc.Status.Modules[0]).To(BeComparableTo(expectedStatus))
@@ -1329,7 +1895,7 @@ var _ = Describe("nmcReconcilerHelperImpl_RemovePodFinalizers", func() {
 		ctrl := gomock.NewController(GinkgoT())
 		kubeClient = testclient.NewMockClient(ctrl)
 		mockWorkerPodManager = pod.NewMockWorkerPodManager(ctrl)
-		wh = newNMCReconcilerHelper(kubeClient, mockWorkerPodManager, nil, nil)
+		wh = newNMCReconcilerHelper(kubeClient, mockWorkerPodManager, nil, nil, 0)
 	})
 
 	It("should do nothing if no pods are present", func() {
@@ -1380,6 +1946,42 @@ const (
 
 var kernelModuleLabelName = utils.GetKernelModuleReadyNodeLabel(moduleNamespace, moduleName)
 
+var _ = Describe("notReadyModules", func() {
+	nsn := types.NamespacedName{Namespace: nsFirst, Name: nameFirst}
+
+	DescribeTable(
+		"should return the modules that are not ready",
+		func(readinessCheck bool, conditions []metav1.Condition, expectNotReady bool) {
+			nmcObj := kmmv1beta1.NodeModulesConfig{
+				Spec: kmmv1beta1.NodeModulesConfigSpec{
+					Modules: []kmmv1beta1.NodeModuleSpec{
+						{ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst}},
+					},
+				},
+				Status: kmmv1beta1.NodeModulesConfigStatus{
+					Modules: []kmmv1beta1.NodeModuleStatus{
+						{
+							ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst},
+							Conditions: conditions,
+						},
+					},
+				},
+			}
+
+			if readinessCheck {
+				nmcObj.Spec.Modules[0].ReadinessCheck = &kmmv1beta1.ReadinessCheck{Command: []string{"/bin/check"}}
+			}
+
+			Expect(notReadyModules(&nmcObj).Has(nsn)).To(Equal(expectNotReady))
+		},
+		Entry("no readiness check, no condition", false, nil, false),
+		Entry("drifted", false, []metav1.Condition{{Type: kmmv1beta1.NodeModuleConditionDrifted, Status: metav1.ConditionTrue}}, true),
+		Entry("readiness check not run yet", true, nil, true),
+		Entry("readiness check failed", true, []metav1.Condition{{Type: kmmv1beta1.NodeModuleConditionReady, Status: metav1.ConditionFalse}}, true),
+		Entry("readiness check passed", true, []metav1.Condition{{Type: kmmv1beta1.NodeModuleConditionReady, Status: metav1.ConditionTrue}}, false),
+	)
+})
+
 var _ = Describe("nmcReconcilerHelperImpl_UpdateNodeLabels", func() {
 	var (
 		ctx                    context.Context
@@ -1422,7 +2024,7 @@ var _ = Describe("nmcReconcilerHelperImpl_UpdateNodeLabels", func() {
 		}
 		fakeRecorder = record.NewFakeRecorder(10)
 		n = node.NewMockNode(ctrl)
-		wh = newNMCReconcilerHelper(client, nil, fakeRecorder, n)
+		wh = newNMCReconcilerHelper(client, nil, fakeRecorder, n, 0)
 		mlph = NewMocklabelPreparationHelper(ctrl)
 		wh = &nmcReconcilerHelperImpl{
 			client:     client,
@@ -1508,6 +2110,403 @@ var _ = Describe("nmcReconcilerHelperImpl_UpdateNodeLabels", func() {
 		Expect(node.Labels).To(HaveKey(firstLabelName))
 
 	})
+	It("Should remove the labels of drifted modules", func() {
+		node := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					firstLabelName: "",
+				},
+				Name: nodeName,
+			},
+		}
+		firstNN := types.NamespacedName{Name: nameFirst, Namespace: nsFirst}
+		readyLabels := sets.New(firstNN)
+		configs := map[types.NamespacedName]kmmv1beta1.ModuleConfig{firstNN: {}}
+
+		nmc := *nmc.DeepCopy()
+		nmc.Status.Modules = []kmmv1beta1.NodeModuleStatus{
+			{
+				ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst},
+				Conditions: []metav1.Condition{
+					{Type: kmmv1beta1.NodeModuleConditionDrifted, Status: metav1.ConditionTrue},
+				},
+			},
+		}
+
+		gomock.InOrder(
+			mlph.EXPECT().getNodeKernelModuleReadyLabels(node).Return(readyLabels),
+			mlph.EXPECT().getDeprecatedKernelModuleReadyLabels(node).Return(map[string]string{}),
+			mlph.EXPECT().getSpecLabelsAndTheirConfigs(&nmc).Return(configs),
+			mlph.EXPECT().getStatusLabelsAndTheirConfigs(&nmc).Return(map[types.NamespacedName]kmmv1beta1.ModuleConfig{firstNN: {}}),
+			mlph.EXPECT().getStatusVersions(&nmc).Return(map[types.NamespacedName]string{}),
+			mlph.EXPECT().removeOrphanedLabels(readyLabels, configs, gomock.Any()).Return(nil),
+			mlph.EXPECT().addEqualLabels(readyLabels, configs, map[types.NamespacedName]kmmv1beta1.ModuleConfig{}).Return(nil),
+			n.EXPECT().
+				UpdateLabels(
+					ctx,
+					&node,
+					map[string]string{},
+					map[string]string{firstLabelName: "", firstVersionLabelName: ""},
+				).Return(nil),
+		)
+
+		_, unloaded, err := wh.UpdateNodeLabels(ctx, &nmc, &node)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unloaded).To(Equal([]types.NamespacedName{firstNN}))
+	})
+	It("Should apply the user-defined labels and record them in an annotation", func() {
+		node := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{firstLabelName: ""},
+				Annotations: map[string]string{
+					utils.GetNodeLabelsAnnotation(nsSecond, nameSecond): "vendor.com/old",
+				},
+				Name: nodeName,
+			},
+		}
+		firstNN := types.NamespacedName{Name: nameFirst, Namespace: nsFirst}
+		readyLabels := sets.New(firstNN)
+		configs := map[types.NamespacedName]kmmv1beta1.ModuleConfig{firstNN: {}}
+
+		nmc := kmmv1beta1.NodeModulesConfig{
+			Spec: kmmv1beta1.NodeModulesConfigSpec{
+				Modules: []kmmv1beta1.NodeModuleSpec{
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst},
+						NodeLabels: map[string]string{"vendor.com/driver-version": "1.2.3"},
+					},
+				},
+			},
+			Status: kmmv1beta1.NodeModulesConfigStatus{
+				Modules: []kmmv1beta1.NodeModuleStatus{
+					{ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst}},
+				},
+			},
+		}
+
+		gomock.InOrder(
+			mlph.EXPECT().getNodeKernelModuleReadyLabels(node).Return(readyLabels),
+			mlph.EXPECT().getDeprecatedKernelModuleReadyLabels(node).Return(nil),
+			mlph.EXPECT().getSpecLabelsAndTheirConfigs(&nmc).Return(configs),
+			mlph.EXPECT().getStatusLabelsAndTheirConfigs(&nmc).Return(configs),
+			mlph.EXPECT().getStatusVersions(&nmc).Return(map[types.NamespacedName]string{}),
+			mlph.EXPECT().removeOrphanedLabels(readyLabels, configs, configs).Return(nil),
+			mlph.EXPECT().addEqualLabels(readyLabels, configs, configs).Return(nil),
+			n.EXPECT().
+				UpdateLabels(
+					ctx,
+					&node,
+					map[string]string{"vendor.com/driver-version": "1.2.3"},
+					map[string]string{"vendor.com/old": ""},
+				).Return(nil),
+			n.EXPECT().
+				UpdateAnnotations(
+					ctx,
+					&node,
+					map[string]string{utils.GetNodeLabelsAnnotation(nsFirst, nameFirst): "vendor.com/driver-version"},
+					map[string]string{utils.GetNodeLabelsAnnotation(nsSecond, nameSecond): ""},
+				).Return(nil),
+		)
+
+		_, _, err := wh.UpdateNodeLabels(ctx, &nmc, &node)
+		Expect(err).ToNot(HaveOccurred())
+	})
+	It("Should record an event when modules set a user-defined label to different values", func() {
+		node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+		configs := map[types.NamespacedName]kmmv1beta1.ModuleConfig{}
+
+		nmc := kmmv1beta1.NodeModulesConfig{
+			Spec: kmmv1beta1.NodeModulesConfigSpec{
+				Modules: []kmmv1beta1.NodeModuleSpec{
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsSecond, Name: nameSecond},
+						NodeLabels: map[string]string{"vendor.com/driver-version": "4.5.6"},
+					},
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst},
+						NodeLabels: map[string]string{"vendor.com/driver-version": "1.2.3"},
+					},
+				},
+			},
+			Status: kmmv1beta1.NodeModulesConfigStatus{
+				Modules: []kmmv1beta1.NodeModuleStatus{
+					{ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst}},
+					{ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsSecond, Name: nameSecond}},
+				},
+			},
+		}
+
+		gomock.InOrder(
+			mlph.EXPECT().getNodeKernelModuleReadyLabels(node).Return(sets.New[types.NamespacedName]()),
+			mlph.EXPECT().getDeprecatedKernelModuleReadyLabels(node).Return(nil),
+			mlph.EXPECT().getSpecLabelsAndTheirConfigs(&nmc).Return(configs),
+			mlph.EXPECT().getStatusLabelsAndTheirConfigs(&nmc).Return(configs),
+			mlph.EXPECT().getStatusVersions(&nmc).Return(map[types.NamespacedName]string{}),
+			mlph.EXPECT().removeOrphanedLabels(gomock.Any(), configs, configs).Return(nil),
+			mlph.EXPECT().addEqualLabels(gomock.Any(), configs, configs).Return(nil),
+			n.EXPECT().UpdateLabels(ctx, &node, map[string]string{"vendor.com/driver-version": "1.2.3"}, nil).Return(nil),
+			n.EXPECT().UpdateAnnotations(ctx, &node, gomock.Any(), map[string]string{}).Return(nil),
+		)
+
+		_, _, err := wh.UpdateNodeLabels(ctx, &nmc, &node)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeRecorder.Events).To(HaveLen(1))
+		Expect(<-fakeRecorder.Events).To(ContainSubstring("Warning NodeLabelConflict"))
+	})
+})
+
+var _ = Describe("nodeLabelChanges", func() {
+	const (
+		labelKey   = "vendor.com/driver-version"
+		labelValue = "1.2.3"
+	)
+
+	annotationKey := utils.GetNodeLabelsAnnotation(nsFirst, nameFirst)
+
+	DescribeTable(
+		"should return the expected changes",
+		func(ready bool, nodeLabels, nodeAnnotations, expectedLabelsToAdd, expectedLabelsToRemove, expectedAnnotationsToAdd, expectedAnnotationsToRemove map[string]string) {
+			nmcObj := kmmv1beta1.NodeModulesConfig{
+				Spec: kmmv1beta1.NodeModulesConfigSpec{
+					Modules: []kmmv1beta1.NodeModuleSpec{
+						{
+							ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst},
+							NodeLabels: map[string]string{labelKey: labelValue},
+						},
+					},
+				},
+			}
+
+			if ready {
+				nmcObj.Status.Modules = []kmmv1beta1.NodeModuleStatus{
+					{ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst}},
+				}
+			}
+
+			node := v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      nodeLabels,
+					Annotations: nodeAnnotations,
+				},
+			}
+
+			labelsToAdd, labelsToRemove, annotationsToAdd, annotationsToRemove, conflicts := nodeLabelChanges(&nmcObj, &node)
+			Expect(labelsToAdd).To(Equal(expectedLabelsToAdd))
+			Expect(labelsToRemove).To(Equal(expectedLabelsToRemove))
+			Expect(annotationsToAdd).To(Equal(expectedAnnotationsToAdd))
+			Expect(annotationsToRemove).To(Equal(expectedAnnotationsToRemove))
+			Expect(conflicts).To(BeEmpty())
+		},
+		Entry(
+			"module not loaded yet",
+			false,
+			nil,
+			nil,
+			map[string]string{},
+			map[string]string{},
+			map[string]string{},
+			map[string]string{},
+		),
+		Entry(
+			"module loaded, labels not applied yet",
+			true,
+			nil,
+			nil,
+			map[string]string{labelKey: labelValue},
+			map[string]string{},
+			map[string]string{annotationKey: labelKey},
+			map[string]string{},
+		),
+		Entry(
+			"module loaded, labels already applied",
+			true,
+			map[string]string{labelKey: labelValue},
+			map[string]string{annotationKey: labelKey},
+			map[string]string{},
+			map[string]string{},
+			map[string]string{},
+			map[string]string{},
+		),
+		Entry(
+			"module unloaded, labels still applied",
+			false,
+			map[string]string{labelKey: labelValue},
+			map[string]string{annotationKey: labelKey},
+			map[string]string{},
+			map[string]string{labelKey: ""},
+			map[string]string{},
+			map[string]string{annotationKey: ""},
+		),
+		Entry(
+			"label renamed",
+			true,
+			map[string]string{"vendor.com/old": labelValue},
+			map[string]string{annotationKey: "vendor.com/old"},
+			map[string]string{labelKey: labelValue},
+			map[string]string{"vendor.com/old": ""},
+			map[string]string{annotationKey: labelKey},
+			map[string]string{},
+		),
+	)
+
+	It("should let the first module by namespace and name win and report the conflict", func() {
+		secondSpec := kmmv1beta1.NodeModuleSpec{
+			ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsSecond, Name: nameSecond},
+			NodeLabels: map[string]string{labelKey: "4.5.6"},
+		}
+		firstSpec := kmmv1beta1.NodeModuleSpec{
+			ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst},
+			NodeLabels: map[string]string{labelKey: labelValue},
+		}
+
+		for _, specs := range [][]kmmv1beta1.NodeModuleSpec{{firstSpec, secondSpec}, {secondSpec, firstSpec}} {
+			nmcObj := kmmv1beta1.NodeModulesConfig{
+				Spec: kmmv1beta1.NodeModulesConfigSpec{Modules: specs},
+				Status: kmmv1beta1.NodeModulesConfigStatus{
+					Modules: []kmmv1beta1.NodeModuleStatus{
+						{ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst}},
+						{ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsSecond, Name: nameSecond}},
+					},
+				},
+			}
+
+			labelsToAdd, _, _, _, conflicts := nodeLabelChanges(&nmcObj, &v1.Node{})
+			Expect(labelsToAdd).To(Equal(map[string]string{labelKey: labelValue}))
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0]).To(ContainSubstring(nsSecond + "/" + nameSecond))
+		}
+	})
+})
+
+var _ = Describe("nmcReconcilerHelperImpl_SyncNodeFeatures", func() {
+	const nodeName = "node-name"
+
+	var (
+		ctx        = context.TODO()
+		kubeClient *testclient.MockClient
+		wh         nmcReconcilerHelper
+		node       *v1.Node
+		nmcObj     *kmmv1beta1.NodeModulesConfig
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubeClient = testclient.NewMockClient(ctrl)
+		wh = newNMCReconcilerHelper(kubeClient, nil, nil, nil, 0)
+		node = &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+		nmcObj = &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Spec: kmmv1beta1.NodeModulesConfigSpec{
+				Modules: []kmmv1beta1.NodeModuleSpec{
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst},
+						Config: kmmv1beta1.ModuleConfig{
+							Modprobe: kmmv1beta1.ModprobeSpec{ModuleName: "kmod"},
+						},
+					},
+				},
+			},
+			Status: kmmv1beta1.NodeModulesConfigStatus{
+				Modules: []kmmv1beta1.NodeModuleStatus{
+					{
+						ModuleItem: kmmv1beta1.ModuleItem{Namespace: nsFirst, Name: nameFirst, Version: "1.2.3"},
+						Config: kmmv1beta1.ModuleConfig{
+							Modprobe: kmmv1beta1.ModprobeSpec{ModuleName: "kmod"},
+						},
+						SrcVersion: "ABCDEF",
+					},
+				},
+			},
+		}
+	})
+
+	It("should do nothing if NodeFeatures are not supported and not needed", func() {
+		kubeClient.
+			EXPECT().
+			List(ctx, gomock.Any(), gomock.Any()).
+			Return(&apimeta.NoKindMatchError{GroupKind: nodeFeatureGVK.GroupKind()})
+
+		Expect(
+			wh.SyncNodeFeatures(ctx, nmcObj, node),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	It("should return an error if NodeFeatures are not supported but needed", func() {
+		nmcObj.Spec.Modules[0].PublishNodeFeature = true
+
+		kubeClient.
+			EXPECT().
+			List(ctx, gomock.Any(), gomock.Any()).
+			Return(&apimeta.NoKindMatchError{GroupKind: nodeFeatureGVK.GroupKind()})
+
+		Expect(
+			wh.SyncNodeFeatures(ctx, nmcObj, node),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	It("should delete the NodeFeatures of modules that are not published anymore", func() {
+		nf := unstructured.Unstructured{}
+		nf.SetGroupVersionKind(nodeFeatureGVK)
+		nf.SetNamespace(nsFirst)
+		nf.SetName(nodeFeatureName(nodeName, nameFirst))
+		nf.SetLabels(map[string]string{constants.ModuleNameLabel: nameFirst})
+
+		gomock.InOrder(
+			kubeClient.
+				EXPECT().
+				List(ctx, gomock.Any(), gomock.Any()).
+				Do(func(_ context.Context, list *unstructured.UnstructuredList, _ ...ctrlclient.ListOption) {
+					list.Items = []unstructured.Unstructured{nf}
+				}),
+			kubeClient.EXPECT().Delete(ctx, &nf),
+		)
+
+		Expect(
+			wh.SyncNodeFeatures(ctx, nmcObj, node),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	It("should create a NodeFeature for published modules", func() {
+		nmcObj.Spec.Modules[0].PublishNodeFeature = true
+
+		gomock.InOrder(
+			kubeClient.EXPECT().List(ctx, gomock.Any(), gomock.Any()),
+			kubeClient.
+				EXPECT().
+				Get(ctx, types.NamespacedName{Namespace: nsFirst, Name: nodeFeatureName(nodeName, nameFirst)}, gomock.Any()).
+				Return(k8serrors.NewNotFound(schema.GroupResource{}, "whatever")),
+			kubeClient.EXPECT().Scheme().Return(scheme),
+			kubeClient.
+				EXPECT().
+				Create(ctx, gomock.Any()).
+				Do(func(_ context.Context, obj ctrlclient.Object, _ ...ctrlclient.CreateOption) {
+					nf := obj.(*unstructured.Unstructured)
+
+					Expect(nf.GetLabels()).To(HaveKeyWithValue(nfdNodeNameLabel, nodeName))
+					Expect(nf.GetLabels()).To(HaveKeyWithValue(constants.ModuleNameLabel, nameFirst))
+					Expect(nf.GetOwnerReferences()).To(HaveLen(1))
+
+					elements, _, err := unstructured.NestedStringMap(
+						nf.Object,
+						"spec", "features", "attributes", nodeFeatureAttributeName(types.NamespacedName{Namespace: nsFirst, Name: nameFirst}), "elements",
+					)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(elements).To(Equal(map[string]string{"name": "kmod", "version": "1.2.3", "srcversion": "ABCDEF"}))
+				}),
+		)
+
+		Expect(
+			wh.SyncNodeFeatures(ctx, nmcObj, node),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
 })
 
 var _ = Describe("nmcReconcilerHelperImpl_RecordEvents", func() {
@@ -1523,7 +2522,7 @@ var _ = Describe("nmcReconcilerHelperImpl_RecordEvents", func() {
 		client = testclient.NewMockClient(ctrl)
 		//nm = node.NewMockNode(ctrl)
 		fakeRecorder = record.NewFakeRecorder(10)
-		wh = newNMCReconcilerHelper(client, nil, fakeRecorder, nil)
+		wh = newNMCReconcilerHelper(client, nil, fakeRecorder, nil, 0)
 	})
 
 	closeAndGetAllEvents := func(events chan string) []string {
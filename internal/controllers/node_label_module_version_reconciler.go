@@ -49,6 +49,12 @@ func NewNodeLabelModuleVersionReconciler(client client.Client) *NodeLabelModuleV
 }
 
 func (nlmvr *NodeLabelModuleVersionReconciler) Reconcile(ctx context.Context, node *v1.Node) (ctrl.Result, error) {
+	if utils.IsNodeExcluded(node.Labels) {
+		// Version labels drive worker Pod and device plugin scheduling; leave them as they are.
+		log.FromContext(ctx).Info("Node is excluded from KMM operations; not updating its version labels", "node name", node.Name)
+		return ctrl.Result{}, nil
+	}
+
 	modulesVersionLabels := nlmvr.helperAPI.getLabelsPerModules(ctx, node.Labels)
 
 	schedulePluginPods, err := nlmvr.helperAPI.getSchedulePluginPods(ctx, node.Name)
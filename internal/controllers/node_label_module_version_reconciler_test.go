@@ -83,6 +83,19 @@ var _ = Describe("Reconcile", func() {
 		Entry("get schedule plugin pods failed", true, false, false),
 		Entry("update node labels failed", false, true, false),
 	)
+
+	It("should not change the labels of an excluded node", func() {
+		node := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{constants.NodeExcludedLabel: "true"},
+				Name:   nodeName,
+			},
+		}
+
+		res, err := nlmvr.Reconcile(ctx, &node)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(ctrl.Result{}))
+	})
 })
 
 var _ = Describe("getLabelsPerModules", func() {
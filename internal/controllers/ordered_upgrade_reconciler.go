@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/filter"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/node"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
+	v1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const OrderedUpgradeReconcilerName = "OrderedUpgradeReconciler"
+
+// OrderedUpgradeReconciler runs the ordered upgrade of the Modules that set spec.orderedUpgrade.
+// It moves the version-module label of the targeted nodes to the Module's version, a few nodes at a time, and lets
+// NodeLabelModuleVersionReconciler drive the upgrade of each node.
+type OrderedUpgradeReconciler struct {
+	client  client.Client
+	filter  *filter.Filter
+	nodeAPI node.Node
+}
+
+func NewOrderedUpgradeReconciler(client client.Client, filter *filter.Filter, nodeAPI node.Node) *OrderedUpgradeReconciler {
+	return &OrderedUpgradeReconciler{
+		client:  client,
+		filter:  filter,
+		nodeAPI: nodeAPI,
+	}
+}
+
+func (r *OrderedUpgradeReconciler) Reconcile(ctx context.Context, mod *kmmv1beta1.Module) (ctrl.Result, error) {
+	if mod.GetDeletionTimestamp() != nil || mod.Spec.Paused {
+		return ctrl.Result{}, nil
+	}
+
+	if mod.Spec.OrderedUpgrade == nil || mod.Spec.ModuleLoader == nil || mod.Spec.ModuleLoader.Container.Version == "" {
+		return ctrl.Result{}, r.patchStatus(ctx, mod, nil)
+	}
+
+	logger := log.FromContext(ctx)
+
+	version := mod.Spec.ModuleLoader.Container.Version
+
+	nodes, err := r.nodeAPI.GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, append(mod.Spec.Tolerations, module.InternalTolerations...))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get list of nodes by selector: %v", err)
+	}
+
+	moduleVersionLabel := utils.GetModuleVersionLabelName(mod.Namespace, mod.Name)
+	status := kmmv1beta1.OrderedUpgradeStatus{TargetVersion: version}
+
+	var toLabel, pending []*v1.Node
+
+	for i := range nodes {
+		n := &nodes[i]
+
+		if utils.IsNodeExcluded(n.Labels) {
+			// The labels of excluded nodes must not change.
+			continue
+		}
+
+		current, ok := n.Labels[moduleVersionLabel]
+
+		switch {
+		case !ok:
+			// No version of the kernel module runs on that node yet; nothing to upgrade.
+			toLabel = append(toLabel, n)
+			status.InProgressNodes++
+		case current != version:
+			pending = append(pending, n)
+		case nodeUpgraded(n, mod.Namespace, mod.Name, version):
+			status.UpgradedNodes++
+		default:
+			status.InProgressNodes++
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Name < pending[j].Name
+	})
+
+	if slots := int(mod.Spec.OrderedUpgrade.MaxParallel - status.InProgressNodes); slots > 0 {
+		n := min(slots, len(pending))
+		toLabel = append(toLabel, pending[:n]...)
+		pending = pending[n:]
+		status.InProgressNodes += int32(n)
+	}
+
+	status.PendingNodes = int32(len(pending))
+
+	errs := make([]error, 0, len(toLabel)+1)
+
+	for _, n := range toLabel {
+		logger.Info("Upgrading node", "node", n.Name, "version", version)
+
+		if err = r.nodeAPI.UpdateLabels(ctx, n, map[string]string{moduleVersionLabel: version}, nil); err != nil {
+			errs = append(errs, fmt.Errorf("could not set label %s on node %s: %v", moduleVersionLabel, n.Name, err))
+		}
+	}
+
+	errs = append(errs, r.patchStatus(ctx, mod, &status))
+
+	return ctrl.Result{}, errors.Join(errs...)
+}
+
+// nodeUpgraded returns true if the worker Pod and schedule plugin version labels caught up with the version-module
+// label on the node, and the kernel module was loaded with that version.
+func nodeUpgraded(n *v1.Node, namespace, name, version string) bool {
+	return n.Labels[utils.GetWorkerPodVersionLabelName(namespace, name)] == version &&
+		n.Labels[utils.GetSchedulePluginVersionLabelName(namespace, name)] == version &&
+		n.Labels[utils.GetKernelModuleVersionReadyNodeLabel(namespace, name)] == version
+}
+
+func (r *OrderedUpgradeReconciler) patchStatus(ctx context.Context, mod *kmmv1beta1.Module, status *kmmv1beta1.OrderedUpgradeStatus) error {
+	if reflect.DeepEqual(mod.Status.OrderedUpgrade, status) {
+		return nil
+	}
+
+	unmodifiedMod := mod.DeepCopy()
+
+	mod.Status.OrderedUpgrade = status
+
+	if err := r.client.Status().Patch(ctx, mod, client.MergeFrom(unmodifiedMod)); err != nil {
+		return fmt.Errorf("could not patch the ordered upgrade status of Module %s/%s: %v", mod.Namespace, mod.Name, err)
+	}
+
+	return nil
+}
+
+func (r *OrderedUpgradeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.
+		NewControllerManagedBy(mgr).
+		For(&kmmv1beta1.Module{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&v1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.filter.FindModulesForOrderedUpgrade),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		).
+		Named(OrderedUpgradeReconcilerName).
+		Complete(
+			reconcile.AsReconciler[*kmmv1beta1.Module](r.client, r),
+		)
+}
@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/node"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
+)
+
+var _ = Describe("OrderedUpgradeReconciler_Reconcile", func() {
+	const (
+		modName      = "modName"
+		modNamespace = "modNamespace"
+		newVersion   = "v2"
+		oldVersion   = "v1"
+	)
+
+	var (
+		ctx          context.Context
+		ctrl         *gomock.Controller
+		clnt         *client.MockClient
+		statusWriter *client.MockStatusWriter
+		mockNode     *node.MockNode
+		mod          *kmmv1beta1.Module
+		r            *OrderedUpgradeReconciler
+	)
+
+	moduleVersionLabel := utils.GetModuleVersionLabelName(modNamespace, modName)
+
+	newNode := func(name, version string, upgraded bool) v1.Node {
+		n := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+		}
+
+		if version != "" {
+			n.Labels[moduleVersionLabel] = version
+		}
+
+		if upgraded {
+			n.Labels[utils.GetWorkerPodVersionLabelName(modNamespace, modName)] = version
+			n.Labels[utils.GetSchedulePluginVersionLabelName(modNamespace, modName)] = version
+			n.Labels[utils.GetKernelModuleVersionReadyNodeLabel(modNamespace, modName)] = version
+		}
+
+		return n
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		statusWriter = client.NewMockStatusWriter(ctrl)
+		mockNode = node.NewMockNode(ctrl)
+		r = NewOrderedUpgradeReconciler(clnt, nil, mockNode)
+		mod = &kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: modName, Namespace: modNamespace},
+			Spec: kmmv1beta1.ModuleSpec{
+				ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{
+					Container: kmmv1beta1.ModuleLoaderContainerSpec{Version: newVersion},
+				},
+				OrderedUpgrade: &kmmv1beta1.OrderedUpgradeSpec{MaxParallel: 2},
+			},
+		}
+	})
+
+	It("should do nothing if no ordered upgrade is configured", func() {
+		mod.Spec.OrderedUpgrade = nil
+
+		res, err := r.Reconcile(ctx, mod)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(reconcile.Result{}))
+	})
+
+	It("should do nothing if the Module is paused", func() {
+		mod.Spec.Paused = true
+
+		res, err := r.Reconcile(ctx, mod)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(reconcile.Result{}))
+	})
+
+	It("should clear the status if the ordered upgrade was removed", func() {
+		mod.Spec.OrderedUpgrade = nil
+		mod.Status.OrderedUpgrade = &kmmv1beta1.OrderedUpgradeStatus{TargetVersion: oldVersion}
+
+		clnt.EXPECT().Status().Return(statusWriter)
+		statusWriter.EXPECT().Patch(ctx, mod, gomock.Any())
+
+		_, err := r.Reconcile(ctx, mod)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mod.Status.OrderedUpgrade).To(BeNil())
+	})
+
+	It("should return an error if the nodes could not be listed", func() {
+		mockNode.
+			EXPECT().
+			GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).
+			Return(nil, errors.New("some error"))
+
+		_, err := r.Reconcile(ctx, mod)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should upgrade at most maxParallel nodes at the same time", func() {
+		nodes := []v1.Node{
+			newNode("upgraded", newVersion, true),
+			newNode("in-progress", newVersion, false),
+			newNode("pending-2", oldVersion, true),
+			newNode("pending-1", oldVersion, true),
+			newNode("new", "", false),
+		}
+
+		gomock.InOrder(
+			mockNode.
+				EXPECT().
+				GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).
+				Return(nodes, nil),
+			mockNode.EXPECT().UpdateLabels(ctx, &nodes[4], map[string]string{moduleVersionLabel: newVersion}, nil),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, mod, gomock.Any()),
+		)
+
+		_, err := r.Reconcile(ctx, mod)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mod.Status.OrderedUpgrade).To(Equal(&kmmv1beta1.OrderedUpgradeStatus{
+			TargetVersion:   newVersion,
+			UpgradedNodes:   1,
+			InProgressNodes: 2,
+			PendingNodes:    2,
+		}))
+	})
+
+	It("should upgrade pending nodes in order when slots are available", func() {
+		nodes := []v1.Node{
+			newNode("upgraded", newVersion, true),
+			newNode("pending-3", oldVersion, true),
+			newNode("pending-2", oldVersion, true),
+			newNode("pending-1", oldVersion, true),
+		}
+
+		gomock.InOrder(
+			mockNode.
+				EXPECT().
+				GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).
+				Return(nodes, nil),
+			mockNode.EXPECT().UpdateLabels(ctx, &nodes[3], map[string]string{moduleVersionLabel: newVersion}, nil),
+			mockNode.EXPECT().UpdateLabels(ctx, &nodes[2], map[string]string{moduleVersionLabel: newVersion}, nil),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, mod, gomock.Any()),
+		)
+
+		_, err := r.Reconcile(ctx, mod)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mod.Status.OrderedUpgrade).To(Equal(&kmmv1beta1.OrderedUpgradeStatus{
+			TargetVersion:   newVersion,
+			UpgradedNodes:   1,
+			InProgressNodes: 2,
+			PendingNodes:    1,
+		}))
+	})
+
+	It("should not upgrade excluded nodes", func() {
+		nodes := []v1.Node{newNode("excluded", oldVersion, true)}
+		nodes[0].Labels[constants.NodeExcludedLabel] = "true"
+
+		mod.Status.OrderedUpgrade = &kmmv1beta1.OrderedUpgradeStatus{TargetVersion: newVersion}
+
+		mockNode.
+			EXPECT().
+			GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).
+			Return(nodes, nil)
+
+		_, err := r.Reconcile(ctx, mod)
+
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not patch the status if it did not change", func() {
+		nodes := []v1.Node{newNode("upgraded", newVersion, true)}
+
+		mod.Status.OrderedUpgrade = &kmmv1beta1.OrderedUpgradeStatus{TargetVersion: newVersion, UpgradedNodes: 1}
+
+		mockNode.
+			EXPECT().
+			GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).
+			Return(nodes, nil)
+
+		_, err := r.Reconcile(ctx, mod)
+
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should return an error if a node could not be labeled", func() {
+		nodes := []v1.Node{newNode("pending", oldVersion, true)}
+
+		gomock.InOrder(
+			mockNode.
+				EXPECT().
+				GetSchedulableNodesBySelector(ctx, mod.Spec.Selector, module.InternalTolerations).
+				Return(nodes, nil),
+			mockNode.
+				EXPECT().
+				UpdateLabels(ctx, &nodes[0], map[string]string{moduleVersionLabel: newVersion}, nil).
+				Return(errors.New("some error")),
+			clnt.EXPECT().Status().Return(statusWriter),
+			statusWriter.EXPECT().Patch(ctx, mod, gomock.Any()),
+		)
+
+		_, err := r.Reconcile(ctx, mod)
+
+		Expect(err).To(HaveOccurred())
+	})
+})
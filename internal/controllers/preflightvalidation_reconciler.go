@@ -29,6 +29,8 @@ import (
 	"github.com/kubernetes-sigs/kernel-module-management/internal/mic"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/preflight"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -120,8 +122,8 @@ func (r *preflightValidationReconciler) Reconcile(ctx context.Context, pv *v1bet
 
 //go:generate mockgen -source=preflightvalidation_reconciler.go -package=controllers -destination=mock_preflightvalidation_reconciler.go preflightReconcilerHelper
 type preflightReconcilerHelper interface {
-	updateStatus(ctx context.Context, modsWithMapping []*api.ModuleLoaderData, modsWithoutMapping []types.NamespacedName, pv *v1beta2.PreflightValidation) error
-	getModulesData(ctx context.Context, pv *v1beta2.PreflightValidation) ([]*api.ModuleLoaderData, []types.NamespacedName, error)
+	updateStatus(ctx context.Context, modsWithMapping []*api.ModuleLoaderData, modsWithoutMapping map[types.NamespacedName]string, pv *v1beta2.PreflightValidation) error
+	getModulesData(ctx context.Context, pv *v1beta2.PreflightValidation) ([]*api.ModuleLoaderData, map[types.NamespacedName]string, error)
 	processPreflightValidation(ctx context.Context, modsWithMapping []*api.ModuleLoaderData, pv *v1beta2.PreflightValidation) error
 }
 
@@ -151,14 +153,14 @@ func newPreflightReconcilerHelper(client client.Client,
 func (p *preflightReconcilerHelperImpl) updateStatus(
 	ctx context.Context,
 	modsWithMapping []*api.ModuleLoaderData,
-	modsWithoutMapping []types.NamespacedName,
+	modsWithoutMapping map[types.NamespacedName]string,
 	pv *v1beta2.PreflightValidation) error {
 
 	unmodifiedPV := pv.DeepCopy()
 
 	// setting the status for modules without mapping
-	for _, mod := range modsWithoutMapping {
-		p.preflightAPI.SetModuleStatus(pv, mod.Namespace, mod.Name, v1beta2.VerificationFailure, "mapping not found")
+	for mod, reason := range modsWithoutMapping {
+		p.preflightAPI.SetModuleStatus(pv, mod.Namespace, mod.Name, v1beta2.VerificationFailure, reason)
 	}
 
 	// setting status for modules with mapping
@@ -186,27 +188,34 @@ func (p *preflightReconcilerHelperImpl) updateStatus(
 	return p.client.Status().Patch(ctx, pv, client.MergeFrom(unmodifiedPV))
 }
 
-func (p *preflightReconcilerHelperImpl) getModulesData(ctx context.Context, pv *v1beta2.PreflightValidation) ([]*api.ModuleLoaderData, []types.NamespacedName, error) {
+// getModulesData returns the ModuleLoaderData of the Modules for the node described in the spec of pv, and the reason
+// why the other Modules have none.
+func (p *preflightReconcilerHelperImpl) getModulesData(ctx context.Context, pv *v1beta2.PreflightValidation) ([]*api.ModuleLoaderData, map[types.NamespacedName]string, error) {
 	modulesList := kmmv1beta1.ModuleList{}
 	err := p.client.List(ctx, &modulesList)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get list of all Modules: %v", err)
 	}
 
-	mldsWithoutMapping := make([]types.NamespacedName, 0, len(modulesList.Items))
+	node := preflightNode(pv)
+
+	mldsWithoutMapping := make(map[types.NamespacedName]string)
 	mldsWithMapping := make([]*api.ModuleLoaderData, 0, len(modulesList.Items))
 	for _, mod := range modulesList.Items {
 		// ignore modules being deleted
 		if mod.GetDeletionTimestamp() != nil {
 			continue
 		}
-		mld, err := p.kernelAPI.GetModuleLoaderDataForKernel(&mod, pv.Spec.KernelVersion)
-		if err != nil {
-			if !errors.Is(err, module.ErrNoMatchingKernelMapping) {
-				return nil, nil, fmt.Errorf("failed to get MLD for module %s/%s: %v", mod.Namespace, mod.Name, err)
-			}
-			mldsWithoutMapping = append(mldsWithoutMapping, types.NamespacedName{Name: mod.Name, Namespace: mod.Namespace})
-		} else {
+		nsn := types.NamespacedName{Name: mod.Name, Namespace: mod.Namespace}
+		mld, err := p.kernelAPI.GetModuleLoaderDataForNode(&mod, node)
+		switch {
+		case errors.Is(err, module.ErrNoMatchingKernelMapping):
+			mldsWithoutMapping[nsn] = "mapping not found"
+		case errors.Is(err, module.ErrUnknownNodeProperty):
+			mldsWithoutMapping[nsn] = fmt.Sprintf("cannot select the kernel mapping: %v", err)
+		case err != nil:
+			return nil, nil, fmt.Errorf("failed to get MLD for module %s/%s: %v", mod.Namespace, mod.Name, err)
+		default:
 			mldsWithMapping = append(mldsWithMapping, mld)
 		}
 	}
@@ -214,6 +223,21 @@ func (p *preflightReconcilerHelperImpl) getModulesData(ctx context.Context, pv *
 	return mldsWithMapping, mldsWithoutMapping, nil
 }
 
+// preflightNode returns a node with the kernel, architecture, OS image and labels from the spec of pv.
+// The properties that are not set are not known when selecting kernel mappings.
+func preflightNode(pv *v1beta2.PreflightValidation) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Labels: pv.Spec.NodeLabels},
+		Status: v1.NodeStatus{
+			NodeInfo: v1.NodeSystemInfo{
+				KernelVersion: pv.Spec.KernelVersion,
+				Architecture:  pv.Spec.Architecture,
+				OSImage:       pv.Spec.OSImage,
+			},
+		},
+	}
+}
+
 func (p *preflightReconcilerHelperImpl) processPreflightValidation(ctx context.Context, modsWithMapping []*api.ModuleLoaderData, pv *v1beta2.PreflightValidation) error {
 	errs := []error{}
 	for _, mod := range modsWithMapping {
@@ -51,7 +51,7 @@ var _ = Describe("PreflightValidationReconciler", func() {
 	DescribeTable("check good and error flows", func(getModulesDataFailed, updateStatusFailed, runValidationFailed, notAllModulesVerified bool) {
 		returnedError := errors.New("some error")
 		modsWithMapping := []*api.ModuleLoaderData{}
-		modsWithoutMapping := []types.NamespacedName{}
+		modsWithoutMapping := map[types.NamespacedName]string{}
 
 		mockMetrics.EXPECT().SetKMMPreflightsNum(1)
 		if getModulesDataFailed {
@@ -135,11 +135,8 @@ var _ = Describe("updateStatus", func() {
 				ContainerImage: "mld container image4",
 			},
 		}
-		modsWithoutMapping := []types.NamespacedName{
-			{
-				Name:      "some name",
-				Namespace: "some namespace",
-			},
+		modsWithoutMapping := map[types.NamespacedName]string{
+			{Name: "some name", Namespace: "some namespace"}: "mapping not found",
 		}
 
 		gomock.InOrder(
@@ -187,6 +184,20 @@ var _ = Describe("getModulesData", func() {
 	pv := &v1beta2.PreflightValidation{
 		Spec: v1beta2.PreflightValidationSpec{
 			KernelVersion: "some kernel version",
+			Architecture:  "arm64",
+			OSImage:       "some OS image",
+			NodeLabels:    map[string]string{"key": "value"},
+		},
+	}
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"key": "value"}},
+		Status: v1.NodeStatus{
+			NodeInfo: v1.NodeSystemInfo{
+				KernelVersion: "some kernel version",
+				Architecture:  "arm64",
+				OSImage:       "some OS image",
+			},
 		},
 	}
 
@@ -200,22 +211,47 @@ var _ = Describe("getModulesData", func() {
 		testMod3 := kmmv1beta1.Module{}
 		now := metav1.Now()
 		testMod3.SetDeletionTimestamp(&now)
+		testMod4 := kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "testMod4 name", Namespace: "testMod4 namespace"},
+		}
 		returnedMLD := api.ModuleLoaderData{Name: "testMod1 name", Namespace: "testMod1 namespace"}
 		gomock.InOrder(
 			mockClient.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
 				func(_ interface{}, list *kmmv1beta1.ModuleList, _ ...interface{}) error {
-					list.Items = []kmmv1beta1.Module{testMod1, testMod2, testMod3}
+					list.Items = []kmmv1beta1.Module{testMod1, testMod2, testMod3, testMod4}
 					return nil
 				},
 			),
-			mockKernel.EXPECT().GetModuleLoaderDataForKernel(&testMod1, "some kernel version").Return(&returnedMLD, nil),
-			mockKernel.EXPECT().GetModuleLoaderDataForKernel(&testMod2, "some kernel version").Return(nil, module.ErrNoMatchingKernelMapping),
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&testMod1, node).Return(&returnedMLD, nil),
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&testMod2, node).Return(nil, module.ErrNoMatchingKernelMapping),
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&testMod4, node).Return(nil, module.ErrUnknownNodeProperty),
 		)
 
 		modsWithMapping, modsWithoutMapping, err := p.getModulesData(ctx, pv)
 		Expect(err).To(BeNil())
 		Expect(modsWithMapping).To(Equal([]*api.ModuleLoaderData{&returnedMLD}))
-		Expect(modsWithoutMapping).To(Equal([]types.NamespacedName{{Name: testMod2.Name, Namespace: testMod2.Namespace}}))
+		Expect(modsWithoutMapping).To(Equal(map[types.NamespacedName]string{
+			{Name: testMod2.Name, Namespace: testMod2.Namespace}: "mapping not found",
+			{Name: testMod4.Name, Namespace: testMod4.Namespace}: "cannot select the kernel mapping: " + module.ErrUnknownNodeProperty.Error(),
+		}))
+	})
+
+	It("should return an error if the ModuleLoaderData cannot be computed", func() {
+		testMod := kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "testMod name", Namespace: "testMod namespace"},
+		}
+		gomock.InOrder(
+			mockClient.EXPECT().List(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, list *kmmv1beta1.ModuleList, _ ...interface{}) error {
+					list.Items = []kmmv1beta1.Module{testMod}
+					return nil
+				},
+			),
+			mockKernel.EXPECT().GetModuleLoaderDataForNode(&testMod, node).Return(nil, errors.New("some error")),
+		)
+
+		_, _, err := p.getModulesData(ctx, pv)
+		Expect(err).To(HaveOccurred())
 	})
 })
 
@@ -1,7 +1,11 @@
 package filter
 
 import (
+	"bytes"
 	"context"
+	"maps"
+	"slices"
+
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -18,6 +22,7 @@ import (
 	hubv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api-hub/v1beta1"
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/nmc"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
 )
@@ -192,13 +197,53 @@ func NMCReconcilerNodePredicate() predicate.Predicate {
 	)
 }
 
-func ModuleReconcilerNodePredicate() predicate.Predicate {
+// ModuleReconcilerNodePredicate only lets through the annotation changes of pendingKernelAnnotation and of the
+// prebuildKernels.nodeAnnotation keys used by Modules, which hold the kernels to prebuild for the node.
+func (f *Filter) ModuleReconcilerNodePredicate(pendingKernelAnnotation string) predicate.Predicate {
 	return predicate.And(
 		skipDeletions,
-		predicate.Or(nodeTaintsChanged, predicate.LabelChangedPredicate{}),
+		predicate.Or(nodeTaintsChanged, predicate.LabelChangedPredicate{}, f.nodeKernelAnnotationsChanged(pendingKernelAnnotation)),
 	)
 }
 
+func (f *Filter) nodeKernelAnnotationsChanged(pendingKernelAnnotation string) predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldAnnotations := e.ObjectOld.GetAnnotations()
+			newAnnotations := e.ObjectNew.GetAnnotations()
+
+			if reflect.DeepEqual(oldAnnotations, newAnnotations) {
+				return false
+			}
+
+			if pendingKernelAnnotation != "" && oldAnnotations[pendingKernelAnnotation] != newAnnotations[pendingKernelAnnotation] {
+				return true
+			}
+
+			ctx := context.Background()
+			mods := kmmv1beta1.ModuleList{}
+
+			if err := f.client.List(ctx, &mods); err != nil {
+				ctrl.LoggerFrom(ctx).Error(err, "could not list modules")
+				return true
+			}
+
+			for _, mod := range mods.Items {
+				pk := mod.Spec.PrebuildKernels
+				if pk == nil || pk.NodeAnnotation == "" {
+					continue
+				}
+
+				if oldAnnotations[pk.NodeAnnotation] != newAnnotations[pk.NodeAnnotation] {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
+
 func DevicePluginReconcilerNodePredicate() predicate.Predicate {
 	return predicate.And(
 		skipDeletions,
@@ -343,6 +388,170 @@ func (f *Filter) FindModulesForNMCNodeChange(ctx context.Context, node client.Ob
 	return reqs
 }
 
+// FindModulesWithConflictingKernelModules finds all the other Modules that load or remove one of the kernel
+// modules handled by mod, so that they can refresh their Conflict condition.
+func (f *Filter) FindModulesWithConflictingKernelModules(ctx context.Context, mod client.Object) []reconcile.Request {
+	logger := ctrl.LoggerFrom(ctx).WithValues("module", mod.GetName())
+
+	kmod, ok := mod.(*kmmv1beta1.Module)
+	if !ok {
+		logger.Info("Object is not a Module", "object", mod)
+		return nil
+	}
+
+	mods := kmmv1beta1.ModuleList{}
+
+	if err := f.client.List(ctx, &mods); err != nil {
+		logger.Error(err, "could not list modules")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0)
+
+	for _, other := range mods.Items {
+		if other.Namespace == kmod.Namespace && other.Name == kmod.Name {
+			continue
+		}
+
+		if len(module.ModulesConflict(kmod, &other)) == 0 {
+			continue
+		}
+
+		nsn := types.NamespacedName{Name: other.Name, Namespace: other.Namespace}
+
+		reqs = append(reqs, reconcile.Request{NamespacedName: nsn})
+	}
+
+	logger.V(1).Info("Modules with conflicting kernel modules", "requests", reqs)
+
+	return reqs
+}
+
+// FindModulesForOrderedUpgrade returns the Modules that have an ordered upgrade configured and target the node.
+func (f *Filter) FindModulesForOrderedUpgrade(ctx context.Context, node client.Object) []reconcile.Request {
+	logger := ctrl.LoggerFrom(ctx).WithValues("node", node.GetName())
+
+	mods := kmmv1beta1.ModuleList{}
+
+	if err := f.client.List(ctx, &mods); err != nil {
+		logger.Error(err, "could not list modules")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0)
+
+	for _, mod := range mods.Items {
+		if mod.Spec.OrderedUpgrade == nil {
+			continue
+		}
+
+		selected, err := utils.IsObjectSelectedByLabels(node.GetLabels(), mod.Spec.Selector)
+		if err != nil {
+			logger.Error(err, "could not determine if the node is selected by the Module", "module", mod.Name)
+			continue
+		}
+
+		if !selected {
+			continue
+		}
+
+		nsn := types.NamespacedName{Name: mod.Name, Namespace: mod.Namespace}
+
+		reqs = append(reqs, reconcile.Request{NamespacedName: nsn})
+	}
+
+	logger.V(1).Info("Modules with an ordered upgrade targeting the node", "requests", reqs)
+
+	return reqs
+}
+
+// FindModulesForConfigMap returns the Modules that read modprobe parameters, build arguments or kernels to prebuild
+// from the ConfigMap.
+func (f *Filter) FindModulesForConfigMap(ctx context.Context, cm client.Object) []reconcile.Request {
+	return f.findModulesForValueSource(ctx, cm, func(src kmmv1beta1.ValueSource) bool {
+		return src.ConfigMapKeyRef != nil && src.ConfigMapKeyRef.Name == cm.GetName()
+	})
+}
+
+// ModuleValueSourceSecretsIndex indexes Modules by the names of the Secrets they read modprobe parameters or build
+// arguments from.
+const ModuleValueSourceSecretsIndex = "kmm.valueSourceSecrets"
+
+// FindModulesForSecret returns the Modules that read modprobe parameters or build arguments from the Secret.
+// It relies on the ModuleValueSourceSecretsIndex index.
+func (f *Filter) FindModulesForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	logger := ctrl.LoggerFrom(ctx).WithValues("namespace", secret.GetNamespace(), "name", secret.GetName())
+
+	mods := kmmv1beta1.ModuleList{}
+
+	opts := []client.ListOption{
+		client.InNamespace(secret.GetNamespace()),
+		client.MatchingFields{ModuleValueSourceSecretsIndex: secret.GetName()},
+	}
+
+	if err := f.client.List(ctx, &mods, opts...); err != nil {
+		logger.Error(err, "could not list modules")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(mods.Items))
+
+	for _, mod := range mods.Items {
+		nsn := types.NamespacedName{Name: mod.Name, Namespace: mod.Namespace}
+		reqs = append(reqs, reconcile.Request{NamespacedName: nsn})
+	}
+
+	logger.V(1).Info("Modules referencing the Secret", "requests", reqs)
+
+	return reqs
+}
+
+// SecretDataChangedPredicate filters out the Secret updates that do not change the data of the Secret, such as
+// changes to its labels or annotations.
+// Modules record a digest of the Secret values they reference, so that changes to those values reload the kernel
+// module and replace running builds.
+func SecretDataChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSecret, ok := e.ObjectOld.(*v1.Secret)
+			if !ok {
+				return true
+			}
+
+			newSecret, ok := e.ObjectNew.(*v1.Secret)
+			if !ok {
+				return true
+			}
+
+			return !maps.EqualFunc(oldSecret.Data, newSecret.Data, bytes.Equal)
+		},
+	}
+}
+
+func (f *Filter) findModulesForValueSource(ctx context.Context, obj client.Object, references func(kmmv1beta1.ValueSource) bool) []reconcile.Request {
+	logger := ctrl.LoggerFrom(ctx).WithValues("namespace", obj.GetNamespace(), "name", obj.GetName())
+
+	mods := kmmv1beta1.ModuleList{}
+
+	if err := f.client.List(ctx, &mods, client.InNamespace(obj.GetNamespace())); err != nil {
+		logger.Error(err, "could not list modules")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0)
+
+	for _, mod := range mods.Items {
+		if slices.ContainsFunc(module.ValueSources(&mod), references) {
+			nsn := types.NamespacedName{Name: mod.Name, Namespace: mod.Namespace}
+			reqs = append(reqs, reconcile.Request{NamespacedName: nsn})
+		}
+	}
+
+	logger.V(1).Info("Modules referencing the object", "requests", reqs)
+
+	return reqs
+}
+
 func (f *Filter) FindManagedClusterModulesForCluster(ctx context.Context, cluster client.Object) []reconcile.Request {
 	logger := ctrl.LoggerFrom(ctx).WithValues("managedcluster", cluster.GetName())
 
@@ -463,6 +672,11 @@ func NodeLabelModuleVersionUpdatePredicate(logger logr.Logger) predicate.Predica
 				return true
 			}
 
+			if utils.IsNodeExcluded(oldNode.Labels) != utils.IsNodeExcluded(newNode.Labels) {
+				// Apply the version label changes that were held while the node was excluded.
+				return true
+			}
+
 			oldNodeVersionLabels := utils.GetNodesVersionLabels(oldNode.Labels)
 			newNodeVersionLabels := utils.GetNodesVersionLabels(newNode.Labels)
 			return !reflect.DeepEqual(oldNodeVersionLabels, newNodeVersionLabels)
@@ -2,6 +2,7 @@ package filter
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -25,6 +26,7 @@ import (
 	mockClient "github.com/kubernetes-sigs/kernel-module-management/internal/client"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/nmc"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
 )
 
 var (
@@ -308,10 +310,15 @@ var _ = Describe("ListModulesForNMC", func() {
 })
 
 var _ = Describe("ModuleReconcilerNodePredicate", func() {
+	const pendingKernelAnnotation = "example.com/pending-kernel"
+
 	var p predicate.Predicate
 
 	BeforeEach(func() {
-		p = ModuleReconcilerNodePredicate()
+		mockCtrl = gomock.NewController(GinkgoT())
+		clnt = mockClient.NewMockClient(mockCtrl)
+		f = New(clnt, nil)
+		p = f.ModuleReconcilerNodePredicate(pendingKernelAnnotation)
 	})
 
 	It("should return true for creations", func() {
@@ -342,6 +349,92 @@ var _ = Describe("ModuleReconcilerNodePredicate", func() {
 			BeTrue(),
 		)
 	})
+
+	It("should return true for pending kernel annotation updates", func() {
+		ev := event.UpdateEvent{
+			ObjectOld: &v1.Node{},
+			ObjectNew: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{pendingKernelAnnotation: "6.3.5"},
+				},
+			},
+		}
+
+		Expect(
+			p.Update(ev),
+		).To(
+			BeTrue(),
+		)
+	})
+
+	DescribeTable("should only return true for updates of the prebuild kernels annotations of Modules",
+		func(annotation string, expected bool) {
+			mod := kmmv1beta1.Module{
+				Spec: kmmv1beta1.ModuleSpec{
+					PrebuildKernels: &kmmv1beta1.PrebuildKernelsSpec{NodeAnnotation: "example.com/next-kernels"},
+				},
+			}
+
+			clnt.EXPECT().List(context.Background(), &kmmv1beta1.ModuleList{}).DoAndReturn(
+				func(_ interface{}, list *kmmv1beta1.ModuleList, _ ...interface{}) error {
+					list.Items = []kmmv1beta1.Module{{}, mod}
+					return nil
+				},
+			)
+
+			ev := event.UpdateEvent{
+				ObjectOld: &v1.Node{},
+				ObjectNew: &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{annotation: "some value"},
+					},
+				},
+			}
+
+			Expect(
+				p.Update(ev),
+			).To(
+				Equal(expected),
+			)
+		},
+		Entry("annotation of a Module", "example.com/next-kernels", true),
+		Entry("other annotation", "some annotation", false),
+	)
+
+	It("should return true for annotation updates if the Modules cannot be listed", func() {
+		clnt.EXPECT().List(context.Background(), &kmmv1beta1.ModuleList{}).Return(errors.New("random error"))
+
+		ev := event.UpdateEvent{
+			ObjectOld: &v1.Node{},
+			ObjectNew: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"some annotation": "some value"},
+				},
+			},
+		}
+
+		Expect(
+			p.Update(ev),
+		).To(
+			BeTrue(),
+		)
+	})
+
+	It("should return false for updates that do not change labels, taints or annotations", func() {
+		ev := event.UpdateEvent{
+			ObjectOld: &v1.Node{},
+			ObjectNew: &v1.Node{
+				Status: v1.NodeStatus{Phase: v1.NodeRunning},
+			},
+		}
+
+		Expect(
+			p.Update(ev),
+		).To(
+			BeFalse(),
+		)
+	})
+
 	It("should return false for deletions", func() {
 		ev := event.DeleteEvent{
 			Object: &v1.Node{},
@@ -387,6 +480,43 @@ var _ = Describe("NodeUpdateKernelChangedPredicate", func() {
 	)
 })
 
+var _ = Describe("NodeLabelModuleVersionUpdatePredicate", func() {
+	updateFunc := NodeLabelModuleVersionUpdatePredicate(GinkgoLogr).Update
+
+	node := v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"some-label": "some-value"},
+		},
+	}
+
+	excludedNode := v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"some-label": "some-value", constants.NodeExcludedLabel: "true"},
+		},
+	}
+
+	versionedNode := v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{utils.GetModuleVersionLabelName("ns", "name"): "v1"},
+		},
+	}
+
+	DescribeTable(
+		"should work as expected",
+		func(updateEvent event.UpdateEvent, expectedResult bool) {
+			Expect(
+				updateFunc(updateEvent),
+			).To(
+				Equal(expectedResult),
+			)
+		},
+		Entry("no change", event.UpdateEvent{ObjectOld: &node, ObjectNew: &node}, false),
+		Entry("version label added", event.UpdateEvent{ObjectOld: &node, ObjectNew: &versionedNode}, true),
+		Entry("node excluded", event.UpdateEvent{ObjectOld: &node, ObjectNew: &excludedNode}, true),
+		Entry("node not excluded anymore", event.UpdateEvent{ObjectOld: &excludedNode, ObjectNew: &node}, true),
+	)
+})
+
 var _ = Describe("FindModulesForNode", func() {
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
@@ -565,6 +695,216 @@ var _ = Describe("FindModulesForNMCNodeChange", func() {
 	})
 })
 
+var _ = Describe("FindModulesForOrderedUpgrade", func() {
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		clnt = mockClient.NewMockClient(mockCtrl)
+		f = New(clnt, nil)
+	})
+
+	ctx := context.Background()
+
+	It("should return nothing if the modules could not be listed", func() {
+		clnt.EXPECT().List(ctx, gomock.Any()).Return(errors.New("some error"))
+
+		Expect(
+			f.FindModulesForOrderedUpgrade(ctx, &v1.Node{}),
+		).To(
+			BeEmpty(),
+		)
+	})
+
+	It("should return only modules with an ordered upgrade matching the node", func() {
+		nodeLabels := map[string]string{"key": "value"}
+
+		node := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Labels: nodeLabels},
+		}
+
+		matching := kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "matching", Namespace: "ns"},
+			Spec: kmmv1beta1.ModuleSpec{
+				Selector:       nodeLabels,
+				OrderedUpgrade: &kmmv1beta1.OrderedUpgradeSpec{MaxParallel: 1},
+			},
+		}
+
+		noOrderedUpgrade := kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-ordered-upgrade", Namespace: "ns"},
+			Spec:       kmmv1beta1.ModuleSpec{Selector: nodeLabels},
+		}
+
+		otherSelector := kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-selector", Namespace: "ns"},
+			Spec: kmmv1beta1.ModuleSpec{
+				Selector:       map[string]string{"other-key": "other-value"},
+				OrderedUpgrade: &kmmv1beta1.OrderedUpgradeSpec{MaxParallel: 1},
+			},
+		}
+
+		clnt.EXPECT().List(ctx, gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.ModuleList, _ ...interface{}) error {
+				list.Items = []kmmv1beta1.Module{matching, noOrderedUpgrade, otherSelector}
+				return nil
+			},
+		)
+
+		Expect(
+			f.FindModulesForOrderedUpgrade(ctx, &node),
+		).To(
+			Equal([]reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "matching", Namespace: "ns"}},
+			}),
+		)
+	})
+})
+
+var _ = Describe("FindModulesForConfigMap and FindModulesForSecret", func() {
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		clnt = mockClient.NewMockClient(mockCtrl)
+		f = New(clnt, nil)
+	})
+
+	ctx := context.Background()
+
+	const (
+		name      = "values"
+		namespace = "ns"
+	)
+
+	paramsFromConfigMap := kmmv1beta1.Module{
+		ObjectMeta: metav1.ObjectMeta{Name: "params-from-configmap", Namespace: namespace},
+		Spec: kmmv1beta1.ModuleSpec{
+			ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{
+				Container: kmmv1beta1.ModuleLoaderContainerSpec{
+					Modprobe: kmmv1beta1.ModprobeSpec{
+						ParametersFrom: []kmmv1beta1.ModprobeParameterSource{
+							{
+								Name: "param",
+								ValueFrom: kmmv1beta1.ValueSource{
+									ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+										LocalObjectReference: v1.LocalObjectReference{Name: name},
+										Key:                  "key",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	buildArgFromSecret := kmmv1beta1.Module{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-arg-from-secret", Namespace: namespace},
+		Spec: kmmv1beta1.ModuleSpec{
+			ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{
+				Container: kmmv1beta1.ModuleLoaderContainerSpec{
+					KernelMappings: []kmmv1beta1.KernelMapping{
+						{
+							Build: &kmmv1beta1.Build{
+								BuildArgs: []kmmv1beta1.BuildArg{
+									{
+										Name: "ARG",
+										ValueFrom: &kmmv1beta1.ValueSource{
+											SecretKeyRef: &v1.SecretKeySelector{
+												LocalObjectReference: v1.LocalObjectReference{Name: name},
+												Key:                  "key",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	noReferences := kmmv1beta1.Module{
+		ObjectMeta: metav1.ObjectMeta{Name: "no-references", Namespace: namespace},
+		Spec:       kmmv1beta1.ModuleSpec{ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{}},
+	}
+
+	listModules := func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), client.InNamespace(namespace)).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.ModuleList, _ ...interface{}) error {
+				list.Items = []kmmv1beta1.Module{paramsFromConfigMap, buildArgFromSecret, noReferences}
+				return nil
+			},
+		)
+	}
+
+	It("should return nothing if the modules could not be listed", func() {
+		clnt.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+
+		Expect(
+			f.FindModulesForConfigMap(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}),
+		).To(
+			BeEmpty(),
+		)
+	})
+
+	It("should return the modules referencing the ConfigMap", func() {
+		listModules()
+
+		Expect(
+			f.FindModulesForConfigMap(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}),
+		).To(
+			Equal([]reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: paramsFromConfigMap.Name, Namespace: namespace}},
+			}),
+		)
+	})
+
+	It("should return the modules indexed by the Secret", func() {
+		clnt.EXPECT().List(
+			ctx,
+			gomock.Any(),
+			client.InNamespace(namespace),
+			client.MatchingFields{ModuleValueSourceSecretsIndex: name},
+		).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.ModuleList, _ ...interface{}) error {
+				list.Items = []kmmv1beta1.Module{buildArgFromSecret}
+				return nil
+			},
+		)
+
+		Expect(
+			f.FindModulesForSecret(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}),
+		).To(
+			Equal([]reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: buildArgFromSecret.Name, Namespace: namespace}},
+			}),
+		)
+	})
+})
+
+var _ = Describe("SecretDataChangedPredicate", func() {
+	updateFunc := SecretDataChangedPredicate().Update
+
+	secret := func(data map[string][]byte) *v1.Secret {
+		return &v1.Secret{Data: data}
+	}
+
+	DescribeTable(
+		"should work as expected",
+		func(oldData, newData map[string][]byte, expectedResult bool) {
+			Expect(
+				updateFunc(event.UpdateEvent{ObjectOld: secret(oldData), ObjectNew: secret(newData)}),
+			).To(
+				Equal(expectedResult),
+			)
+		},
+		Entry("value changed", map[string][]byte{"a": []byte("1")}, map[string][]byte{"a": []byte("2")}, true),
+		Entry("data unchanged", map[string][]byte{"a": []byte("1")}, map[string][]byte{"a": []byte("1")}, false),
+		Entry("key added", map[string][]byte{"a": []byte("1")}, map[string][]byte{"a": []byte("1"), "b": nil}, true),
+		Entry("key removed", map[string][]byte{"a": []byte("1")}, nil, true),
+	)
+})
+
 var _ = Describe("FindManagedClusterModulesForCluster", func() {
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
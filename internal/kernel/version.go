@@ -0,0 +1,200 @@
+package kernel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Version is a kernel version split into its upstream part (e.g. 5.14.0) and its distribution release part
+// (e.g. 284.11.1.el9_2.x86_64 in RHEL's 5.14.0-284.11.1.el9_2.x86_64).
+type Version struct {
+	upstream []string
+	release  []string
+}
+
+// ParseVersion parses a kernel version such as 5.14.0-284.11.1.el9_2.x86_64.
+// Both parts are split into numeric and alphabetic segments; all other characters only separate segments.
+func ParseVersion(version string) (Version, error) {
+	upstream, release, _ := strings.Cut(version, "-")
+
+	v := Version{
+		upstream: segments(upstream),
+		release:  segments(release),
+	}
+
+	if len(v.upstream) == 0 || !isDigit(rune(v.upstream[0][0])) {
+		return Version{}, fmt.Errorf("%q is not a kernel version", version)
+	}
+
+	return v, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower, equal to or greater than other.
+// Missing upstream segments count as 0, so 5.14 equals 5.14.0.
+// A version without a release part is lower than the same upstream version with any release, so 5.14.0 is lower
+// than 5.14.0-284.
+func (v Version) Compare(other Version) int {
+	if c := compareSegments(v.upstream, other.upstream, "0"); c != 0 {
+		return c
+	}
+
+	return compareSegments(v.release, other.release, "")
+}
+
+type versionConstraint struct {
+	op      string
+	version Version
+}
+
+func (c versionConstraint) matches(v Version) bool {
+	cmp := v.Compare(c.version)
+
+	switch c.op {
+	case ">=":
+		return cmp >= 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp == 0
+	}
+}
+
+// VersionRange is a set of constraints that a kernel version must all satisfy.
+type VersionRange []versionConstraint
+
+// ParseVersionRange parses space-separated constraints such as ">=5.14.0-284 <5.15".
+// Each constraint is an operator among >=, >, <=, < and =, followed by a kernel version.
+// A version without an operator must be matched exactly.
+func ParseVersionRange(s string) (VersionRange, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, errors.New("empty version range")
+	}
+
+	vr := make(VersionRange, 0, len(fields))
+
+	for _, f := range fields {
+		op := "="
+
+		for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+			if strings.HasPrefix(f, candidate) {
+				op = candidate
+				f = strings.TrimPrefix(f, candidate)
+				break
+			}
+		}
+
+		v, err := ParseVersion(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraint %s%s: %v", op, f, err)
+		}
+
+		vr = append(vr, versionConstraint{op: op, version: v})
+	}
+
+	return vr, nil
+}
+
+// Matches returns true if v satisfies all the constraints of the range.
+func (vr VersionRange) Matches(v Version) bool {
+	for _, c := range vr {
+		if !c.matches(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// segments splits s into runs of digits and runs of letters, dropping every other character.
+func segments(s string) []string {
+	var (
+		res   []string
+		start = -1
+	)
+
+	runes := []rune(s)
+
+	for i, r := range runes {
+		if start >= 0 && (isDigit(r) != isDigit(runes[start]) || !(isDigit(r) || isLetter(r))) {
+			res = append(res, string(runes[start:i]))
+			start = -1
+		}
+
+		if start < 0 && (isDigit(r) || isLetter(r)) {
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		res = append(res, string(runes[start:]))
+	}
+
+	return res
+}
+
+// compareSegments compares a and b segment by segment, in the way rpm compares versions: numeric segments are
+// compared as numbers, alphabetic ones as strings, and a numeric segment is greater than an alphabetic one.
+// A missing segment is replaced by padding if it is not empty; otherwise the shorter list is lower.
+func compareSegments(a, b []string, padding string) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var sa, sb string
+
+		switch {
+		case i < len(a) && i < len(b):
+			sa, sb = a[i], b[i]
+		case padding == "" && i >= len(a):
+			return -1
+		case padding == "" && i >= len(b):
+			return 1
+		case i >= len(a):
+			sa, sb = padding, b[i]
+		default:
+			sa, sb = a[i], padding
+		}
+
+		if c := compareSegment(sa, sb); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func compareSegment(a, b string) int {
+	aNumeric := isDigit(rune(a[0]))
+	bNumeric := isDigit(rune(b[0]))
+
+	switch {
+	case aNumeric && !bNumeric:
+		return 1
+	case !aNumeric && bNumeric:
+		return -1
+	case aNumeric:
+		a = strings.TrimLeft(a, "0")
+		b = strings.TrimLeft(b, "0")
+
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return strings.Compare(a, b)
+}
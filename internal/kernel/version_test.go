@@ -0,0 +1,80 @@
+package kernel
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseVersion", func() {
+	DescribeTable(
+		"should reject invalid versions",
+		func(version string) {
+			_, err := ParseVersion(version)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("no upstream part", "-284"),
+		Entry("not starting with a number", "abc"),
+	)
+})
+
+var _ = Describe("Version.Compare", func() {
+	DescribeTable(
+		"should compare kernel versions",
+		func(a, b string, expected int) {
+			va, err := ParseVersion(a)
+			Expect(err).NotTo(HaveOccurred())
+
+			vb, err := ParseVersion(b)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(va.Compare(vb)).To(Equal(expected))
+			Expect(vb.Compare(va)).To(Equal(-expected))
+		},
+		Entry(nil, "5.14.0", "5.14.0", 0),
+		Entry(nil, "5.14", "5.14.0", 0),
+		Entry(nil, "5.14.0", "5.15.0", -1),
+		Entry(nil, "5.9.0", "5.14.0", -1),
+		Entry(nil, "5.14.0", "5.14.0-284", -1),
+		Entry(nil, "5.14.0-284.11.1.el9_2.x86_64", "5.14.0-284", 1),
+		Entry(nil, "5.14.0-284.11.1.el9_2.x86_64", "5.14.0-284.30.1.el9_2.x86_64", -1),
+		Entry(nil, "5.14.0-284.11.1.el9_2.x86_64", "5.14.0-284.11.1.el9_10.x86_64", -1),
+		Entry(nil, "5.14.0-070.el9", "5.14.0-70.el9", 0),
+		Entry(nil, "6.1", "6.1rc1", 1),
+		Entry(nil, "6.0.15-300.fc37.x86_64", "5.14.0-284.11.1.el9_2.x86_64", 1),
+	)
+})
+
+var _ = Describe("ParseVersionRange", func() {
+	DescribeTable(
+		"should reject invalid ranges",
+		func(s string) {
+			_, err := ParseVersionRange(s)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", " "),
+		Entry("operator only", ">="),
+		Entry("invalid version", "<abc"),
+	)
+
+	DescribeTable(
+		"should match kernel versions",
+		func(s, version string, expected bool) {
+			vr, err := ParseVersionRange(s)
+			Expect(err).NotTo(HaveOccurred())
+
+			v, err := ParseVersion(version)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vr.Matches(v)).To(Equal(expected))
+		},
+		Entry(nil, ">=5.14.0-284 <5.15", "5.14.0-284.11.1.el9_2.x86_64", true),
+		Entry(nil, ">=5.14.0-284 <5.15", "5.14.0-162.6.1.el9_1.x86_64", false),
+		Entry(nil, ">=5.14.0-284 <5.15", "5.15.0-1.el9.x86_64", false),
+		Entry(nil, ">5.14", "5.14.0-1", true),
+		Entry(nil, ">5.14", "5.14.0", false),
+		Entry(nil, "<=6.0", "6.0.0", true),
+		Entry(nil, "5.14.0-284.11.1.el9_2.x86_64", "5.14.0-284.11.1.el9_2.x86_64", true),
+		Entry(nil, "=5.14.0", "5.14.0-284", false),
+	)
+})
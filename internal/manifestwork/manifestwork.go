@@ -22,6 +22,7 @@ import (
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
 )
 
 var moduleStatusJSONPaths = []workv1.JsonPath{
@@ -132,6 +133,9 @@ func (mwg *manifestWorkGenerator) SetManifestWorkAsDesired(
 	mcm.Spec.ModuleSpec.ModuleLoader.Container.Build = nil
 	mcm.Spec.ModuleSpec.ModuleLoader.Container.Sign = nil
 
+	// Spokes cannot build, so they would have nothing to do with the kernels to prebuild
+	mcm.Spec.ModuleSpec.PrebuildKernels = nil
+
 	// Recreate Module KernelMappings
 	mcm.Spec.ModuleSpec.ModuleLoader.Container.KernelMappings = mwg.managedClusterKernelMappings(ctx, mcm, kernelVersions)
 
@@ -227,7 +231,12 @@ func (mwg *manifestWorkGenerator) managedClusterKernelMappings(
 
 		mld, err := mwg.kernelAPI.GetModuleLoaderDataForKernel(mod, kernelVersion)
 		if err != nil {
-			kernelVersionLogger.Info("no suitable container image found; skipping kernel version")
+			if errors.Is(err, module.ErrNoMatchingKernelMapping) {
+				kernelVersionLogger.Info("no suitable container image found; skipping kernel version")
+			} else {
+				kernelVersionLogger.Info(utils.WarnString("could not get the kernel mapping; skipping kernel version"), "error", err)
+			}
+
 			continue
 		}
 
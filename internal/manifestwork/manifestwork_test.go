@@ -210,7 +210,8 @@ var _ = Describe("SetManifestWorkAsDesired", func() {
 							},
 						},
 					},
-					Selector: map[string]string{"key": "value"},
+					Selector:        map[string]string{"key": "value"},
+					PrebuildKernels: &kmmv1beta1.PrebuildKernelsSpec{Versions: []string{"6.0.0"}},
 				},
 			},
 		}
@@ -247,6 +248,7 @@ var _ = Describe("SetManifestWorkAsDesired", func() {
 		manifestModuleSpec := (mw.Spec.Workload.Manifests[0].RawExtension.Object).(*kmmv1beta1.Module).Spec
 		Expect(manifestModuleSpec.ModuleLoader.Container.Build).To(BeNil())
 		Expect(manifestModuleSpec.ModuleLoader.Container.Sign).To(BeNil())
+		Expect(manifestModuleSpec.PrebuildKernels).To(BeNil())
 		Expect(manifestModuleSpec.ModuleLoader.Container.KernelMappings).To(BeEmpty())
 	})
 
@@ -3,8 +3,9 @@ package mbsc
 import (
 	"context"
 	"fmt"
-	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	"slices"
 
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -23,6 +24,15 @@ type MBSC interface {
 	GetImageSpec(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string) *kmmv1beta1.ModuleBuildSignSpec
 	SetImageStatus(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string, action kmmv1beta1.BuildOrSignAction, status kmmv1beta1.BuildOrSignStatus)
 	GetImageStatus(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string, action kmmv1beta1.BuildOrSignAction) kmmv1beta1.BuildOrSignStatus
+	SetImageArchitectureStatus(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string, action kmmv1beta1.BuildOrSignAction,
+		arch string, status kmmv1beta1.BuildOrSignStatus)
+	GetImageArchitectureStatus(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string, action kmmv1beta1.BuildOrSignAction,
+		arch string) kmmv1beta1.BuildOrSignStatus
+	SetImageSourceCommit(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image, commit string)
+	SetImageAttempts(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string, action kmmv1beta1.BuildOrSignAction,
+		attempts int32, failureReason string)
+	SetImageQueuePosition(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string, action kmmv1beta1.BuildOrSignAction,
+		position int32)
 }
 
 type mbsc struct {
@@ -93,6 +103,12 @@ func (m *mbsc) SetImageStatus(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image s
 	}
 	for i, imageStatus := range mbscObj.Status.Images {
 		if imageStatus.Image == image {
+			if imageStatus.Action == action {
+				imageState.Architectures = imageStatus.Architectures
+				imageState.Attempts = imageStatus.Attempts
+				imageState.FailureReason = imageStatus.FailureReason
+			}
+			imageState.SourceCommit = imageStatus.SourceCommit
 			mbscObj.Status.Images[i] = imageState
 			return
 		}
@@ -109,6 +125,86 @@ func (m *mbsc) GetImageStatus(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image s
 	return kmmv1beta1.BuildOrSignStatus("")
 }
 
+// SetImageArchitectureStatus sets the status of action for one architecture of image.
+// The status of the image is reset if it was set for another action.
+func (m *mbsc) SetImageArchitectureStatus(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string,
+	action kmmv1beta1.BuildOrSignAction, arch string, status kmmv1beta1.BuildOrSignStatus) {
+
+	idx := slices.IndexFunc(mbscObj.Status.Images, func(s kmmv1beta1.BuildSignImageState) bool { return s.Image == image })
+	if idx == -1 {
+		mbscObj.Status.Images = append(mbscObj.Status.Images, kmmv1beta1.BuildSignImageState{Image: image, Action: action})
+		idx = len(mbscObj.Status.Images) - 1
+	} else if mbscObj.Status.Images[idx].Action != action {
+		mbscObj.Status.Images[idx] = kmmv1beta1.BuildSignImageState{
+			Image:        image,
+			Action:       action,
+			SourceCommit: mbscObj.Status.Images[idx].SourceCommit,
+		}
+	}
+
+	imageState := &mbscObj.Status.Images[idx]
+	archState := kmmv1beta1.BuildSignArchitectureState{Architecture: arch, Status: status}
+
+	for i, s := range imageState.Architectures {
+		if s.Architecture == arch {
+			imageState.Architectures[i] = archState
+			return
+		}
+	}
+	imageState.Architectures = append(imageState.Architectures, archState)
+}
+
+func (m *mbsc) GetImageArchitectureStatus(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string,
+	action kmmv1beta1.BuildOrSignAction, arch string) kmmv1beta1.BuildOrSignStatus {
+
+	for _, imageState := range mbscObj.Status.Images {
+		if imageState.Image != image || imageState.Action != action {
+			continue
+		}
+		for _, archState := range imageState.Architectures {
+			if archState.Architecture == arch {
+				return archState.Status
+			}
+		}
+	}
+	return kmmv1beta1.BuildOrSignStatus("")
+}
+
+// SetImageSourceCommit records the commit of the Git source from which image was built, if image has a status.
+func (m *mbsc) SetImageSourceCommit(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image, commit string) {
+	for i, imageState := range mbscObj.Status.Images {
+		if imageState.Image == image {
+			mbscObj.Status.Images[i].SourceCommit = commit
+			return
+		}
+	}
+}
+
+// SetImageAttempts records the attempts made for action on image, keeping the largest number of attempts made for
+// one of its architectures, and the reason of the last failure if failureReason is not empty.
+// The status of the image is reset if it was set for another action.
+func (m *mbsc) SetImageAttempts(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string, action kmmv1beta1.BuildOrSignAction,
+	attempts int32, failureReason string) {
+
+	idx := slices.IndexFunc(mbscObj.Status.Images, func(s kmmv1beta1.BuildSignImageState) bool { return s.Image == image })
+	if idx == -1 {
+		mbscObj.Status.Images = append(mbscObj.Status.Images, kmmv1beta1.BuildSignImageState{Image: image, Action: action})
+		idx = len(mbscObj.Status.Images) - 1
+	} else if mbscObj.Status.Images[idx].Action != action {
+		mbscObj.Status.Images[idx] = kmmv1beta1.BuildSignImageState{
+			Image:        image,
+			Action:       action,
+			SourceCommit: mbscObj.Status.Images[idx].SourceCommit,
+		}
+	}
+
+	imageState := &mbscObj.Status.Images[idx]
+	imageState.Attempts = max(imageState.Attempts, attempts)
+	if failureReason != "" {
+		imageState.FailureReason = failureReason
+	}
+}
+
 func setModuleImageSpec(mbscObj *kmmv1beta1.ModuleBuildSignConfig, moduleImageSpec *kmmv1beta1.ModuleImageSpec, action kmmv1beta1.BuildOrSignAction) {
 	specEntry := kmmv1beta1.ModuleBuildSignSpec{
 		ModuleImageSpec: *moduleImageSpec,
@@ -122,3 +218,28 @@ func setModuleImageSpec(mbscObj *kmmv1beta1.ModuleBuildSignConfig, moduleImageSp
 	}
 	mbscObj.Spec.Images = append(mbscObj.Spec.Images, specEntry)
 }
+
+// SetImageQueuePosition records the position of the image in the build queue; 0 means that it is not queued.
+func (m *mbsc) SetImageQueuePosition(mbscObj *kmmv1beta1.ModuleBuildSignConfig, image string, action kmmv1beta1.BuildOrSignAction,
+	position int32) {
+
+	idx := slices.IndexFunc(mbscObj.Status.Images, func(s kmmv1beta1.BuildSignImageState) bool { return s.Image == image })
+	switch {
+	case idx == -1 && position == 0:
+		return
+	case idx == -1:
+		mbscObj.Status.Images = append(mbscObj.Status.Images, kmmv1beta1.BuildSignImageState{Image: image, Action: action})
+		idx = len(mbscObj.Status.Images) - 1
+	case mbscObj.Status.Images[idx].Action != action:
+		if position == 0 {
+			return
+		}
+		mbscObj.Status.Images[idx] = kmmv1beta1.BuildSignImageState{
+			Image:        image,
+			Action:       action,
+			SourceCommit: mbscObj.Status.Images[idx].SourceCommit,
+		}
+	}
+
+	mbscObj.Status.Images[idx].QueuePosition = position
+}
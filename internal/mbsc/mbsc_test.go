@@ -265,3 +265,125 @@ var _ = Describe("GetImageStatus", func() {
 		Expect(res).To(Equal(kmmv1beta1.BuildOrSignStatus("")))
 	})
 })
+
+var _ = Describe("SetImageArchitectureStatus and GetImageArchitectureStatus", func() {
+	mbscAPI := New(nil, nil)
+
+	It("should track the status of each architecture for the current action", func() {
+		testMBSC := kmmv1beta1.ModuleBuildSignConfig{}
+
+		By("adding the image")
+		mbscAPI.SetImageArchitectureStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, "amd64", kmmv1beta1.ActionSuccess)
+		mbscAPI.SetImageArchitectureStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, "arm64", kmmv1beta1.ActionFailure)
+		Expect(mbscAPI.GetImageArchitectureStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, "amd64")).To(Equal(kmmv1beta1.ActionSuccess))
+		Expect(mbscAPI.GetImageArchitectureStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, "arm64")).To(Equal(kmmv1beta1.ActionFailure))
+		Expect(mbscAPI.GetImageStatus(&testMBSC, "image1", kmmv1beta1.BuildImage)).To(BeEmpty())
+
+		By("keeping the architectures when the image status is set for the same action")
+		mbscAPI.SetImageStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, kmmv1beta1.ActionFailure)
+		Expect(mbscAPI.GetImageArchitectureStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, "amd64")).To(Equal(kmmv1beta1.ActionSuccess))
+
+		By("resetting the image for another action")
+		mbscAPI.SetImageArchitectureStatus(&testMBSC, "image1", kmmv1beta1.SignImage, "arm64", kmmv1beta1.ActionSuccess)
+		Expect(testMBSC.Status.Images).To(Equal([]kmmv1beta1.BuildSignImageState{
+			{
+				Image:  "image1",
+				Action: kmmv1beta1.SignImage,
+				Architectures: []kmmv1beta1.BuildSignArchitectureState{
+					{Architecture: "arm64", Status: kmmv1beta1.ActionSuccess},
+				},
+			},
+		}))
+		Expect(mbscAPI.GetImageArchitectureStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, "amd64")).To(BeEmpty())
+		Expect(mbscAPI.GetImageArchitectureStatus(&testMBSC, "image1", kmmv1beta1.SignImage, "amd64")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("SetImageSourceCommit", func() {
+	mbscAPI := New(nil, nil)
+
+	It("should record the commit of images with a status and keep it when the status changes", func() {
+		testMBSC := kmmv1beta1.ModuleBuildSignConfig{}
+
+		By("ignoring images without a status")
+		mbscAPI.SetImageSourceCommit(&testMBSC, "image1", "abc123")
+		Expect(testMBSC.Status.Images).To(BeEmpty())
+
+		By("recording the commit")
+		mbscAPI.SetImageArchitectureStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, "amd64", kmmv1beta1.ActionSuccess)
+		mbscAPI.SetImageSourceCommit(&testMBSC, "image1", "abc123")
+		Expect(testMBSC.Status.Images[0].SourceCommit).To(Equal("abc123"))
+
+		By("keeping the commit")
+		mbscAPI.SetImageStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, kmmv1beta1.ActionSuccess)
+		Expect(testMBSC.Status.Images[0].SourceCommit).To(Equal("abc123"))
+
+		mbscAPI.SetImageArchitectureStatus(&testMBSC, "image1", kmmv1beta1.SignImage, "amd64", kmmv1beta1.ActionSuccess)
+		Expect(testMBSC.Status.Images[0].SourceCommit).To(Equal("abc123"))
+	})
+})
+
+var _ = Describe("SetImageAttempts", func() {
+	mbscAPI := New(nil, nil)
+
+	It("should record the attempts and the last failure of an action", func() {
+		testMBSC := kmmv1beta1.ModuleBuildSignConfig{}
+
+		By("adding the image")
+		mbscAPI.SetImageAttempts(&testMBSC, "image1", kmmv1beta1.BuildImage, 1, "container kaniko exited with code 1")
+		Expect(testMBSC.Status.Images).To(Equal([]kmmv1beta1.BuildSignImageState{
+			{
+				Image:         "image1",
+				Action:        kmmv1beta1.BuildImage,
+				Attempts:      1,
+				FailureReason: "container kaniko exited with code 1",
+			},
+		}))
+
+		By("keeping the largest number of attempts and the last failure")
+		mbscAPI.SetImageAttempts(&testMBSC, "image1", kmmv1beta1.BuildImage, 3, "DeadlineExceeded")
+		mbscAPI.SetImageAttempts(&testMBSC, "image1", kmmv1beta1.BuildImage, 2, "")
+		Expect(testMBSC.Status.Images[0].Attempts).To(BeEquivalentTo(3))
+		Expect(testMBSC.Status.Images[0].FailureReason).To(Equal("DeadlineExceeded"))
+
+		By("keeping them when the status is set")
+		mbscAPI.SetImageStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, kmmv1beta1.ActionFailure)
+		Expect(testMBSC.Status.Images[0].Attempts).To(BeEquivalentTo(3))
+		Expect(testMBSC.Status.Images[0].FailureReason).To(Equal("DeadlineExceeded"))
+
+		By("resetting them for another action")
+		mbscAPI.SetImageAttempts(&testMBSC, "image1", kmmv1beta1.SignImage, 1, "")
+		Expect(testMBSC.Status.Images).To(Equal([]kmmv1beta1.BuildSignImageState{
+			{Image: "image1", Action: kmmv1beta1.SignImage, Attempts: 1},
+		}))
+	})
+})
+
+var _ = Describe("SetImageQueuePosition", func() {
+	mbscAPI := New(nil, nil)
+
+	It("should record the position of a queued image", func() {
+		testMBSC := kmmv1beta1.ModuleBuildSignConfig{}
+
+		By("not adding an image that is not queued")
+		mbscAPI.SetImageQueuePosition(&testMBSC, "image1", kmmv1beta1.BuildImage, 0)
+		Expect(testMBSC.Status.Images).To(BeEmpty())
+
+		By("adding the image")
+		mbscAPI.SetImageQueuePosition(&testMBSC, "image1", kmmv1beta1.BuildImage, 3)
+		Expect(testMBSC.Status.Images).To(Equal([]kmmv1beta1.BuildSignImageState{
+			{Image: "image1", Action: kmmv1beta1.BuildImage, QueuePosition: 3},
+		}))
+
+		By("clearing it once the image leaves the queue")
+		mbscAPI.SetImageQueuePosition(&testMBSC, "image1", kmmv1beta1.BuildImage, 0)
+		Expect(testMBSC.Status.Images[0].QueuePosition).To(BeZero())
+
+		By("not touching the status of another action")
+		mbscAPI.SetImageStatus(&testMBSC, "image1", kmmv1beta1.BuildImage, kmmv1beta1.ActionSuccess)
+		mbscAPI.SetImageQueuePosition(&testMBSC, "image1", kmmv1beta1.SignImage, 0)
+		Expect(testMBSC.Status.Images).To(Equal([]kmmv1beta1.BuildSignImageState{
+			{Image: "image1", Action: kmmv1beta1.BuildImage, Status: kmmv1beta1.ActionSuccess},
+		}))
+	})
+})
@@ -82,6 +82,20 @@ func (mr *MockMBSCMockRecorder) Get(ctx, name, namespace any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMBSC)(nil).Get), ctx, name, namespace)
 }
 
+// GetImageArchitectureStatus mocks base method.
+func (m *MockMBSC) GetImageArchitectureStatus(mbscObj *v1beta1.ModuleBuildSignConfig, image string, action v1beta1.BuildOrSignAction, arch string) v1beta1.BuildOrSignStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageArchitectureStatus", mbscObj, image, action, arch)
+	ret0, _ := ret[0].(v1beta1.BuildOrSignStatus)
+	return ret0
+}
+
+// GetImageArchitectureStatus indicates an expected call of GetImageArchitectureStatus.
+func (mr *MockMBSCMockRecorder) GetImageArchitectureStatus(mbscObj, image, action, arch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageArchitectureStatus", reflect.TypeOf((*MockMBSC)(nil).GetImageArchitectureStatus), mbscObj, image, action, arch)
+}
+
 // GetImageSpec mocks base method.
 func (m *MockMBSC) GetImageSpec(mbscObj *v1beta1.ModuleBuildSignConfig, image string) *v1beta1.ModuleBuildSignSpec {
 	m.ctrl.T.Helper()
@@ -110,6 +124,54 @@ func (mr *MockMBSCMockRecorder) GetImageStatus(mbscObj, image, action any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageStatus", reflect.TypeOf((*MockMBSC)(nil).GetImageStatus), mbscObj, image, action)
 }
 
+// SetImageArchitectureStatus mocks base method.
+func (m *MockMBSC) SetImageArchitectureStatus(mbscObj *v1beta1.ModuleBuildSignConfig, image string, action v1beta1.BuildOrSignAction, arch string, status v1beta1.BuildOrSignStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetImageArchitectureStatus", mbscObj, image, action, arch, status)
+}
+
+// SetImageArchitectureStatus indicates an expected call of SetImageArchitectureStatus.
+func (mr *MockMBSCMockRecorder) SetImageArchitectureStatus(mbscObj, image, action, arch, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetImageArchitectureStatus", reflect.TypeOf((*MockMBSC)(nil).SetImageArchitectureStatus), mbscObj, image, action, arch, status)
+}
+
+// SetImageAttempts mocks base method.
+func (m *MockMBSC) SetImageAttempts(mbscObj *v1beta1.ModuleBuildSignConfig, image string, action v1beta1.BuildOrSignAction, attempts int32, failureReason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetImageAttempts", mbscObj, image, action, attempts, failureReason)
+}
+
+// SetImageAttempts indicates an expected call of SetImageAttempts.
+func (mr *MockMBSCMockRecorder) SetImageAttempts(mbscObj, image, action, attempts, failureReason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetImageAttempts", reflect.TypeOf((*MockMBSC)(nil).SetImageAttempts), mbscObj, image, action, attempts, failureReason)
+}
+
+// SetImageQueuePosition mocks base method.
+func (m *MockMBSC) SetImageQueuePosition(mbscObj *v1beta1.ModuleBuildSignConfig, image string, action v1beta1.BuildOrSignAction, position int32) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetImageQueuePosition", mbscObj, image, action, position)
+}
+
+// SetImageQueuePosition indicates an expected call of SetImageQueuePosition.
+func (mr *MockMBSCMockRecorder) SetImageQueuePosition(mbscObj, image, action, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetImageQueuePosition", reflect.TypeOf((*MockMBSC)(nil).SetImageQueuePosition), mbscObj, image, action, position)
+}
+
+// SetImageSourceCommit mocks base method.
+func (m *MockMBSC) SetImageSourceCommit(mbscObj *v1beta1.ModuleBuildSignConfig, image, commit string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetImageSourceCommit", mbscObj, image, commit)
+}
+
+// SetImageSourceCommit indicates an expected call of SetImageSourceCommit.
+func (mr *MockMBSCMockRecorder) SetImageSourceCommit(mbscObj, image, commit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetImageSourceCommit", reflect.TypeOf((*MockMBSC)(nil).SetImageSourceCommit), mbscObj, image, commit)
+}
+
 // SetImageStatus mocks base method.
 func (m *MockMBSC) SetImageStatus(mbscObj *v1beta1.ModuleBuildSignConfig, image string, action v1beta1.BuildOrSignAction, status v1beta1.BuildOrSignStatus) {
 	m.ctrl.T.Helper()
@@ -2,6 +2,18 @@ package meta
 
 import "sigs.k8s.io/controller-runtime/pkg/client"
 
+func RemoveAnnotation(obj client.Object, key string) {
+	ann := obj.GetAnnotations()
+
+	if ann == nil {
+		return
+	}
+
+	delete(ann, key)
+
+	obj.SetAnnotations(ann)
+}
+
 func SetAnnotation(obj client.Object, key, value string) {
 	ann := obj.GetAnnotations()
 
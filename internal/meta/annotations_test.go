@@ -29,3 +29,27 @@ var _ = Describe("SetAnnotation", func() {
 		Entry("existing annotation", map[string]string{key: "some-other-value"}, key, "test value"),
 	)
 })
+
+var _ = Describe("RemoveAnnotation", func() {
+	const key = "test-key"
+
+	DescribeTable(
+		"should work as expected",
+		func(annotations map[string]string) {
+			obj := &unstructured.Unstructured{}
+
+			obj.SetAnnotations(annotations)
+
+			RemoveAnnotation(obj, key)
+
+			Expect(
+				obj.GetAnnotations(),
+			).NotTo(
+				HaveKey(key),
+			)
+		},
+		Entry("nil annotations", nil),
+		Entry("other annotation", map[string]string{"other-key": "value"}),
+		Entry("existing annotation", map[string]string{key: "value"}),
+	)
+})
@@ -14,6 +14,8 @@ const (
 	kmmPreflightQuery       = "kmm_preflight_num"
 	kmmModprobeArgsQuery    = "kmm_modprobe_args"
 	kmmModprobeRawArgsQuery = "kmm_modprobe_raw_args"
+	kmmQueueLengthQuery     = "kmm_build_sign_queue_length"
+	kmmQueuePositionQuery   = "kmm_build_sign_queue_position"
 )
 
 //go:generate mockgen -source=metrics.go -package=metrics -destination=mock_metrics_api.go
@@ -28,6 +30,9 @@ type Metrics interface {
 	SetKMMPreflightsNum(value int)
 	SetKMMModprobeArgs(modName, namespace, modprobeArgs string)
 	SetKMMModprobeRawArgs(modName, namespace, modprobeArgs string)
+	SetKMMQueueLength(action string, value int)
+	SetKMMQueuePosition(name, namespace, kernelVersion, arch, action string, position int)
+	DeleteKMMQueuePosition(name, namespace, kernelVersion, arch, action string)
 }
 
 type metrics struct {
@@ -38,6 +43,8 @@ type metrics struct {
 	kmmPreflightResourceNum     prometheus.Gauge
 	kmmModprobeArgs             *prometheus.GaugeVec
 	kmmModprobeRawArgs          *prometheus.GaugeVec
+	kmmQueueLength              *prometheus.GaugeVec
+	kmmQueuePosition            *prometheus.GaugeVec
 }
 
 func New() Metrics {
@@ -93,6 +100,22 @@ func New() Metrics {
 		[]string{"name", "namespace", "modprobeRawArgs"},
 	)
 
+	kmmQueueLength := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: kmmQueueLengthQuery,
+			Help: "Number of build or sign pods waiting for the concurrency limits",
+		},
+		[]string{"action"},
+	)
+
+	kmmQueuePosition := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: kmmQueuePositionQuery,
+			Help: "Position of a build or sign pod waiting for the concurrency limits in the queue",
+		},
+		[]string{"name", "namespace", "kernelVersion", "architecture", "action"},
+	)
+
 	return &metrics{
 		kmmModuleResourcesNum:       kmmModuleResourcesNum,
 		kmmInClusterBuildNum:        kmmInClusterBuildNum,
@@ -101,6 +124,8 @@ func New() Metrics {
 		kmmPreflightResourceNum:     kmmPreflightResourceNum,
 		kmmModprobeArgs:             kmmModprobeArgs,
 		kmmModprobeRawArgs:          kmmModprobeRawArgs,
+		kmmQueueLength:              kmmQueueLength,
+		kmmQueuePosition:            kmmQueuePosition,
 	}
 }
 
@@ -112,6 +137,8 @@ func (m *metrics) Register() {
 		m.kmmDevicePluginResourcesNum,
 		m.kmmPreflightResourceNum,
 		m.kmmModprobeArgs,
+		m.kmmQueueLength,
+		m.kmmQueuePosition,
 	)
 }
 
@@ -142,3 +169,15 @@ func (m *metrics) SetKMMModprobeArgs(modName, namespace, modprobeArgs string) {
 func (m *metrics) SetKMMModprobeRawArgs(modName, namespace, modprobeRawArgs string) {
 	m.kmmModprobeRawArgs.WithLabelValues(modName, namespace, modprobeRawArgs).Set(float64(1))
 }
+
+func (m *metrics) SetKMMQueueLength(action string, value int) {
+	m.kmmQueueLength.WithLabelValues(action).Set(float64(value))
+}
+
+func (m *metrics) SetKMMQueuePosition(name, namespace, kernelVersion, arch, action string, position int) {
+	m.kmmQueuePosition.WithLabelValues(name, namespace, kernelVersion, arch, action).Set(float64(position))
+}
+
+func (m *metrics) DeleteKMMQueuePosition(name, namespace, kernelVersion, arch, action string) {
+	m.kmmQueuePosition.DeleteLabelValues(name, namespace, kernelVersion, arch, action)
+}
@@ -37,6 +37,18 @@ func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
 	return m.recorder
 }
 
+// DeleteKMMQueuePosition mocks base method.
+func (m *MockMetrics) DeleteKMMQueuePosition(name, namespace, kernelVersion, arch, action string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteKMMQueuePosition", name, namespace, kernelVersion, arch, action)
+}
+
+// DeleteKMMQueuePosition indicates an expected call of DeleteKMMQueuePosition.
+func (mr *MockMetricsMockRecorder) DeleteKMMQueuePosition(name, namespace, kernelVersion, arch, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKMMQueuePosition", reflect.TypeOf((*MockMetrics)(nil).DeleteKMMQueuePosition), name, namespace, kernelVersion, arch, action)
+}
+
 // Register mocks base method.
 func (m *MockMetrics) Register() {
 	m.ctrl.T.Helper()
@@ -132,3 +144,27 @@ func (mr *MockMetricsMockRecorder) SetKMMPreflightsNum(value any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKMMPreflightsNum", reflect.TypeOf((*MockMetrics)(nil).SetKMMPreflightsNum), value)
 }
+
+// SetKMMQueueLength mocks base method.
+func (m *MockMetrics) SetKMMQueueLength(action string, value int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetKMMQueueLength", action, value)
+}
+
+// SetKMMQueueLength indicates an expected call of SetKMMQueueLength.
+func (mr *MockMetricsMockRecorder) SetKMMQueueLength(action, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKMMQueueLength", reflect.TypeOf((*MockMetrics)(nil).SetKMMQueueLength), action, value)
+}
+
+// SetKMMQueuePosition mocks base method.
+func (m *MockMetrics) SetKMMQueuePosition(name, namespace, kernelVersion, arch, action string, position int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetKMMQueuePosition", name, namespace, kernelVersion, arch, action, position)
+}
+
+// SetKMMQueuePosition indicates an expected call of SetKMMQueuePosition.
+func (mr *MockMetricsMockRecorder) SetKMMQueuePosition(name, namespace, kernelVersion, arch, action, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKMMQueuePosition", reflect.TypeOf((*MockMetrics)(nil).SetKMMQueuePosition), name, namespace, kernelVersion, arch, action, position)
+}
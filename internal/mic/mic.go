@@ -3,13 +3,14 @@ package mic
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -22,9 +23,12 @@ type MIC interface {
 		imageRepoSecret *v1.LocalObjectReference, pullPolicy v1.PullPolicy, pushBuiltImage bool,
 		imageRebuildTriggerGeneration *int, tolerations []v1.Toleration, owner metav1.Object) error
 	Get(ctx context.Context, name, ns string) (*kmmv1beta1.ModuleImagesConfig, error)
+	SetPaused(ctx context.Context, name, ns string, paused bool) error
 	GetModuleImageSpec(micObj *kmmv1beta1.ModuleImagesConfig, image string) *kmmv1beta1.ModuleImageSpec
 	SetImageStatus(micObj *kmmv1beta1.ModuleImagesConfig, image string, status kmmv1beta1.ImageState)
+	SetImageArchitectureStatus(micObj *kmmv1beta1.ModuleImagesConfig, image, arch string, status kmmv1beta1.ImageState)
 	GetImageState(micObj *kmmv1beta1.ModuleImagesConfig, image string) kmmv1beta1.ImageState
+	GetImageArchitectureState(micObj *kmmv1beta1.ModuleImagesConfig, image, arch string) kmmv1beta1.ImageState
 	DoAllImagesExist(micObj *kmmv1beta1.ModuleImagesConfig) bool
 }
 
@@ -58,6 +62,8 @@ func (mici *micImpl) CreateOrPatch(ctx context.Context, name, ns string, images
 	opRes, err := controllerutil.CreateOrPatch(ctx, mici.client, mic, func() error {
 
 		mic.Spec = kmmv1beta1.ModuleImagesConfigSpec{
+			// Only SetPaused pauses or unpauses the MIC.
+			Paused:                        mic.Spec.Paused,
 			Images:                        images,
 			ImageRepoSecret:               imageRepoSecret,
 			ImagePullPolicy:               pullPolicy,
@@ -87,6 +93,30 @@ func (mici *micImpl) Get(ctx context.Context, name, ns string) (*kmmv1beta1.Modu
 	return &micObj, nil
 }
 
+// SetPaused sets spec.paused on an existing MIC. It does nothing if the MIC does not exist.
+func (mici *micImpl) SetPaused(ctx context.Context, name, ns string, paused bool) error {
+	var micObj kmmv1beta1.ModuleImagesConfig
+	if err := mici.client.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &micObj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("could not get ModuleImagesConfig %s: %v", name, err)
+	}
+
+	if micObj.Spec.Paused == paused {
+		return nil
+	}
+
+	micCopy := micObj.DeepCopy()
+	micObj.Spec.Paused = paused
+
+	if err := mici.client.Patch(ctx, &micObj, client.MergeFrom(micCopy)); err != nil {
+		return fmt.Errorf("could not patch ModuleImagesConfig %s: %v", name, err)
+	}
+
+	return nil
+}
+
 func (mici *micImpl) GetModuleImageSpec(micObj *kmmv1beta1.ModuleImagesConfig, image string) *kmmv1beta1.ModuleImageSpec {
 	for _, imageSpec := range micObj.Spec.Images {
 		if imageSpec.Image == image {
@@ -103,6 +133,7 @@ func (mici *micImpl) SetImageStatus(micObj *kmmv1beta1.ModuleImagesConfig, image
 	}
 	for i, imageStatus := range micObj.Status.ImagesStates {
 		if imageStatus.Image == image {
+			imageState.Architectures = imageStatus.Architectures
 			micObj.Status.ImagesStates[i] = imageState
 			return
 		}
@@ -110,6 +141,28 @@ func (mici *micImpl) SetImageStatus(micObj *kmmv1beta1.ModuleImagesConfig, image
 	micObj.Status.ImagesStates = append(micObj.Status.ImagesStates, imageState)
 }
 
+// SetImageArchitectureStatus sets the state of image for one of the architectures it is built for.
+func (mici *micImpl) SetImageArchitectureStatus(micObj *kmmv1beta1.ModuleImagesConfig, image, arch string,
+	status kmmv1beta1.ImageState) {
+
+	idx := slices.IndexFunc(micObj.Status.ImagesStates, func(s kmmv1beta1.ModuleImageState) bool { return s.Image == image })
+	if idx == -1 {
+		micObj.Status.ImagesStates = append(micObj.Status.ImagesStates, kmmv1beta1.ModuleImageState{Image: image})
+		idx = len(micObj.Status.ImagesStates) - 1
+	}
+
+	imageState := &micObj.Status.ImagesStates[idx]
+	archState := kmmv1beta1.ArchitectureImageState{Architecture: arch, Status: status}
+
+	for i, s := range imageState.Architectures {
+		if s.Architecture == arch {
+			imageState.Architectures[i] = archState
+			return
+		}
+	}
+	imageState.Architectures = append(imageState.Architectures, archState)
+}
+
 func (mici *micImpl) GetImageState(micObj *kmmv1beta1.ModuleImagesConfig, image string) kmmv1beta1.ImageState {
 	for _, imageState := range micObj.Status.ImagesStates {
 		if imageState.Image == image {
@@ -119,6 +172,23 @@ func (mici *micImpl) GetImageState(micObj *kmmv1beta1.ModuleImagesConfig, image
 	return ""
 }
 
+// GetImageArchitectureState returns the state of image for one of the architectures it is needed for, or an empty
+// state if it is not known yet.
+func (mici *micImpl) GetImageArchitectureState(micObj *kmmv1beta1.ModuleImagesConfig, image, arch string) kmmv1beta1.ImageState {
+	for _, imageState := range micObj.Status.ImagesStates {
+		if imageState.Image != image {
+			continue
+		}
+
+		for _, archState := range imageState.Architectures {
+			if archState.Architecture == arch {
+				return archState.Status
+			}
+		}
+	}
+	return ""
+}
+
 func (mici *micImpl) DoAllImagesExist(micObj *kmmv1beta1.ModuleImagesConfig) bool {
 
 	imagesStates := map[string]kmmv1beta1.ImageState{}
@@ -136,14 +206,27 @@ func (mici *micImpl) DoAllImagesExist(micObj *kmmv1beta1.ModuleImagesConfig) boo
 	return true
 }
 
+// filterDuplicateImages keeps the first spec of each image, and merges the architectures of all its specs so that
+// an image needed by nodes of several architectures is built for all of them.
 func filterDuplicateImages(images []kmmv1beta1.ModuleImageSpec) []kmmv1beta1.ModuleImageSpec {
-	imagesSet := sets.New[string]()
+	imagesIndexes := make(map[string]int, len(images))
 	filteredImages := make([]kmmv1beta1.ModuleImageSpec, 0, len(images))
 	for _, image := range images {
-		if !imagesSet.Has(image.Image) {
-			imagesSet.Insert(image.Image)
+		i, ok := imagesIndexes[image.Image]
+		if !ok {
+			imagesIndexes[image.Image] = len(filteredImages)
+			image.Architectures = slices.Clone(image.Architectures)
 			filteredImages = append(filteredImages, image)
+			continue
 		}
+		for _, arch := range image.Architectures {
+			if !slices.Contains(filteredImages[i].Architectures, arch) {
+				filteredImages[i].Architectures = append(filteredImages[i].Architectures, arch)
+			}
+		}
+	}
+	for _, image := range filteredImages {
+		slices.Sort(image.Architectures)
 	}
 	return filteredImages
 }
@@ -132,6 +132,34 @@ var _ = Describe("CreateOrPatch", func() {
 
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	It("should not unpause the MIC", func() {
+		gomock.InOrder(
+			mockClient.EXPECT().Get(ctx, types.NamespacedName{Name: micName, Namespace: micNamespace}, gomock.Any()).DoAndReturn(
+				func(_ interface{}, _ interface{}, mic *kmmv1beta1.ModuleImagesConfig, _ ...ctrlclient.GetOption) error {
+					mic.ObjectMeta = metav1.ObjectMeta{Name: micName, Namespace: micNamespace}
+					mic.Spec = kmmv1beta1.ModuleImagesConfigSpec{Paused: true}
+					return nil
+				},
+			),
+			mockClient.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, mic *kmmv1beta1.ModuleImagesConfig, _ ctrlclient.Patch, _ ...ctrlclient.PatchOption) error {
+					Expect(mic.Spec.Paused).To(BeTrue())
+					return nil
+				},
+			),
+		)
+
+		owner := &kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-module", Namespace: micNamespace},
+		}
+
+		Expect(
+			micAPI.CreateOrPatch(ctx, micName, micNamespace, nil, nil, v1.PullIfNotPresent, true, nil, nil, owner),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
 })
 
 var _ = Describe("Get", func() {
@@ -177,6 +205,83 @@ var _ = Describe("Get", func() {
 
 })
 
+var _ = Describe("SetPaused", func() {
+
+	const (
+		micName      = "my-name"
+		micNamespace = "my-namespace"
+	)
+
+	var (
+		ctx        context.Context
+		ctrl       *gomock.Controller
+		mockClient *client.MockClient
+		micAPI     MIC
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		mockClient = client.NewMockClient(ctrl)
+		micAPI = New(mockClient, scheme)
+	})
+
+	nsn := types.NamespacedName{Name: micName, Namespace: micNamespace}
+
+	It("should do nothing if the MIC does not exist", func() {
+		mockClient.EXPECT().Get(ctx, nsn, gomock.Any()).Return(k8serrors.NewNotFound(schema.GroupResource{}, micName))
+
+		Expect(
+			micAPI.SetPaused(ctx, micName, micNamespace, true),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	It("should fail if we failed to get the MIC", func() {
+		mockClient.EXPECT().Get(ctx, nsn, gomock.Any()).Return(fmt.Errorf("some error"))
+
+		Expect(
+			micAPI.SetPaused(ctx, micName, micNamespace, true),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	It("should not patch the MIC if it is already paused", func() {
+		mockClient.EXPECT().Get(ctx, nsn, gomock.Any()).DoAndReturn(
+			func(_ interface{}, _ interface{}, micObj *kmmv1beta1.ModuleImagesConfig, _ ...ctrlclient.GetOption) error {
+				micObj.Spec.Paused = true
+				return nil
+			},
+		)
+
+		Expect(
+			micAPI.SetPaused(ctx, micName, micNamespace, true),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	It("should patch the MIC", func() {
+		gomock.InOrder(
+			mockClient.EXPECT().Get(ctx, nsn, gomock.Any()),
+			mockClient.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, micObj *kmmv1beta1.ModuleImagesConfig, _ ctrlclient.Patch, _ ...ctrlclient.PatchOption) error {
+					Expect(micObj.Spec.Paused).To(BeTrue())
+					return nil
+				},
+			),
+		)
+
+		Expect(
+			micAPI.SetPaused(ctx, micName, micNamespace, true),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+})
+
 var _ = Describe("GetModuleImageSpec", func() {
 	var (
 		micAPI MIC
@@ -250,6 +355,58 @@ var _ = Describe("SetImageStatus", func() {
 	})
 })
 
+var _ = Describe("SetImageArchitectureStatus", func() {
+	var (
+		micAPI MIC
+	)
+
+	BeforeEach(func() {
+		micAPI = New(nil, nil)
+	})
+
+	It("should set the status of the architectures and keep them when the image status changes", func() {
+		testMic := kmmv1beta1.ModuleImagesConfig{
+			Status: kmmv1beta1.ModuleImagesConfigStatus{
+				ImagesStates: []kmmv1beta1.ModuleImageState{
+					{
+						Image:  "image 1",
+						Status: kmmv1beta1.ImageNeedsBuilding,
+					},
+				},
+			},
+		}
+
+		micAPI.SetImageArchitectureStatus(&testMic, "image 1", "amd64", kmmv1beta1.ImageNeedsSigning)
+		micAPI.SetImageArchitectureStatus(&testMic, "image 1", "arm64", kmmv1beta1.ImageDoesNotExist)
+		micAPI.SetImageArchitectureStatus(&testMic, "image 1", "amd64", kmmv1beta1.ImageExists)
+		micAPI.SetImageStatus(&testMic, "image 1", kmmv1beta1.ImageDoesNotExist)
+
+		Expect(testMic.Status.ImagesStates).To(Equal([]kmmv1beta1.ModuleImageState{
+			{
+				Image:  "image 1",
+				Status: kmmv1beta1.ImageDoesNotExist,
+				Architectures: []kmmv1beta1.ArchitectureImageState{
+					{Architecture: "amd64", Status: kmmv1beta1.ImageExists},
+					{Architecture: "arm64", Status: kmmv1beta1.ImageDoesNotExist},
+				},
+			},
+		}))
+	})
+
+	It("should add the image if it has no status yet", func() {
+		testMic := kmmv1beta1.ModuleImagesConfig{}
+
+		micAPI.SetImageArchitectureStatus(&testMic, "image 1", "arm64", kmmv1beta1.ImageExists)
+
+		Expect(testMic.Status.ImagesStates).To(Equal([]kmmv1beta1.ModuleImageState{
+			{
+				Image:         "image 1",
+				Architectures: []kmmv1beta1.ArchitectureImageState{{Architecture: "arm64", Status: kmmv1beta1.ImageExists}},
+			},
+		}))
+	})
+})
+
 var _ = Describe("GetImageState", func() {
 	var (
 		micAPI MIC
@@ -286,6 +443,40 @@ var _ = Describe("GetImageState", func() {
 	})
 })
 
+var _ = Describe("GetImageArchitectureState", func() {
+	var (
+		micAPI MIC
+	)
+
+	BeforeEach(func() {
+		micAPI = New(nil, nil)
+	})
+
+	testMic := kmmv1beta1.ModuleImagesConfig{
+		Status: kmmv1beta1.ModuleImagesConfigStatus{
+			ImagesStates: []kmmv1beta1.ModuleImageState{
+				{
+					Image:  "image 1",
+					Status: kmmv1beta1.ImageNeedsBuilding,
+					Architectures: []kmmv1beta1.ArchitectureImageState{
+						{Architecture: "amd64", Status: kmmv1beta1.ImageExists},
+						{Architecture: "arm64", Status: kmmv1beta1.ImageNeedsBuilding},
+					},
+				},
+			},
+		},
+	}
+
+	DescribeTable("should return the state of the architecture",
+		func(image, arch string, expected kmmv1beta1.ImageState) {
+			Expect(micAPI.GetImageArchitectureState(&testMic, image, arch)).To(Equal(expected))
+		},
+		Entry("present", "image 1", "arm64", kmmv1beta1.ImageNeedsBuilding),
+		Entry("architecture not present", "image 1", "s390x", kmmv1beta1.ImageState("")),
+		Entry("image not present", "image 2", "amd64", kmmv1beta1.ImageState("")),
+	)
+})
+
 var _ = Describe("DoAllImagesExist", func() {
 
 	var micAPI MIC
@@ -391,4 +582,22 @@ var _ = Describe("filterDuplicateImages", func() {
 		res := filterDuplicateImages(images)
 		Expect(res).To(Equal(expectedRes))
 	})
+
+	It("should merge the architectures of the same image", func() {
+		images := []kmmv1beta1.ModuleImageSpec{
+			{Image: "example.registry.com/org/user/image1:tag", Architectures: []string{"arm64"}},
+			{Image: "example.registry.com/org/user/image2:tag", Architectures: []string{"amd64"}},
+			{Image: "example.registry.com/org/user/image1:tag", Architectures: []string{"amd64"}},
+			{Image: "example.registry.com/org/user/image1:tag", Architectures: []string{"arm64"}},
+		}
+
+		expectedRes := []kmmv1beta1.ModuleImageSpec{
+			{Image: "example.registry.com/org/user/image1:tag", Architectures: []string{"amd64", "arm64"}},
+			{Image: "example.registry.com/org/user/image2:tag", Architectures: []string{"amd64"}},
+		}
+
+		res := filterDuplicateImages(images)
+		Expect(res).To(Equal(expectedRes))
+		Expect(images[0].Architectures).To(Equal([]string{"arm64"}))
+	})
 })
@@ -84,6 +84,20 @@ func (mr *MockMICMockRecorder) Get(ctx, name, ns any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMIC)(nil).Get), ctx, name, ns)
 }
 
+// GetImageArchitectureState mocks base method.
+func (m *MockMIC) GetImageArchitectureState(micObj *v1beta1.ModuleImagesConfig, image, arch string) v1beta1.ImageState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageArchitectureState", micObj, image, arch)
+	ret0, _ := ret[0].(v1beta1.ImageState)
+	return ret0
+}
+
+// GetImageArchitectureState indicates an expected call of GetImageArchitectureState.
+func (mr *MockMICMockRecorder) GetImageArchitectureState(micObj, image, arch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageArchitectureState", reflect.TypeOf((*MockMIC)(nil).GetImageArchitectureState), micObj, image, arch)
+}
+
 // GetImageState mocks base method.
 func (m *MockMIC) GetImageState(micObj *v1beta1.ModuleImagesConfig, image string) v1beta1.ImageState {
 	m.ctrl.T.Helper()
@@ -112,6 +126,18 @@ func (mr *MockMICMockRecorder) GetModuleImageSpec(micObj, image any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModuleImageSpec", reflect.TypeOf((*MockMIC)(nil).GetModuleImageSpec), micObj, image)
 }
 
+// SetImageArchitectureStatus mocks base method.
+func (m *MockMIC) SetImageArchitectureStatus(micObj *v1beta1.ModuleImagesConfig, image, arch string, status v1beta1.ImageState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetImageArchitectureStatus", micObj, image, arch, status)
+}
+
+// SetImageArchitectureStatus indicates an expected call of SetImageArchitectureStatus.
+func (mr *MockMICMockRecorder) SetImageArchitectureStatus(micObj, image, arch, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetImageArchitectureStatus", reflect.TypeOf((*MockMIC)(nil).SetImageArchitectureStatus), micObj, image, arch, status)
+}
+
 // SetImageStatus mocks base method.
 func (m *MockMIC) SetImageStatus(micObj *v1beta1.ModuleImagesConfig, image string, status v1beta1.ImageState) {
 	m.ctrl.T.Helper()
@@ -123,3 +149,17 @@ func (mr *MockMICMockRecorder) SetImageStatus(micObj, image, status any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetImageStatus", reflect.TypeOf((*MockMIC)(nil).SetImageStatus), micObj, image, status)
 }
+
+// SetPaused mocks base method.
+func (m *MockMIC) SetPaused(ctx context.Context, name, ns string, paused bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaused", ctx, name, ns, paused)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaused indicates an expected call of SetPaused.
+func (mr *MockMICMockRecorder) SetPaused(ctx, name, ns, paused any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaused", reflect.TypeOf((*MockMIC)(nil).SetPaused), ctx, name, ns, paused)
+}
@@ -1,9 +1,14 @@
 package module
 
 import (
-	v1 "k8s.io/api/core/v1"
+	"slices"
 	"strings"
 
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
 )
 
@@ -40,3 +45,205 @@ func AppendToTag(name string, tag string) string {
 func ShouldBeBuilt(mld *api.ModuleLoaderData) bool {
 	return mld.Build != nil
 }
+
+// normalizeKernelModuleName returns the name under which the kernel knows a module; modprobe treats
+// dashes and underscores as equivalent.
+func normalizeKernelModuleName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// LoadedKernelModules returns the normalized names of the kernel modules that modprobe loads for the
+// given ModprobeSpec. It returns nil when only raw arguments are used, since they cannot be interpreted.
+func LoadedKernelModules(modprobe kmmv1beta1.ModprobeSpec) sets.Set[string] {
+	if modprobe.ModuleName == "" {
+		return nil
+	}
+
+	s := sets.New[string](normalizeKernelModuleName(modprobe.ModuleName))
+
+	for _, m := range modprobe.ModulesLoadingOrder {
+		s.Insert(normalizeKernelModuleName(m))
+	}
+
+	return s
+}
+
+// ResolveModprobeParameters applies the overrides whose selector matches nodeLabels on top of parameters, in order.
+// An overriding parameter replaces the parameter with the same name, or is appended if there is none.
+func ResolveModprobeParameters(parameters []string, overrides []kmmv1beta1.ParameterOverride, nodeLabels map[string]string) []string {
+	if len(overrides) == 0 {
+		return parameters
+	}
+
+	res := slices.Clone(parameters)
+
+	for _, o := range overrides {
+		if !labels.SelectorFromSet(o.Selector).Matches(labels.Set(nodeLabels)) {
+			continue
+		}
+
+		for _, p := range o.Parameters {
+			res = overrideParameter(res, p)
+		}
+	}
+
+	return res
+}
+
+// overrideParameter replaces the parameter with the same name as p in parameters, or appends p if there is none.
+func overrideParameter(parameters []string, p string) []string {
+	name := parameterName(p)
+
+	if i := slices.IndexFunc(parameters, func(e string) bool { return parameterName(e) == name }); i >= 0 {
+		parameters[i] = p
+		return parameters
+	}
+
+	return append(parameters, p)
+}
+
+// parameterName returns the name of a kernel module parameter in the form of key=value.
+func parameterName(parameter string) string {
+	name, _, _ := strings.Cut(parameter, "=")
+	return name
+}
+
+// ValueSources returns the ConfigMap and Secret references of the modprobe parameters and build arguments of the
+// ModuleLoader of mod, and of its kernels to prebuild.
+func ValueSources(mod *kmmv1beta1.Module) []kmmv1beta1.ValueSource {
+	if mod.Spec.ModuleLoader == nil {
+		return nil
+	}
+
+	container := mod.Spec.ModuleLoader.Container
+	sources := make([]kmmv1beta1.ValueSource, 0, len(container.Modprobe.ParametersFrom))
+
+	parametersFrom := [][]kmmv1beta1.ModprobeParameterSource{container.Modprobe.ParametersFrom}
+	builds := []*kmmv1beta1.Build{container.Build}
+
+	for _, km := range container.KernelMappings {
+		if km.Modprobe != nil {
+			parametersFrom = append(parametersFrom, km.Modprobe.ParametersFrom)
+		}
+
+		builds = append(builds, km.Build)
+	}
+
+	for _, pf := range parametersFrom {
+		for _, p := range pf {
+			sources = append(sources, p.ValueFrom)
+		}
+	}
+
+	for _, b := range builds {
+		if b == nil {
+			continue
+		}
+
+		for _, arg := range b.BuildArgs {
+			if arg.ValueFrom != nil {
+				sources = append(sources, *arg.ValueFrom)
+			}
+		}
+	}
+
+	if pk := mod.Spec.PrebuildKernels; pk != nil && pk.ConfigMapKeyRef != nil {
+		sources = append(sources, kmmv1beta1.ValueSource{ConfigMapKeyRef: pk.ConfigMapKeyRef})
+	}
+
+	return sources
+}
+
+// ValueSourceSecretNames returns the sorted names of the Secrets referenced by ValueSources(mod).
+func ValueSourceSecretNames(mod *kmmv1beta1.Module) []string {
+	names := sets.New[string]()
+
+	for _, src := range ValueSources(mod) {
+		if src.SecretKeyRef != nil {
+			names.Insert(src.SecretKeyRef.Name)
+		}
+	}
+
+	return sets.List(names)
+}
+
+// RemovedKernelModules returns the normalized names of the in-tree kernel modules removed before loading.
+func RemovedKernelModules(inTreeModulesToRemove []string, inTreeModuleToRemove string) sets.Set[string] {
+	s := sets.New[string]()
+
+	for _, m := range inTreeModulesToRemove {
+		s.Insert(normalizeKernelModuleName(m))
+	}
+
+	if inTreeModuleToRemove != "" {
+		s.Insert(normalizeKernelModuleName(inTreeModuleToRemove))
+	}
+
+	return s
+}
+
+// ConflictingKernelModules returns the sorted names of the kernel modules that are loaded by both sides, or
+// loaded by one side and removed by the other.
+func ConflictingKernelModules(aLoaded, aRemoved, bLoaded, bRemoved sets.Set[string]) []string {
+	conflicts := aLoaded.Intersection(bLoaded)
+	conflicts = conflicts.Union(aLoaded.Intersection(bRemoved))
+	conflicts = conflicts.Union(aRemoved.Intersection(bLoaded))
+
+	return sets.List(conflicts)
+}
+
+// ModuleKernelModules returns all the kernel modules a Module may load and remove, across all its kernel
+// mappings.
+func ModuleKernelModules(mod *kmmv1beta1.Module) (loaded sets.Set[string], removed sets.Set[string]) {
+	if mod.Spec.ModuleLoader == nil {
+		return nil, nil
+	}
+
+	container := mod.Spec.ModuleLoader.Container
+
+	loaded = LoadedKernelModules(container.Modprobe)
+	removed = RemovedKernelModules(container.InTreeModulesToRemove, container.InTreeModuleToRemove) //nolint:staticcheck
+
+	for _, km := range container.KernelMappings {
+		if km.Modprobe != nil {
+			loaded = loaded.Union(LoadedKernelModules(MappingModprobe(container.Modprobe, km.Modprobe)))
+		}
+
+		removed = removed.Union(RemovedKernelModules(km.InTreeModulesToRemove, km.InTreeModuleToRemove)) //nolint:staticcheck
+	}
+
+	return loaded, removed
+}
+
+// ModulesConflict returns the kernel modules on which a and b conflict, or nil if they do not.
+func ModulesConflict(a, b *kmmv1beta1.Module) []string {
+	aLoaded, aRemoved := ModuleKernelModules(a)
+	bLoaded, bRemoved := ModuleKernelModules(b)
+
+	return ConflictingKernelModules(aLoaded, aRemoved, bLoaded, bRemoved)
+}
+
+// ModuleConfigsConflict returns the kernel modules on which two NMC module configurations conflict, or nil if
+// they do not.
+func ModuleConfigsConflict(a, b *kmmv1beta1.ModuleConfig) []string {
+	return ConflictingKernelModules(
+		LoadedKernelModules(a.Modprobe),
+		RemovedKernelModules(a.InTreeModulesToRemove, a.InTreeModuleToRemove),
+		LoadedKernelModules(b.Modprobe),
+		RemovedKernelModules(b.InTreeModulesToRemove, b.InTreeModuleToRemove),
+	)
+}
+
+// TakesPrecedence returns true if a takes precedence over b when both manage the same kernel module on a node.
+// The oldest Module takes precedence; Modules created in the same second are ordered by namespace and name.
+func TakesPrecedence(a, b *kmmv1beta1.Module) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+
+	return a.Name < b.Name
+}
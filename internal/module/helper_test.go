@@ -1,8 +1,13 @@
 package module
 
 import (
+	"time"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("AppendToTag", func() {
@@ -28,3 +33,243 @@ var _ = Describe("AppendToTag", func() {
 		)
 	})
 })
+
+var _ = Describe("TakesPrecedence", func() {
+	newModule := func(namespace, name string, creation int64) *kmmv1beta1.Module {
+		return &kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(time.Unix(creation, 0)),
+			},
+		}
+	}
+
+	DescribeTable("should order Modules",
+		func(a, b *kmmv1beta1.Module) {
+			Expect(TakesPrecedence(a, b)).To(BeTrue())
+			Expect(TakesPrecedence(b, a)).To(BeFalse())
+		},
+		Entry("older Module", newModule("ns-b", "b", 1000), newModule("ns-a", "a", 2000)),
+		Entry("same creation time, different namespaces", newModule("ns-a", "b", 1000), newModule("ns-b", "a", 1000)),
+		Entry("same creation time and namespace", newModule("ns", "a", 1000), newModule("ns", "b", 1000)),
+	)
+})
+
+var _ = Describe("ModulesConflict", func() {
+	newModule := func(modprobe kmmv1beta1.ModprobeSpec, inTreeModulesToRemove []string) *kmmv1beta1.Module {
+		return &kmmv1beta1.Module{
+			Spec: kmmv1beta1.ModuleSpec{
+				ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{
+					Container: kmmv1beta1.ModuleLoaderContainerSpec{
+						Modprobe: modprobe,
+						KernelMappings: []kmmv1beta1.KernelMapping{
+							{InTreeModulesToRemove: inTreeModulesToRemove},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	DescribeTable("should return the conflicting kernel modules",
+		func(a, b *kmmv1beta1.Module, expected []string) {
+			Expect(ModulesConflict(a, b)).To(Equal(expected))
+			Expect(ModulesConflict(b, a)).To(Equal(expected))
+		},
+		Entry(
+			"no ModuleLoader",
+			&kmmv1beta1.Module{},
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod"}, nil),
+			[]string{},
+		),
+		Entry(
+			"different kernel modules",
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod-a"}, nil),
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod-b"}, nil),
+			[]string{},
+		),
+		Entry(
+			"same kernel module, different separators",
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod-a"}, nil),
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod_a"}, nil),
+			[]string{"kmod_a"},
+		),
+		Entry(
+			"kernel module in the loading order of the other Module",
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod_a", ModulesLoadingOrder: []string{"kmod_a", "kmod_dep"}}, nil),
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod_dep"}, nil),
+			[]string{"kmod_dep"},
+		),
+		Entry(
+			"kernel module removed by the other Module",
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod_a"}, []string{"kmod_b"}),
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod_b"}, nil),
+			[]string{"kmod_b"},
+		),
+		Entry(
+			"raw arguments only",
+			newModule(kmmv1beta1.ModprobeSpec{RawArgs: &kmmv1beta1.ModprobeArgs{Load: []string{"kmod"}}}, nil),
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod"}, nil),
+			[]string{},
+		),
+		Entry(
+			"kernel module renamed in a kernel mapping",
+			func() *kmmv1beta1.Module {
+				mod := newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod_a"}, nil)
+				mod.Spec.ModuleLoader.Container.KernelMappings[0].Modprobe = &kmmv1beta1.ModprobeOverride{ModuleName: "kmod_new"}
+				return mod
+			}(),
+			newModule(kmmv1beta1.ModprobeSpec{ModuleName: "kmod_new"}, nil),
+			[]string{"kmod_new"},
+		),
+	)
+})
+
+var _ = Describe("ResolveModprobeParameters", func() {
+	parameters := []string{"queues=4", "debug=0"}
+	nodeLabels := map[string]string{"kubernetes.io/hostname": "node-a", "example.com/nic": "fast"}
+
+	DescribeTable("should return the parameters for the node",
+		func(overrides []kmmv1beta1.ParameterOverride, expected []string) {
+			Expect(ResolveModprobeParameters(parameters, overrides, nodeLabels)).To(Equal(expected))
+			Expect(parameters).To(Equal([]string{"queues=4", "debug=0"}))
+		},
+		Entry("no overrides", nil, []string{"queues=4", "debug=0"}),
+		Entry(
+			"override not matching the node",
+			[]kmmv1beta1.ParameterOverride{
+				{Selector: map[string]string{"kubernetes.io/hostname": "node-b"}, Parameters: []string{"queues=8"}},
+			},
+			[]string{"queues=4", "debug=0"},
+		),
+		Entry(
+			"override replacing and adding parameters",
+			[]kmmv1beta1.ParameterOverride{
+				{Selector: map[string]string{"example.com/nic": "fast"}, Parameters: []string{"queues=16", "mtu=9000"}},
+			},
+			[]string{"queues=16", "debug=0", "mtu=9000"},
+		),
+		Entry(
+			"later overrides take precedence",
+			[]kmmv1beta1.ParameterOverride{
+				{Selector: map[string]string{"example.com/nic": "fast"}, Parameters: []string{"queues=16"}},
+				{Selector: map[string]string{"kubernetes.io/hostname": "node-a"}, Parameters: []string{"queues=32"}},
+			},
+			[]string{"queues=32", "debug=0"},
+		),
+		Entry(
+			"parameter without a value",
+			[]kmmv1beta1.ParameterOverride{
+				{Selector: map[string]string{"example.com/nic": "fast"}, Parameters: []string{"debug"}},
+			},
+			[]string{"queues=4", "debug"},
+		),
+	)
+})
+
+var _ = Describe("ValueSources", func() {
+	configMapSource := kmmv1beta1.ValueSource{
+		ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "cm"},
+			Key:                  "key",
+		},
+	}
+
+	secretSource := kmmv1beta1.ValueSource{
+		SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "secret"},
+			Key:                  "key",
+		},
+	}
+
+	It("should return nothing if the Module has no ModuleLoader", func() {
+		Expect(ValueSources(&kmmv1beta1.Module{})).To(BeEmpty())
+	})
+
+	It("should return the sources of the parameters and build arguments", func() {
+		mod := kmmv1beta1.Module{
+			Spec: kmmv1beta1.ModuleSpec{
+				ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{
+					Container: kmmv1beta1.ModuleLoaderContainerSpec{
+						Modprobe: kmmv1beta1.ModprobeSpec{
+							ParametersFrom: []kmmv1beta1.ModprobeParameterSource{
+								{Name: "param", ValueFrom: configMapSource},
+							},
+						},
+						Build: &kmmv1beta1.Build{
+							BuildArgs: []kmmv1beta1.BuildArg{
+								{Name: "PLAIN", Value: "value"},
+								{Name: "FROM_SECRET", ValueFrom: &secretSource},
+							},
+						},
+						KernelMappings: []kmmv1beta1.KernelMapping{
+							{
+								Modprobe: &kmmv1beta1.ModprobeOverride{
+									ParametersFrom: []kmmv1beta1.ModprobeParameterSource{
+										{Name: "mapping-param", ValueFrom: secretSource},
+									},
+								},
+							},
+							{
+								Build: &kmmv1beta1.Build{
+									BuildArgs: []kmmv1beta1.BuildArg{
+										{Name: "FROM_CONFIGMAP", ValueFrom: &configMapSource},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		Expect(
+			ValueSources(&mod),
+		).To(
+			Equal([]kmmv1beta1.ValueSource{configMapSource, secretSource, secretSource, configMapSource}),
+		)
+	})
+
+	It("should return the names of the referenced Secrets once", func() {
+		mod := kmmv1beta1.Module{
+			Spec: kmmv1beta1.ModuleSpec{
+				ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{
+					Container: kmmv1beta1.ModuleLoaderContainerSpec{
+						Modprobe: kmmv1beta1.ModprobeSpec{
+							ParametersFrom: []kmmv1beta1.ModprobeParameterSource{
+								{Name: "param", ValueFrom: secretSource},
+								{Name: "other-param", ValueFrom: configMapSource},
+							},
+						},
+						Build: &kmmv1beta1.Build{
+							BuildArgs: []kmmv1beta1.BuildArg{
+								{Name: "FROM_SECRET", ValueFrom: &secretSource},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		Expect(ValueSourceSecretNames(&mod)).To(Equal([]string{"secret"}))
+	})
+
+	It("should return the source of the kernels to prebuild", func() {
+		mod := kmmv1beta1.Module{
+			Spec: kmmv1beta1.ModuleSpec{
+				ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{},
+				PrebuildKernels: &kmmv1beta1.PrebuildKernelsSpec{
+					Versions:        []string{"5.14.0"},
+					ConfigMapKeyRef: configMapSource.ConfigMapKeyRef,
+				},
+			},
+		}
+
+		Expect(
+			ValueSources(&mod),
+		).To(
+			Equal([]kmmv1beta1.ValueSource{configMapSource}),
+		)
+	})
+})
@@ -4,19 +4,28 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/kernel"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
-var ErrNoMatchingKernelMapping = errors.New("kernel mapping not found")
+var (
+	ErrNoMatchingKernelMapping = errors.New("kernel mapping not found")
+	ErrUnknownNodeProperty     = errors.New("the kernel mapping restricts nodes on a property that is not known")
+)
 
 //go:generate mockgen -source=kernelmapper.go -package=module -destination=mock_kernelmapper.go KernelMapper,kernelMapperHelperAPI
 
 type KernelMapper interface {
 	GetModuleLoaderDataForKernel(mod *kmmv1beta1.Module, kernelVersion string) (*api.ModuleLoaderData, error)
+	GetModuleLoaderDataForNode(mod *kmmv1beta1.Module, node *v1.Node) (*api.ModuleLoaderData, error)
 }
 
 type kernelMapper struct {
@@ -29,9 +38,20 @@ func NewKernelMapper(buildArgOverrider BuildArgOverrider) KernelMapper {
 	}
 }
 
+// GetModuleLoaderDataForKernel returns the ModuleLoaderData of mod for kernelVersion, when the node is not known.
+// It returns ErrUnknownNodeProperty if the kernel mapping to use restricts the nodes it applies to.
 func (k *kernelMapper) GetModuleLoaderDataForKernel(mod *kmmv1beta1.Module, kernelVersion string) (*api.ModuleLoaderData, error) {
+	return k.getModuleLoaderData(mod, kernelVersion, nil)
+}
+
+// GetModuleLoaderDataForNode returns the ModuleLoaderData of mod for the kernel and the properties of node.
+func (k *kernelMapper) GetModuleLoaderDataForNode(mod *kmmv1beta1.Module, node *v1.Node) (*api.ModuleLoaderData, error) {
+	return k.getModuleLoaderData(mod, strings.TrimSuffix(node.Status.NodeInfo.KernelVersion, "+"), node)
+}
+
+func (k *kernelMapper) getModuleLoaderData(mod *kmmv1beta1.Module, kernelVersion string, node *v1.Node) (*api.ModuleLoaderData, error) {
 	mappings := mod.Spec.ModuleLoader.Container.KernelMappings
-	foundMapping, err := k.helper.findKernelMapping(mappings, kernelVersion)
+	foundMapping, err := k.helper.findKernelMapping(mappings, kernelVersion, node)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find mapping for kernel %s: %w", kernelVersion, err)
 	}
@@ -40,6 +60,11 @@ func (k *kernelMapper) GetModuleLoaderDataForKernel(mod *kmmv1beta1.Module, kern
 		return nil, fmt.Errorf("failed to prepare module loader data for kernel %s: %v", kernelVersion, err)
 	}
 
+	if node != nil {
+		mld.Architecture = node.Status.NodeInfo.Architecture
+		mld.OSID = nodeOSID(node)
+	}
+
 	err = k.helper.replaceTemplates(mld)
 	if err != nil {
 		return nil, fmt.Errorf("failed to replace templates in module loader data for kernel %s: %v", kernelVersion, err)
@@ -47,12 +72,27 @@ func (k *kernelMapper) GetModuleLoaderDataForKernel(mod *kmmv1beta1.Module, kern
 	return mld, nil
 }
 
+// nodeOSID returns the ID of the operating system of node, as published by Node Feature Discovery, or else the
+// first word of the node's OS image in lower case.
+func nodeOSID(node *v1.Node) string {
+	if id := node.Labels[constants.NFDOSReleaseIDLabel]; id != "" {
+		return id
+	}
+
+	if fields := strings.Fields(node.Status.NodeInfo.OSImage); len(fields) > 0 {
+		return strings.ToLower(fields[0])
+	}
+
+	return ""
+}
+
 type kernelMapperHelperAPI interface {
-	findKernelMapping(mappings []kmmv1beta1.KernelMapping, kernelVersion string) (*kmmv1beta1.KernelMapping, error)
+	findKernelMapping(mappings []kmmv1beta1.KernelMapping, kernelVersion string, node *v1.Node) (*kmmv1beta1.KernelMapping, error)
 	prepareModuleLoaderData(mapping *kmmv1beta1.KernelMapping, mod *kmmv1beta1.Module, kernelVersion string) (*api.ModuleLoaderData, error)
 	replaceTemplates(mld *api.ModuleLoaderData) error
 	getRelevantBuild(moduleBuild *kmmv1beta1.Build, mappingBuild *kmmv1beta1.Build) *kmmv1beta1.Build
 	getRelevantSign(moduleSign *kmmv1beta1.Sign, mappingSign *kmmv1beta1.Sign, kernel string) (*kmmv1beta1.Sign, error)
+	getRelevantModprobe(moduleModprobe kmmv1beta1.ModprobeSpec, mappingModprobe *kmmv1beta1.ModprobeOverride) kmmv1beta1.ModprobeSpec
 }
 
 type kernelMapperHelper struct {
@@ -65,24 +105,132 @@ func newKernelMapperHelper(buildArgOverrider BuildArgOverrider) kernelMapperHelp
 	}
 }
 
-func (kh *kernelMapperHelper) findKernelMapping(mappings []kmmv1beta1.KernelMapping, kernelVersion string) (*kmmv1beta1.KernelMapping, error) {
+// findKernelMapping returns the mapping matching kernelVersion and node with the highest priority, or the first one
+// in mappings among those with the same priority.
+// It returns ErrUnknownNodeProperty if a mapping that would otherwise be used restricts nodes on a property of node
+// that is not known, or if node is nil.
+func (kh *kernelMapperHelper) findKernelMapping(mappings []kmmv1beta1.KernelMapping, kernelVersion string, node *v1.Node) (*kmmv1beta1.KernelMapping, error) {
+	var (
+		found      *kmmv1beta1.KernelMapping
+		unknownErr error
+		unknownAt  *kmmv1beta1.KernelMapping
+	)
+
 	for _, m := range mappings {
-		if m.Literal != "" && m.Literal == kernelVersion {
-			return &m, nil
+		if found != nil && m.Priority <= found.Priority {
+			continue
 		}
 
-		if m.Regexp == "" {
+		matches, err := mappingMatches(m, kernelVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		if !matches {
 			continue
 		}
 
-		if matches, err := regexp.MatchString(m.Regexp, kernelVersion); err != nil {
-			return nil, fmt.Errorf("could not match regexp %q against kernel %q: %v", m.Regexp, kernelVersion, err)
-		} else if matches {
-			return &m, nil
+		matches, err = mappingMatchesNode(m, node)
+		if err != nil {
+			if !errors.Is(err, ErrUnknownNodeProperty) {
+				return nil, err
+			}
+
+			// Only report the mapping if no mapping with a higher priority matches.
+			if unknownAt == nil || m.Priority > unknownAt.Priority {
+				unknownErr = err
+				unknownAt = &m
+			}
+
+			continue
+		}
+
+		if matches {
+			found = &m
+		}
+	}
+
+	if unknownAt != nil && (found == nil || unknownAt.Priority >= found.Priority) {
+		return nil, unknownErr
+	}
+
+	if found == nil {
+		return nil, ErrNoMatchingKernelMapping
+	}
+
+	return found, nil
+}
+
+func mappingMatches(m kmmv1beta1.KernelMapping, kernelVersion string) (bool, error) {
+	switch {
+	case m.Literal != "":
+		return m.Literal == kernelVersion, nil
+	case m.Regexp != "":
+		matches, err := regexp.MatchString(m.Regexp, kernelVersion)
+		if err != nil {
+			return false, fmt.Errorf("could not match regexp %q against kernel %q: %v", m.Regexp, kernelVersion, err)
+		}
+
+		return matches, nil
+	case m.VersionRange != "":
+		vr, err := kernel.ParseVersionRange(m.VersionRange)
+		if err != nil {
+			return false, fmt.Errorf("could not parse version range %q: %v", m.VersionRange, err)
+		}
+
+		v, err := kernel.ParseVersion(kernelVersion)
+		if err != nil {
+			// Kernels that cannot be parsed are not in any range.
+			return false, nil
+		}
+
+		return vr.Matches(v), nil
+	}
+
+	return false, nil
+}
+
+// mappingMatchesNode returns true if node satisfies the architecture, OS image and label criteria of m.
+// A nil node, an empty architecture or OS image and nil labels are not known, and cannot be matched against.
+func mappingMatchesNode(m kmmv1beta1.KernelMapping, node *v1.Node) (bool, error) {
+	if len(m.Architectures) == 0 && m.OSImageRegexp == "" && len(m.NodeSelector) == 0 {
+		return true, nil
+	}
+
+	if node == nil {
+		return false, fmt.Errorf("no node: %w", ErrUnknownNodeProperty)
+	}
+
+	if len(m.Architectures) > 0 {
+		if node.Status.NodeInfo.Architecture == "" {
+			return false, fmt.Errorf("architecture: %w", ErrUnknownNodeProperty)
+		}
+
+		if !slices.Contains(m.Architectures, node.Status.NodeInfo.Architecture) {
+			return false, nil
 		}
 	}
 
-	return nil, ErrNoMatchingKernelMapping
+	if m.OSImageRegexp != "" {
+		if node.Status.NodeInfo.OSImage == "" {
+			return false, fmt.Errorf("OS image: %w", ErrUnknownNodeProperty)
+		}
+
+		matches, err := regexp.MatchString(m.OSImageRegexp, node.Status.NodeInfo.OSImage)
+		if err != nil {
+			return false, fmt.Errorf("could not match regexp %q against OS image %q: %v", m.OSImageRegexp, node.Status.NodeInfo.OSImage, err)
+		}
+
+		if !matches {
+			return false, nil
+		}
+	}
+
+	if len(m.NodeSelector) > 0 && node.Labels == nil {
+		return false, fmt.Errorf("labels: %w", ErrUnknownNodeProperty)
+	}
+
+	return labels.SelectorFromSet(m.NodeSelector).Matches(labels.Set(node.Labels)), nil
 }
 
 func (kh *kernelMapperHelper) prepareModuleLoaderData(mapping *kmmv1beta1.KernelMapping, mod *kmmv1beta1.Module, kernelVersion string) (*api.ModuleLoaderData, error) {
@@ -140,9 +288,14 @@ func (kh *kernelMapperHelper) prepareModuleLoaderData(mapping *kmmv1beta1.Kernel
 	mld.Selector = mod.Spec.Selector
 	mld.Tolerations = append(mod.Spec.Tolerations, InternalTolerations...)
 	mld.ServiceAccountName = mod.Spec.ModuleLoader.ServiceAccountName
-	mld.Modprobe = mod.Spec.ModuleLoader.Container.Modprobe
+	mld.Modprobe = kh.getRelevantModprobe(mod.Spec.ModuleLoader.Container.Modprobe, mapping.Modprobe)
+	mld.ParameterOverrides = mod.Spec.ModuleLoader.Container.ParameterOverrides
 	mld.ModuleVersion = mod.Spec.ModuleLoader.Container.Version
 	mld.ImagePullPolicy = mod.Spec.ModuleLoader.Container.ImagePullPolicy
+	mld.DriftPolicy = mod.Spec.ModuleLoader.DriftPolicy
+	mld.ReadinessCheck = mod.Spec.ModuleLoader.ReadinessCheck
+	mld.NodeLabels = mod.Spec.ModuleLoader.NodeLabels
+	mld.PublishNodeFeature = mod.Spec.ModuleLoader.PublishNodeFeature
 	mld.Owner = mod
 
 	return mld, nil
@@ -153,7 +306,13 @@ func (kh *kernelMapperHelper) replaceTemplates(mld *api.ModuleLoaderData) error
 	if err != nil {
 		return fmt.Errorf("failed to get kernel componnents as env variables, %v", err)
 	}
-	osConfigEnvVars = append(osConfigEnvVars, "MOD_NAME="+mld.Name, "MOD_NAMESPACE="+mld.Namespace)
+	osConfigEnvVars = append(
+		osConfigEnvVars,
+		"MOD_NAME="+mld.Name,
+		"MOD_NAMESPACE="+mld.Namespace,
+		"ARCH="+mld.Architecture,
+		"OS_ID="+mld.OSID,
+	)
 
 	replacedContainerImage, err := utils.ReplaceInTemplates(osConfigEnvVars, mld.ContainerImage)
 	if err != nil {
@@ -178,12 +337,87 @@ func (kh *kernelMapperHelper) getRelevantBuild(moduleBuild *kmmv1beta1.Build, ma
 		buildConfig.DockerfileConfigMap = mappingBuild.DockerfileConfigMap
 	}
 
+	if mappingBuild.Source != nil {
+		buildConfig.Source = mappingBuild.Source.DeepCopy()
+	}
+
+	if mappingBuild.Backend != "" {
+		buildConfig.Backend = mappingBuild.Backend
+	}
+
+	if mappingBuild.Cache != nil {
+		buildConfig.Cache = mappingBuild.Cache.DeepCopy()
+	}
+
+	if mappingBuild.ActiveDeadline != nil {
+		buildConfig.ActiveDeadline = mappingBuild.ActiveDeadline.DeepCopy()
+	}
+
+	if mappingBuild.MaxRetries != 0 {
+		buildConfig.MaxRetries = mappingBuild.MaxRetries
+	}
+
+	if mappingBuild.Backoff != nil {
+		buildConfig.Backoff = mappingBuild.Backoff.DeepCopy()
+	}
+
+	if mappingBuild.Priority != 0 {
+		buildConfig.Priority = mappingBuild.Priority
+	}
+
 	buildConfig.BuildArgs = kh.buildArgOverrider.ApplyBuildArgOverrides(buildConfig.BuildArgs, mappingBuild.BuildArgs...)
 
 	buildConfig.Secrets = append(buildConfig.Secrets, mappingBuild.Secrets...)
 	return buildConfig
 }
 
+// MappingModprobe returns the modprobe settings that apply to a kernel mapping with the mappingModprobe override.
+func MappingModprobe(moduleModprobe kmmv1beta1.ModprobeSpec, mappingModprobe *kmmv1beta1.ModprobeOverride) kmmv1beta1.ModprobeSpec {
+	return (&kernelMapperHelper{}).getRelevantModprobe(moduleModprobe, mappingModprobe)
+}
+
+// getRelevantModprobe returns the modprobe settings of a kernel mapping: the fields set in mappingModprobe replace
+// those of moduleModprobe, and its parameters replace the Module's parameter with the same name or are appended.
+func (kh *kernelMapperHelper) getRelevantModprobe(moduleModprobe kmmv1beta1.ModprobeSpec, mappingModprobe *kmmv1beta1.ModprobeOverride) kmmv1beta1.ModprobeSpec {
+	modprobe := *moduleModprobe.DeepCopy()
+
+	if mappingModprobe == nil {
+		return modprobe
+	}
+
+	if mappingModprobe.ModuleName != "" {
+		modprobe.ModuleName = mappingModprobe.ModuleName
+	}
+
+	if mappingModprobe.DirName != "" {
+		modprobe.DirName = mappingModprobe.DirName
+	}
+
+	if mappingModprobe.FirmwarePath != "" {
+		modprobe.FirmwarePath = mappingModprobe.FirmwarePath
+	}
+
+	if mappingModprobe.Args != nil {
+		modprobe.Args = mappingModprobe.Args.DeepCopy()
+	}
+
+	if mappingModprobe.RawArgs != nil {
+		modprobe.RawArgs = mappingModprobe.RawArgs.DeepCopy()
+	}
+
+	if mappingModprobe.ModulesLoadingOrder != nil {
+		modprobe.ModulesLoadingOrder = slices.Clone(mappingModprobe.ModulesLoadingOrder)
+	}
+
+	for _, p := range mappingModprobe.Parameters {
+		modprobe.Parameters = overrideParameter(modprobe.Parameters, p)
+	}
+
+	modprobe.ParametersFrom = append(modprobe.ParametersFrom, mappingModprobe.ParametersFrom...)
+
+	return modprobe
+}
+
 func (kh *kernelMapperHelper) getRelevantSign(moduleSign *kmmv1beta1.Sign, mappingSign *kmmv1beta1.Sign, kernelVersion string) (*kmmv1beta1.Sign, error) {
 	var signConfig *kmmv1beta1.Sign
 	if moduleSign == nil {
@@ -204,6 +438,18 @@ func (kh *kernelMapperHelper) getRelevantSign(moduleSign *kmmv1beta1.Sign, mappi
 		if mappingSign.CertSecret != nil {
 			signConfig.CertSecret = mappingSign.CertSecret
 		}
+		if mappingSign.ActiveDeadline != nil {
+			signConfig.ActiveDeadline = mappingSign.ActiveDeadline
+		}
+		if mappingSign.MaxRetries != 0 {
+			signConfig.MaxRetries = mappingSign.MaxRetries
+		}
+		if mappingSign.Backoff != nil {
+			signConfig.Backoff = mappingSign.Backoff
+		}
+		if mappingSign.Priority != 0 {
+			signConfig.Priority = mappingSign.Priority
+		}
 		//append (not overwrite) any files in the km to the defaults
 		signConfig.FilesToSign = append(signConfig.FilesToSign, mappingSign.FilesToSign...)
 	}
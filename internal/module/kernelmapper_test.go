@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"github.com/google/go-cmp/cmp"
 	"strings"
+	"time"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/api"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("GetModuleLoaderDataForKernel", func() {
@@ -42,7 +45,7 @@ var _ = Describe("GetModuleLoaderDataForKernel", func() {
 	It("good flow", func() {
 		mapping := kmmv1beta1.KernelMapping{}
 		mld := api.ModuleLoaderData{KernelVersion: kernelVersion}
-		kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion).Return(&mapping, nil)
+		kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion, nil).Return(&mapping, nil)
 		kh.EXPECT().prepareModuleLoaderData(&mapping, &mod, kernelVersion).Return(&mld, nil)
 		kh.EXPECT().replaceTemplates(&mld).Return(nil)
 		res, err := km.GetModuleLoaderDataForKernel(&mod, kernelVersion)
@@ -51,14 +54,14 @@ var _ = Describe("GetModuleLoaderDataForKernel", func() {
 	})
 
 	It("failed to find kernel mapping, internal error", func() {
-		kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion).Return(nil, fmt.Errorf("some error"))
+		kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion, nil).Return(nil, fmt.Errorf("some error"))
 		res, err := km.GetModuleLoaderDataForKernel(&mod, kernelVersion)
 		Expect(err).To(HaveOccurred())
 		Expect(res).To(BeNil())
 	})
 
 	It("failed to find kernel mapping, mapping not present", func() {
-		kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion).Return(nil, ErrNoMatchingKernelMapping)
+		kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion, nil).Return(nil, ErrNoMatchingKernelMapping)
 		res, err := km.GetModuleLoaderDataForKernel(&mod, kernelVersion)
 		Expect(errors.Is(err, ErrNoMatchingKernelMapping)).To(BeTrue())
 		Expect(res).To(BeNil())
@@ -66,7 +69,7 @@ var _ = Describe("GetModuleLoaderDataForKernel", func() {
 
 	It("failed to merge mapping data", func() {
 		mapping := kmmv1beta1.KernelMapping{}
-		kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion).Return(&mapping, nil)
+		kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion, nil).Return(&mapping, nil)
 		kh.EXPECT().prepareModuleLoaderData(&mapping, &mod, kernelVersion).Return(nil, fmt.Errorf("some error"))
 		res, err := km.GetModuleLoaderDataForKernel(&mod, kernelVersion)
 		Expect(err).To(HaveOccurred())
@@ -76,13 +79,57 @@ var _ = Describe("GetModuleLoaderDataForKernel", func() {
 	It("failed to replace templates", func() {
 		mapping := kmmv1beta1.KernelMapping{}
 		mld := api.ModuleLoaderData{KernelVersion: kernelVersion}
-		kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion).Return(&mapping, nil)
+		kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion, nil).Return(&mapping, nil)
 		kh.EXPECT().prepareModuleLoaderData(&mapping, &mod, kernelVersion).Return(&mld, nil)
 		kh.EXPECT().replaceTemplates(&mld).Return(fmt.Errorf("some error"))
 		res, err := km.GetModuleLoaderDataForKernel(&mod, kernelVersion)
 		Expect(err).To(HaveOccurred())
 		Expect(res).To(BeNil())
 	})
+
+	It("should use the kernel and the properties of the node", func() {
+		node := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{constants.NFDOSReleaseIDLabel: "rhcos"},
+			},
+			Status: v1.NodeStatus{
+				NodeInfo: v1.NodeSystemInfo{
+					Architecture:  "arm64",
+					KernelVersion: kernelVersion + "+",
+					OSImage:       "Red Hat Enterprise Linux CoreOS 9.6",
+				},
+			},
+		}
+		mapping := kmmv1beta1.KernelMapping{}
+		mld := api.ModuleLoaderData{KernelVersion: kernelVersion}
+		expectedMLD := api.ModuleLoaderData{KernelVersion: kernelVersion, Architecture: "arm64", OSID: "rhcos"}
+
+		gomock.InOrder(
+			kh.EXPECT().findKernelMapping(mod.Spec.ModuleLoader.Container.KernelMappings, kernelVersion, &node).Return(&mapping, nil),
+			kh.EXPECT().prepareModuleLoaderData(&mapping, &mod, kernelVersion).Return(&mld, nil),
+			kh.EXPECT().replaceTemplates(&expectedMLD),
+		)
+
+		res, err := km.GetModuleLoaderDataForNode(&mod, &node)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(&expectedMLD))
+	})
+})
+
+var _ = Describe("nodeOSID", func() {
+	DescribeTable("should return the OS ID of the node",
+		func(labels map[string]string, osImage, expected string) {
+			node := v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Status:     v1.NodeStatus{NodeInfo: v1.NodeSystemInfo{OSImage: osImage}},
+			}
+
+			Expect(nodeOSID(&node)).To(Equal(expected))
+		},
+		Entry("NFD label", map[string]string{constants.NFDOSReleaseIDLabel: "rhcos"}, "Red Hat Enterprise Linux CoreOS", "rhcos"),
+		Entry("OS image", nil, "Ubuntu 22.04.3 LTS", "ubuntu"),
+		Entry("nothing", nil, "", ""),
+	)
 })
 
 var _ = Describe("findKernelMapping", func() {
@@ -109,7 +156,7 @@ var _ = Describe("findKernelMapping", func() {
 			Literal: "1.2.3",
 		}
 
-		m, err := kh.findKernelMapping([]kmmv1beta1.KernelMapping{mapping}, kernelVersion)
+		m, err := kh.findKernelMapping([]kmmv1beta1.KernelMapping{mapping}, kernelVersion, nil)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(m).To(Equal(&mapping))
 	})
@@ -119,7 +166,7 @@ var _ = Describe("findKernelMapping", func() {
 			Regexp: `1\..*`,
 		}
 
-		m, err := kh.findKernelMapping([]kmmv1beta1.KernelMapping{mapping}, kernelVersion)
+		m, err := kh.findKernelMapping([]kmmv1beta1.KernelMapping{mapping}, kernelVersion, nil)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(m).To(Equal(&mapping))
 	})
@@ -129,7 +176,7 @@ var _ = Describe("findKernelMapping", func() {
 			Regexp: "invalid)",
 		}
 
-		m, err := kh.findKernelMapping([]kmmv1beta1.KernelMapping{mapping}, kernelVersion)
+		m, err := kh.findKernelMapping([]kmmv1beta1.KernelMapping{mapping}, kernelVersion, nil)
 		Expect(err).To(HaveOccurred())
 		Expect(m).To(BeNil())
 	})
@@ -144,10 +191,134 @@ var _ = Describe("findKernelMapping", func() {
 			},
 		}
 
-		m, err := kh.findKernelMapping(mappings, kernelVersion)
+		m, err := kh.findKernelMapping(mappings, kernelVersion, nil)
 		Expect(errors.Is(err, ErrNoMatchingKernelMapping)).To(BeTrue())
 		Expect(m).To(BeNil())
 	})
+
+	It("one versionRange mapping", func() {
+		mappings := []kmmv1beta1.KernelMapping{
+			{VersionRange: ">=1.3"},
+			{VersionRange: ">=1.2 <1.3"},
+		}
+
+		m, err := kh.findKernelMapping(mappings, kernelVersion, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m).To(Equal(&mappings[1]))
+	})
+
+	It("should not match a versionRange against a kernel that cannot be parsed", func() {
+		m, err := kh.findKernelMapping([]kmmv1beta1.KernelMapping{{VersionRange: ">=1.2"}}, "custom-kernel", nil)
+		Expect(errors.Is(err, ErrNoMatchingKernelMapping)).To(BeTrue())
+		Expect(m).To(BeNil())
+	})
+
+	It("should return the first matching mapping if priorities are equal", func() {
+		mappings := []kmmv1beta1.KernelMapping{
+			{Regexp: `1\..*`, ContainerImage: "first"},
+			{Literal: kernelVersion, ContainerImage: "second"},
+		}
+
+		m, err := kh.findKernelMapping(mappings, kernelVersion, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m).To(Equal(&mappings[0]))
+	})
+
+	DescribeTable("should match the properties of the node",
+		func(mapping kmmv1beta1.KernelMapping, withNode, expectMatch bool) {
+			mapping.Literal = kernelVersion
+
+			var node *v1.Node
+
+			if withNode {
+				node = &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"pool": "gpu"}},
+					Status: v1.NodeStatus{
+						NodeInfo: v1.NodeSystemInfo{Architecture: "arm64", OSImage: "Ubuntu 22.04.3 LTS"},
+					},
+				}
+			}
+
+			m, err := kh.findKernelMapping([]kmmv1beta1.KernelMapping{mapping}, kernelVersion, node)
+
+			if expectMatch {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(m).To(Equal(&mapping))
+			} else {
+				Expect(errors.Is(err, ErrNoMatchingKernelMapping)).To(BeTrue())
+			}
+		},
+		Entry("no criteria, no node", kmmv1beta1.KernelMapping{}, false, true),
+		Entry("architecture", kmmv1beta1.KernelMapping{Architectures: []string{"amd64", "arm64"}}, true, true),
+		Entry("other architecture", kmmv1beta1.KernelMapping{Architectures: []string{"amd64"}}, true, false),
+		Entry("OS image", kmmv1beta1.KernelMapping{OSImageRegexp: "^Ubuntu 22"}, true, true),
+		Entry("other OS image", kmmv1beta1.KernelMapping{OSImageRegexp: "^Red Hat"}, true, false),
+		Entry("node selector", kmmv1beta1.KernelMapping{NodeSelector: map[string]string{"pool": "gpu"}}, true, true),
+		Entry("other node selector", kmmv1beta1.KernelMapping{NodeSelector: map[string]string{"pool": "cpu"}}, true, false),
+	)
+
+	DescribeTable("should return an error if a node property to match is not known",
+		func(mapping kmmv1beta1.KernelMapping, node *v1.Node) {
+			mapping.Literal = kernelVersion
+
+			_, err := kh.findKernelMapping([]kmmv1beta1.KernelMapping{mapping}, kernelVersion, node)
+			Expect(errors.Is(err, ErrUnknownNodeProperty)).To(BeTrue())
+		},
+		Entry("no node", kmmv1beta1.KernelMapping{Architectures: []string{"arm64"}}, nil),
+		Entry("architecture", kmmv1beta1.KernelMapping{Architectures: []string{"arm64"}}, &v1.Node{}),
+		Entry("OS image", kmmv1beta1.KernelMapping{OSImageRegexp: "^Ubuntu"}, &v1.Node{}),
+		Entry("labels", kmmv1beta1.KernelMapping{NodeSelector: map[string]string{"pool": "gpu"}}, &v1.Node{}),
+	)
+
+	It("should ignore unknown node properties in mappings with a lower priority than the one found", func() {
+		mappings := []kmmv1beta1.KernelMapping{
+			{Literal: kernelVersion, Architectures: []string{"arm64"}},
+			{Literal: kernelVersion, Priority: 10},
+		}
+
+		m, err := kh.findKernelMapping(mappings, kernelVersion, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m).To(Equal(&mappings[1]))
+	})
+
+	It("should return an error if a mapping with an unknown node property has the highest priority", func() {
+		mappings := []kmmv1beta1.KernelMapping{
+			{Literal: kernelVersion},
+			{Literal: kernelVersion, Architectures: []string{"arm64"}, Priority: 10},
+		}
+
+		_, err := kh.findKernelMapping(mappings, kernelVersion, nil)
+		Expect(errors.Is(err, ErrUnknownNodeProperty)).To(BeTrue())
+	})
+
+	It("should return an error if the OS image regexp is invalid", func() {
+		node := v1.Node{
+			Status: v1.NodeStatus{
+				NodeInfo: v1.NodeSystemInfo{OSImage: "Ubuntu 22.04.3 LTS"},
+			},
+		}
+
+		_, err := kh.findKernelMapping(
+			[]kmmv1beta1.KernelMapping{{Literal: kernelVersion, OSImageRegexp: "invalid)"}},
+			kernelVersion,
+			&node,
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(errors.Is(err, ErrNoMatchingKernelMapping)).To(BeFalse())
+	})
+
+	It("should return the matching mapping with the highest priority", func() {
+		mappings := []kmmv1beta1.KernelMapping{
+			{Regexp: `.*`},
+			{Regexp: `1\..*`, Priority: 10},
+			{Literal: "0.0.0", Priority: 20},
+			{VersionRange: ">=1.2", Priority: 10},
+		}
+
+		m, err := kh.findKernelMapping(mappings, kernelVersion, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m).To(Equal(&mappings[1]))
+	})
 })
 
 var _ = Describe("prepareModuleLoaderData", func() {
@@ -169,7 +340,15 @@ var _ = Describe("prepareModuleLoaderData", func() {
 		mockBuildArgOverrider = NewMockBuildArgOverrider(ctrl)
 		kh = newKernelMapperHelper(mockBuildArgOverrider)
 		mod = kmmv1beta1.Module{}
-		ModuleLoader := kmmv1beta1.ModuleLoaderSpec{Container: kmmv1beta1.ModuleLoaderContainerSpec{ContainerImage: "spec container image", ImagePullPolicy: "Always"}}
+		ModuleLoader := kmmv1beta1.ModuleLoaderSpec{
+			Container:   kmmv1beta1.ModuleLoaderContainerSpec{ContainerImage: "spec container image", ImagePullPolicy: "Always"},
+			DriftPolicy: kmmv1beta1.DriftPolicyReload,
+			ReadinessCheck: &kmmv1beta1.ReadinessCheck{
+				Command: []string{"/bin/check"},
+			},
+			NodeLabels:         map[string]string{"vendor.com/driver-version": "1.2.3"},
+			PublishNodeFeature: true,
+		}
 		mod.Spec.ModuleLoader = &ModuleLoader
 		mapping = kmmv1beta1.KernelMapping{}
 	})
@@ -201,6 +380,10 @@ var _ = Describe("prepareModuleLoaderData", func() {
 			ServiceAccountName:      mod.Spec.ModuleLoader.ServiceAccountName,
 			Modprobe:                mod.Spec.ModuleLoader.Container.Modprobe,
 			ImagePullPolicy:         mod.Spec.ModuleLoader.Container.ImagePullPolicy,
+			DriftPolicy:             mod.Spec.ModuleLoader.DriftPolicy,
+			ReadinessCheck:          mod.Spec.ModuleLoader.ReadinessCheck,
+			NodeLabels:              mod.Spec.ModuleLoader.NodeLabels,
+			PublishNodeFeature:      mod.Spec.ModuleLoader.PublishNodeFeature,
 			KernelVersion:           kernelVersion,
 			KernelNormalizedVersion: kernelVersion,
 			Tolerations:             InternalTolerations,
@@ -254,6 +437,27 @@ var _ = Describe("prepareModuleLoaderData", func() {
 		Entry("inTreeModulesToRemove in mapping", false, false, false, false, false, false, true),
 	)
 
+	It("should merge the modprobe settings of the mapping", func() {
+		mod.Spec.ModuleLoader.Container.Modprobe = kmmv1beta1.ModprobeSpec{
+			ModuleName: "kmod",
+			Parameters: []string{"a=1"},
+			DirName:    "/usr/lib",
+		}
+		mapping.Modprobe = &kmmv1beta1.ModprobeOverride{
+			ModuleName: "kmod_renamed",
+			Parameters: []string{"b=2"},
+		}
+
+		res, err := kh.prepareModuleLoaderData(&mapping, &mod, kernelVersion)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Modprobe).To(Equal(kmmv1beta1.ModprobeSpec{
+			ModuleName: "kmod_renamed",
+			Parameters: []string{"a=1", "b=2"},
+			DirName:    "/usr/lib",
+		}))
+		Expect(mod.Spec.ModuleLoader.Container.Modprobe.Parameters).To(Equal([]string{"a=1"}))
+	})
+
 	// [TODO] remove this unit test once InTreeModuleToRemove depricated field is removed from CRD
 	DescribeTable("prepare InTreeModules based on InTreeModule", func(inTreeModuleInContainer, inTreeModuleInMapping bool, expectedInTreeModules []string) {
 		mld := api.ModuleLoaderData{
@@ -265,6 +469,10 @@ var _ = Describe("prepareModuleLoaderData", func() {
 			ServiceAccountName:      mod.Spec.ModuleLoader.ServiceAccountName,
 			Modprobe:                mod.Spec.ModuleLoader.Container.Modprobe,
 			ImagePullPolicy:         mod.Spec.ModuleLoader.Container.ImagePullPolicy,
+			DriftPolicy:             mod.Spec.ModuleLoader.DriftPolicy,
+			ReadinessCheck:          mod.Spec.ModuleLoader.ReadinessCheck,
+			NodeLabels:              mod.Spec.ModuleLoader.NodeLabels,
+			PublishNodeFeature:      mod.Spec.ModuleLoader.PublishNodeFeature,
 			KernelVersion:           kernelVersion,
 			KernelNormalizedVersion: kernelVersion,
 			Tolerations:             InternalTolerations,
@@ -339,6 +547,18 @@ var _ = Describe("replaceTemplates", func() {
 		Expect(mld).To(Equal(expectMld))
 	})
 
+	It("should substitute the node's architecture and OS ID", func() {
+		mld := api.ModuleLoaderData{
+			ContainerImage:          "some-image:${OS_ID}-${ARCH}",
+			KernelVersion:           kernelVersion,
+			KernelNormalizedVersion: kernelVersion,
+			Architecture:            "arm64",
+			OSID:                    "fedora",
+		}
+
+		Expect(kh.replaceTemplates(&mld)).To(Succeed())
+		Expect(mld.ContainerImage).To(Equal("some-image:fedora-arm64"))
+	})
 })
 
 var _ = Describe("getRelevantBuild", func() {
@@ -386,6 +606,60 @@ var _ = Describe("getRelevantBuild", func() {
 		Expect(res.DockerfileConfigMap).To(Equal(mappingBuild.DockerfileConfigMap))
 		Expect(res.BaseImageRegistryTLS).To(Equal(moduleBuild.BaseImageRegistryTLS))
 	})
+
+	It("should use the kernel mapping's backend if set", func() {
+		moduleBuild := &kmmv1beta1.Build{Backend: kmmv1beta1.BuildBackendBuildah}
+
+		res := kh.getRelevantBuild(moduleBuild, &kmmv1beta1.Build{})
+		Expect(res.Backend).To(Equal(kmmv1beta1.BuildBackendBuildah))
+
+		res = kh.getRelevantBuild(moduleBuild, &kmmv1beta1.Build{Backend: kmmv1beta1.BuildBackendBuildKit})
+		Expect(res.Backend).To(Equal(kmmv1beta1.BuildBackendBuildKit))
+	})
+
+	It("should use the kernel mapping's cache if set", func() {
+		moduleBuild := &kmmv1beta1.Build{Cache: &kmmv1beta1.BuildCache{Enabled: true}}
+
+		res := kh.getRelevantBuild(moduleBuild, &kmmv1beta1.Build{})
+		Expect(res.Cache).To(Equal(moduleBuild.Cache))
+
+		mappingCache := &kmmv1beta1.BuildCache{Enabled: false}
+		res = kh.getRelevantBuild(moduleBuild, &kmmv1beta1.Build{Cache: mappingCache})
+		Expect(res.Cache).To(Equal(mappingCache))
+	})
+
+	It("should use the kernel mapping's timeout and retries if set", func() {
+		moduleBuild := &kmmv1beta1.Build{
+			ActiveDeadline: &metav1.Duration{Duration: time.Hour},
+			MaxRetries:     1,
+			Backoff:        &metav1.Duration{Duration: time.Minute},
+		}
+
+		res := kh.getRelevantBuild(moduleBuild, &kmmv1beta1.Build{})
+		Expect(res.ActiveDeadline).To(Equal(moduleBuild.ActiveDeadline))
+		Expect(res.MaxRetries).To(BeEquivalentTo(1))
+		Expect(res.Backoff).To(Equal(moduleBuild.Backoff))
+
+		mappingBuild := &kmmv1beta1.Build{
+			ActiveDeadline: &metav1.Duration{Duration: 2 * time.Hour},
+			MaxRetries:     3,
+			Backoff:        &metav1.Duration{Duration: time.Second},
+		}
+		res = kh.getRelevantBuild(moduleBuild, mappingBuild)
+		Expect(res.ActiveDeadline).To(Equal(mappingBuild.ActiveDeadline))
+		Expect(res.MaxRetries).To(BeEquivalentTo(3))
+		Expect(res.Backoff).To(Equal(mappingBuild.Backoff))
+	})
+
+	It("should use the kernel mapping's priority if set", func() {
+		moduleBuild := &kmmv1beta1.Build{Priority: 1}
+
+		res := kh.getRelevantBuild(moduleBuild, &kmmv1beta1.Build{})
+		Expect(res.Priority).To(BeEquivalentTo(1))
+
+		res = kh.getRelevantBuild(moduleBuild, &kmmv1beta1.Build{Priority: 5})
+		Expect(res.Priority).To(BeEquivalentTo(5))
+	})
 })
 
 var _ = Describe("getRelevantSign", func() {
@@ -490,4 +764,83 @@ var _ = Describe("getRelevantSign", func() {
 		),
 	)
 
+	It("should use the kernel mapping's timeout and retries if set", func() {
+		moduleSign := &kmmv1beta1.Sign{
+			ActiveDeadline: &metav1.Duration{Duration: time.Hour},
+			MaxRetries:     1,
+		}
+		mappingSign := &kmmv1beta1.Sign{
+			MaxRetries: 2,
+			Backoff:    &metav1.Duration{Duration: time.Minute},
+		}
+
+		actual, err := kh.getRelevantSign(moduleSign, mappingSign, kernelVersion)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.ActiveDeadline).To(Equal(moduleSign.ActiveDeadline))
+		Expect(actual.MaxRetries).To(BeEquivalentTo(2))
+		Expect(actual.Backoff).To(Equal(mappingSign.Backoff))
+	})
+
+	It("should use the kernel mapping's priority if set", func() {
+		actual, err := kh.getRelevantSign(&kmmv1beta1.Sign{Priority: 1}, &kmmv1beta1.Sign{Priority: 5}, kernelVersion)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Priority).To(BeEquivalentTo(5))
+	})
+
+})
+
+var _ = Describe("getRelevantModprobe", func() {
+	kh := newKernelMapperHelper(nil)
+
+	moduleModprobe := kmmv1beta1.ModprobeSpec{
+		ModuleName:          "kmod",
+		Parameters:          []string{"a=1", "b=2"},
+		DirName:             "/opt",
+		Args:                &kmmv1beta1.ModprobeArgs{Load: []string{"--verbose"}},
+		FirmwarePath:        "/firmware",
+		ModulesLoadingOrder: []string{"kmod", "dep"},
+	}
+
+	DescribeTable("should merge the modprobe settings",
+		func(mappingModprobe *kmmv1beta1.ModprobeOverride, expected kmmv1beta1.ModprobeSpec) {
+			Expect(kh.getRelevantModprobe(moduleModprobe, mappingModprobe)).To(Equal(expected))
+			Expect(moduleModprobe.Parameters).To(Equal([]string{"a=1", "b=2"}))
+		},
+		Entry("no mapping modprobe", nil, moduleModprobe),
+		Entry(
+			"empty mapping modprobe",
+			&kmmv1beta1.ModprobeOverride{},
+			moduleModprobe,
+		),
+		Entry(
+			"fields set in the mapping",
+			&kmmv1beta1.ModprobeOverride{
+				ModuleName:          "kmod_new",
+				DirName:             "/usr",
+				Args:                &kmmv1beta1.ModprobeArgs{Load: []string{"--force"}},
+				FirmwarePath:        "/lib/firmware",
+				ModulesLoadingOrder: []string{"kmod_new", "dep_new"},
+			},
+			kmmv1beta1.ModprobeSpec{
+				ModuleName:          "kmod_new",
+				Parameters:          []string{"a=1", "b=2"},
+				DirName:             "/usr",
+				Args:                &kmmv1beta1.ModprobeArgs{Load: []string{"--force"}},
+				FirmwarePath:        "/lib/firmware",
+				ModulesLoadingOrder: []string{"kmod_new", "dep_new"},
+			},
+		),
+		Entry(
+			"parameters replaced and appended",
+			&kmmv1beta1.ModprobeOverride{Parameters: []string{"b=3", "c=4"}},
+			kmmv1beta1.ModprobeSpec{
+				ModuleName:          "kmod",
+				Parameters:          []string{"a=1", "b=3", "c=4"},
+				DirName:             "/opt",
+				Args:                &kmmv1beta1.ModprobeArgs{Load: []string{"--verbose"}},
+				FirmwarePath:        "/firmware",
+				ModulesLoadingOrder: []string{"kmod", "dep"},
+			},
+		),
+	)
 })
@@ -0,0 +1,84 @@
+package module
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+)
+
+// scheduleParser only accepts 5-field cron expressions: descriptors such as @every would yield schedules that do not
+// honor the window's time zone.
+var scheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// ParseMaintenanceWindow returns the cron schedule of the window, evaluated in the window's time zone.
+func ParseMaintenanceWindow(mw *kmmv1beta1.MaintenanceWindow) (cron.Schedule, error) {
+	loc := time.UTC
+
+	if mw.TimeZone != "" {
+		var err error
+
+		if loc, err = time.LoadLocation(mw.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %v", mw.TimeZone, err)
+		}
+	}
+
+	if strings.HasPrefix(mw.Schedule, "TZ=") || strings.HasPrefix(mw.Schedule, "CRON_TZ=") {
+		return nil, fmt.Errorf("invalid schedule %q: use timeZone instead of a TZ prefix", mw.Schedule)
+	}
+
+	sched, err := scheduleParser.Parse(mw.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %v", mw.Schedule, err)
+	}
+
+	s, ok := sched.(*cron.SpecSchedule)
+	if !ok {
+		return nil, fmt.Errorf("invalid schedule %q: unexpected schedule type %T", mw.Schedule, sched)
+	}
+
+	s.Location = loc
+
+	return s, nil
+}
+
+// GetMaintenanceWindowStatus returns the state of the maintenance window at the given time.
+func GetMaintenanceWindowStatus(mw *kmmv1beta1.MaintenanceWindow, now time.Time) (*kmmv1beta1.MaintenanceWindowStatus, error) {
+	sched, err := ParseMaintenanceWindow(mw)
+	if err != nil {
+		return nil, err
+	}
+
+	// The first window starting after now-duration is the one that may still be open.
+	start := sched.Next(now.Add(-mw.Duration.Duration))
+	if start.IsZero() {
+		return nil, fmt.Errorf("schedule %q never fires", mw.Schedule)
+	}
+
+	if start.After(now) {
+		return &kmmv1beta1.MaintenanceWindowStatus{
+			NextStart: &metav1.Time{Time: start},
+		}, nil
+	}
+
+	end := metav1.NewTime(start.Add(mw.Duration.Duration))
+
+	return &kmmv1beta1.MaintenanceWindowStatus{
+		Open:       true,
+		CurrentEnd: &end,
+		NextStart:  &metav1.Time{Time: sched.Next(now)},
+	}, nil
+}
+
+// NextMaintenanceWindowTransition returns the next time the window opens or closes.
+func NextMaintenanceWindowTransition(status *kmmv1beta1.MaintenanceWindowStatus) time.Time {
+	if status.Open {
+		return status.CurrentEnd.Time
+	}
+
+	return status.NextStart.Time
+}
@@ -0,0 +1,84 @@
+package module
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+)
+
+var _ = Describe("GetMaintenanceWindowStatus", func() {
+	// Every day at 02:00, for one hour.
+	mw := &kmmv1beta1.MaintenanceWindow{
+		Schedule: "0 2 * * *",
+		Duration: metav1.Duration{Duration: time.Hour},
+	}
+
+	at := func(hour, min int) time.Time {
+		return time.Date(2024, 3, 10, hour, min, 0, 0, time.UTC)
+	}
+
+	It("should return an error for an invalid schedule", func() {
+		_, err := GetMaintenanceWindowStatus(&kmmv1beta1.MaintenanceWindow{Schedule: "not a cron"}, at(0, 0))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error for an invalid time zone", func() {
+		_, err := GetMaintenanceWindowStatus(
+			&kmmv1beta1.MaintenanceWindow{Schedule: "0 2 * * *", TimeZone: "Nowhere/Land"},
+			at(0, 0),
+		)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should report a closed window before it opens", func() {
+		s, err := GetMaintenanceWindowStatus(mw, at(1, 0))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Open).To(BeFalse())
+		Expect(s.CurrentEnd).To(BeNil())
+		Expect(s.NextStart.Time).To(Equal(at(2, 0)))
+		Expect(NextMaintenanceWindowTransition(s)).To(Equal(at(2, 0)))
+	})
+
+	It("should report an open window", func() {
+		s, err := GetMaintenanceWindowStatus(mw, at(2, 30))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Open).To(BeTrue())
+		Expect(s.CurrentEnd.Time).To(Equal(at(3, 0)))
+		Expect(s.NextStart.Time).To(Equal(at(2, 0).AddDate(0, 0, 1)))
+		Expect(NextMaintenanceWindowTransition(s)).To(Equal(at(3, 0)))
+	})
+
+	It("should report the window as open exactly when it starts", func() {
+		s, err := GetMaintenanceWindowStatus(mw, at(2, 0))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Open).To(BeTrue())
+	})
+
+	It("should report a closed window after it ends", func() {
+		s, err := GetMaintenanceWindowStatus(mw, at(3, 0))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Open).To(BeFalse())
+		Expect(s.NextStart.Time).To(Equal(at(2, 0).AddDate(0, 0, 1)))
+	})
+
+	It("should evaluate the schedule in the time zone", func() {
+		tz := &kmmv1beta1.MaintenanceWindow{
+			Schedule: "0 2 * * *",
+			Duration: metav1.Duration{Duration: time.Hour},
+			TimeZone: "Asia/Tokyo",
+		}
+
+		// 02:30 in Tokyo is 17:30 UTC the previous day.
+		s, err := GetMaintenanceWindowStatus(tz, time.Date(2024, 3, 9, 17, 30, 0, 0, time.UTC))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Open).To(BeTrue())
+
+		s, err = GetMaintenanceWindowStatus(tz, at(2, 30))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Open).To(BeFalse())
+	})
+})
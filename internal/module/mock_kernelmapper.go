@@ -14,6 +14,7 @@ import (
 	v1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	api "github.com/kubernetes-sigs/kernel-module-management/internal/api"
 	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 )
 
 // MockKernelMapper is a mock of KernelMapper interface.
@@ -54,6 +55,21 @@ func (mr *MockKernelMapperMockRecorder) GetModuleLoaderDataForKernel(mod, kernel
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModuleLoaderDataForKernel", reflect.TypeOf((*MockKernelMapper)(nil).GetModuleLoaderDataForKernel), mod, kernelVersion)
 }
 
+// GetModuleLoaderDataForNode mocks base method.
+func (m *MockKernelMapper) GetModuleLoaderDataForNode(mod *v1beta1.Module, node *v1.Node) (*api.ModuleLoaderData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModuleLoaderDataForNode", mod, node)
+	ret0, _ := ret[0].(*api.ModuleLoaderData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModuleLoaderDataForNode indicates an expected call of GetModuleLoaderDataForNode.
+func (mr *MockKernelMapperMockRecorder) GetModuleLoaderDataForNode(mod, node any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModuleLoaderDataForNode", reflect.TypeOf((*MockKernelMapper)(nil).GetModuleLoaderDataForNode), mod, node)
+}
+
 // MockkernelMapperHelperAPI is a mock of kernelMapperHelperAPI interface.
 type MockkernelMapperHelperAPI struct {
 	ctrl     *gomock.Controller
@@ -78,18 +94,18 @@ func (m *MockkernelMapperHelperAPI) EXPECT() *MockkernelMapperHelperAPIMockRecor
 }
 
 // findKernelMapping mocks base method.
-func (m *MockkernelMapperHelperAPI) findKernelMapping(mappings []v1beta1.KernelMapping, kernelVersion string) (*v1beta1.KernelMapping, error) {
+func (m *MockkernelMapperHelperAPI) findKernelMapping(mappings []v1beta1.KernelMapping, kernelVersion string, node *v1.Node) (*v1beta1.KernelMapping, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "findKernelMapping", mappings, kernelVersion)
+	ret := m.ctrl.Call(m, "findKernelMapping", mappings, kernelVersion, node)
 	ret0, _ := ret[0].(*v1beta1.KernelMapping)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // findKernelMapping indicates an expected call of findKernelMapping.
-func (mr *MockkernelMapperHelperAPIMockRecorder) findKernelMapping(mappings, kernelVersion any) *gomock.Call {
+func (mr *MockkernelMapperHelperAPIMockRecorder) findKernelMapping(mappings, kernelVersion, node any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "findKernelMapping", reflect.TypeOf((*MockkernelMapperHelperAPI)(nil).findKernelMapping), mappings, kernelVersion)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "findKernelMapping", reflect.TypeOf((*MockkernelMapperHelperAPI)(nil).findKernelMapping), mappings, kernelVersion, node)
 }
 
 // getRelevantBuild mocks base method.
@@ -106,6 +122,20 @@ func (mr *MockkernelMapperHelperAPIMockRecorder) getRelevantBuild(moduleBuild, m
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getRelevantBuild", reflect.TypeOf((*MockkernelMapperHelperAPI)(nil).getRelevantBuild), moduleBuild, mappingBuild)
 }
 
+// getRelevantModprobe mocks base method.
+func (m *MockkernelMapperHelperAPI) getRelevantModprobe(moduleModprobe v1beta1.ModprobeSpec, mappingModprobe *v1beta1.ModprobeOverride) v1beta1.ModprobeSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getRelevantModprobe", moduleModprobe, mappingModprobe)
+	ret0, _ := ret[0].(v1beta1.ModprobeSpec)
+	return ret0
+}
+
+// getRelevantModprobe indicates an expected call of getRelevantModprobe.
+func (mr *MockkernelMapperHelperAPIMockRecorder) getRelevantModprobe(moduleModprobe, mappingModprobe any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getRelevantModprobe", reflect.TypeOf((*MockkernelMapperHelperAPI)(nil).getRelevantModprobe), moduleModprobe, mappingModprobe)
+}
+
 // getRelevantSign mocks base method.
 func (m *MockkernelMapperHelperAPI) getRelevantSign(moduleSign, mappingSign *v1beta1.Sign, kernel string) (*v1beta1.Sign, error) {
 	m.ctrl.T.Helper()
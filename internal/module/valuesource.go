@@ -0,0 +1,116 @@
+package module
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+)
+
+// ValueSourceDigest returns the digest of the value selected by vs in namespace, or an empty string if the value is
+// optional and does not exist.
+func ValueSourceDigest(ctx context.Context, reader client.Reader, vs *kmmv1beta1.ValueSource, namespace string) (string, error) {
+	var (
+		name     string
+		key      string
+		optional *bool
+		obj      client.Object
+	)
+
+	switch {
+	case vs.ConfigMapKeyRef != nil:
+		name, key, optional, obj = vs.ConfigMapKeyRef.Name, vs.ConfigMapKeyRef.Key, vs.ConfigMapKeyRef.Optional, &v1.ConfigMap{}
+	case vs.SecretKeyRef != nil:
+		name, key, optional, obj = vs.SecretKeyRef.Name, vs.SecretKeyRef.Key, vs.SecretKeyRef.Optional, &v1.Secret{}
+	default:
+		return "", errors.New("no value source set")
+	}
+
+	nsn := types.NamespacedName{Name: name, Namespace: namespace}
+
+	if err := reader.Get(ctx, nsn, obj); err != nil {
+		if k8serrors.IsNotFound(err) && ptr.Deref(optional, false) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("could not get %s: %v", nsn, err)
+	}
+
+	var (
+		value []byte
+		ok    bool
+	)
+
+	switch o := obj.(type) {
+	case *v1.ConfigMap:
+		var s string
+		if s, ok = o.Data[key]; ok {
+			value = []byte(s)
+		} else {
+			value, ok = o.BinaryData[key]
+		}
+	case *v1.Secret:
+		value, ok = o.Data[key]
+	}
+
+	if !ok {
+		if ptr.Deref(optional, false) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("key %s not found in %s", key, nsn)
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(value)), nil
+}
+
+// ValueSourcesDigest returns a digest of the values selected by sources in namespace, or an empty string if there are
+// no sources.
+// It lets resources that only hold references to Secret values change when those values change, without holding them.
+func ValueSourcesDigest(ctx context.Context, reader client.Reader, sources []kmmv1beta1.ValueSource, namespace string) (string, error) {
+	if len(sources) == 0 {
+		return "", nil
+	}
+
+	h := sha256.New()
+
+	for i := range sources {
+		digest, err := ValueSourceDigest(ctx, reader, &sources[i], namespace)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(h, "%s\x00", digest)
+	}
+
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
+
+// SecretDigest returns the digest of the data of the Secret secretName in namespace.
+func SecretDigest(ctx context.Context, reader client.Reader, secretName, namespace string) (string, error) {
+	secret := v1.Secret{}
+	nsn := types.NamespacedName{Name: secretName, Namespace: namespace}
+
+	if err := reader.Get(ctx, nsn, &secret); err != nil {
+		return "", fmt.Errorf("could not get Secret %s: %v", nsn, err)
+	}
+
+	h := sha256.New()
+
+	for _, key := range slices.Sorted(maps.Keys(secret.Data)) {
+		fmt.Fprintf(h, "%s\x00%d\x00", key, len(secret.Data[key]))
+		h.Write(secret.Data[key])
+	}
+
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
@@ -0,0 +1,93 @@
+package module
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
+)
+
+var _ = Describe("ValueSourcesDigest", func() {
+	var (
+		ctrl *gomock.Controller
+		clnt *client.MockClient
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+	})
+
+	ctx := context.Background()
+
+	secretSource := func(optional bool) kmmv1beta1.ValueSource {
+		return kmmv1beta1.ValueSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "secret"},
+				Key:                  "key",
+				Optional:             ptr.To(optional),
+			},
+		}
+	}
+
+	expectSecret := func(value string) {
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Name: "secret", Namespace: "ns"}, &v1.Secret{}).DoAndReturn(
+			func(_ interface{}, _ interface{}, secret *v1.Secret, _ ...ctrlclient.GetOption) error {
+				secret.Data = map[string][]byte{"key": []byte(value)}
+				return nil
+			},
+		)
+	}
+
+	It("should return an empty digest if there are no sources", func() {
+		Expect(ValueSourcesDigest(ctx, clnt, nil, "ns")).To(BeEmpty())
+	})
+
+	It("should depend on the values of the sources", func() {
+		sources := []kmmv1beta1.ValueSource{secretSource(false)}
+
+		expectSecret("value-1")
+		first, err := ValueSourcesDigest(ctx, clnt, sources, "ns")
+		Expect(err).NotTo(HaveOccurred())
+
+		expectSecret("value-2")
+		second, err := ValueSourcesDigest(ctx, clnt, sources, "ns")
+		Expect(err).NotTo(HaveOccurred())
+
+		expectSecret("value-2")
+		third, err := ValueSourcesDigest(ctx, clnt, sources, "ns")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(first).NotTo(Equal(second))
+		Expect(second).To(Equal(third))
+	})
+
+	It("should ignore missing optional Secrets", func() {
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Name: "secret", Namespace: "ns"}, &v1.Secret{}).
+			Return(k8serrors.NewNotFound(schema.GroupResource{}, "secret"))
+
+		Expect(
+			ValueSourcesDigest(ctx, clnt, []kmmv1beta1.ValueSource{secretSource(true)}, "ns"),
+		).NotTo(
+			BeEmpty(),
+		)
+	})
+
+	It("should return an error if a required Secret is missing", func() {
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Name: "secret", Namespace: "ns"}, &v1.Secret{}).
+			Return(k8serrors.NewNotFound(schema.GroupResource{}, "secret"))
+
+		_, err := ValueSourcesDigest(ctx, clnt, []kmmv1beta1.ValueSource{secretSource(false)}, "ns")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -71,6 +71,12 @@ func (h *helper) SetModuleConfig(
 	foundEntry.ServiceAccountName = saName
 	foundEntry.Tolerations = mld.Tolerations
 	foundEntry.Version = mld.ModuleVersion
+	foundEntry.DriftPolicy = mld.DriftPolicy
+	foundEntry.ReadinessCheck = mld.ReadinessCheck
+	foundEntry.NodeLabels = mld.NodeLabels
+	foundEntry.PublishNodeFeature = mld.PublishNodeFeature
+	foundEntry.Orphaned = false
+	foundEntry.Paused = false
 
 	return nil
 }
@@ -134,6 +134,10 @@ var _ = Describe("SetModuleConfig", func() {
 			Namespace:          namespace,
 			ServiceAccountName: saName,
 			Tolerations:        []v1.Toleration{testToleration},
+			DriftPolicy:        kmmv1beta1.DriftPolicyReload,
+			ReadinessCheck:     &kmmv1beta1.ReadinessCheck{Command: []string{"/bin/check"}},
+			NodeLabels:         map[string]string{"vendor.com/driver-version": "1.2.3"},
+			PublishNodeFeature: true,
 		}
 
 		err := nmcHelper.SetModuleConfig(&nmc, &mld, &moduleConfig)
@@ -143,6 +147,10 @@ var _ = Describe("SetModuleConfig", func() {
 		Expect(nmc.Spec.Modules[1].Config.InTreeModulesToRemove).To(Equal([]string{"in-tree-module1", "in-tree-module2"}))
 		Expect(nmc.Spec.Modules[1].ServiceAccountName).To(Equal(saName))
 		Expect(nmc.Spec.Modules[1].Tolerations).To(Equal([]v1.Toleration{testToleration}))
+		Expect(nmc.Spec.Modules[1].DriftPolicy).To(Equal(kmmv1beta1.DriftPolicyReload))
+		Expect(nmc.Spec.Modules[1].ReadinessCheck).To(Equal(mld.ReadinessCheck))
+		Expect(nmc.Spec.Modules[1].NodeLabels).To(Equal(mld.NodeLabels))
+		Expect(nmc.Spec.Modules[1].PublishNodeFeature).To(BeTrue())
 	})
 })
 
@@ -112,6 +112,20 @@ func (mr *MockNodeMockRecorder) IsNodeSchedulable(node, tolerations any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsNodeSchedulable", reflect.TypeOf((*MockNode)(nil).IsNodeSchedulable), node, tolerations)
 }
 
+// UpdateAnnotations mocks base method.
+func (m *MockNode) UpdateAnnotations(ctx context.Context, node *v1.Node, toBeAdded, toBeRemoved map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnnotations", ctx, node, toBeAdded, toBeRemoved)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnnotations indicates an expected call of UpdateAnnotations.
+func (mr *MockNodeMockRecorder) UpdateAnnotations(ctx, node, toBeAdded, toBeRemoved any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnnotations", reflect.TypeOf((*MockNode)(nil).UpdateAnnotations), ctx, node, toBeAdded, toBeRemoved)
+}
+
 // UpdateLabels mocks base method.
 func (m *MockNode) UpdateLabels(ctx context.Context, node *v1.Node, toBeAdded, toBeRemoved map[string]string) error {
 	m.ctrl.T.Helper()
@@ -18,6 +18,7 @@ type Node interface {
 	GetSchedulableNodesBySelector(ctx context.Context, selector map[string]string, tolerations []v1.Toleration) ([]v1.Node, error)
 	GetNumTargetedNodes(ctx context.Context, selector map[string]string, tolerations []v1.Toleration) (int, error)
 	UpdateLabels(ctx context.Context, node *v1.Node, toBeAdded, toBeRemoved map[string]string) error
+	UpdateAnnotations(ctx context.Context, node *v1.Node, toBeAdded, toBeRemoved map[string]string) error
 	IsNodeRebooted(node *v1.Node, statusBootId string) bool
 }
 
@@ -94,6 +95,23 @@ func (n *node) UpdateLabels(ctx context.Context, node *v1.Node, toBeAdded, toBeR
 	return nil
 }
 
+func (n *node) UpdateAnnotations(ctx context.Context, node *v1.Node, toBeAdded, toBeRemoved map[string]string) error {
+	patchFrom := client.MergeFrom(node.DeepCopy())
+
+	for key, value := range toBeAdded {
+		meta.SetAnnotation(node, key, value)
+	}
+
+	for key := range toBeRemoved {
+		meta.RemoveAnnotation(node, key)
+	}
+
+	if err := n.client.Patch(ctx, node, patchFrom); err != nil {
+		return fmt.Errorf("could not patch node: %v", err)
+	}
+	return nil
+}
+
 func (n *node) IsNodeRebooted(node *v1.Node, statusBootId string) bool {
 	conds := node.Status.Conditions
 	for i := 0; i < len(conds); i++ {
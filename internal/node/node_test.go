@@ -259,6 +259,45 @@ var _ = Describe("UpdateLabels", func() {
 	})
 })
 
+var _ = Describe("UpdateAnnotations", func() {
+	var (
+		ctrl *gomock.Controller
+		n    Node
+		ctx  context.Context
+		clnt *client.MockClient
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+		ctx = context.TODO()
+		n = NewNode(clnt)
+	})
+
+	It("Should work as expected", func() {
+		node := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"to-be-removed": "value"},
+			},
+		}
+
+		clnt.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Return(nil)
+
+		err := n.UpdateAnnotations(ctx, &node, map[string]string{"to-be-added": "value"}, map[string]string{"to-be-removed": ""})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(node.Annotations).To(Equal(map[string]string{"to-be-added": "value"}))
+	})
+
+	It("Should fail to patch node", func() {
+		node := v1.Node{}
+
+		clnt.EXPECT().Patch(ctx, gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+
+		err := n.UpdateAnnotations(ctx, &node, map[string]string{"to-be-added": "value"}, nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
 var _ = Describe("GetNumTargetedNodes", func() {
 	var (
 		ctrl *gomock.Controller
@@ -24,6 +24,7 @@ const (
 
 	imageOwnerLabelKey  = "kmm.node.kubernetes.io/image-owner"
 	pullPodTypeLabelKey = "kmm.node.kubernetes.io/pull-pod-type"
+	pullPodArchLabelKey = "kmm.node.kubernetes.io/pull-pod-arch"
 
 	pullerContainerName = "puller"
 
@@ -34,12 +35,13 @@ const (
 //go:generate mockgen -source=imagepuller.go -package=pod -destination=mock_imagepuller.go
 
 type ImagePuller interface {
-	CreatePullPod(ctx context.Context, name, namespace, imageToPull string, oneTimePod bool,
+	CreatePullPod(ctx context.Context, name, namespace, imageToPull, arch string, oneTimePod bool,
 		imageRepoSecret *v1.LocalObjectReference, pullPolicy v1.PullPolicy, owner metav1.Object) error
 	DeletePod(ctx context.Context, pod *v1.Pod) error
 	ListPullPods(ctx context.Context, name, namespace string) ([]v1.Pod, error)
-	GetPullPodForImage(pods []v1.Pod, image string) *v1.Pod
+	GetPullPodForImage(pods []v1.Pod, image, arch string) *v1.Pod
 	GetPullPodImage(pod v1.Pod) string
+	GetPullPodArchitecture(pod v1.Pod) string
 	GetPullPodStatus(pod *v1.Pod) PullPodStatus
 }
 
@@ -55,7 +57,9 @@ func NewImagePuller(client client.Client, scheme *runtime.Scheme) ImagePuller {
 	}
 }
 
-func (ipi *imagePullerImpl) CreatePullPod(ctx context.Context, name, namespace, imageToPull string, oneTimePod bool,
+// CreatePullPod creates a Pod that pulls imageToPull.
+// If arch is not empty, the Pod runs on a node of that architecture, so that it pulls the image for it.
+func (ipi *imagePullerImpl) CreatePullPod(ctx context.Context, name, namespace, imageToPull, arch string, oneTimePod bool,
 	imageRepoSecret *v1.LocalObjectReference, pullPolicy v1.PullPolicy, owner metav1.Object) error {
 
 	pullPodTypeLabelValue := pullPodUntilSuccess
@@ -91,6 +95,11 @@ func (ipi *imagePullerImpl) CreatePullPod(ctx context.Context, name, namespace,
 		},
 	}
 
+	if arch != "" {
+		pullPod.Labels[pullPodArchLabelKey] = arch
+		pullPod.Spec.NodeSelector = map[string]string{v1.LabelArchStable: arch}
+	}
+
 	err := ctrl.SetControllerReference(owner, &pullPod, ipi.scheme)
 	if err != nil {
 		return fmt.Errorf("failed to set owner for pullPod for image %s: %v", imageToPull, err)
@@ -120,10 +129,10 @@ func (ipi *imagePullerImpl) ListPullPods(ctx context.Context, name, namespace st
 	return pl.Items, nil
 }
 
-func (ipi *imagePullerImpl) GetPullPodForImage(pods []v1.Pod, image string) *v1.Pod {
+func (ipi *imagePullerImpl) GetPullPodForImage(pods []v1.Pod, image, arch string) *v1.Pod {
 
 	for i, pod := range pods {
-		if image == pod.Spec.Containers[0].Image {
+		if image == pod.Spec.Containers[0].Image && arch == ipi.GetPullPodArchitecture(pod) {
 			return &pods[i]
 		}
 	}
@@ -134,6 +143,12 @@ func (ipi *imagePullerImpl) GetPullPodImage(pod v1.Pod) string {
 	return pod.Spec.Containers[0].Image
 }
 
+// GetPullPodArchitecture returns the architecture for which pod pulls its image, or an empty string if the Pod may
+// run on a node of any architecture.
+func (ipi *imagePullerImpl) GetPullPodArchitecture(pod v1.Pod) string {
+	return pod.Labels[pullPodArchLabelKey]
+}
+
 func (ipi *imagePullerImpl) GetPullPodStatus(pod *v1.Pod) PullPodStatus {
 	switch pod.Status.Phase {
 	case v1.PodSucceeded:
@@ -129,16 +129,37 @@ var _ = Describe("GetPullPodForImage", func() {
 				},
 			},
 		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{pullPodArchLabelKey: "arm64"},
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Image: "image 2",
+					},
+				},
+			},
+		},
 	}
 
 	It("there is a pull pod for that image", func() {
-		res := ip.GetPullPodForImage(pullPods, "image 2")
-		Expect(res).ToNot(BeNil())
-		Expect(res.Spec.Containers[0].Image).To(Equal("image 2"))
+		res := ip.GetPullPodForImage(pullPods, "image 2", "")
+		Expect(res).To(Equal(&pullPods[1]))
+	})
+
+	It("there is a pull pod for that image and architecture", func() {
+		res := ip.GetPullPodForImage(pullPods, "image 2", "arm64")
+		Expect(res).To(Equal(&pullPods[2]))
 	})
 
 	It("there is no pull pod for that image", func() {
-		res := ip.GetPullPodForImage(pullPods, "image 23")
+		res := ip.GetPullPodForImage(pullPods, "image 23", "")
+		Expect(res).To(BeNil())
+	})
+
+	It("there is no pull pod for that image and architecture", func() {
+		res := ip.GetPullPodForImage(pullPods, "image 1", "arm64")
 		Expect(res).To(BeNil())
 	})
 })
@@ -200,7 +221,20 @@ var _ = Describe("CreatePullPod", func() {
 				}
 				return nil
 			})
-		err := ip.CreatePullPod(ctx, testName, testNamespace, testImage, false, &testRepoSecret, imagePullPolicy, &testMic)
+		err := ip.CreatePullPod(ctx, testName, testNamespace, testImage, "", false, &testRepoSecret, imagePullPolicy, &testMic)
+		Expect(err).To(BeNil())
+	})
+
+	It("should run the pod on a node of the architecture", func() {
+		clnt.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, obj ctrlclient.Object, _ ...ctrlclient.CreateOption) error {
+				pullPod := obj.(*v1.Pod)
+				Expect(pullPod.Labels).To(HaveKeyWithValue(pullPodArchLabelKey, "arm64"))
+				Expect(pullPod.Spec.NodeSelector).To(Equal(map[string]string{"kubernetes.io/arch": "arm64"}))
+				return nil
+			})
+
+		err := ip.CreatePullPod(ctx, testName, testNamespace, testImage, "arm64", false, &testRepoSecret, imagePullPolicy, &testMic)
 		Expect(err).To(BeNil())
 	})
 })
@@ -41,17 +41,17 @@ func (m *MockImagePuller) EXPECT() *MockImagePullerMockRecorder {
 }
 
 // CreatePullPod mocks base method.
-func (m *MockImagePuller) CreatePullPod(ctx context.Context, name, namespace, imageToPull string, oneTimePod bool, imageRepoSecret *v1.LocalObjectReference, pullPolicy v1.PullPolicy, owner v10.Object) error {
+func (m *MockImagePuller) CreatePullPod(ctx context.Context, name, namespace, imageToPull, arch string, oneTimePod bool, imageRepoSecret *v1.LocalObjectReference, pullPolicy v1.PullPolicy, owner v10.Object) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePullPod", ctx, name, namespace, imageToPull, oneTimePod, imageRepoSecret, pullPolicy, owner)
+	ret := m.ctrl.Call(m, "CreatePullPod", ctx, name, namespace, imageToPull, arch, oneTimePod, imageRepoSecret, pullPolicy, owner)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePullPod indicates an expected call of CreatePullPod.
-func (mr *MockImagePullerMockRecorder) CreatePullPod(ctx, name, namespace, imageToPull, oneTimePod, imageRepoSecret, pullPolicy, owner any) *gomock.Call {
+func (mr *MockImagePullerMockRecorder) CreatePullPod(ctx, name, namespace, imageToPull, arch, oneTimePod, imageRepoSecret, pullPolicy, owner any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullPod", reflect.TypeOf((*MockImagePuller)(nil).CreatePullPod), ctx, name, namespace, imageToPull, oneTimePod, imageRepoSecret, pullPolicy, owner)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullPod", reflect.TypeOf((*MockImagePuller)(nil).CreatePullPod), ctx, name, namespace, imageToPull, arch, oneTimePod, imageRepoSecret, pullPolicy, owner)
 }
 
 // DeletePod mocks base method.
@@ -68,18 +68,32 @@ func (mr *MockImagePullerMockRecorder) DeletePod(ctx, pod any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePod", reflect.TypeOf((*MockImagePuller)(nil).DeletePod), ctx, pod)
 }
 
+// GetPullPodArchitecture mocks base method.
+func (m *MockImagePuller) GetPullPodArchitecture(pod v1.Pod) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullPodArchitecture", pod)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetPullPodArchitecture indicates an expected call of GetPullPodArchitecture.
+func (mr *MockImagePullerMockRecorder) GetPullPodArchitecture(pod any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullPodArchitecture", reflect.TypeOf((*MockImagePuller)(nil).GetPullPodArchitecture), pod)
+}
+
 // GetPullPodForImage mocks base method.
-func (m *MockImagePuller) GetPullPodForImage(pods []v1.Pod, image string) *v1.Pod {
+func (m *MockImagePuller) GetPullPodForImage(pods []v1.Pod, image, arch string) *v1.Pod {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPullPodForImage", pods, image)
+	ret := m.ctrl.Call(m, "GetPullPodForImage", pods, image, arch)
 	ret0, _ := ret[0].(*v1.Pod)
 	return ret0
 }
 
 // GetPullPodForImage indicates an expected call of GetPullPodForImage.
-func (mr *MockImagePullerMockRecorder) GetPullPodForImage(pods, image any) *gomock.Call {
+func (mr *MockImagePullerMockRecorder) GetPullPodForImage(pods, image, arch any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullPodForImage", reflect.TypeOf((*MockImagePuller)(nil).GetPullPodForImage), pods, image)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullPodForImage", reflect.TypeOf((*MockImagePuller)(nil).GetPullPodForImage), pods, image, arch)
 }
 
 // GetPullPodImage mocks base method.
@@ -41,6 +41,35 @@ func (m *MockWorkerPodManager) EXPECT() *MockWorkerPodManagerMockRecorder {
 	return m.recorder
 }
 
+// CheckerPodTemplate mocks base method.
+func (m *MockWorkerPodManager) CheckerPodTemplate(ctx context.Context, nmc client.Object, nms *v1beta1.NodeModuleStatus) (*v1.Pod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckerPodTemplate", ctx, nmc, nms)
+	ret0, _ := ret[0].(*v1.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckerPodTemplate indicates an expected call of CheckerPodTemplate.
+func (mr *MockWorkerPodManagerMockRecorder) CheckerPodTemplate(ctx, nmc, nms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckerPodTemplate", reflect.TypeOf((*MockWorkerPodManager)(nil).CheckerPodTemplate), ctx, nmc, nms)
+}
+
+// CreateCheckerPod mocks base method.
+func (m *MockWorkerPodManager) CreateCheckerPod(ctx context.Context, nmc client.Object, nms *v1beta1.NodeModuleStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCheckerPod", ctx, nmc, nms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCheckerPod indicates an expected call of CreateCheckerPod.
+func (mr *MockWorkerPodManagerMockRecorder) CreateCheckerPod(ctx, nmc, nms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckerPod", reflect.TypeOf((*MockWorkerPodManager)(nil).CreateCheckerPod), ctx, nmc, nms)
+}
+
 // CreateLoaderPod mocks base method.
 func (m *MockWorkerPodManager) CreateLoaderPod(ctx context.Context, nmc client.Object, nms *v1beta1.NodeModuleSpec) error {
 	m.ctrl.T.Helper()
@@ -55,6 +84,20 @@ func (mr *MockWorkerPodManagerMockRecorder) CreateLoaderPod(ctx, nmc, nms any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoaderPod", reflect.TypeOf((*MockWorkerPodManager)(nil).CreateLoaderPod), ctx, nmc, nms)
 }
 
+// CreateReadinessCheckPod mocks base method.
+func (m *MockWorkerPodManager) CreateReadinessCheckPod(ctx context.Context, nmc client.Object, nms *v1beta1.NodeModuleSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReadinessCheckPod", ctx, nmc, nms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReadinessCheckPod indicates an expected call of CreateReadinessCheckPod.
+func (mr *MockWorkerPodManagerMockRecorder) CreateReadinessCheckPod(ctx, nmc, nms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReadinessCheckPod", reflect.TypeOf((*MockWorkerPodManager)(nil).CreateReadinessCheckPod), ctx, nmc, nms)
+}
+
 // CreateUnloaderPod mocks base method.
 func (m *MockWorkerPodManager) CreateUnloaderPod(ctx context.Context, nmc client.Object, nms *v1beta1.NodeModuleStatus) error {
 	m.ctrl.T.Helper()
@@ -154,6 +197,20 @@ func (mr *MockWorkerPodManagerMockRecorder) HashAnnotationDiffer(p1, p2 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashAnnotationDiffer", reflect.TypeOf((*MockWorkerPodManager)(nil).HashAnnotationDiffer), p1, p2)
 }
 
+// IsCheckerPod mocks base method.
+func (m *MockWorkerPodManager) IsCheckerPod(p *v1.Pod) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCheckerPod", p)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCheckerPod indicates an expected call of IsCheckerPod.
+func (mr *MockWorkerPodManagerMockRecorder) IsCheckerPod(p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCheckerPod", reflect.TypeOf((*MockWorkerPodManager)(nil).IsCheckerPod), p)
+}
+
 // IsLoaderPod mocks base method.
 func (m *MockWorkerPodManager) IsLoaderPod(p *v1.Pod) bool {
 	m.ctrl.T.Helper()
@@ -168,6 +225,20 @@ func (mr *MockWorkerPodManagerMockRecorder) IsLoaderPod(p any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLoaderPod", reflect.TypeOf((*MockWorkerPodManager)(nil).IsLoaderPod), p)
 }
 
+// IsReadinessCheckPod mocks base method.
+func (m *MockWorkerPodManager) IsReadinessCheckPod(p *v1.Pod) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReadinessCheckPod", p)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsReadinessCheckPod indicates an expected call of IsReadinessCheckPod.
+func (mr *MockWorkerPodManagerMockRecorder) IsReadinessCheckPod(p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReadinessCheckPod", reflect.TypeOf((*MockWorkerPodManager)(nil).IsReadinessCheckPod), p)
+}
+
 // IsUnloaderPod mocks base method.
 func (m *MockWorkerPodManager) IsUnloaderPod(p *v1.Pod) bool {
 	m.ctrl.T.Helper()
@@ -212,6 +283,21 @@ func (mr *MockWorkerPodManagerMockRecorder) LoaderPodTemplate(ctx, nmc, nms any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoaderPodTemplate", reflect.TypeOf((*MockWorkerPodManager)(nil).LoaderPodTemplate), ctx, nmc, nms)
 }
 
+// ReadinessCheckPodTemplate mocks base method.
+func (m *MockWorkerPodManager) ReadinessCheckPodTemplate(ctx context.Context, nmc client.Object, nms *v1beta1.NodeModuleSpec) (*v1.Pod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadinessCheckPodTemplate", ctx, nmc, nms)
+	ret0, _ := ret[0].(*v1.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadinessCheckPodTemplate indicates an expected call of ReadinessCheckPodTemplate.
+func (mr *MockWorkerPodManagerMockRecorder) ReadinessCheckPodTemplate(ctx, nmc, nms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadinessCheckPodTemplate", reflect.TypeOf((*MockWorkerPodManager)(nil).ReadinessCheckPodTemplate), ctx, nmc, nms)
+}
+
 // UnloaderPodTemplate mocks base method.
 func (m *MockWorkerPodManager) UnloaderPodTemplate(ctx context.Context, nmc client.Object, nms *v1beta1.NodeModuleStatus) (*v1.Pod, error) {
 	m.ctrl.T.Helper()
@@ -30,14 +30,20 @@ import (
 //go:generate mockgen -source=workerpodmanager.go -package=pod -destination=mock_workerpodmanager.go
 
 type WorkerPodManager interface {
+	CheckerPodTemplate(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleStatus) (*v1.Pod, error)
+	CreateCheckerPod(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleStatus) error
 	CreateLoaderPod(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleSpec) error
+	CreateReadinessCheckPod(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleSpec) error
 	CreateUnloaderPod(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleStatus) error
 	DeletePod(ctx context.Context, pod *v1.Pod) error
 	GetWorkerPod(ctx context.Context, podName, namespace string) (*v1.Pod, error)
 	ListWorkerPodsOnNode(ctx context.Context, nodeName string) ([]v1.Pod, error)
 	LoaderPodTemplate(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleSpec) (*v1.Pod, error)
+	ReadinessCheckPodTemplate(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleSpec) (*v1.Pod, error)
 	UnloaderPodTemplate(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleStatus) (*v1.Pod, error)
+	IsCheckerPod(p *v1.Pod) bool
 	IsLoaderPod(p *v1.Pod) bool
+	IsReadinessCheckPod(p *v1.Pod) bool
 	IsUnloaderPod(p *v1.Pod) bool
 	GetConfigAnnotation(p *v1.Pod) string
 	HashAnnotationDiffer(p1, p2 *v1.Pod) bool
@@ -58,7 +64,9 @@ const (
 	volumeNameConfig           = "config"
 	initContainerName          = "image-extractor"
 	modulesOrderKey            = "kmm.node.kubernetes.io/modules-order"
+	workerActionCheck          = "Check"
 	workerActionLoad           = "Load"
+	workerActionReadinessCheck = "ReadinessCheck"
 	workerActionUnload         = "Unload"
 	actionLabelKey             = "kmm.node.kubernetes.io/worker-action"
 	configAnnotationKey        = "kmm.node.kubernetes.io/worker-config"
@@ -94,6 +102,15 @@ func NewWorkerPodManager(client client.Client, workerImage string, scheme *runti
 	}
 }
 
+func (wpmi *workerPodManagerImpl) CreateCheckerPod(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleStatus) error {
+	pod, err := wpmi.CheckerPodTemplate(ctx, nmc, nms)
+	if err != nil {
+		return fmt.Errorf("could not get checker Pod template: %v", err)
+	}
+
+	return wpmi.client.Create(ctx, pod)
+}
+
 func (wpmi *workerPodManagerImpl) CreateLoaderPod(ctx context.Context, nmcObj client.Object, nms *kmmv1beta1.NodeModuleSpec) error {
 	pod, err := wpmi.LoaderPodTemplate(ctx, nmcObj, nms)
 	if err != nil {
@@ -103,6 +120,15 @@ func (wpmi *workerPodManagerImpl) CreateLoaderPod(ctx context.Context, nmcObj cl
 	return wpmi.client.Create(ctx, pod)
 }
 
+func (wpmi *workerPodManagerImpl) CreateReadinessCheckPod(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleSpec) error {
+	pod, err := wpmi.ReadinessCheckPodTemplate(ctx, nmc, nms)
+	if err != nil {
+		return fmt.Errorf("could not get readiness check Pod template: %v", err)
+	}
+
+	return wpmi.client.Create(ctx, pod)
+}
+
 func (wpmi *workerPodManagerImpl) CreateUnloaderPod(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleStatus) error {
 	pod, err := wpmi.UnloaderPodTemplate(ctx, nmc, nms)
 	if err != nil {
@@ -203,6 +229,9 @@ func (wpmi *workerPodManagerImpl) LoaderPodTemplate(ctx context.Context, nmc cli
 	if err = setWorkerTolerationsAnnotation(pod, nms.Tolerations); err != nil {
 		return nil, fmt.Errorf("could not set worker tolerations: %v", err)
 	}
+	if err = setWorkerParametersEnv(pod, nms.Config.Modprobe.ParametersFrom); err != nil {
+		return nil, fmt.Errorf("could not set the environment of parameters read from Secrets: %v", err)
+	}
 
 	setWorkerModuleVersionAnnotation(pod, nms.Version)
 
@@ -271,6 +300,174 @@ func (wpmi *workerPodManagerImpl) UnloaderPodTemplate(ctx context.Context, nmc c
 	return pod, setHashAnnotation(pod)
 }
 
+// CheckerPodTemplate returns a lightweight worker Pod that only reads /proc/modules, which is not namespaced, to find
+// out if the kernel modules described in nms are still loaded.
+// It does not need the kmod image nor any privilege.
+func (wpmi *workerPodManagerImpl) CheckerPodTemplate(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleStatus) (*v1.Pod, error) {
+	nodeName := nmc.GetName()
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: nms.Namespace,
+			Name:      CheckerPodName(nodeName, nms.Name),
+			Labels: map[string]string{
+				"app.kubernetes.io/name":      "kmm",
+				"app.kubernetes.io/component": "worker",
+				"app.kubernetes.io/part-of":   "kmm",
+				constants.ModuleNameLabel:     nms.Name,
+				actionLabelKey:                workerActionCheck,
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  WorkerContainerName,
+					Image: wpmi.workerImage,
+					Args:  []string{"kmod", "check", configFullPath},
+					VolumeMounts: []v1.VolumeMount{
+						{
+							Name:      volNameConfig,
+							MountPath: volMountPointConfig,
+							ReadOnly:  true,
+						},
+					},
+					Resources: v1.ResourceRequirements{
+						Requests: requests,
+						Limits:   limits,
+					},
+					SecurityContext: &v1.SecurityContext{
+						RunAsUser:      wpmi.workerCfg.RunAsUser,
+						SELinuxOptions: &v1.SELinuxOptions{Type: wpmi.workerCfg.SELinuxType},
+					},
+				},
+			},
+			NodeName:           nodeName,
+			RestartPolicy:      v1.RestartPolicyNever,
+			ServiceAccountName: nms.ServiceAccountName,
+			Volumes: []v1.Volume{
+				{
+					Name: volumeNameConfig,
+					VolumeSource: v1.VolumeSource{
+						DownwardAPI: &v1.DownwardAPIVolumeSource{
+							Items: []v1.DownwardAPIVolumeFile{
+								{
+									Path: configFileName,
+									FieldRef: &v1.ObjectFieldSelector{
+										FieldPath: fmt.Sprintf("metadata.annotations['%s']", configAnnotationKey),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Tolerations: nms.Tolerations,
+		},
+	}
+
+	if err := ctrl.SetControllerReference(nmc, &pod, wpmi.scheme); err != nil {
+		return nil, fmt.Errorf("could not set the owner as controller: %v", err)
+	}
+
+	if err := setWorkerConfigAnnotation(&pod, nms.Config); err != nil {
+		return nil, fmt.Errorf("could not set worker config: %v", err)
+	}
+
+	controllerutil.AddFinalizer(&pod, NodeModulesConfigFinalizer)
+
+	return &pod, setHashAnnotation(&pod)
+}
+
+// ReadinessCheckPodTemplate returns a Pod that runs the readiness check of nms on the node.
+// Unless specified otherwise, the check runs in the kernel module image.
+func (wpmi *workerPodManagerImpl) ReadinessCheckPodTemplate(ctx context.Context, nmc client.Object, nms *kmmv1beta1.NodeModuleSpec) (*v1.Pod, error) {
+	check := nms.ReadinessCheck
+	if check == nil {
+		return nil, errors.New("the module does not define a readiness check")
+	}
+
+	image := check.Image
+	if image == "" {
+		image = nms.Config.ContainerImage
+	}
+
+	var imagePullSecrets []v1.LocalObjectReference
+	if nms.ImageRepoSecret != nil {
+		imagePullSecrets = append(imagePullSecrets, *nms.ImageRepoSecret)
+	}
+
+	var activeDeadlineSeconds *int64
+	if check.TimeoutSeconds > 0 {
+		activeDeadlineSeconds = ptr.To(check.TimeoutSeconds)
+	}
+
+	nodeName := nmc.GetName()
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: nms.Namespace,
+			Name:      ReadinessCheckPodName(nodeName, nms.Name),
+			Labels: map[string]string{
+				"app.kubernetes.io/name":      "kmm",
+				"app.kubernetes.io/component": "worker",
+				"app.kubernetes.io/part-of":   "kmm",
+				constants.ModuleNameLabel:     nms.Name,
+				actionLabelKey:                workerActionReadinessCheck,
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:            WorkerContainerName,
+					Image:           image,
+					ImagePullPolicy: nms.Config.ImagePullPolicy,
+					Command:         check.Command,
+					Args:            check.Args,
+					Resources: v1.ResourceRequirements{
+						Requests: requests,
+						Limits:   limits,
+					},
+					TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+				},
+			},
+			ActiveDeadlineSeconds: activeDeadlineSeconds,
+			NodeName:              nodeName,
+			RestartPolicy:         v1.RestartPolicyNever,
+			ServiceAccountName:    nms.ServiceAccountName,
+			ImagePullSecrets:      imagePullSecrets,
+			Tolerations:           nms.Tolerations,
+		},
+	}
+
+	if err := ctrl.SetControllerReference(nmc, &pod, wpmi.scheme); err != nil {
+		return nil, fmt.Errorf("could not set the owner as controller: %v", err)
+	}
+
+	if err := setWorkerConfigAnnotation(&pod, nms.Config); err != nil {
+		return nil, fmt.Errorf("could not set worker config: %v", err)
+	}
+
+	controllerutil.AddFinalizer(&pod, NodeModulesConfigFinalizer)
+
+	return &pod, setHashAnnotation(&pod)
+}
+
+func (wpmi *workerPodManagerImpl) IsReadinessCheckPod(p *v1.Pod) bool {
+
+	if p == nil {
+		return false
+	}
+
+	return p.Labels[actionLabelKey] == workerActionReadinessCheck
+}
+
+func (wpmi *workerPodManagerImpl) IsCheckerPod(p *v1.Pod) bool {
+
+	if p == nil {
+		return false
+	}
+
+	return p.Labels[actionLabelKey] == workerActionCheck
+}
+
 func (wpmi *workerPodManagerImpl) IsLoaderPod(p *v1.Pod) bool {
 
 	if p == nil {
@@ -486,6 +683,31 @@ func setWorkerTolerationsAnnotation(pod *v1.Pod, tolerations []v1.Toleration) er
 	return nil
 }
 
+// setWorkerParametersEnv exposes the values of parametersFrom to the worker container as environment variables, so
+// that they are only read by the kubelet and never copied to the Pod's annotations.
+func setWorkerParametersEnv(pod *v1.Pod, parametersFrom []kmmv1beta1.ModprobeParameterSource) error {
+	if len(parametersFrom) == 0 {
+		return nil
+	}
+
+	container, _ := podcmd.FindContainerByName(pod, WorkerContainerName)
+	if container == nil {
+		return errors.New("could not find the worker container")
+	}
+
+	for i, p := range parametersFrom {
+		container.Env = append(container.Env, v1.EnvVar{
+			Name: worker.ParameterEnvVar(i),
+			ValueFrom: &v1.EnvVarSource{
+				ConfigMapKeyRef: p.ValueFrom.ConfigMapKeyRef,
+				SecretKeyRef:    p.ValueFrom.SecretKeyRef,
+			},
+		})
+	}
+
+	return nil
+}
+
 func setWorkerModuleVersionAnnotation(pod *v1.Pod, moduleVersion string) {
 	if moduleVersion != "" {
 		meta.SetAnnotation(pod, moduleVersionAnnotationKey, moduleVersion)
@@ -623,6 +845,18 @@ func WorkerPodName(nodeName, moduleName string) string {
 	return fmt.Sprintf("kmm-worker-%s-%s", nodeName, moduleName)
 }
 
+// CheckerPodName returns the name of the drift checker Pod, which differs from the name of the loader and unloader
+// Pods so that a check never blocks them.
+func CheckerPodName(nodeName, moduleName string) string {
+	return WorkerPodName(nodeName, moduleName) + "-check"
+}
+
+// ReadinessCheckPodName returns the name of the readiness check Pod, which differs from the name of the loader and
+// unloader Pods so that a check never blocks them.
+func ReadinessCheckPodName(nodeName, moduleName string) string {
+	return WorkerPodName(nodeName, moduleName) + "-readiness"
+}
+
 func getModulesOrderAnnotationValue(modulesNames []string) string {
 	var softDepData strings.Builder
 	for i := 0; i < len(modulesNames)-1; i++ {
@@ -11,6 +11,7 @@ import (
 	testclient "github.com/kubernetes-sigs/kernel-module-management/internal/client"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/config"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/worker"
 	"github.com/mitchellh/hashstructure/v2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -242,6 +243,41 @@ var _ = Describe("CreateLoaderPod", func() {
 		Entry("firmwareHostPath set, firmware loading not requested", ptr.To("some-path"), false),
 		Entry("firmwareHostPath set , firmware loading requested", ptr.To("some-path"), true),
 	)
+
+	It("should expose the parameters read from Secrets as environment variables", func() {
+		secretRef := &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "secret"},
+			Key:                  "key",
+		}
+
+		moduleConfigToUse.Modprobe.ParametersFrom = []kmmv1beta1.ModprobeParameterSource{
+			{Name: "param", ValueFrom: kmmv1beta1.ValueSource{SecretKeyRef: secretRef}},
+		}
+
+		nms := &kmmv1beta1.NodeModuleSpec{
+			ModuleItem: mi,
+			Config:     moduleConfigToUse,
+		}
+
+		kli := &workerPodManagerImpl{
+			client:      client,
+			scheme:      scheme,
+			workerImage: workerImage,
+			workerCfg:   workerCfg,
+		}
+
+		p, err := kli.LoaderPodTemplate(ctx, nmc, nms)
+		Expect(err).NotTo(HaveOccurred())
+
+		container, _ := podcmd.FindContainerByName(p, "worker")
+		Expect(container).NotTo(BeNil())
+		Expect(container.Env).To(
+			ContainElement(v1.EnvVar{
+				Name:      worker.ParameterEnvVar(0),
+				ValueFrom: &v1.EnvVarSource{SecretKeyRef: secretRef},
+			}),
+		)
+	})
 })
 
 var _ = Describe("CreateUnloaderPod", func() {
@@ -337,6 +373,251 @@ var _ = Describe("CreateUnloaderPod", func() {
 	})
 })
 
+var _ = Describe("CreateCheckerPod", func() {
+	It("should create a lightweight Pod that only reads the config", func() {
+		ctx := context.TODO()
+		ctrl := gomock.NewController(GinkgoT())
+		client := testclient.NewMockClient(ctrl)
+
+		nmc := &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+		}
+
+		cfg := kmmv1beta1.ModuleConfig{
+			KernelVersion:  "kernel-version",
+			ContainerImage: "container image",
+			Modprobe:       kmmv1beta1.ModprobeSpec{ModuleName: "test"},
+		}
+
+		status := &kmmv1beta1.NodeModuleStatus{
+			ModuleItem: kmmv1beta1.ModuleItem{
+				ImageRepoSecret:    &v1.LocalObjectReference{Name: "some-secret"},
+				Name:               moduleName,
+				Namespace:          namespace,
+				ServiceAccountName: serviceAccountName,
+			},
+			Config: cfg,
+		}
+
+		expected := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      CheckerPodName(nmcName, moduleName),
+				Namespace: namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/component": "worker",
+					"app.kubernetes.io/name":      "kmm",
+					"app.kubernetes.io/part-of":   "kmm",
+					actionLabelKey:                workerActionCheck,
+					constants.ModuleNameLabel:     moduleName,
+				},
+				Annotations: map[string]string{
+					configAnnotationKey: `containerImage: container image
+imagePullPolicy: ""
+insecurePull: false
+kernelVersion: kernel-version
+modprobe:
+  moduleName: test
+`,
+				},
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name:  "worker",
+						Image: workerImage,
+						Args:  []string{"kmod", "check", "/etc/kmm-worker/config.yaml"},
+						Resources: v1.ResourceRequirements{
+							Limits:   limits,
+							Requests: requests,
+						},
+						SecurityContext: &v1.SecurityContext{
+							RunAsUser:      workerCfg.RunAsUser,
+							SELinuxOptions: &v1.SELinuxOptions{Type: workerCfg.SELinuxType},
+						},
+						VolumeMounts: []v1.VolumeMount{
+							{
+								Name:      volNameConfig,
+								MountPath: "/etc/kmm-worker",
+								ReadOnly:  true,
+							},
+						},
+					},
+				},
+				NodeName:           nmcName,
+				RestartPolicy:      v1.RestartPolicyNever,
+				ServiceAccountName: serviceAccountName,
+				Volumes: []v1.Volume{
+					{
+						Name: volumeNameConfig,
+						VolumeSource: v1.VolumeSource{
+							DownwardAPI: &v1.DownwardAPIVolumeSource{
+								Items: []v1.DownwardAPIVolumeFile{
+									{
+										Path: "config.yaml",
+										FieldRef: &v1.ObjectFieldSelector{
+											FieldPath: fmt.Sprintf("metadata.annotations['%s']", configAnnotationKey),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		Expect(
+			controllerutil.SetControllerReference(nmc, &expected, scheme),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		controllerutil.AddFinalizer(&expected, NodeModulesConfigFinalizer)
+
+		hash, err := hashstructure.Hash(&expected, hashstructure.FormatV2, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		expected.Annotations[hashAnnotationKey] = fmt.Sprintf("%d", hash)
+
+		client.EXPECT().Create(ctx, cmpmock.DiffEq(&expected))
+
+		wpm := NewWorkerPodManager(client, workerImage, scheme, workerCfg)
+
+		Expect(
+			wpm.CreateCheckerPod(ctx, nmc, status),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+})
+
+var _ = Describe("CreateReadinessCheckPod", func() {
+	ctx := context.TODO()
+
+	var (
+		ctrl   *gomock.Controller
+		client *testclient.MockClient
+		wpm    WorkerPodManager
+		nmc    *kmmv1beta1.NodeModulesConfig
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		client = testclient.NewMockClient(ctrl)
+		wpm = NewWorkerPodManager(client, workerImage, scheme, workerCfg)
+		nmc = &kmmv1beta1.NodeModulesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: nmcName},
+		}
+	})
+
+	It("should return an error if the module has no readiness check", func() {
+		nms := &kmmv1beta1.NodeModuleSpec{
+			ModuleItem: kmmv1beta1.ModuleItem{Name: moduleName, Namespace: namespace},
+		}
+
+		Expect(
+			wpm.CreateReadinessCheckPod(ctx, nmc, nms),
+		).To(
+			HaveOccurred(),
+		)
+	})
+
+	DescribeTable(
+		"should create a Pod running the readiness check",
+		func(checkImage, expectedImage string) {
+			cfg := kmmv1beta1.ModuleConfig{
+				KernelVersion:   "kernel-version",
+				ContainerImage:  "container image",
+				ImagePullPolicy: v1.PullIfNotPresent,
+				Modprobe:        kmmv1beta1.ModprobeSpec{ModuleName: "test"},
+			}
+
+			nms := &kmmv1beta1.NodeModuleSpec{
+				ModuleItem: kmmv1beta1.ModuleItem{
+					ImageRepoSecret:    &v1.LocalObjectReference{Name: "some-secret"},
+					Name:               moduleName,
+					Namespace:          namespace,
+					ServiceAccountName: serviceAccountName,
+				},
+				Config: cfg,
+				ReadinessCheck: &kmmv1beta1.ReadinessCheck{
+					Image:          checkImage,
+					Command:        []string{"/bin/check"},
+					Args:           []string{"--device", "/dev/test"},
+					TimeoutSeconds: 30,
+				},
+			}
+
+			expected := v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ReadinessCheckPodName(nmcName, moduleName),
+					Namespace: namespace,
+					Labels: map[string]string{
+						"app.kubernetes.io/component": "worker",
+						"app.kubernetes.io/name":      "kmm",
+						"app.kubernetes.io/part-of":   "kmm",
+						actionLabelKey:                workerActionReadinessCheck,
+						constants.ModuleNameLabel:     moduleName,
+					},
+					Annotations: map[string]string{
+						configAnnotationKey: `containerImage: container image
+imagePullPolicy: IfNotPresent
+insecurePull: false
+kernelVersion: kernel-version
+modprobe:
+  moduleName: test
+`,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:            "worker",
+							Image:           expectedImage,
+							ImagePullPolicy: v1.PullIfNotPresent,
+							Command:         []string{"/bin/check"},
+							Args:            []string{"--device", "/dev/test"},
+							Resources: v1.ResourceRequirements{
+								Limits:   limits,
+								Requests: requests,
+							},
+							TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+						},
+					},
+					ActiveDeadlineSeconds: ptr.To[int64](30),
+					ImagePullSecrets:      []v1.LocalObjectReference{{Name: "some-secret"}},
+					NodeName:              nmcName,
+					RestartPolicy:         v1.RestartPolicyNever,
+					ServiceAccountName:    serviceAccountName,
+				},
+			}
+
+			Expect(
+				controllerutil.SetControllerReference(nmc, &expected, scheme),
+			).NotTo(
+				HaveOccurred(),
+			)
+
+			controllerutil.AddFinalizer(&expected, NodeModulesConfigFinalizer)
+
+			hash, err := hashstructure.Hash(&expected, hashstructure.FormatV2, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected.Annotations[hashAnnotationKey] = fmt.Sprintf("%d", hash)
+
+			client.EXPECT().Create(ctx, cmpmock.DiffEq(&expected))
+
+			Expect(
+				wpm.CreateReadinessCheckPod(ctx, nmc, nms),
+			).NotTo(
+				HaveOccurred(),
+			)
+		},
+		Entry("default image", "", "container image"),
+		Entry("custom image", "check-image", "check-image"),
+	)
+})
+
 var _ = Describe("DeletePod", func() {
 	ctx := context.TODO()
 	now := metav1.Now()
@@ -651,3 +932,17 @@ cp -R /firmware-path/* /tmp/firmware-path;
 
 	return &pod
 }
+
+var _ = Describe("worker Pod names", func() {
+	It("should not reuse the name of the loader and unloader Pods for checks", func() {
+		names := []string{
+			WorkerPodName("node", "module"),
+			CheckerPodName("node", "module"),
+			ReadinessCheckPodName("node", "module"),
+		}
+
+		Expect(names[1]).NotTo(Equal(names[0]))
+		Expect(names[2]).NotTo(Equal(names[0]))
+		Expect(names[2]).NotTo(Equal(names[1]))
+	})
+})
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: registry.go
+//
+// Generated by this command:
+//
+//	mockgen -source=registry.go -package=registry -destination=mock_registry.go
+//
+// Package registry is a generated GoMock package.
+package registry
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRegistry is a mock of Registry interface.
+type MockRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistryMockRecorder
+}
+
+// MockRegistryMockRecorder is the mock recorder for MockRegistry.
+type MockRegistryMockRecorder struct {
+	mock *MockRegistry
+}
+
+// NewMockRegistry creates a new mock instance.
+func NewMockRegistry(ctrl *gomock.Controller) *MockRegistry {
+	mock := &MockRegistry{ctrl: ctrl}
+	mock.recorder = &MockRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistry) EXPECT() *MockRegistryMockRecorder {
+	return m.recorder
+}
+
+// CopyWithLabels mocks base method.
+func (m *MockRegistry) CopyWithLabels(ctx context.Context, srcImage string, srcAccess Access, dstImage string, dstAccess Access, labels map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyWithLabels", ctx, srcImage, srcAccess, dstImage, dstAccess, labels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyWithLabels indicates an expected call of CopyWithLabels.
+func (mr *MockRegistryMockRecorder) CopyWithLabels(ctx, srcImage, srcAccess, dstImage, dstAccess, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyWithLabels", reflect.TypeOf((*MockRegistry)(nil).CopyWithLabels), ctx, srcImage, srcAccess, dstImage, dstAccess, labels)
+}
+
+// GetDigest mocks base method.
+func (m *MockRegistry) GetDigest(ctx context.Context, image string, access Access) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigest", ctx, image, access)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigest indicates an expected call of GetDigest.
+func (mr *MockRegistryMockRecorder) GetDigest(ctx, image, access any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigest", reflect.TypeOf((*MockRegistry)(nil).GetDigest), ctx, image, access)
+}
+
+// GetLabels mocks base method.
+func (m *MockRegistry) GetLabels(ctx context.Context, image string, access Access) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabels", ctx, image, access)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabels indicates an expected call of GetLabels.
+func (mr *MockRegistryMockRecorder) GetLabels(ctx, image, access any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabels", reflect.TypeOf((*MockRegistry)(nil).GetLabels), ctx, image, access)
+}
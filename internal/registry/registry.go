@@ -0,0 +1,196 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/authn/kubernetes"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+)
+
+// ErrImageNotFound is returned when an image does not exist in its registry.
+var ErrImageNotFound = errors.New("image not found")
+
+// Access describes how to access the registry of an image: with the credentials of PullSecret in Namespace, or
+// anonymously if it is not set, and with TLSOptions.
+type Access struct {
+	Namespace  string
+	PullSecret *v1.LocalObjectReference
+	TLSOptions kmmv1beta1.TLSOptions
+}
+
+//go:generate mockgen -source=registry.go -package=registry -destination=mock_registry.go
+
+// Registry reads images from their registry and copies them between registries.
+type Registry interface {
+	// GetLabels returns the labels of image.
+	// It returns ErrImageNotFound if image does not exist.
+	GetLabels(ctx context.Context, image string, access Access) (map[string]string, error)
+
+	// GetDigest returns the digest of image.
+	// It returns ErrImageNotFound if image does not exist.
+	GetDigest(ctx context.Context, image string, access Access) (string, error)
+
+	// CopyWithLabels copies the single-platform image srcImage to dstImage, adding labels to its configuration.
+	CopyWithLabels(ctx context.Context, srcImage string, srcAccess Access, dstImage string, dstAccess Access,
+		labels map[string]string) error
+}
+
+type registry struct {
+	client client.Client
+}
+
+func New(client client.Client) Registry {
+	return &registry{client: client}
+}
+
+func (r *registry) GetLabels(ctx context.Context, image string, access Access) (map[string]string, error) {
+	ref, opts, err := r.remoteOptions(ctx, image, access)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := remote.Image(ref, opts...)
+	if err != nil {
+		return nil, imageError(image, err)
+	}
+
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("could not get the configuration of image %s: %v", image, err)
+	}
+
+	return cfg.Config.Labels, nil
+}
+
+func (r *registry) GetDigest(ctx context.Context, image string, access Access) (string, error) {
+	ref, opts, err := r.remoteOptions(ctx, image, access)
+	if err != nil {
+		return "", err
+	}
+
+	desc, err := remote.Head(ref, opts...)
+	if err != nil {
+		return "", imageError(image, err)
+	}
+
+	return desc.Digest.String(), nil
+}
+
+func (r *registry) CopyWithLabels(ctx context.Context, srcImage string, srcAccess Access, dstImage string,
+	dstAccess Access, labels map[string]string) error {
+
+	srcRef, srcOpts, err := r.remoteOptions(ctx, srcImage, srcAccess)
+	if err != nil {
+		return err
+	}
+
+	dstRef, dstOpts, err := r.remoteOptions(ctx, dstImage, dstAccess)
+	if err != nil {
+		return err
+	}
+
+	desc, err := remote.Get(srcRef, srcOpts...)
+	if err != nil {
+		return imageError(srcImage, err)
+	}
+
+	if desc.MediaType.IsIndex() {
+		return fmt.Errorf("image %s is an image index", srcImage)
+	}
+
+	img, err := desc.Image()
+	if err != nil {
+		return fmt.Errorf("could not read image %s: %v", srcImage, err)
+	}
+
+	// Without labels to add, the image is copied as is and keeps its digest.
+	if len(labels) > 0 {
+		cfg, err := img.ConfigFile()
+		if err != nil {
+			return fmt.Errorf("could not get the configuration of image %s: %v", srcImage, err)
+		}
+
+		cfg = cfg.DeepCopy()
+		if cfg.Config.Labels == nil {
+			cfg.Config.Labels = make(map[string]string, len(labels))
+		}
+		maps.Copy(cfg.Config.Labels, labels)
+
+		if img, err = mutate.ConfigFile(img, cfg); err != nil {
+			return fmt.Errorf("could not label image %s: %v", srcImage, err)
+		}
+	}
+
+	if err = remote.Write(dstRef, img, dstOpts...); err != nil {
+		return fmt.Errorf("could not push image %s: %v", dstImage, err)
+	}
+
+	return nil
+}
+
+// remoteOptions parses image and returns the options to access its registry.
+func (r *registry) remoteOptions(ctx context.Context, image string, access Access) (name.Reference, []remote.Option, error) {
+	var nameOpts []name.Option
+	if access.TLSOptions.Insecure {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+
+	ref, err := name.ParseReference(image, nameOpts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse image %s: %v", image, err)
+	}
+
+	keychain, err := r.keychain(ctx, access.Namespace, access.PullSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)}
+
+	if access.TLSOptions.InsecureSkipTLSVerify {
+		t := remote.DefaultTransport.(*http.Transport).Clone()
+		t.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+		opts = append(opts, remote.WithTransport(t))
+	}
+
+	return ref, opts, nil
+}
+
+// imageError returns ErrImageNotFound if err reports that image does not exist, or wraps err otherwise.
+func imageError(image string, err error) error {
+	var terr *transport.Error
+	if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+		return ErrImageNotFound
+	}
+
+	return fmt.Errorf("could not get image %s: %v", image, err)
+}
+
+// keychain returns the credentials of pullSecret, or anonymous credentials if it is not set.
+func (r *registry) keychain(ctx context.Context, namespace string, pullSecret *v1.LocalObjectReference) (authn.Keychain, error) {
+	if pullSecret == nil {
+		return authn.NewMultiKeychain(), nil
+	}
+
+	secret := v1.Secret{}
+
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: pullSecret.Name}, &secret); err != nil {
+		return nil, fmt.Errorf("could not get pull secret %s/%s: %v", namespace, pullSecret.Name, err)
+	}
+
+	return kubernetes.NewFromPullSecrets(ctx, []v1.Secret{secret})
+}
@@ -0,0 +1,229 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	gcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
+)
+
+var insecure = Access{Namespace: "ns", TLSOptions: kmmv1beta1.TLSOptions{Insecure: true}}
+
+var _ = Describe("GetLabels", func() {
+	var (
+		clnt   *client.MockClient
+		r      Registry
+		server *httptest.Server
+		repo   string
+	)
+
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		clnt = client.NewMockClient(gomock.NewController(GinkgoT()))
+		r = New(clnt)
+		server = httptest.NewServer(gcrregistry.New())
+		repo = strings.TrimPrefix(server.URL, "http://") + "/some/repo"
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	pushImage := func(image string, labels map[string]string) {
+		img, err := random.Image(16, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg, err := img.ConfigFile()
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg.Config.Labels = labels
+
+		img, err = mutate.ConfigFile(img, cfg)
+		Expect(err).NotTo(HaveOccurred())
+
+		ref, err := name.ParseReference(image)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(remote.Write(ref, img)).To(Succeed())
+	}
+
+	It("should return the labels of the image", func() {
+		pushImage(repo+":tag", map[string]string{"key": "value"})
+
+		Expect(
+			r.GetLabels(ctx, repo+":tag", insecure),
+		).To(
+			Equal(map[string]string{"key": "value"}),
+		)
+	})
+
+	It("should return ErrImageNotFound if the image does not exist", func() {
+		pushImage(repo+":tag", nil)
+
+		_, err := r.GetLabels(ctx, repo+":other-tag", insecure)
+		Expect(err).To(MatchError(ErrImageNotFound))
+	})
+
+	It("should return an error if the pull secret could not be fetched", func() {
+		clnt.EXPECT().Get(ctx, types.NamespacedName{Namespace: "ns", Name: "secret"}, &v1.Secret{}).Return(errors.New("some error"))
+
+		_, err := r.GetLabels(ctx, repo+":tag", Access{Namespace: "ns", PullSecret: &v1.LocalObjectReference{Name: "secret"}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error if the image is invalid", func() {
+		_, err := r.GetLabels(ctx, "invalid image", Access{})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("GetDigest", func() {
+	var (
+		r      Registry
+		server *httptest.Server
+		repo   string
+	)
+
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		r = New(client.NewMockClient(gomock.NewController(GinkgoT())))
+		server = httptest.NewServer(gcrregistry.New())
+		repo = strings.TrimPrefix(server.URL, "http://") + "/some/repo"
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should return the digest of the image", func() {
+		img, err := random.Image(16, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		ref, err := name.ParseReference(repo + ":tag")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(remote.Write(ref, img)).To(Succeed())
+
+		digest, err := img.Digest()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(
+			r.GetDigest(ctx, repo+":tag", insecure),
+		).To(
+			Equal(digest.String()),
+		)
+	})
+
+	It("should return ErrImageNotFound if the image does not exist", func() {
+		_, err := r.GetDigest(ctx, repo+":tag", insecure)
+		Expect(err).To(MatchError(ErrImageNotFound))
+	})
+})
+
+var _ = Describe("CopyWithLabels", func() {
+	var (
+		r      Registry
+		server *httptest.Server
+		repo   string
+	)
+
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		r = New(client.NewMockClient(gomock.NewController(GinkgoT())))
+		server = httptest.NewServer(gcrregistry.New())
+		repo = strings.TrimPrefix(server.URL, "http://") + "/some/repo"
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should copy the image and add the labels", func() {
+		img, err := random.Image(16, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg, err := img.ConfigFile()
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg.Config.Labels = map[string]string{"key": "value"}
+
+		img, err = mutate.ConfigFile(img, cfg)
+		Expect(err).NotTo(HaveOccurred())
+
+		ref, err := name.ParseReference(repo + ":src")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(remote.Write(ref, img)).To(Succeed())
+
+		Expect(
+			r.CopyWithLabels(ctx, repo+":src", insecure, repo+":dst", insecure, map[string]string{"other": "label"}),
+		).To(
+			Succeed(),
+		)
+
+		Expect(
+			r.GetLabels(ctx, repo+":dst", insecure),
+		).To(
+			Equal(map[string]string{"key": "value", "other": "label"}),
+		)
+	})
+
+	It("should keep the digest of the image if there are no labels to add", func() {
+		img, err := random.Image(16, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		ref, err := name.ParseReference(repo + ":src")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(remote.Write(ref, img)).To(Succeed())
+
+		Expect(
+			r.CopyWithLabels(ctx, repo+":src", insecure, repo+":dst", insecure, nil),
+		).To(
+			Succeed(),
+		)
+
+		srcDigest, err := r.GetDigest(ctx, repo+":src", insecure)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(
+			r.GetDigest(ctx, repo+":dst", insecure),
+		).To(
+			Equal(srcDigest),
+		)
+	})
+
+	It("should return ErrImageNotFound if the source image does not exist", func() {
+		err := r.CopyWithLabels(ctx, repo+":src", insecure, repo+":dst", insecure, nil)
+		Expect(err).To(MatchError(ErrImageNotFound))
+	})
+
+	It("should not copy image indexes", func() {
+		idx, err := random.Index(16, 1, 2)
+		Expect(err).NotTo(HaveOccurred())
+
+		ref, err := name.ParseReference(repo + ":src")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(remote.WriteIndex(ref, idx)).To(Succeed())
+
+		Expect(r.CopyWithLabels(ctx, repo+":src", insecure, repo+":dst", insecure, nil)).NotTo(Succeed())
+	})
+})
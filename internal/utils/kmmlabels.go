@@ -14,6 +14,7 @@ import (
 var reKernelModuleReadyLabel = regexp.MustCompile(`^kmm\.node\.kubernetes\.io/([a-zA-Z0-9-]+)\.([a-zA-Z0-9-\.]+)\.ready$`)
 var reKernelModuleVersionReadyLabel = regexp.MustCompile(`^kmm\.node\.kubernetes\.io/([a-zA-Z0-9-]+)\.([a-zA-Z0-9-\.]+)\.version\.ready$`)
 var reDeprecatedKernelModuleReadyLabel = regexp.MustCompile(`^kmm\.node\.kubernetes\.io/[a-zA-Z0-9-]+\.ready$`)
+var reNodeLabelsAnnotation = regexp.MustCompile(`^kmm\.node\.kubernetes\.io/([a-zA-Z0-9-]+)\.([a-zA-Z0-9-\.]+)\.node-labels$`)
 
 func GetModuleVersionLabelName(namespace, name string) string {
 	return fmt.Sprintf("%s.%s.%s", constants.ModuleVersionLabelPrefix, namespace, name)
@@ -119,6 +120,27 @@ func IsKernelModuleReadyNodeLabel(label string) (bool, string, string) {
 	return true, matches[1], matches[2]
 }
 
+// GetNodeLabelsAnnotation returns the key of the node annotation that holds the user-defined labels that KMM
+// added to the node for a Module.
+func GetNodeLabelsAnnotation(namespace, moduleName string) string {
+	return fmt.Sprintf("kmm.node.kubernetes.io/%s.%s.node-labels", namespace, moduleName)
+}
+
+func IsNodeLabelsAnnotation(annotation string) (bool, string, string) {
+	matches := reNodeLabelsAnnotation.FindStringSubmatch(annotation)
+
+	if len(matches) != 3 {
+		return false, "", ""
+	}
+
+	return true, matches[1], matches[2]
+}
+
+// IsNodeExcluded returns true if the node was opted out of KMM operations by setting the excluded label to "true".
+func IsNodeExcluded(nodeLabels map[string]string) bool {
+	return nodeLabels[constants.NodeExcludedLabel] == "true"
+}
+
 func IsObjectSelectedByLabels(objectLabels map[string]string, selectorLabels map[string]string) (bool, error) {
 	objectLabelsSet := labels.Set(objectLabels)
 	sel := labels.NewSelector()
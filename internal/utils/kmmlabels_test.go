@@ -131,3 +131,39 @@ var _ = Describe("IsKernelModuleReadyNodeLabel", func() {
 		Entry(nil, "kmm.node.kubernetes.io/ns.dot.in.name.version.ready", false, "", ""),
 	)
 })
+
+var _ = Describe("IsNodeLabelsAnnotation", func() {
+	DescribeTable(
+		"should work as expected",
+		func(input string, expectedOK bool, expectedNamespace, expectedName string) {
+			ok, namespace, name := IsNodeLabelsAnnotation(input)
+
+			if !expectedOK {
+				Expect(ok).To(BeFalse())
+				return
+			}
+
+			Expect(ok).To(BeTrue())
+			Expect(namespace).To(Equal(expectedNamespace))
+			Expect(name).To(Equal(expectedName))
+		},
+		Entry(nil, "kmm.node.kubernetes.io/a.node-labels", false, "", ""),
+		Entry(nil, "kmm.node.kubernetes.io/a.b.ready", false, "", ""),
+		Entry(nil, "a.b.node-labels", false, "", ""),
+		Entry(nil, GetNodeLabelsAnnotation("a", "b"), true, "a", "b"),
+		Entry(nil, "kmm.node.kubernetes.io/ns.dot.in.name.node-labels", true, "ns", "dot.in.name"),
+	)
+})
+
+var _ = Describe("IsNodeExcluded", func() {
+	DescribeTable(
+		"should work as expected",
+		func(nodeLabels map[string]string, expected bool) {
+			Expect(IsNodeExcluded(nodeLabels)).To(Equal(expected))
+		},
+		Entry("nil labels", nil, false),
+		Entry("no excluded label", map[string]string{"a": "b"}, false),
+		Entry("excluded label set to false", map[string]string{"kmm.node.kubernetes.io/excluded": "false"}, false),
+		Entry("excluded label set to true", map[string]string{"kmm.node.kubernetes.io/excluded": "true"}, true),
+	)
+})
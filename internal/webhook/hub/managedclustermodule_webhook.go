@@ -37,7 +37,7 @@ type ManagedClusterModuleValidator struct {
 func NewManagedClusterModuleValidator(logger logr.Logger, kubeVersion *webhook.KubeVersion) *ManagedClusterModuleValidator {
 	return &ManagedClusterModuleValidator{
 		logger: logger,
-		m:      webhook.NewModuleValidator(logger, kubeVersion),
+		m:      webhook.NewModuleValidator(logger, kubeVersion, nil),
 	}
 }
 
@@ -61,6 +61,10 @@ func (m *ManagedClusterModuleValidator) ValidateCreate(ctx context.Context, obj
 
 	m.logger.Info("Validating ManagedClusterModule creation", "name", mcm.Name, "namespace", mcm.Namespace)
 
+	if err := validateKernelMappingsNodeCriteria(mcm.Spec.ModuleSpec); err != nil {
+		return nil, err
+	}
+
 	return m.m.ValidateCreate(ctx, &kmmv1beta1.Module{Spec: mcm.Spec.ModuleSpec})
 }
 
@@ -78,6 +82,10 @@ func (m *ManagedClusterModuleValidator) ValidateUpdate(ctx context.Context, oldO
 
 	m.logger.Info("Validating ManagedClusterModule update", "name", oldMCM.Name, "namespace", oldMCM.Namespace)
 
+	if err := validateKernelMappingsNodeCriteria(newMCM.Spec.ModuleSpec); err != nil {
+		return nil, err
+	}
+
 	return m.m.ValidateUpdate(ctx, &kmmv1beta1.Module{Spec: oldMCM.Spec.ModuleSpec}, &kmmv1beta1.Module{Spec: newMCM.Spec.ModuleSpec})
 }
 
@@ -85,3 +93,22 @@ func (m *ManagedClusterModuleValidator) ValidateUpdate(ctx context.Context, oldO
 func (m *ManagedClusterModuleValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	return nil, webhook.NotImplemented
 }
+
+// validateKernelMappingsNodeCriteria rejects the kernel mappings that restrict the nodes they apply to: the hub only
+// knows the kernel versions of the managed clusters, and could not select those mappings.
+func validateKernelMappingsNodeCriteria(spec kmmv1beta1.ModuleSpec) error {
+	if spec.ModuleLoader == nil {
+		return nil
+	}
+
+	for i, km := range spec.ModuleLoader.Container.KernelMappings {
+		if len(km.Architectures) > 0 || km.OSImageRegexp != "" || len(km.NodeSelector) > 0 {
+			return fmt.Errorf(
+				"kernelMappings[%d]: architectures, osImageRegexp and nodeSelector are not supported in ManagedClusterModules",
+				i,
+			)
+		}
+	}
+
+	return nil
+}
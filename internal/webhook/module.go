@@ -20,22 +20,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/go-logr/logr"
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/constants"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/kernel"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/module"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -54,10 +63,14 @@ type KubeVersion struct {
 type ModuleValidator struct {
 	logger      logr.Logger
 	kubeVersion *KubeVersion
+	reader      client.Reader
 }
 
-func NewModuleValidator(logger logr.Logger, kubeVersion *KubeVersion) *ModuleValidator {
-	return &ModuleValidator{logger: logger, kubeVersion: kubeVersion}
+// NewModuleValidator returns a new ModuleValidator.
+// reader is used to look for other Modules managing the same kernel modules; it may be nil, in which case
+// no such check is performed.
+func NewModuleValidator(logger logr.Logger, kubeVersion *KubeVersion, reader client.Reader) *ModuleValidator {
+	return &ModuleValidator{logger: logger, kubeVersion: kubeVersion, reader: reader}
 }
 
 // DiscoverKubeVersion queries the Kubernetes API server and returns its version.
@@ -113,7 +126,7 @@ func (m *ModuleValidator) ValidateCreate(ctx context.Context, obj runtime.Object
 
 	m.logger.Info("Validating Module creation", "name", mod.Name, "namespace", mod.Namespace)
 
-	return validateModule(mod, m.kubeVersion)
+	return validateModule(mod, m.kubeVersion, m.listOtherModules(ctx, mod))
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -137,7 +150,34 @@ func (m *ModuleValidator) ValidateUpdate(ctx context.Context, oldObj, newObj run
 		}
 	}
 
-	return validateModule(newMod, m.kubeVersion)
+	return validateModule(newMod, m.kubeVersion, m.listOtherModules(ctx, newMod))
+}
+
+// listOtherModules returns all the Modules in the cluster except mod.
+// Errors are only logged, as the checks that use the result are best-effort.
+func (m *ModuleValidator) listOtherModules(ctx context.Context, mod *kmmv1beta1.Module) []kmmv1beta1.Module {
+	if m.reader == nil {
+		return nil
+	}
+
+	modList := kmmv1beta1.ModuleList{}
+
+	if err := m.reader.List(ctx, &modList); err != nil {
+		m.logger.Error(err, "Could not list Modules; skipping the checks against other Modules")
+		return nil
+	}
+
+	others := make([]kmmv1beta1.Module, 0, len(modList.Items))
+
+	for _, other := range modList.Items {
+		if other.Namespace == mod.Namespace && other.Name == mod.Name {
+			continue
+		}
+
+		others = append(others, other)
+	}
+
+	return others
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -145,7 +185,7 @@ func (m *ModuleValidator) ValidateDelete(ctx context.Context, obj runtime.Object
 	return nil, NotImplemented
 }
 
-func validateModule(mod *kmmv1beta1.Module, kubeVersion *KubeVersion) (admission.Warnings, error) {
+func validateModule(mod *kmmv1beta1.Module, kubeVersion *KubeVersion, otherModules []kmmv1beta1.Module) (admission.Warnings, error) {
 	nameLength := len(mod.Name + mod.Namespace)
 
 	if nameLength > maxCombinedLength {
@@ -176,6 +216,18 @@ func validateModule(mod *kmmv1beta1.Module, kubeVersion *KubeVersion) (admission
 		}
 	}
 
+	if err := validateMaintenanceWindow(mod.Spec.MaintenanceWindow); err != nil {
+		return nil, fmt.Errorf("failed to validate maintenanceWindow: %v", err)
+	}
+
+	if err := validateCanary(mod.Spec.Canary); err != nil {
+		return nil, fmt.Errorf("failed to validate canary: %v", err)
+	}
+
+	if mod.Spec.OrderedUpgrade != nil && (mod.Spec.ModuleLoader == nil || mod.Spec.ModuleLoader.Container.Version == "") {
+		return nil, errors.New("orderedUpgrade requires spec.moduleLoader.container.version to be set")
+	}
+
 	if mod.Spec.ModuleLoader == nil {
 		// If ModuleLoader is nil, there is no need to validate related fields
 		return nil, nil
@@ -189,7 +241,72 @@ func validateModule(mod *kmmv1beta1.Module, kubeVersion *KubeVersion) (admission
 		return nil, fmt.Errorf("failed to validate modprobe: %v", err)
 	}
 
-	return nil, validateFilesToSign(mod.Spec.ModuleLoader.Container)
+	for i, km := range mod.Spec.ModuleLoader.Container.KernelMappings {
+		if km.Modprobe == nil {
+			continue
+		}
+
+		if err := validateModprobe(module.MappingModprobe(mod.Spec.ModuleLoader.Container.Modprobe, km.Modprobe)); err != nil {
+			return nil, fmt.Errorf("failed to validate modprobe of kernelMappings[%d]: %v", i, err)
+		}
+	}
+
+	if err := validateValueSources(mod.Spec.ModuleLoader.Container); err != nil {
+		return nil, err
+	}
+
+	if err := validateParameterOverrides(mod.Spec.ModuleLoader.Container); err != nil {
+		return nil, fmt.Errorf("failed to validate parameterOverrides: %v", err)
+	}
+
+	if err := validateFilesToSign(mod.Spec.ModuleLoader.Container); err != nil {
+		return nil, err
+	}
+
+	if err := validateNodeLabels(mod, otherModules); err != nil {
+		return nil, fmt.Errorf("failed to validate nodeLabels: %v", err)
+	}
+
+	return kernelModuleConflictWarnings(mod, otherModules), nil
+}
+
+// kernelModuleConflictWarnings returns a warning for each Module in otherModules that loads or removes the same
+// kernel modules as mod on at least one common node.
+// Node selectors are only compared when they share a key, so the warnings may include false positives.
+func kernelModuleConflictWarnings(mod *kmmv1beta1.Module, otherModules []kmmv1beta1.Module) admission.Warnings {
+	var warnings admission.Warnings
+
+	for _, other := range otherModules {
+		if !selectorsMayOverlap(mod.Spec.Selector, other.Spec.Selector) {
+			continue
+		}
+
+		if kernelModules := module.ModulesConflict(mod, &other); len(kernelModules) > 0 {
+			warnings = append(
+				warnings,
+				fmt.Sprintf(
+					"Module %s/%s also manages kernel module(s) %s; KMM will not load both Modules on the same node",
+					other.Namespace,
+					other.Name,
+					strings.Join(kernelModules, ","),
+				),
+			)
+		}
+	}
+
+	return warnings
+}
+
+// selectorsMayOverlap returns false if a node cannot be selected by both a and b, i.e. if both require different
+// values for the same label.
+func selectorsMayOverlap(a, b map[string]string) bool {
+	for k, v := range a {
+		if otherValue, ok := b[k]; ok && otherValue != v {
+			return false
+		}
+	}
+
+	return true
 }
 
 func validateDRA(mod *kmmv1beta1.Module, kubeVersion *KubeVersion) error {
@@ -282,19 +399,44 @@ func validateModuleLoaderContainerSpec(container kmmv1beta1.ModuleLoaderContaine
 		}
 	}
 
+	if err := validateBuild(container.Build); err != nil {
+		return fmt.Errorf("invalid build: %v", err)
+	}
+
 	for idx, km := range container.KernelMappings {
 		if km.Regexp != "" && km.Literal != "" {
 			return fmt.Errorf("regexp and literal are mutually exclusive properties at kernelMappings[%d]", idx)
 		}
 
-		if km.Regexp == "" && km.Literal == "" {
-			return fmt.Errorf("regexp or literal must be set at kernelMappings[%d]", idx)
+		if km.VersionRange != "" {
+			if km.Regexp != "" || km.Literal != "" {
+				return fmt.Errorf("versionRange is mutually exclusive with regexp and literal at kernelMappings[%d]", idx)
+			}
+
+			if _, err := kernel.ParseVersionRange(km.VersionRange); err != nil {
+				return fmt.Errorf("invalid versionRange at index %d: %v", idx, err)
+			}
+		} else if km.Regexp == "" && km.Literal == "" {
+			return fmt.Errorf("regexp, literal or versionRange must be set at kernelMappings[%d]", idx)
 		}
 
 		if _, err := regexp.Compile(km.Regexp); err != nil {
 			return fmt.Errorf("invalid regexp at index %d: %v", idx, err)
 		}
 
+		if _, err := regexp.Compile(km.OSImageRegexp); err != nil {
+			return fmt.Errorf("invalid osImageRegexp at index %d: %v", idx, err)
+		}
+
+		if slices.Contains(km.Architectures, "") {
+			return fmt.Errorf("architectures must not contain empty strings at kernelMappings[%d]", idx)
+		}
+
+		nodeSelectorPath := field.NewPath("kernelMappings").Index(idx).Child("nodeSelector")
+		if err := metav1validation.ValidateLabels(km.NodeSelector, nodeSelectorPath).ToAggregate(); err != nil {
+			return err
+		}
+
 		if kmImg := km.ContainerImage; kmImg == "" {
 			if container.ContainerImage == "" {
 				return fmt.Errorf("missing spec.moduleLoader.container.kernelMappings[%d].containerImage", idx)
@@ -303,6 +445,10 @@ func validateModuleLoaderContainerSpec(container kmmv1beta1.ModuleLoaderContaine
 			return fmt.Errorf("failed to validate image format: %v", err)
 		}
 
+		if err := validateBuild(km.Build); err != nil {
+			return fmt.Errorf("invalid build at kernelMappings[%d]: %v", idx, err)
+		}
+
 		if km.InTreeModulesToRemove != nil && km.InTreeModuleToRemove != "" { //nolint:staticcheck
 			return fmt.Errorf("only one of the KernelMapping fields: InTreeModulesToRemove or InTreeModuleToRemove can be defined")
 		}
@@ -316,6 +462,69 @@ func validateModuleLoaderContainerSpec(container kmmv1beta1.ModuleLoaderContaine
 	return nil
 }
 
+func validateBuild(build *kmmv1beta1.Build) error {
+	if build == nil {
+		return nil
+	}
+
+	var git *kmmv1beta1.GitSource
+	if build.Source != nil {
+		if git = build.Source.Git; git == nil {
+			return errors.New("source.git is required when source is set")
+		}
+	}
+
+	if build.DockerfileConfigMap == nil && git == nil {
+		return errors.New("one of dockerfileConfigMap or source.git is required")
+	}
+
+	if git != nil {
+		if git.URL == "" {
+			return errors.New("source.git.url is required")
+		}
+
+		if dir := git.ContextDir; dir != "" && !filepath.IsLocal(dir) {
+			return fmt.Errorf("source.git.contextDir must be a relative path inside the repository; got: %s", dir)
+		}
+	}
+
+	// The BuildKit backend cannot disable TLS for a single registry.
+	if build.Backend == kmmv1beta1.BuildBackendBuildKit &&
+		(build.BaseImageRegistryTLS.Insecure || build.BaseImageRegistryTLS.InsecureSkipTLSVerify) {
+		return fmt.Errorf("baseImageRegistryTLS is not supported by the %s backend", build.Backend)
+	}
+
+	if cache := build.Cache; cache != nil {
+		if strings.Contains(cache.Repository, "@") {
+			return fmt.Errorf("cache repository must not contain a digest; got: %s", cache.Repository)
+		}
+
+		if cache.TTL != nil && cache.TTL.Duration < 0 {
+			return fmt.Errorf("cache TTL must not be negative; got: %v", cache.TTL.Duration)
+		}
+	}
+
+	return validateRetries(build.ActiveDeadline, build.MaxRetries, build.Backoff)
+}
+
+// validateRetries validates the timeout and retry settings of a build or sign.
+func validateRetries(activeDeadline *metav1.Duration, maxRetries int32, backoff *metav1.Duration) error {
+	// Pods' active deadlines are a positive number of seconds.
+	if activeDeadline != nil && activeDeadline.Duration < time.Second {
+		return fmt.Errorf("activeDeadline must be at least 1s; got: %v", activeDeadline.Duration)
+	}
+
+	if maxRetries < 0 {
+		return fmt.Errorf("maxRetries must not be negative; got: %d", maxRetries)
+	}
+
+	if backoff != nil && backoff.Duration < 0 {
+		return fmt.Errorf("backoff must not be negative; got: %v", backoff.Duration)
+	}
+
+	return nil
+}
+
 func validateModprobe(modprobe kmmv1beta1.ModprobeSpec) error {
 	moduleName := modprobe.ModuleName
 	moduleNameDefined := moduleName != ""
@@ -357,6 +566,123 @@ func validateModprobe(modprobe kmmv1beta1.ModprobeSpec) error {
 	return nil
 }
 
+func validateValueSources(container kmmv1beta1.ModuleLoaderContainerSpec) error {
+	validateParametersFrom := func(path string, parametersFrom []kmmv1beta1.ModprobeParameterSource) error {
+		for i, p := range parametersFrom {
+			if p.Name == "" {
+				return fmt.Errorf("%s.parametersFrom[%d]: name must not be empty", path, i)
+			}
+
+			if err := validateValueSource(p.ValueFrom); err != nil {
+				return fmt.Errorf("%s.parametersFrom[%d]: %v", path, i, err)
+			}
+		}
+
+		return nil
+	}
+
+	if err := validateParametersFrom("modprobe", container.Modprobe.ParametersFrom); err != nil {
+		return err
+	}
+
+	for i, km := range container.KernelMappings {
+		if km.Modprobe == nil {
+			continue
+		}
+
+		if err := validateParametersFrom(fmt.Sprintf("kernelMappings[%d].modprobe", i), km.Modprobe.ParametersFrom); err != nil {
+			return err
+		}
+	}
+
+	validateBuildArgs := func(path string, b *kmmv1beta1.Build) error {
+		if b == nil {
+			return nil
+		}
+
+		for i, arg := range b.BuildArgs {
+			if arg.ValueFrom == nil {
+				continue
+			}
+
+			if err := validateValueSource(*arg.ValueFrom); err != nil {
+				return fmt.Errorf("%s.buildArgs[%d]: %v", path, i, err)
+			}
+		}
+
+		return nil
+	}
+
+	if err := validateBuildArgs("build", container.Build); err != nil {
+		return err
+	}
+
+	for i, km := range container.KernelMappings {
+		if err := validateBuildArgs(fmt.Sprintf("kernelMappings[%d].build", i), km.Build); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateValueSource(src kmmv1beta1.ValueSource) error {
+	switch {
+	case src.ConfigMapKeyRef != nil && src.SecretKeyRef != nil:
+		return errors.New("configMapKeyRef and secretKeyRef are mutually exclusive")
+	case src.ConfigMapKeyRef != nil:
+		if src.ConfigMapKeyRef.Name == "" || src.ConfigMapKeyRef.Key == "" {
+			return errors.New("configMapKeyRef: name and key must be set")
+		}
+	case src.SecretKeyRef != nil:
+		if src.SecretKeyRef.Name == "" || src.SecretKeyRef.Key == "" {
+			return errors.New("secretKeyRef: name and key must be set")
+		}
+	default:
+		return errors.New("one of configMapKeyRef or secretKeyRef must be set")
+	}
+
+	return nil
+}
+
+func validateParameterOverrides(container kmmv1beta1.ModuleLoaderContainerSpec) error {
+	if len(container.ParameterOverrides) == 0 {
+		return nil
+	}
+
+	if container.Modprobe.RawArgs != nil {
+		return errors.New("parameterOverrides cannot be used with modprobe.rawArgs")
+	}
+
+	for i, km := range container.KernelMappings {
+		if km.Modprobe != nil && km.Modprobe.RawArgs != nil {
+			return fmt.Errorf("parameterOverrides cannot be used with kernelMappings[%d].modprobe.rawArgs", i)
+		}
+	}
+
+	for i, o := range container.ParameterOverrides {
+		if len(o.Selector) == 0 {
+			return fmt.Errorf("parameterOverrides[%d]: selector must not be empty", i)
+		}
+
+		if err := metav1validation.ValidateLabels(o.Selector, field.NewPath("parameterOverrides").Index(i).Child("selector")).ToAggregate(); err != nil {
+			return err
+		}
+
+		if len(o.Parameters) == 0 {
+			return fmt.Errorf("parameterOverrides[%d]: parameters must not be empty", i)
+		}
+
+		for _, p := range o.Parameters {
+			if strings.TrimSpace(p) == "" {
+				return fmt.Errorf("parameterOverrides[%d]: parameters must not be empty strings", i)
+			}
+		}
+	}
+
+	return nil
+}
+
 func validateSignSection(sign *kmmv1beta1.Sign, dirName string) error {
 	if sign == nil {
 		return nil
@@ -369,7 +695,7 @@ func validateSignSection(sign *kmmv1beta1.Sign, dirName string) error {
 			return fmt.Errorf("filesToSign[%q] must be under dirName %q", filePath, dirName)
 		}
 	}
-	return nil
+	return validateRetries(sign.ActiveDeadline, sign.MaxRetries, sign.Backoff)
 }
 
 func validateFilesToSign(container kmmv1beta1.ModuleLoaderContainerSpec) error {
@@ -382,7 +708,7 @@ func validateFilesToSign(container kmmv1beta1.ModuleLoaderContainerSpec) error {
 
 	// Validate Sign section in each kernelMapping
 	for idx, km := range container.KernelMappings {
-		if err := validateSignSection(km.Sign, dirName); err != nil {
+		if err := validateSignSection(km.Sign, module.MappingModprobe(container.Modprobe, km.Modprobe).DirName); err != nil {
 			return fmt.Errorf("kernelMappings[%d].Sign: %v", idx, err)
 		}
 	}
@@ -390,6 +716,87 @@ func validateFilesToSign(container kmmv1beta1.ModuleLoaderContainerSpec) error {
 	return nil
 }
 
+// validateNodeLabels checks that all labels are valid, that none of them is in the namespace reserved for the
+// labels that KMM manages itself, and that no other Module that may target the same nodes sets one of them to a
+// different value.
+// Modules that are being deleted are not compared, so that a conflict cannot block their finalizers.
+func validateNodeLabels(mod *kmmv1beta1.Module, otherModules []kmmv1beta1.Module) error {
+	labels := mod.Spec.ModuleLoader.NodeLabels
+
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+		}
+
+		if prefix, _, ok := strings.Cut(key, "/"); ok &&
+			(prefix == constants.KMMNodeLabelDomain || strings.HasSuffix(prefix, "."+constants.KMMNodeLabelDomain)) {
+			return fmt.Errorf("invalid key %q: the %s domain is reserved", key, constants.KMMNodeLabelDomain)
+		}
+
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value %q for key %q: %s", value, key, strings.Join(errs, "; "))
+		}
+	}
+
+	if len(labels) == 0 || mod.DeletionTimestamp != nil {
+		return nil
+	}
+
+	for _, other := range otherModules {
+		if other.Spec.ModuleLoader == nil || other.DeletionTimestamp != nil || !selectorsMayOverlap(mod.Spec.Selector, other.Spec.Selector) {
+			continue
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(labels)) {
+			if otherValue, ok := other.Spec.ModuleLoader.NodeLabels[key]; ok && otherValue != labels[key] {
+				return fmt.Errorf(
+					"key %q is set to %q by Module %s/%s, which may target the same nodes",
+					key,
+					otherValue,
+					other.Namespace,
+					other.Name,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateMaintenanceWindow(mw *kmmv1beta1.MaintenanceWindow) error {
+	if mw == nil {
+		return nil
+	}
+
+	if mw.Duration.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+
+	_, err := module.ParseMaintenanceWindow(mw)
+
+	return err
+}
+
+func validateCanary(canary *kmmv1beta1.CanarySpec) error {
+	if canary == nil {
+		return nil
+	}
+
+	if len(canary.Selector) == 0 {
+		return errors.New("selector must not be empty")
+	}
+
+	if err := metav1validation.ValidateLabels(canary.Selector, field.NewPath("selector")).ToAggregate(); err != nil {
+		return err
+	}
+
+	if canary.SoakDuration.Duration < 0 {
+		return errors.New("soakDuration must not be negative")
+	}
+
+	return nil
+}
+
 func validateTolerations(tolerations []corev1.Toleration) error {
 
 	for i, toleration := range tolerations {
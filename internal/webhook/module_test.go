@@ -18,13 +18,19 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	v1 "k8s.io/api/core/v1"
 	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kmmv1beta1 "github.com/kubernetes-sigs/kernel-module-management/api/v1beta1"
+	"github.com/kubernetes-sigs/kernel-module-management/internal/client"
 	"github.com/kubernetes-sigs/kernel-module-management/internal/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
 )
 
 func getLengthAfterSlash(s string) int {
@@ -53,7 +59,7 @@ var (
 		},
 	}
 
-	moduleWebhook = NewModuleValidator(GinkgoLogr, &KubeVersion{Major: 1, Minor: 34})
+	moduleWebhook = NewModuleValidator(GinkgoLogr, &KubeVersion{Major: 1, Minor: 34}, nil)
 )
 
 var _ = Describe("maxCombinedLength", func() {
@@ -98,6 +104,8 @@ var _ = Describe("validateImageFormat", func() {
 })
 
 var _ = Describe("validateModuleLoaderContainerSpec", func() {
+	dockerfileConfigMap := &v1.LocalObjectReference{Name: "dockerfile"}
+
 	It("should pass when there are not kernel mappings", func() {
 		Expect(
 			validateModuleLoaderContainerSpec(kmmv1beta1.ModuleLoaderContainerSpec{}),
@@ -184,7 +192,7 @@ var _ = Describe("validateModuleLoaderContainerSpec", func() {
 		)
 	})
 
-	It("should fail when neither literal and regex are set", func() {
+	It("should fail when neither literal, regex nor versionRange are set", func() {
 		containerSpec := kmmv1beta1.ModuleLoaderContainerSpec{
 			KernelMappings: []kmmv1beta1.KernelMapping{
 				{ContainerImage: "image-url"},
@@ -195,11 +203,170 @@ var _ = Describe("validateModuleLoaderContainerSpec", func() {
 			validateModuleLoaderContainerSpec(containerSpec),
 		).To(
 			MatchError(
-				ContainSubstring("regexp or literal must be set at kernelMappings"),
+				ContainSubstring("regexp, literal or versionRange must be set at kernelMappings"),
 			),
 		)
 	})
 
+	DescribeTable("should validate the build",
+		func(build *kmmv1beta1.Build, errExpected bool) {
+			err := validateModuleLoaderContainerSpec(kmmv1beta1.ModuleLoaderContainerSpec{
+				KernelMappings: []kmmv1beta1.KernelMapping{
+					{Literal: "1.2.3", ContainerImage: "image-url:mytag", Build: build},
+				},
+			})
+
+			if errExpected {
+				Expect(err).To(MatchError(ContainSubstring("invalid build at kernelMappings[0]")))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("no build", nil, false),
+		Entry("buildah with insecure pulls",
+			&kmmv1beta1.Build{
+				DockerfileConfigMap:  dockerfileConfigMap,
+				Backend:              kmmv1beta1.BuildBackendBuildah,
+				BaseImageRegistryTLS: kmmv1beta1.TLSOptions{Insecure: true},
+			},
+			false,
+		),
+		Entry("buildkit", &kmmv1beta1.Build{DockerfileConfigMap: dockerfileConfigMap, Backend: kmmv1beta1.BuildBackendBuildKit}, false),
+		Entry("buildkit with insecure pulls",
+			&kmmv1beta1.Build{
+				DockerfileConfigMap:  dockerfileConfigMap,
+				Backend:              kmmv1beta1.BuildBackendBuildKit,
+				BaseImageRegistryTLS: kmmv1beta1.TLSOptions{InsecureSkipTLSVerify: true},
+			},
+			true,
+		),
+		Entry("cache",
+			&kmmv1beta1.Build{
+				DockerfileConfigMap: dockerfileConfigMap,
+				Cache: &kmmv1beta1.BuildCache{
+					Enabled:    true,
+					Repository: "registry:5000/org/cache",
+					TTL:        &metav1.Duration{Duration: time.Hour},
+				},
+			},
+			false,
+		),
+		Entry("cache repository with a digest",
+			&kmmv1beta1.Build{
+				DockerfileConfigMap: dockerfileConfigMap,
+				Cache:               &kmmv1beta1.BuildCache{Repository: "registry/cache@sha256:1234"},
+			},
+			true,
+		),
+		Entry("negative cache TTL",
+			&kmmv1beta1.Build{
+				DockerfileConfigMap: dockerfileConfigMap,
+				Cache:               &kmmv1beta1.BuildCache{TTL: &metav1.Duration{Duration: -time.Hour}},
+			},
+			true,
+		),
+		Entry("no Dockerfile", &kmmv1beta1.Build{}, true),
+		Entry("git source",
+			&kmmv1beta1.Build{
+				Source: &kmmv1beta1.BuildSource{
+					Git: &kmmv1beta1.GitSource{URL: "https://github.com/org/repo.git", Ref: "v1.0", ContextDir: "driver"},
+				},
+			},
+			false,
+		),
+		Entry("source without git", &kmmv1beta1.Build{DockerfileConfigMap: dockerfileConfigMap, Source: &kmmv1beta1.BuildSource{}}, true),
+		Entry("git source without URL", &kmmv1beta1.Build{Source: &kmmv1beta1.BuildSource{Git: &kmmv1beta1.GitSource{}}}, true),
+		Entry("git context directory outside of the repository",
+			&kmmv1beta1.Build{
+				Source: &kmmv1beta1.BuildSource{
+					Git: &kmmv1beta1.GitSource{URL: "https://github.com/org/repo.git", ContextDir: "../driver"},
+				},
+			},
+			true,
+		),
+		Entry("timeout and retries",
+			&kmmv1beta1.Build{
+				DockerfileConfigMap: dockerfileConfigMap,
+				ActiveDeadline:      &metav1.Duration{Duration: time.Hour},
+				MaxRetries:          3,
+				Backoff:             &metav1.Duration{Duration: time.Minute},
+			},
+			false,
+		),
+		Entry("active deadline under one second",
+			&kmmv1beta1.Build{DockerfileConfigMap: dockerfileConfigMap, ActiveDeadline: &metav1.Duration{Duration: time.Millisecond}},
+			true,
+		),
+		Entry("negative retries", &kmmv1beta1.Build{DockerfileConfigMap: dockerfileConfigMap, MaxRetries: -1}, true),
+		Entry("negative backoff",
+			&kmmv1beta1.Build{DockerfileConfigMap: dockerfileConfigMap, Backoff: &metav1.Duration{Duration: -time.Minute}},
+			true,
+		),
+	)
+
+	It("should validate the timeout and retries of the sign", func() {
+		sign := &kmmv1beta1.Sign{
+			FilesToSign: []string{"/opt/lib/modules/kmod.ko"},
+			Backoff:     &metav1.Duration{Duration: -time.Minute},
+		}
+
+		Expect(
+			validateSignSection(sign, "/opt"),
+		).To(
+			MatchError(ContainSubstring("backoff must not be negative")),
+		)
+	})
+
+	DescribeTable("should validate versionRange",
+		func(km kmmv1beta1.KernelMapping, errExpected bool) {
+			km.ContainerImage = "image-url:mytag"
+
+			err := validateModuleLoaderContainerSpec(kmmv1beta1.ModuleLoaderContainerSpec{
+				KernelMappings: []kmmv1beta1.KernelMapping{km},
+			})
+
+			if errExpected {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("valid range", kmmv1beta1.KernelMapping{VersionRange: ">=5.14.0-284 <5.15"}, false),
+		Entry("invalid version", kmmv1beta1.KernelMapping{VersionRange: ">=abc"}, true),
+		Entry("operator only", kmmv1beta1.KernelMapping{VersionRange: ">="}, true),
+		Entry("with regexp", kmmv1beta1.KernelMapping{VersionRange: ">=5.14", Regexp: ".*"}, true),
+		Entry("with literal", kmmv1beta1.KernelMapping{VersionRange: ">=5.14", Literal: "5.14.0"}, true),
+	)
+
+	DescribeTable("should validate the node criteria",
+		func(km kmmv1beta1.KernelMapping, errExpected bool) {
+			km.Literal = "5.14.0"
+			km.ContainerImage = "image-url:mytag"
+
+			err := validateModuleLoaderContainerSpec(kmmv1beta1.ModuleLoaderContainerSpec{
+				KernelMappings: []kmmv1beta1.KernelMapping{km},
+			})
+
+			if errExpected {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry(
+			"valid criteria",
+			kmmv1beta1.KernelMapping{
+				Architectures: []string{"arm64"},
+				OSImageRegexp: "^Red Hat",
+				NodeSelector:  map[string]string{"pool": "gpu"},
+			},
+			false,
+		),
+		Entry("invalid OS image regexp", kmmv1beta1.KernelMapping{OSImageRegexp: "invalid)"}, true),
+		Entry("empty architecture", kmmv1beta1.KernelMapping{Architectures: []string{""}}, true),
+		Entry("invalid node selector", kmmv1beta1.KernelMapping{NodeSelector: map[string]string{"example.com/": "b"}}, true),
+	)
+
 	It("should fail when a kernel-mapping has invalid containerName", func() {
 		containerSpec := kmmv1beta1.ModuleLoaderContainerSpec{
 			KernelMappings: []kmmv1beta1.KernelMapping{
@@ -411,7 +578,7 @@ var _ = Describe("validateModule", func() {
 			mod.Name = name
 			mod.Namespace = ns
 
-			_, err := validateModule(&mod, &KubeVersion{Major: 1, Minor: 34})
+			_, err := validateModule(&mod, &KubeVersion{Major: 1, Minor: 34}, nil)
 			exp := Expect(err)
 
 			if errExpected {
@@ -426,9 +593,133 @@ var _ = Describe("validateModule", func() {
 	It("should pass when moduleLoader is not defined", func() {
 		mod := validModule
 		mod.Spec.ModuleLoader = nil
-		_, err := validateModule(&mod, &KubeVersion{Major: 1, Minor: 34})
+		_, err := validateModule(&mod, &KubeVersion{Major: 1, Minor: 34}, nil)
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	DescribeTable(
+		"should validate the modprobe settings of kernel mappings",
+		func(modprobe *kmmv1beta1.ModprobeOverride, errExpected bool) {
+			mod := validModule.DeepCopy()
+			mod.Spec.ModuleLoader.Container.KernelMappings[0].Modprobe = modprobe
+
+			_, err := validateModule(mod, &KubeVersion{Major: 1, Minor: 34}, nil)
+
+			if errExpected {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("module renamed", &kmmv1beta1.ModprobeOverride{ModuleName: "new-name"}, false),
+		Entry(
+			"valid loading order",
+			&kmmv1beta1.ModprobeOverride{ModulesLoadingOrder: []string{"mod-name", "dep"}},
+			false,
+		),
+		Entry(
+			"loading order not starting with the module name",
+			&kmmv1beta1.ModprobeOverride{ModuleName: "new-name", ModulesLoadingOrder: []string{"mod-name", "dep"}},
+			true,
+		),
+		Entry(
+			"rawArgs while the Module sets moduleName",
+			&kmmv1beta1.ModprobeOverride{RawArgs: &kmmv1beta1.ModprobeArgs{Load: []string{"a"}, Unload: []string{"b"}}},
+			true,
+		),
+	)
+
+	It("should fail when orderedUpgrade is set without a version", func() {
+		mod := validModule
+		mod.Spec.OrderedUpgrade = &kmmv1beta1.OrderedUpgradeSpec{MaxParallel: 1}
+		_, err := validateModule(&mod, &KubeVersion{Major: 1, Minor: 34}, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable(
+		"should warn about other Modules managing the same kernel module",
+		func(otherSelector map[string]string, otherModuleName string, expectWarning bool) {
+			mod := validModule
+			mod.Spec.Selector = map[string]string{"pool": "a"}
+
+			other := validModule
+			other.Name = "other"
+			other.Namespace = "other-ns"
+			other.Spec.Selector = otherSelector
+			other.Spec.ModuleLoader = &kmmv1beta1.ModuleLoaderSpec{
+				Container: kmmv1beta1.ModuleLoaderContainerSpec{
+					Modprobe: kmmv1beta1.ModprobeSpec{ModuleName: otherModuleName},
+				},
+			}
+
+			warnings, err := validateModule(&mod, &KubeVersion{Major: 1, Minor: 34}, []kmmv1beta1.Module{other})
+			Expect(err).NotTo(HaveOccurred())
+
+			if expectWarning {
+				Expect(warnings).To(HaveExactElements(ContainSubstring("Module other-ns/other also manages kernel module(s) mod_name")))
+			} else {
+				Expect(warnings).To(BeEmpty())
+			}
+		},
+		Entry("same kernel module, overlapping selectors", map[string]string{"gpu": "true"}, "mod_name", true),
+		Entry("same kernel module, disjoint selectors", map[string]string{"pool": "b"}, "mod-name", false),
+		Entry("different kernel module", nil, "other-mod", false),
+	)
+})
+
+var _ = Describe("listOtherModules", func() {
+	var (
+		ctrl *gomock.Controller
+		clnt *client.MockClient
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clnt = client.NewMockClient(ctrl)
+	})
+
+	ctx := context.TODO()
+
+	It("should return nothing if there is no reader", func() {
+		Expect(
+			NewModuleValidator(GinkgoLogr, nil, nil).listOtherModules(ctx, &validModule),
+		).To(
+			BeEmpty(),
+		)
+	})
+
+	It("should return nothing if the Modules could not be listed", func() {
+		clnt.EXPECT().List(ctx, gomock.Any()).Return(errors.New("some error"))
+
+		Expect(
+			NewModuleValidator(GinkgoLogr, nil, clnt).listOtherModules(ctx, &validModule),
+		).To(
+			BeEmpty(),
+		)
+	})
+
+	It("should return all Modules but the one being validated", func() {
+		mod := validModule
+		mod.Name = "name"
+		mod.Namespace = "ns"
+
+		other := validModule
+		other.Name = "name"
+		other.Namespace = "other-ns"
+
+		clnt.EXPECT().List(ctx, gomock.Any()).DoAndReturn(
+			func(_ interface{}, list *kmmv1beta1.ModuleList, _ ...interface{}) error {
+				list.Items = []kmmv1beta1.Module{mod, other}
+				return nil
+			},
+		)
+
+		Expect(
+			NewModuleValidator(GinkgoLogr, nil, clnt).listOtherModules(ctx, &mod),
+		).To(
+			Equal([]kmmv1beta1.Module{other}),
+		)
+	})
 })
 
 var _ = Describe("ValidateCreate", func() {
@@ -563,6 +854,355 @@ var _ = Describe("ValidateDelete", func() {
 	})
 })
 
+var _ = Describe("validateNodeLabels", func() {
+	newModule := func(name string, selector, nodeLabels map[string]string) kmmv1beta1.Module {
+		return kmmv1beta1.Module{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: kmmv1beta1.ModuleSpec{
+				ModuleLoader: &kmmv1beta1.ModuleLoaderSpec{NodeLabels: nodeLabels},
+				Selector:     selector,
+			},
+		}
+	}
+
+	DescribeTable(
+		"should validate the labels",
+		func(labels map[string]string, expectErr bool) {
+			mod := newModule("mod", nil, labels)
+			err := validateNodeLabels(&mod, nil)
+
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("no labels", nil, false),
+		Entry("valid labels", map[string]string{"vendor.com/driver-version": "1.2.3", "simple": ""}, false),
+		Entry("invalid key", map[string]string{"vendor.com/": "value"}, true),
+		Entry("invalid value", map[string]string{"vendor.com/driver-version": "not a valid value"}, true),
+		Entry("reserved domain", map[string]string{"kmm.node.kubernetes.io/something": ""}, true),
+		Entry("reserved subdomain", map[string]string{"beta.kmm.node.kubernetes.io/something": ""}, true),
+		Entry("domain ending like the reserved one", map[string]string{"notkmm.node.kubernetes.io/something": ""}, false),
+	)
+
+	DescribeTable(
+		"should compare the labels with the other Modules",
+		func(other kmmv1beta1.Module, deleting, expectErr bool) {
+			mod := newModule("mod", map[string]string{"pool": "a"}, map[string]string{"vendor.com/driver-version": "1.2.3"})
+			if deleting {
+				mod.DeletionTimestamp = &metav1.Time{}
+			}
+
+			err := validateNodeLabels(&mod, []kmmv1beta1.Module{other})
+
+			if expectErr {
+				Expect(err).To(MatchError(ContainSubstring("ns/other")))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry(
+			"same value",
+			newModule("other", nil, map[string]string{"vendor.com/driver-version": "1.2.3"}),
+			false,
+			false,
+		),
+		Entry(
+			"different value",
+			newModule("other", nil, map[string]string{"vendor.com/driver-version": "4.5.6"}),
+			false,
+			true,
+		),
+		Entry(
+			"different value on other nodes",
+			newModule("other", map[string]string{"pool": "b"}, map[string]string{"vendor.com/driver-version": "4.5.6"}),
+			false,
+			false,
+		),
+		Entry(
+			"different value, Module being deleted",
+			newModule("other", nil, map[string]string{"vendor.com/driver-version": "4.5.6"}),
+			true,
+			false,
+		),
+		Entry(
+			"different value, other Module being deleted",
+			func() kmmv1beta1.Module {
+				other := newModule("other", nil, map[string]string{"vendor.com/driver-version": "4.5.6"})
+				other.DeletionTimestamp = &metav1.Time{}
+				return other
+			}(),
+			false,
+			false,
+		),
+		Entry("other Module without a ModuleLoader", kmmv1beta1.Module{}, false, false),
+	)
+})
+
+var _ = Describe("validateMaintenanceWindow", func() {
+	DescribeTable(
+		"should validate the window",
+		func(mw *kmmv1beta1.MaintenanceWindow, expectErr bool) {
+			err := validateMaintenanceWindow(mw)
+
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("no window", nil, false),
+		Entry(
+			"valid window",
+			&kmmv1beta1.MaintenanceWindow{
+				Schedule: "0 2 * * 6",
+				Duration: metav1.Duration{Duration: time.Hour},
+				TimeZone: "Europe/Paris",
+			},
+			false,
+		),
+		Entry(
+			"invalid schedule",
+			&kmmv1beta1.MaintenanceWindow{Schedule: "0 2 * *", Duration: metav1.Duration{Duration: time.Hour}},
+			true,
+		),
+		Entry(
+			"invalid time zone",
+			&kmmv1beta1.MaintenanceWindow{
+				Schedule: "0 2 * * 6",
+				Duration: metav1.Duration{Duration: time.Hour},
+				TimeZone: "Nowhere/Land",
+			},
+			true,
+		),
+		Entry("zero duration", &kmmv1beta1.MaintenanceWindow{Schedule: "0 2 * * 6"}, true),
+		Entry(
+			"@every descriptor",
+			&kmmv1beta1.MaintenanceWindow{Schedule: "@every 1h", Duration: metav1.Duration{Duration: time.Hour}},
+			true,
+		),
+		Entry(
+			"@daily descriptor",
+			&kmmv1beta1.MaintenanceWindow{Schedule: "@daily", Duration: metav1.Duration{Duration: time.Hour}},
+			true,
+		),
+		Entry(
+			"CRON_TZ prefix",
+			&kmmv1beta1.MaintenanceWindow{
+				Schedule: "CRON_TZ=Asia/Tokyo 0 2 * * 6",
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+			true,
+		),
+		Entry(
+			"TZ prefix",
+			&kmmv1beta1.MaintenanceWindow{Schedule: "TZ=UTC 0 2 * * 6", Duration: metav1.Duration{Duration: time.Hour}},
+			true,
+		),
+	)
+})
+
+var _ = Describe("validateCanary", func() {
+	DescribeTable(
+		"should validate the canary stanza",
+		func(canary *kmmv1beta1.CanarySpec, expectErr bool) {
+			err := validateCanary(canary)
+
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("no canary", nil, false),
+		Entry(
+			"valid canary",
+			&kmmv1beta1.CanarySpec{
+				Selector:     map[string]string{"example.com/canary": "true"},
+				SoakDuration: metav1.Duration{Duration: time.Hour},
+			},
+			false,
+		),
+		Entry("empty selector", &kmmv1beta1.CanarySpec{}, true),
+		Entry("invalid selector", &kmmv1beta1.CanarySpec{Selector: map[string]string{"example.com/": "true"}}, true),
+		Entry(
+			"negative soak duration",
+			&kmmv1beta1.CanarySpec{
+				Selector:     map[string]string{"canary": "true"},
+				SoakDuration: metav1.Duration{Duration: -time.Hour},
+			},
+			true,
+		),
+	)
+})
+
+var _ = Describe("validateValueSources", func() {
+	cmRef := &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "cm"}, Key: "key"}
+	secretRef := &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "secret"}, Key: "key"}
+
+	DescribeTable(
+		"should validate the ConfigMap and Secret references",
+		func(container kmmv1beta1.ModuleLoaderContainerSpec, expectErr bool) {
+			err := validateValueSources(container)
+
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("no references", kmmv1beta1.ModuleLoaderContainerSpec{}, false),
+		Entry(
+			"valid references",
+			kmmv1beta1.ModuleLoaderContainerSpec{
+				Modprobe: kmmv1beta1.ModprobeSpec{
+					ParametersFrom: []kmmv1beta1.ModprobeParameterSource{
+						{Name: "licence", ValueFrom: kmmv1beta1.ValueSource{SecretKeyRef: secretRef}},
+					},
+				},
+				Build: &kmmv1beta1.Build{
+					BuildArgs: []kmmv1beta1.BuildArg{
+						{Name: "SITE", ValueFrom: &kmmv1beta1.ValueSource{ConfigMapKeyRef: cmRef}},
+					},
+				},
+			},
+			false,
+		),
+		Entry(
+			"parameter without a name",
+			kmmv1beta1.ModuleLoaderContainerSpec{
+				Modprobe: kmmv1beta1.ModprobeSpec{
+					ParametersFrom: []kmmv1beta1.ModprobeParameterSource{
+						{ValueFrom: kmmv1beta1.ValueSource{SecretKeyRef: secretRef}},
+					},
+				},
+			},
+			true,
+		),
+		Entry(
+			"parameter without a source",
+			kmmv1beta1.ModuleLoaderContainerSpec{
+				Modprobe: kmmv1beta1.ModprobeSpec{
+					ParametersFrom: []kmmv1beta1.ModprobeParameterSource{{Name: "licence"}},
+				},
+			},
+			true,
+		),
+		Entry(
+			"build argument with both sources",
+			kmmv1beta1.ModuleLoaderContainerSpec{
+				Build: &kmmv1beta1.Build{
+					BuildArgs: []kmmv1beta1.BuildArg{
+						{Name: "SITE", ValueFrom: &kmmv1beta1.ValueSource{ConfigMapKeyRef: cmRef, SecretKeyRef: secretRef}},
+					},
+				},
+			},
+			true,
+		),
+		Entry(
+			"kernel mapping parameter without a name",
+			kmmv1beta1.ModuleLoaderContainerSpec{
+				KernelMappings: []kmmv1beta1.KernelMapping{
+					{
+						Modprobe: &kmmv1beta1.ModprobeOverride{
+							ParametersFrom: []kmmv1beta1.ModprobeParameterSource{
+								{ValueFrom: kmmv1beta1.ValueSource{SecretKeyRef: secretRef}},
+							},
+						},
+					},
+				},
+			},
+			true,
+		),
+		Entry(
+			"kernel mapping build argument without a key",
+			kmmv1beta1.ModuleLoaderContainerSpec{
+				KernelMappings: []kmmv1beta1.KernelMapping{
+					{
+						Build: &kmmv1beta1.Build{
+							BuildArgs: []kmmv1beta1.BuildArg{
+								{
+									Name: "SITE",
+									ValueFrom: &kmmv1beta1.ValueSource{
+										ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "cm"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			true,
+		),
+	)
+})
+
+var _ = Describe("validateParameterOverrides", func() {
+	newContainer := func(rawArgs *kmmv1beta1.ModprobeArgs, overrides ...kmmv1beta1.ParameterOverride) kmmv1beta1.ModuleLoaderContainerSpec {
+		return kmmv1beta1.ModuleLoaderContainerSpec{
+			Modprobe:           kmmv1beta1.ModprobeSpec{ModuleName: "kmod", RawArgs: rawArgs},
+			ParameterOverrides: overrides,
+		}
+	}
+
+	DescribeTable(
+		"should validate the parameter overrides",
+		func(container kmmv1beta1.ModuleLoaderContainerSpec, expectErr bool) {
+			err := validateParameterOverrides(container)
+
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("no overrides", newContainer(nil), false),
+		Entry(
+			"valid override",
+			newContainer(nil, kmmv1beta1.ParameterOverride{
+				Selector:   map[string]string{"kubernetes.io/hostname": "node-a"},
+				Parameters: []string{"queues=8"},
+			}),
+			false,
+		),
+		Entry(
+			"rawArgs",
+			newContainer(
+				&kmmv1beta1.ModprobeArgs{Load: []string{"kmod"}},
+				kmmv1beta1.ParameterOverride{Selector: map[string]string{"a": "b"}, Parameters: []string{"queues=8"}},
+			),
+			true,
+		),
+		Entry(
+			"rawArgs in a kernel mapping",
+			func() kmmv1beta1.ModuleLoaderContainerSpec {
+				container := newContainer(
+					nil,
+					kmmv1beta1.ParameterOverride{Selector: map[string]string{"a": "b"}, Parameters: []string{"queues=8"}},
+				)
+				container.KernelMappings = []kmmv1beta1.KernelMapping{
+					{Modprobe: &kmmv1beta1.ModprobeOverride{RawArgs: &kmmv1beta1.ModprobeArgs{Load: []string{"kmod"}}}},
+				}
+				return container
+			}(),
+			true,
+		),
+		Entry("empty selector", newContainer(nil, kmmv1beta1.ParameterOverride{Parameters: []string{"queues=8"}}), true),
+		Entry(
+			"invalid selector",
+			newContainer(nil, kmmv1beta1.ParameterOverride{Selector: map[string]string{"example.com/": "b"}, Parameters: []string{"queues=8"}}),
+			true,
+		),
+		Entry("no parameters", newContainer(nil, kmmv1beta1.ParameterOverride{Selector: map[string]string{"a": "b"}}), true),
+		Entry(
+			"empty parameter",
+			newContainer(nil, kmmv1beta1.ParameterOverride{Selector: map[string]string{"a": "b"}, Parameters: []string{" "}}),
+			true,
+		),
+	)
+})
+
 var _ = Describe("validateTolerations", func() {
 	It("should fail when Module has an invalid toleration effect", func() {
 		tolerations := []v1.Toleration{
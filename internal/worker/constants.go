@@ -1,9 +1,19 @@
 package worker
 
+import "fmt"
+
 const (
 	FlagFirmwarePath = "firmware-path"
 
 	FirmwareClassPathLocation = "/sys/module/firmware_class/parameters/path"
 	ImagesDir                 = "/var/run/kmm/images"
+	ProcModulesLocation       = "/proc/modules"
 	PullSecretsDir            = "/var/run/kmm/pull-secrets"
+	SysModuleLocation         = "/sys/module"
 )
+
+// ParameterEnvVar returns the name of the environment variable holding the value of the i-th element of
+// modprobe.parametersFrom in the worker container.
+func ParameterEnvVar(i int) string {
+	return fmt.Sprintf("KMM_MODPROBE_PARAMETER_%d", i)
+}
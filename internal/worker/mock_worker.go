@@ -39,6 +39,21 @@ func (m *MockWorker) EXPECT() *MockWorkerMockRecorder {
 	return m.recorder
 }
 
+// CheckKmod mocks base method.
+func (m *MockWorker) CheckKmod(cfg *v1beta1.ModuleConfig) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckKmod", cfg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckKmod indicates an expected call of CheckKmod.
+func (mr *MockWorkerMockRecorder) CheckKmod(cfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckKmod", reflect.TypeOf((*MockWorker)(nil).CheckKmod), cfg)
+}
+
 // LoadKmod mocks base method.
 func (m *MockWorker) LoadKmod(ctx context.Context, cfg *v1beta1.ModuleConfig, firmwareMountPath string) error {
 	m.ctrl.T.Helper()
@@ -67,6 +82,21 @@ func (mr *MockWorkerMockRecorder) SetFirmwareClassPath(value any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFirmwareClassPath", reflect.TypeOf((*MockWorker)(nil).SetFirmwareClassPath), value)
 }
 
+// SrcVersion mocks base method.
+func (m *MockWorker) SrcVersion(cfg *v1beta1.ModuleConfig) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SrcVersion", cfg)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SrcVersion indicates an expected call of SrcVersion.
+func (mr *MockWorkerMockRecorder) SrcVersion(cfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SrcVersion", reflect.TypeOf((*MockWorker)(nil).SrcVersion), cfg)
+}
+
 // UnloadKmod mocks base method.
 func (m *MockWorker) UnloadKmod(ctx context.Context, cfg *v1beta1.ModuleConfig, firmwareMountPath string) error {
 	m.ctrl.T.Helper()
@@ -16,8 +16,10 @@ import (
 //go:generate mockgen -source=worker.go -package=worker -destination=mock_worker.go
 
 type Worker interface {
+	CheckKmod(cfg *kmmv1beta1.ModuleConfig) ([]string, error)
 	LoadKmod(ctx context.Context, cfg *kmmv1beta1.ModuleConfig, firmwareMountPath string) error
 	SetFirmwareClassPath(value string) error
+	SrcVersion(cfg *kmmv1beta1.ModuleConfig) (string, error)
 	UnloadKmod(ctx context.Context, cfg *kmmv1beta1.ModuleConfig, firmwareMountPath string) error
 }
 
@@ -37,6 +39,72 @@ func NewWorker(mr ModprobeRunner, fh utils.FSHelper, logger logr.Logger) Worker
 
 const sharedFilesDir = "/tmp"
 
+var (
+	procModulesLocation = ProcModulesLocation
+	sysModuleLocation   = SysModuleLocation
+)
+
+// CheckKmod returns the names of the kernel modules from cfg that are not listed in /proc/modules.
+// Kernel modules loaded with raw arguments only are not known by name, so there is nothing to check for them.
+func (w *worker) CheckKmod(cfg *kmmv1beta1.ModuleConfig) ([]string, error) {
+	expected := cfg.Modprobe.ModulesLoadingOrder
+	if len(expected) == 0 && cfg.Modprobe.ModuleName != "" {
+		expected = []string{cfg.Modprobe.ModuleName}
+	}
+
+	missing := make([]string, 0)
+
+	if len(expected) == 0 {
+		w.logger.Info("No kernel module name to check")
+		return missing, nil
+	}
+
+	b, err := os.ReadFile(procModulesLocation)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %v", procModulesLocation, err)
+	}
+
+	loaded := make(map[string]bool)
+
+	for _, line := range strings.Split(string(b), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			loaded[fields[0]] = true
+		}
+	}
+
+	for _, name := range expected {
+		// the kernel always reports module names with underscores
+		if !loaded[strings.ReplaceAll(name, "-", "_")] {
+			missing = append(missing, name)
+		}
+	}
+
+	w.logger.Info("Checked kernel modules", "expected", expected, "missing", missing)
+
+	return missing, nil
+}
+
+// SrcVersion returns the srcversion that the kernel reports for the kernel module in cfg.
+// It returns an empty string if the kernel module has no srcversion or if it is loaded with raw arguments.
+func (w *worker) SrcVersion(cfg *kmmv1beta1.ModuleConfig) (string, error) {
+	if cfg.Modprobe.ModuleName == "" {
+		return "", nil
+	}
+
+	path := filepath.Join(sysModuleLocation, strings.ReplaceAll(cfg.Modprobe.ModuleName, "-", "_"), "srcversion")
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("could not read %s: %v", path, err)
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
 func (w *worker) LoadKmod(ctx context.Context, cfg *kmmv1beta1.ModuleConfig, firmwareMountPath string) error {
 
 	inTreeModulesToRemove := cfg.InTreeModulesToRemove
@@ -103,6 +171,16 @@ func (w *worker) LoadKmod(ctx context.Context, cfg *kmmv1beta1.ModuleConfig, fir
 
 		args = append(args, moduleName)
 		args = append(args, cfg.Modprobe.Parameters...)
+
+		for i, p := range cfg.Modprobe.ParametersFrom {
+			value, ok := os.LookupEnv(ParameterEnvVar(i))
+			if !ok {
+				w.logger.Info("Optional parameter value not found; skipping it", "name", p.Name)
+				continue
+			}
+
+			args = append(args, p.Name+"="+value)
+		}
 	}
 
 	return w.mr.Run(ctx, args...)
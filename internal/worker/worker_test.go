@@ -121,6 +121,31 @@ var _ = Describe("worker_LoadKmod", func() {
 		)
 	})
 
+	It("should append the parameters read from Secrets, skipping the missing ones", func() {
+		cfg := v1beta1.ModuleConfig{
+			ContainerImage: imageName,
+			Modprobe: v1beta1.ModprobeSpec{
+				ModuleName: moduleName,
+				DirName:    dirName,
+				Parameters: []string{"a=1"},
+				ParametersFrom: []v1beta1.ModprobeParameterSource{
+					{Name: "key"},
+					{Name: "missing"},
+				},
+			},
+		}
+
+		GinkgoT().Setenv(ParameterEnvVar(0), "secret")
+
+		mr.EXPECT().Run(ctx, "-vd", filepath.Join(sharedFilesDir, dirName), moduleName, "a=1", "key=secret")
+
+		Expect(
+			w.LoadKmod(ctx, &cfg, ""),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
 	It("should copy all the firmware files/directories if configured", func() {
 		cfg := v1beta1.ModuleConfig{
 			ContainerImage: imageName,
@@ -364,3 +389,97 @@ func ToInterfaceSlice[T any](s []T) []interface{} {
 
 	return r
 }
+
+var _ = Describe("worker_CheckKmod", func() {
+	const procModules = `kmm_ci_a 16384 0 - Live 0x0000000000000000
+kmm_ci_b 16384 1 kmm_ci_a, Live 0x0000000000000000 (O)
+`
+
+	w := NewWorker(nil, nil, GinkgoLogr)
+
+	BeforeEach(func() {
+		procModulesLocation = filepath.Join(GinkgoT().TempDir(), "modules")
+
+		Expect(
+			os.WriteFile(procModulesLocation, []byte(procModules), 0666),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	AfterEach(func() {
+		procModulesLocation = ProcModulesLocation
+	})
+
+	It("should return an error if /proc/modules cannot be read", func() {
+		procModulesLocation = "/non/existent/path"
+
+		_, err := w.CheckKmod(&v1beta1.ModuleConfig{Modprobe: v1beta1.ModprobeSpec{ModuleName: "kmm_ci_a"}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable(
+		"should return the missing modules",
+		func(modprobe v1beta1.ModprobeSpec, expected []string) {
+			missing, err := w.CheckKmod(&v1beta1.ModuleConfig{Modprobe: modprobe})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(Equal(expected))
+		},
+		Entry("module loaded", v1beta1.ModprobeSpec{ModuleName: "kmm_ci_a"}, []string{}),
+		Entry("module loaded, name with dashes", v1beta1.ModprobeSpec{ModuleName: "kmm-ci-a"}, []string{}),
+		Entry("module missing", v1beta1.ModprobeSpec{ModuleName: "kmm_ci_c"}, []string{"kmm_ci_c"}),
+		Entry(
+			"modules loading order with a missing module",
+			v1beta1.ModprobeSpec{ModuleName: "kmm_ci_a", ModulesLoadingOrder: []string{"kmm_ci_a", "kmm_ci_b", "kmm_ci_c"}},
+			[]string{"kmm_ci_c"},
+		),
+		Entry("raw arguments only", v1beta1.ModprobeSpec{RawArgs: &v1beta1.ModprobeArgs{Load: []string{"kmm_ci_c"}}}, []string{}),
+	)
+
+	It("should not read /proc/modules if no kernel module is named", func() {
+		procModulesLocation = "/non/existent/path"
+
+		missing, err := w.CheckKmod(&v1beta1.ModuleConfig{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missing).To(BeEmpty())
+	})
+})
+
+var _ = Describe("worker_SrcVersion", func() {
+	w := NewWorker(nil, nil, GinkgoLogr)
+
+	BeforeEach(func() {
+		sysModuleLocation = GinkgoT().TempDir()
+
+		dir := filepath.Join(sysModuleLocation, "kmm_ci_a")
+
+		Expect(
+			os.MkdirAll(dir, 0755),
+		).NotTo(
+			HaveOccurred(),
+		)
+
+		Expect(
+			os.WriteFile(filepath.Join(dir, "srcversion"), []byte("ABCDEF0123456789\n"), 0666),
+		).NotTo(
+			HaveOccurred(),
+		)
+	})
+
+	AfterEach(func() {
+		sysModuleLocation = SysModuleLocation
+	})
+
+	DescribeTable(
+		"should return the srcversion",
+		func(modprobe v1beta1.ModprobeSpec, expected string) {
+			srcVersion, err := w.SrcVersion(&v1beta1.ModuleConfig{Modprobe: modprobe})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(srcVersion).To(Equal(expected))
+		},
+		Entry("module with srcversion", v1beta1.ModprobeSpec{ModuleName: "kmm_ci_a"}, "ABCDEF0123456789"),
+		Entry("name with dashes", v1beta1.ModprobeSpec{ModuleName: "kmm-ci-a"}, "ABCDEF0123456789"),
+		Entry("module without srcversion", v1beta1.ModprobeSpec{ModuleName: "kmm_ci_b"}, ""),
+		Entry("raw args", v1beta1.ModprobeSpec{}, ""),
+	)
+})